@@ -7,11 +7,13 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/routes"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/config"
 )
 
 type App struct {
-	Router *gin.Engine
+	Router               *gin.Engine
+	EventConsumerService services.EventConsumerService
 }
 
 func InitApp() *App {
@@ -27,10 +29,12 @@ func InitApp() *App {
 	config.InitMinio()
 	config.InitConfigcat()
 	config.InitKafkaProducer()
+	config.InitKafkaConsumerGroup()
 	config.InitCronjobManager()
 
 	router := routes.RegisterRoutes()
 	return &App{
-		Router: router,
+		Router:               router,
+		EventConsumerService: routes.GetEventConsumerService(),
 	}
 }
@@ -1,16 +1,47 @@
 package auth
 
 import (
-	"github.com/google/uuid"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	totpDigits      = 6
+	totpPeriod      = 30
+	totpSkewSteps   = 1
+	totpSecretBytes = 20
+
+	recoveryCodeBytes = 5
+	randomTokenBytes  = 32
+
+	// DummyPasswordHash is compared against when no user matches a login, so unknown emails take as long as wrong passwords
+	DummyPasswordHash = "$2a$10$uV/Vaud2BWlynsuMuxCH7.eRqlDRN9LZEWvzUToy5Jm2T9h5uUIEW"
+)
+
 type Authenticator interface {
 	GenerateHashedPassword(rawPassword string) (string, error)
 	DoPasswordsMatch(hashedPassword, rawPassword string) bool
-	GenerateLoginToken() string
-	GeneratePasswordResetToken() string
-	GenerateRegistrationToken() string
+	GenerateRandomToken() (string, error)
+	HashToken(token string) string
+	DoTokensMatch(tokenHash, rawToken string) bool
+	GenerateTotpSecret() (string, error)
+	GetTotpProvisioningUri(secret, issuer, accountName string) string
+	ValidateTotpCode(secret, code string, at time.Time) (int64, bool)
+	GenerateRecoveryCodes(count int) ([]string, error)
+	GetPkceCodeChallenge(codeVerifier string) string
 }
 
 func NewAuthenticator() Authenticator {
@@ -36,10 +67,97 @@ func (a *authenticator) DoPasswordsMatch(hashedPassword, rawPassword string) boo
 	return true
 }
 
-func (a *authenticator) GenerateLoginToken() string {
-	return uuid.NewString()
+func (a *authenticator) GenerateRandomToken() (string, error) {
+	b := make([]byte, randomTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (a *authenticator) HashToken(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
+
+func (a *authenticator) DoTokensMatch(tokenHash, rawToken string) bool {
+	return subtle.ConstantTimeCompare([]byte(tokenHash), []byte(a.HashToken(rawToken))) == 1
+}
+
+func (a *authenticator) GenerateTotpSecret() (string, error) {
+	secret := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+func (a *authenticator) GetTotpProvisioningUri(secret, issuer, accountName string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", totpPeriod))
+
+	label := url.PathEscape(fmt.Sprintf("%s:%s", issuer, accountName))
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// ValidateTotpCode returns the matched time step so callers can reject a replayed code.
+func (a *authenticator) ValidateTotpCode(secret, code string, at time.Time) (int64, bool) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	currentStep := at.Unix() / totpPeriod
+	for step := currentStep - totpSkewSteps; step <= currentStep+totpSkewSteps; step++ {
+		if hmac.Equal([]byte(generateTotpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
 }
 
-func (a *authenticator) GeneratePasswordResetToken() string { return uuid.NewString() }
+func (a *authenticator) GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, count)
+	for i := range codes {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
 
-func (a *authenticator) GenerateRegistrationToken() string { return uuid.NewString() }
+		code := hex.EncodeToString(b)
+		codes[i] = fmt.Sprintf("%s-%s", code[:len(code)/2], code[len(code)/2:])
+	}
+
+	return codes, nil
+}
+
+func (a *authenticator) GetPkceCodeChallenge(codeVerifier string) string {
+	digest := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func generateTotpCode(key []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo)
+}
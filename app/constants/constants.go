@@ -5,8 +5,15 @@ const (
 	GinReleaseMode = "release"
 	GinDebugMode   = "debug"
 
+	// User location providers
+	IpApiLocationProvider         = "ip-api"
+	GeoIpDatabaseLocationProvider = "geoip-database"
+
 	// Request headers
 	ProfilePictureRequestFormKey = "Profile-Picture"
+	LocationImportFileFormKey    = "File"
+	CountryInfoFileFormKey       = "Country-Info"
+	Admin1CodesFileFormKey       = "Admin1-Codes"
 	UserPasswordResetToken       = "Reset-Token"
 	UserVerificationToken        = "Verification-Token"
 	RetryAfter                   = "Retry-After"
@@ -16,9 +23,29 @@ const (
 	Offset                 = "offset"
 	IncludeUserProfile     = "includeProfile"
 	IncludeGenres          = "includeGenres"
+	IncludeMovieDetails    = "includeDetails"
 	IncludeTheaterLocation = "includeLocation"
+	IncludeTheaterDetails  = "includeDetails"
+	Amenity                = "amenity"
 	MaxDistance            = "distance"
+	Latitude               = "lat"
+	Longitude              = "lon"
+	CityID                 = "cityId"
 	Email                  = "email"
+	SearchQuery            = "q"
+	ImportFormat           = "format"
+	TheaterID              = "theaterId"
+	ShowDate               = "date"
+	Today                  = "today"
+	PersonID               = "personId"
+	Role                   = "role"
+	CountryID              = "countryId"
+	Certification          = "certification"
+	MaxAge                 = "maxAge"
+	SubtitleLanguage       = "subtitle"
+	DubLanguage            = "dub"
+	ExternalSource         = "externalSource"
+	ExternalID             = "externalId"
 
 	// Content types
 	ContentType     = "Content-Type"
@@ -35,19 +62,86 @@ const (
 	CanModifyShows     = "canModifyShows"
 
 	// Redis key
+	UserSession     = "session"
 	ClientRateLimit = "rateLimit"
+	LoginAttempts   = "loginAttempts"
+	LoginLockout    = "loginLockout"
+	LoginChallenge  = "loginChallenge"
+	OidcState       = "oidcState"
+	UserLocation    = "userLocation"
+
+	VerificationResendRateLimit = "verificationResendRateLimit"
 
 	DateTimeFormat = "2006-01-02T15:04:05Z"
+
+	TwoFactorRecoveryCodeCount = 10
+
+	// Certifications from this age require an ID check at the theater unless the country sets its own age
+	DefaultIdCheckMinAge = 18
+
+	// Movies without a certification in the theater's country are restricted to this age, as they may be adult only elsewhere
+	UnratedMinAge = 18
 )
 
 // TODO: Check for other use cases of enum type
+type LocationImportFormat string
+
+const (
+	CsvImportFormat      LocationImportFormat = "csv"
+	GeoNamesImportFormat LocationImportFormat = "geonames"
+)
+
 type SeatType string
 
 const (
-	Regular SeatType = "REGULAR"
-	Vip     SeatType = "VIP"
+	Regular    SeatType = "REGULAR"
+	Vip        SeatType = "VIP"
+	Wheelchair SeatType = "WHEELCHAIR"
+	Companion  SeatType = "COMPANION"
+	Couple     SeatType = "COUPLE"
+)
+
+type TheaterAmenity string
+
+const (
+	Parking          TheaterAmenity = "PARKING"
+	Food             TheaterAmenity = "FOOD"
+	Imax             TheaterAmenity = "IMAX"
+	DolbyAtmos       TheaterAmenity = "DOLBY_ATMOS"
+	WheelchairAccess TheaterAmenity = "WHEELCHAIR_ACCESS"
+	HearingLoop      TheaterAmenity = "HEARING_LOOP"
 )
 
+var TheaterAmenities = []TheaterAmenity{Parking, Food, Imax, DolbyAtmos, WheelchairAccess, HearingLoop}
+
+type CreditRole string
+
+const (
+	Actor    CreditRole = "ACTOR"
+	Director CreditRole = "DIRECTOR"
+	Writer   CreditRole = "WRITER"
+	Producer CreditRole = "PRODUCER"
+	Composer CreditRole = "COMPOSER"
+)
+
+var CreditRoles = []CreditRole{Actor, Director, Writer, Producer, Composer}
+
+type MovieLanguageType string
+
+const (
+	Subtitle MovieLanguageType = "SUBTITLE"
+	Dub      MovieLanguageType = "DUB"
+)
+
+type ExternalIDSource string
+
+const (
+	Imdb ExternalIDSource = "IMDB"
+	Tmdb ExternalIDSource = "TMDB"
+)
+
+var ExternalIDSources = []ExternalIDSource{Imdb, Tmdb}
+
 type ShowStatus string
 
 const (
@@ -58,3 +152,62 @@ const (
 	Scheduled ShowStatus = "SCHEDULED"
 	OnHold    ShowStatus = "ON-HOLD"
 )
+
+type OutboxMessageStatus string
+
+const (
+	OutboxPending    OutboxMessageStatus = "PENDING"
+	OutboxSent       OutboxMessageStatus = "SENT"
+	OutboxDeadLetter OutboxMessageStatus = "DEAD_LETTER"
+)
+
+// OutboxRelayLockKey is the Postgres advisory lock that allows only one relay to publish outbox messages at a time.
+const OutboxRelayLockKey = 7_310_001
+
+type EventType string
+
+const (
+	UserRegistered         EventType = "user.registered"
+	PasswordResetRequested EventType = "user.password_reset_requested"
+	MovieCreated           EventType = "movie.created"
+	MovieUpdated           EventType = "movie.updated"
+	MovieDeleted           EventType = "movie.deleted"
+	GenreCreated           EventType = "genre.created"
+	GenreUpdated           EventType = "genre.updated"
+	GenreDeleted           EventType = "genre.deleted"
+	TheaterCreated         EventType = "theater.created"
+	TheaterUpdated         EventType = "theater.updated"
+	TheaterDeleted         EventType = "theater.deleted"
+	ShowCreated            EventType = "show.created"
+	ShowUpdated            EventType = "show.updated"
+)
+
+const (
+	EventIDHeader            = "event_id"
+	EventTypeHeader          = "event_type"
+	EventSchemaVersionHeader = "schema_version"
+	EventProducerHeader      = "producer"
+	RequestIDHeader          = "request_id"
+)
+
+const (
+	DeadLetterOriginalTopicHeader     = "dlt_original_topic"
+	DeadLetterOriginalPartitionHeader = "dlt_original_partition"
+	DeadLetterOriginalOffsetHeader    = "dlt_original_offset"
+	DeadLetterConsumerGroupHeader     = "dlt_consumer_group"
+	DeadLetterErrorHeader             = "dlt_error"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentSucceeded PaymentStatus = "SUCCEEDED"
+	PaymentFailed    PaymentStatus = "FAILED"
+)
+
+type EmailBounceType string
+
+const (
+	HardBounce EmailBounceType = "HARD"
+	SoftBounce EmailBounceType = "SOFT"
+)
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -52,7 +53,13 @@ func (c *GenreController) CreateGenre(ctx *gin.Context) {
 		return
 	}
 
-	g, err := c.GenreService.CreateGenre(req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	g, err := c.GenreService.CreateGenre(req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -74,7 +81,13 @@ func (c *GenreController) UpdateGenre(ctx *gin.Context) {
 		return
 	}
 
-	g, err := c.GenreService.UpdateGenre(id, req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	g, err := c.GenreService.UpdateGenre(id, req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -90,7 +103,13 @@ func (c *GenreController) DeleteGenre(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.GenreService.DeleteGenre(id); err != nil {
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.GenreService.DeleteGenre(id, reqContext.RequestID); err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
@@ -9,8 +9,10 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/context"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
@@ -126,7 +128,12 @@ func TestGenreController_CreateGenre(t *testing.T) {
 		GenreService: service,
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.POST("/genres", controller.CreateGenre)
 
 	genre := utils.GenerateGenre()
@@ -135,7 +142,7 @@ func TestGenreController_CreateGenre(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().CreateGenre(payload).Return(genre, nil).Times(1)
+		service.EXPECT().CreateGenre(payload, requestID).Return(genre, nil).Times(1)
 
 		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
 
@@ -161,7 +168,7 @@ func TestGenreController_CreateGenre(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreateGenre(payload).Return(nil, errors.InternalServerError("service error"))
+		service.EXPECT().CreateGenre(payload, requestID).Return(nil, errors.InternalServerError("service error"))
 
 		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
 
@@ -185,7 +192,12 @@ func TestGenreController_UpdateGenre(t *testing.T) {
 		GenreService: service,
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.PUT("/genres/:id", controller.UpdateGenre)
 
 	genre := utils.GenerateGenre()
@@ -194,7 +206,7 @@ func TestGenreController_UpdateGenre(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().UpdateGenre(genre.ID, payload).Return(genre, nil).Times(1)
+		service.EXPECT().UpdateGenre(genre.ID, payload, requestID).Return(genre, nil).Times(1)
 
 		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
 
@@ -220,7 +232,7 @@ func TestGenreController_UpdateGenre(t *testing.T) {
 	})
 
 	t.Run("error updating genre", func(t *testing.T) {
-		service.EXPECT().UpdateGenre(genre.ID, payload).Return(nil, errors.InternalServerError("error updating genre")).Times(1)
+		service.EXPECT().UpdateGenre(genre.ID, payload, requestID).Return(nil, errors.InternalServerError("error updating genre")).Times(1)
 
 		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
 
@@ -244,13 +256,18 @@ func TestGenreController_DeleteGenre(t *testing.T) {
 		GenreService: service,
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.DELETE("/genres/:id", controller.DeleteGenre)
 
 	genre := utils.GenerateGenre()
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().DeleteGenre(genre.ID).Return(nil).Times(1)
+		service.EXPECT().DeleteGenre(genre.ID, requestID).Return(nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/genres/%s", genre.ID), nil)
@@ -260,7 +277,7 @@ func TestGenreController_DeleteGenre(t *testing.T) {
 	})
 
 	t.Run("error deleting genre", func(t *testing.T) {
-		service.EXPECT().DeleteGenre(genre.ID).Return(errors.InternalServerError("error deleting genre")).Times(1)
+		service.EXPECT().DeleteGenre(genre.ID, requestID).Return(errors.InternalServerError("error deleting genre")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/genres/%s", genre.ID), nil)
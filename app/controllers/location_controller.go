@@ -3,29 +3,54 @@ package controllers
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/middlewares"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type LocationController struct {
-	LocationService services.LocationService
+	LocationService       services.LocationService
+	LocationImportService services.LocationImportService
 }
 
-func NewLocationController(locationService *services.LocationService) *LocationController {
-	return &LocationController{LocationService: *locationService}
+func NewLocationController(locationService *services.LocationService, locationImportService *services.LocationImportService) *LocationController {
+	return &LocationController{LocationService: *locationService, LocationImportService: *locationImportService}
 }
 
 func (c *LocationController) GetCountries(ctx *gin.Context) {
-	countries, err := c.LocationService.GetCountries()
+	limit, offset := getLocationPagination(ctx)
+
+	countries, meta, err := c.LocationService.GetCountries(limit, offset)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"countries": utils.SliceToMaps(countries), "meta": utils.StructToMap(meta)})
+}
+
+func (c *LocationController) GetCountry(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	country, err := c.LocationService.GetCountry(countryID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"countries": utils.SliceToMaps(countries)})
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(country)})
 }
 
 func (c *LocationController) CreateCountry(ctx *gin.Context) {
@@ -44,6 +69,43 @@ func (c *LocationController) CreateCountry(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(country)})
 }
 
+func (c *LocationController) UpdateCountry(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	var req payloads.UpdateCountryRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	country, err := c.LocationService.UpdateCountry(countryID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(country)})
+}
+
+func (c *LocationController) DeleteCountry(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	if err := c.LocationService.DeleteCountry(countryID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
 func (c *LocationController) GetStatesByCountry(ctx *gin.Context) {
 	countryID, e := uuid.Parse(ctx.Param("countryId"))
 	if e != nil {
@@ -51,13 +113,37 @@ func (c *LocationController) GetStatesByCountry(ctx *gin.Context) {
 		return
 	}
 
-	states, err := c.LocationService.GetStatesByCountry(countryID)
+	limit, offset := getLocationPagination(ctx)
+
+	states, meta, err := c.LocationService.GetStatesByCountry(countryID, limit, offset)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(states), "meta": utils.StructToMap(meta)})
+}
+
+func (c *LocationController) GetState(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	stateID, e := uuid.Parse(ctx.Param("stateId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid state id"})
+		return
+	}
+
+	state, err := c.LocationService.GetState(countryID, stateID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(states)})
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(state)})
 }
 
 func (c *LocationController) CreateState(ctx *gin.Context) {
@@ -82,6 +168,55 @@ func (c *LocationController) CreateState(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(state)})
 }
 
+func (c *LocationController) UpdateState(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	stateID, e := uuid.Parse(ctx.Param("stateId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid state id"})
+		return
+	}
+
+	var req payloads.UpdateStateRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	state, err := c.LocationService.UpdateState(countryID, stateID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(state)})
+}
+
+func (c *LocationController) DeleteState(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	stateID, e := uuid.Parse(ctx.Param("stateId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid state id"})
+		return
+	}
+
+	if err := c.LocationService.DeleteState(countryID, stateID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
 func (c *LocationController) GetCitiesByState(ctx *gin.Context) {
 	countryID, e := uuid.Parse(ctx.Param("countryId"))
 	if e != nil {
@@ -95,13 +230,43 @@ func (c *LocationController) GetCitiesByState(ctx *gin.Context) {
 		return
 	}
 
-	cities, err := c.LocationService.GetCitiesByState(countryID, stateID)
+	limit, offset := getLocationPagination(ctx)
+
+	cities, meta, err := c.LocationService.GetCitiesByState(countryID, stateID, limit, offset)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(cities)})
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(cities), "meta": utils.StructToMap(meta)})
+}
+
+func (c *LocationController) GetCity(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	stateID, e := uuid.Parse(ctx.Param("stateId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid state id"})
+		return
+	}
+
+	cityID, e := uuid.Parse(ctx.Param("cityId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid city id"})
+		return
+	}
+
+	city, err := c.LocationService.GetCity(countryID, stateID, cityID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(city)})
 }
 
 func (c *LocationController) CreateCity(ctx *gin.Context) {
@@ -131,3 +296,151 @@ func (c *LocationController) CreateCity(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(city)})
 }
+
+func (c *LocationController) UpdateCity(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	stateID, e := uuid.Parse(ctx.Param("stateId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid state id"})
+		return
+	}
+
+	cityID, e := uuid.Parse(ctx.Param("cityId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid city id"})
+		return
+	}
+
+	var req payloads.UpdateCityRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	city, err := c.LocationService.UpdateCity(countryID, stateID, cityID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(city)})
+}
+
+func (c *LocationController) DeleteCity(ctx *gin.Context) {
+	countryID, e := uuid.Parse(ctx.Param("countryId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid country id"})
+		return
+	}
+
+	stateID, e := uuid.Parse(ctx.Param("stateId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid state id"})
+		return
+	}
+
+	cityID, e := uuid.Parse(ctx.Param("cityId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid city id"})
+		return
+	}
+
+	if err := c.LocationService.DeleteCity(countryID, stateID, cityID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+func (c *LocationController) SearchCities(ctx *gin.Context) {
+	keyword := strings.TrimSpace(ctx.Query(constants.SearchQuery))
+	if len([]rune(keyword)) < 2 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "search query must have at least 2 characters"})
+		return
+	}
+
+	limit, offset := getLocationPagination(ctx)
+
+	cities, err := c.LocationService.SearchCities(keyword, limit, offset)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(cities)})
+}
+
+func (c *LocationController) ImportLocations(ctx *gin.Context) {
+	format := constants.LocationImportFormat(ctx.DefaultQuery(constants.ImportFormat, string(constants.CsvImportFormat)))
+
+	files, err := middlewares.GetUploadedFiles(ctx, constants.LocationImportFileFormKey)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	data, e := files[0].Open()
+	if e != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": e.Error()})
+		return
+	}
+	defer data.Close()
+
+	source := payloads.LocationImportSource{Format: format, Data: data}
+	for formKey, reader := range map[string]*io.Reader{
+		constants.CountryInfoFileFormKey: &source.CountryInfo,
+		constants.Admin1CodesFileFormKey: &source.Admin1Codes,
+	} {
+		file, e := openOptionalFormFile(ctx, formKey)
+		if e != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": e.Error()})
+			return
+		}
+		if file != nil {
+			defer file.Close()
+			*reader = file
+		}
+	}
+
+	report, err := c.LocationImportService.ImportLocations(source, nil)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(report)})
+}
+
+func openOptionalFormFile(ctx *gin.Context, formKey string) (multipart.File, error) {
+	header, err := ctx.FormFile(formKey)
+	if err == http.ErrMissingFile {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return header.Open()
+}
+
+func getLocationPagination(ctx *gin.Context) (int, int) {
+	limitParam := ctx.DefaultQuery(constants.Limit, "10")
+	limit, e := strconv.Atoi(limitParam)
+	if e != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offsetParam := ctx.DefaultQuery(constants.Offset, "0")
+	offset, e := strconv.Atoi(offsetParam)
+	if e != nil || offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
@@ -8,10 +8,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/middlewares"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"go.uber.org/mock/gomock"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -32,7 +36,7 @@ func TestLocationController_GetCountries(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		countries := utils.GenerateCountries(3)
 
-		service.EXPECT().GetCountries().Return(countries, nil).Times(1)
+		service.EXPECT().GetCountries(10, 0).Return(countries, &models.ResponseMeta{Limit: 10, Total: 3}, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/countries", nil)
@@ -45,8 +49,19 @@ func TestLocationController_GetCountries(t *testing.T) {
 		}
 	})
 
+	t.Run("with pagination", func(t *testing.T) {
+		service.EXPECT().GetCountries(5, 20).Return(nil, &models.ResponseMeta{Limit: 5, Offset: 20, Total: 3}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/countries?limit=5&offset=20", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total":3`)
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetCountries().Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetCountries(10, 0).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/countries", nil)
@@ -119,6 +134,53 @@ func TestLocationController_CreateCountry(t *testing.T) {
 	})
 }
 
+func TestLocationController_GetCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/countries/:countryId", controller.GetCountry)
+
+	country := utils.GenerateCountry()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetCountry(country.ID).Return(country, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s", country.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), country.ID.String())
+		assert.Contains(t, w.Body.String(), country.Name)
+	})
+
+	t.Run("invalid country id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/countries/invalid", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid country id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().GetCountry(country.ID).Return(nil, errors.NotFoundError("country does not exist")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s", country.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "country does not exist")
+	})
+}
+
 func TestLocationController_GetStatesByCountry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -136,7 +198,7 @@ func TestLocationController_GetStatesByCountry(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		states := utils.GenerateStates(3)
 
-		service.EXPECT().GetStatesByCountry(countryID).Return(states, nil).Times(1)
+		service.EXPECT().GetStatesByCountry(countryID, 10, 0).Return(states, &models.ResponseMeta{Limit: 10, Total: 3}, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states", countryID), nil)
@@ -151,7 +213,7 @@ func TestLocationController_GetStatesByCountry(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetStatesByCountry(countryID).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetStatesByCountry(countryID, 10, 0).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states", countryID), nil)
@@ -162,6 +224,62 @@ func TestLocationController_GetStatesByCountry(t *testing.T) {
 	})
 }
 
+func TestLocationController_GetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/countries/:countryId/states/:stateId", controller.GetState)
+
+	state := utils.GenerateState()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetState(state.CountryID, state.ID).Return(state, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s", state.CountryID, state.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), state.ID.String())
+		assert.Contains(t, w.Body.String(), state.Name)
+	})
+
+	t.Run("invalid country id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/invalid/states/%s", state.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid country id")
+	})
+
+	t.Run("invalid state id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/invalid", state.CountryID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid state id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().GetState(state.CountryID, state.ID).Return(nil, errors.NotFoundError("state does not exist")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s", state.CountryID, state.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "state does not exist")
+	})
+}
+
 func TestLocationController_CreateState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -249,7 +367,7 @@ func TestLocationController_GetCitiesByState(t *testing.T) {
 	cities := utils.GenerateCities(3)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetCitiesByState(gomock.Any(), gomock.Any()).Return(cities, nil).Times(1)
+		service.EXPECT().GetCitiesByState(gomock.Any(), gomock.Any(), 10, 0).Return(cities, &models.ResponseMeta{Limit: 10, Total: 3}, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s/cities", uuid.NewString(), uuid.NewString()), nil)
@@ -262,7 +380,7 @@ func TestLocationController_GetCitiesByState(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetCitiesByState(gomock.Any(), gomock.Any()).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetCitiesByState(gomock.Any(), gomock.Any(), 10, 0).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s/cities", uuid.NewString(), uuid.NewString()), nil)
@@ -273,6 +391,54 @@ func TestLocationController_GetCitiesByState(t *testing.T) {
 	})
 }
 
+func TestLocationController_GetCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/countries/:countryId/states/:stateId/cities/:cityId", controller.GetCity)
+
+	countryID := uuid.New()
+	city := utils.GenerateCity()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetCity(countryID, city.StateID, city.ID).Return(city, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, city.StateID, city.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), city.ID.String())
+		assert.Contains(t, w.Body.String(), city.Name)
+	})
+
+	t.Run("invalid city id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s/cities/invalid", countryID, city.StateID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid city id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().GetCity(countryID, city.StateID, city.ID).Return(nil, errors.NotFoundError("city does not exist")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, city.StateID, city.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "city does not exist")
+	})
+}
+
 func TestLocationController_CreateCity(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -285,6 +451,8 @@ func TestLocationController_CreateCity(t *testing.T) {
 	router := gin.Default()
 	router.POST("/countries/:countryId/states/:stateId/cities", controller.CreateCity)
 
+	errors.RegisterCustomValidators()
+
 	state := utils.GenerateState()
 	city := utils.GenerateCity()
 	payload := payloads.CreateCityRequest{
@@ -341,6 +509,18 @@ func TestLocationController_CreateCity(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "Should be greater than or equal to 2")
 	})
 
+	t.Run("invalid time zone", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"name": "%s", "time_zone": "Europe/Atlantis"}`, payload.Name)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/countries/%s/states/%s/cities", state.CountryID, city.StateID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be a valid IANA time zone")
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		service.EXPECT().CreateCity(state.CountryID, city.StateID, payload).Return(nil, errors.InternalServerError("service error")).Times(1)
 
@@ -355,3 +535,497 @@ func TestLocationController_CreateCity(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "service error")
 	})
 }
+
+func TestLocationController_UpdateCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.PUT("/countries/:countryId", controller.UpdateCountry)
+
+	country := utils.GenerateCountry()
+	payload := payloads.UpdateCountryRequest{
+		Name: country.Name,
+		Code: country.Code,
+	}
+	reqBody := fmt.Sprintf(`{"name": "%s", "code": "%s"}`, payload.Name, payload.Code)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateCountry(country.ID, payload).Return(country, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s", country.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), country.Name)
+		assert.Contains(t, w.Body.String(), country.Code)
+	})
+
+	t.Run("invalid country id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/countries/invalid", bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid country id")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		invalidBody := fmt.Sprintf(`{"name": "%s", "code": "%s"}`, payload.Name, "INVALID_CODE")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s", country.ID), bytes.NewBufferString(invalidBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be a valid length of 2")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateCountry(country.ID, payload).Return(nil, errors.BadRequestError("duplicate country name or code")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s", country.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "duplicate country name or code")
+	})
+}
+
+func TestLocationController_DeleteCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.DELETE("/countries/:countryId", controller.DeleteCountry)
+
+	countryID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeleteCountry(countryID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s", countryID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid country id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/countries/invalid", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid country id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DeleteCountry(countryID).Return(errors.BadRequestError("country has cities used by theaters")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s", countryID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "country has cities used by theaters")
+	})
+}
+
+func TestLocationController_UpdateState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.PUT("/countries/:countryId/states/:stateId", controller.UpdateState)
+
+	state := utils.GenerateState()
+	payload := payloads.UpdateStateRequest{
+		Name: state.Name,
+		Code: state.Code,
+	}
+	reqBody := fmt.Sprintf(`{"name": "%s", "code": "%s"}`, payload.Name, *payload.Code)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateState(state.CountryID, state.ID, payload).Return(state, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s", state.CountryID, state.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), state.Name)
+	})
+
+	t.Run("invalid state id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/invalid", state.CountryID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid state id")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s", state.CountryID, state.ID), bytes.NewBufferString(`{"name": "a"}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateState(state.CountryID, state.ID, payload).Return(nil, errors.NotFoundError("state does not exist")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s", state.CountryID, state.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "state does not exist")
+	})
+}
+
+func TestLocationController_DeleteState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.DELETE("/countries/:countryId/states/:stateId", controller.DeleteState)
+
+	countryID := uuid.New()
+	stateID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeleteState(countryID, stateID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s/states/%s", countryID, stateID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid state id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s/states/invalid", countryID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid state id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DeleteState(countryID, stateID).Return(errors.BadRequestError("state has cities used by theaters")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s/states/%s", countryID, stateID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "state has cities used by theaters")
+	})
+}
+
+func TestLocationController_UpdateCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.PUT("/countries/:countryId/states/:stateId/cities/:cityId", controller.UpdateCity)
+
+	errors.RegisterCustomValidators()
+
+	countryID := uuid.New()
+	city := utils.GenerateCity()
+	payload := payloads.UpdateCityRequest{
+		Name: city.Name,
+	}
+	reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateCity(countryID, city.StateID, city.ID, payload).Return(city, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, city.StateID, city.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), city.Name)
+	})
+
+	t.Run("invalid city id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s/cities/invalid", countryID, city.StateID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid city id")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, city.StateID, city.ID), bytes.NewBufferString(`{}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateCity(countryID, city.StateID, city.ID, payload).Return(nil, errors.BadRequestError("duplicate city name for this state")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, city.StateID, city.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "duplicate city name for this state")
+	})
+}
+
+func TestLocationController_DeleteCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.DELETE("/countries/:countryId/states/:stateId/cities/:cityId", controller.DeleteCity)
+
+	countryID := uuid.New()
+	stateID := uuid.New()
+	cityID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeleteCity(countryID, stateID, cityID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, stateID, cityID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid city id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s/states/%s/cities/invalid", countryID, stateID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid city id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DeleteCity(countryID, stateID, cityID).Return(errors.BadRequestError("city is used by theaters")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/countries/%s/states/%s/cities/%s", countryID, stateID, cityID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "city is used by theaters")
+	})
+}
+
+func TestLocationController_SearchCities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationService(ctrl)
+	controller := LocationController{
+		LocationService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/cities/search", controller.SearchCities)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.Country = country
+	city := utils.GenerateCity()
+	city.State = state
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().SearchCities("spring", 10, 0).Return([]*models.City{city}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cities/search?q=%20spring%20", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), city.Name)
+		assert.Contains(t, w.Body.String(), state.Name)
+		assert.Contains(t, w.Body.String(), country.Name)
+	})
+
+	t.Run("with pagination", func(t *testing.T) {
+		service.EXPECT().SearchCities("spring", 5, 10).Return([]*models.City{}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cities/search?q=spring&limit=5&offset=10", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("query too short", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cities/search?q=s", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "search query must have at least 2 characters")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().SearchCities("spring", 10, 0).Return(nil, errors.InternalServerError("service error")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cities/search?q=spring", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "service error")
+	})
+}
+
+func TestLocationController_ImportLocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockLocationImportService(ctrl)
+	controller := LocationController{
+		LocationImportService: service,
+	}
+
+	filesUploadMiddleware := middlewares.NewFilesUploadMiddleware()
+	router := gin.Default()
+	router.POST(
+		"/countries/import",
+		filesUploadMiddleware.RequireNumberOfUploadedFilesMiddleware(constants.LocationImportFileFormKey, 1),
+		controller.ImportLocations,
+	)
+
+	newImportRequest := func(url string, files map[string]string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for formKey, content := range files {
+			part, _ := writer.CreateFormFile(formKey, formKey+".txt")
+			_, _ = part.Write([]byte(content))
+		}
+		_ = writer.Close()
+
+		req, _ := http.NewRequest(http.MethodPost, url, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	t.Run("success with csv", func(t *testing.T) {
+		service.EXPECT().ImportLocations(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(source payloads.LocationImportSource, onProgress func(report *payloads.LocationImportReport)) (*payloads.LocationImportReport, *errors.ApiError) {
+				data, _ := io.ReadAll(source.Data)
+				assert.Equal(t, constants.CsvImportFormat, source.Format)
+				assert.Equal(t, "country_code,state_code,city_name", string(data))
+				assert.Nil(t, source.CountryInfo)
+				assert.Nil(t, source.Admin1Codes)
+				return &payloads.LocationImportReport{RowsProcessed: 1, RowsImported: 1, CitiesCreated: 1}, nil
+			},
+		).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest("/countries/import", map[string]string{
+			constants.LocationImportFileFormKey: "country_code,state_code,city_name",
+		}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"rows_imported":1`)
+		assert.Contains(t, w.Body.String(), `"cities_created":1`)
+	})
+
+	t.Run("success with geonames", func(t *testing.T) {
+		service.EXPECT().ImportLocations(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(source payloads.LocationImportSource, onProgress func(report *payloads.LocationImportReport)) (*payloads.LocationImportReport, *errors.ApiError) {
+				countryInfo, _ := io.ReadAll(source.CountryInfo)
+				admin1Codes, _ := io.ReadAll(source.Admin1Codes)
+				assert.Equal(t, constants.GeoNamesImportFormat, source.Format)
+				assert.Equal(t, "VN\tVNM", string(countryInfo))
+				assert.Equal(t, "VN.20\tHo Chi Minh", string(admin1Codes))
+				return &payloads.LocationImportReport{}, nil
+			},
+		).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest("/countries/import?format=geonames", map[string]string{
+			constants.LocationImportFileFormKey: "cities",
+			constants.CountryInfoFileFormKey:    "VN\tVNM",
+			constants.Admin1CodesFileFormKey:    "VN.20\tHo Chi Minh",
+		}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("missing import file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest("/countries/import", map[string]string{
+			constants.CountryInfoFileFormKey: "VN\tVNM",
+		}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().ImportLocations(gomock.Any(), gomock.Any()).Return(nil, errors.BadRequestError("unsupported import format xlsx")).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newImportRequest("/countries/import?format=xlsx", map[string]string{
+			constants.LocationImportFileFormKey: "cities",
+		}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unsupported import format xlsx")
+	})
+}
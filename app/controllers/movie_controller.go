@@ -10,6 +10,7 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"net/http"
+	"slices"
 	"strconv"
 )
 
@@ -28,7 +29,7 @@ func (c *MovieController) GetMovie(ctx *gin.Context) {
 		return
 	}
 
-	m, err := c.MovieService.GetMovie(id, c.getUserEmail(ctx), c.doIncludeGenres(ctx))
+	m, err := c.MovieService.GetMovie(id, c.getUserEmail(ctx), c.doIncludeGenres(ctx), c.doIncludeDetails(ctx))
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -50,7 +51,13 @@ func (c *MovieController) GetMovies(ctx *gin.Context) {
 		offset = 0
 	}
 
-	movies, meta, err := c.MovieService.GetMovies(limit, offset, c.getUserEmail(ctx), c.doIncludeGenres(ctx))
+	search, err := getMovieSearchFilter(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	movies, meta, err := c.MovieService.GetMovies(search, limit, offset, c.getUserEmail(ctx), c.doIncludeGenres(ctx), c.doIncludeDetails(ctx))
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -76,7 +83,7 @@ func (c *MovieController) CreateMovie(ctx *gin.Context) {
 		return
 	}
 
-	m, err := c.MovieService.CreateMovie(req, reqContext.UserSession.UserID)
+	m, err := c.MovieService.CreateMovie(req, reqContext.UserSession.UserID, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -108,7 +115,7 @@ func (c *MovieController) UpdateMovie(ctx *gin.Context) {
 		return
 	}
 
-	m, err := c.MovieService.UpdateMovie(id, reqContext.UserSession.UserID, req)
+	m, err := c.MovieService.UpdateMovie(id, reqContext.UserSession.UserID, req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -130,7 +137,13 @@ func (c *MovieController) UpdateMovieGenres(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.MovieService.AssignGenres(id, req.GenreIDs); err != nil {
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.MovieService.AssignGenres(id, req.GenreIDs, reqContext.RequestID); err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
@@ -138,6 +151,118 @@ func (c *MovieController) UpdateMovieGenres(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": "genres of movie are updated successfully"})
 }
 
+func (c *MovieController) UpdateMovieCredits(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var req payloads.UpdateMovieCreditsRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	credits, err := c.MovieService.UpdateMovieCredits(id, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(credits)})
+}
+
+func (c *MovieController) UpdateMovieCertifications(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var req payloads.UpdateMovieCertificationsRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	certifications, err := c.MovieService.UpdateMovieCertifications(id, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(certifications)})
+}
+
+func (c *MovieController) UpdateMovieLanguages(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var req payloads.UpdateMovieLanguagesRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	languages, err := c.MovieService.UpdateMovieLanguages(id, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(languages)})
+}
+
+func (c *MovieController) UpdateMovieExternalIDs(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var req payloads.UpdateMovieExternalIDsRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	externalIDs, err := c.MovieService.UpdateMovieExternalIDs(id, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(externalIDs)})
+}
+
 func (c *MovieController) DeleteMovie(ctx *gin.Context) {
 	id, e := uuid.Parse(ctx.Param("id"))
 	if e != nil {
@@ -155,7 +280,7 @@ func (c *MovieController) DeleteMovie(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.MovieService.DeleteMovie(id, reqContext.UserSession.UserID); err != nil {
+	if err := c.MovieService.DeleteMovie(id, reqContext.UserSession.UserID, reqContext.RequestID); err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
@@ -167,6 +292,10 @@ func (c *MovieController) doIncludeGenres(ctx *gin.Context) bool {
 	return ctx.Query(constants.IncludeGenres) == "true"
 }
 
+func (c *MovieController) doIncludeDetails(ctx *gin.Context) bool {
+	return ctx.Query(constants.IncludeMovieDetails) == "true"
+}
+
 func (c *MovieController) getUserEmail(ctx *gin.Context) *string {
 	var userEmail *string
 	reqContext, err := context.GetRequestContext(ctx)
@@ -176,3 +305,64 @@ func (c *MovieController) getUserEmail(ctx *gin.Context) *string {
 
 	return userEmail
 }
+
+func getMovieSearchFilter(ctx *gin.Context) (payloads.MovieSearchFilter, *errors.ApiError) {
+	var search payloads.MovieSearchFilter
+	if personIdParam := ctx.Query(constants.PersonID); personIdParam != "" {
+		personId, e := uuid.Parse(personIdParam)
+		if e != nil {
+			return search, errors.BadRequestError("invalid person id")
+		}
+		search.PersonID = &personId
+	}
+
+	if roleParam := ctx.Query(constants.Role); roleParam != "" {
+		role := constants.CreditRole(roleParam)
+		if !slices.Contains(constants.CreditRoles, role) {
+			return search, errors.BadRequestError("invalid role")
+		}
+		search.Role = &role
+	}
+
+	if countryIdParam := ctx.Query(constants.CountryID); countryIdParam != "" {
+		countryId, e := uuid.Parse(countryIdParam)
+		if e != nil {
+			return search, errors.BadRequestError("invalid country id")
+		}
+		search.CountryID = &countryId
+	}
+
+	if certification := ctx.Query(constants.Certification); certification != "" {
+		search.Certification = &certification
+	}
+
+	if maxAgeParam := ctx.Query(constants.MaxAge); maxAgeParam != "" {
+		maxAge, e := strconv.Atoi(maxAgeParam)
+		if e != nil || maxAge < 0 {
+			return search, errors.BadRequestError("invalid max age")
+		}
+		search.MaxAge = &maxAge
+	}
+
+	if subtitle := ctx.Query(constants.SubtitleLanguage); subtitle != "" {
+		search.Subtitle = &subtitle
+	}
+
+	if dub := ctx.Query(constants.DubLanguage); dub != "" {
+		search.Dub = &dub
+	}
+
+	if sourceParam := ctx.Query(constants.ExternalSource); sourceParam != "" {
+		source := constants.ExternalIDSource(sourceParam)
+		if !slices.Contains(constants.ExternalIDSources, source) {
+			return search, errors.BadRequestError("invalid external source")
+		}
+		search.ExternalSource = &source
+	}
+
+	if externalId := ctx.Query(constants.ExternalID); externalId != "" {
+		search.ExternalID = &externalId
+	}
+
+	return search, nil
+}
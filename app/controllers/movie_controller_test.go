@@ -42,7 +42,7 @@ func TestMovieController_GetMovie(t *testing.T) {
 	router.GET("/movies/:id", controller.GetMovie)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetMovie(movie.ID, &session.Email, true).Return(movie, nil).Times(1)
+		service.EXPECT().GetMovie(movie.ID, &session.Email, true, false).Return(movie, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/movies/%s?%s=true", movie.ID, constants.IncludeGenres), nil)
@@ -60,7 +60,7 @@ func TestMovieController_GetMovie(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetMovie(movie.ID, &session.Email, false).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetMovie(movie.ID, &session.Email, false, false).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/movies/%s?%s=false", movie.ID, constants.IncludeGenres), nil)
@@ -99,7 +99,7 @@ func TestMovieController_GetMovies(t *testing.T) {
 	router.GET("/movies", controller.GetMovies)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetMovies(10, 0, &session.Email, false).Return(movies, meta, nil).Times(1)
+		service.EXPECT().GetMovies(payloads.MovieSearchFilter{}, 10, 0, &session.Email, false, false).Return(movies, meta, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/movies?%s=10&%s=0", constants.Limit, constants.Offset), nil)
@@ -118,7 +118,7 @@ func TestMovieController_GetMovies(t *testing.T) {
 	})
 
 	t.Run("default limit and offset when receiving invalid values", func(t *testing.T) {
-		service.EXPECT().GetMovies(10, 0, &session.Email, false).Return(movies, meta, nil).Times(1)
+		service.EXPECT().GetMovies(payloads.MovieSearchFilter{}, 10, 0, &session.Email, false, false).Return(movies, meta, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/movies?%s=a&%s=b", constants.Limit, constants.Offset), nil)
@@ -136,8 +136,40 @@ func TestMovieController_GetMovies(t *testing.T) {
 		}
 	})
 
+	t.Run("success with search filter", func(t *testing.T) {
+		personID := uuid.New()
+		role := constants.Actor
+		maxAge := 16
+		dub := "vi"
+		search := payloads.MovieSearchFilter{PersonID: &personID, Role: &role, MaxAge: &maxAge, Dub: &dub}
+		service.EXPECT().GetMovies(search, 10, 0, &session.Email, false, true).Return(movies, meta, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/movies?%s=%s&%s=ACTOR&%s=16&%s=vi&%s=true", constants.PersonID, personID, constants.Role, constants.MaxAge, constants.DubLanguage, constants.IncludeMovieDetails), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid search filter", func(t *testing.T) {
+		for query, msg := range map[string]string{
+			constants.PersonID + "=abc":        "invalid person id",
+			constants.Role + "=GRIP":           "invalid role",
+			constants.CountryID + "=abc":       "invalid country id",
+			constants.MaxAge + "=-1":           "invalid max age",
+			constants.ExternalSource + "=IMBD": "invalid external source",
+		} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/movies?"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), msg)
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetMovies(10, 0, &session.Email, false).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetMovies(payloads.MovieSearchFilter{}, 10, 0, &session.Email, false, false).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/movies?%s=10&%s=0", constants.Limit, constants.Offset), nil)
@@ -170,9 +202,10 @@ func TestMovieController_CreateMovie(t *testing.T) {
 		IsActive:        utils.GetPointerOf(true),
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
 	router.Use(func(c *gin.Context) {
-		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		context.SetRequestContext(c, context.RequestContext{UserSession: session, RequestID: requestID})
 		c.Next()
 	})
 	router.POST("/movies", controller.CreateMovie)
@@ -180,7 +213,7 @@ func TestMovieController_CreateMovie(t *testing.T) {
 	errors.RegisterCustomValidators()
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().CreateMovie(payload, session.UserID).
+		service.EXPECT().CreateMovie(payload, session.UserID, requestID).
 			Return(movie, nil)
 
 		reqBody := fmt.Sprintf(`{"title": "%s", "description": "%s", "release_date": "%s", "duration_minutes": %d, "language": "%s", "rating": %g, "is_active": %v}`,
@@ -236,7 +269,7 @@ func TestMovieController_CreateMovie(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreateMovie(payload, session.UserID).
+		service.EXPECT().CreateMovie(payload, session.UserID, requestID).
 			Return(nil, errors.InternalServerError("Service error"))
 
 		reqBody := fmt.Sprintf(`{"title": "%s", "description": "%s", "release_date": "%s", "duration_minutes": %d, "language": "%s", "rating": %g, "is_active": %v}`,
@@ -273,9 +306,10 @@ func TestMovieController_UpdateMovie(t *testing.T) {
 		IsActive:        utils.GetPointerOf(true),
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
 	router.Use(func(c *gin.Context) {
-		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		context.SetRequestContext(c, context.RequestContext{UserSession: session, RequestID: requestID})
 		c.Next()
 	})
 	router.PUT("/movies/:id", controller.UpdateMovie)
@@ -283,7 +317,7 @@ func TestMovieController_UpdateMovie(t *testing.T) {
 	errors.RegisterCustomValidators()
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().UpdateMovie(movie.ID, session.UserID, payload).
+		service.EXPECT().UpdateMovie(movie.ID, session.UserID, payload, requestID).
 			Return(movie, nil)
 
 		reqBody := fmt.Sprintf(`{"title": "%s", "description": "%s", "release_date": "%s", "duration_minutes": %d, "language": "%s", "rating": %g, "is_active": %v}`,
@@ -320,7 +354,7 @@ func TestMovieController_UpdateMovie(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().UpdateMovie(movie.ID, session.UserID, payload).
+		service.EXPECT().UpdateMovie(movie.ID, session.UserID, payload, requestID).
 			Return(nil, errors.InternalServerError("Service error"))
 
 		reqBody := fmt.Sprintf(`{"title": "%s", "description": "%s", "release_date": "%s", "duration_minutes": %d, "language": "%s", "rating": %g, "is_active": %v}`,
@@ -350,13 +384,18 @@ func TestMovieController_UpdateMovieGenres(t *testing.T) {
 		GenreIDs: []uuid.UUID{uuid.New(), uuid.New()},
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.PUT("/movies/:id/genres", controller.UpdateMovieGenres)
 
 	errors.RegisterCustomValidators()
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().AssignGenres(movie.ID, payload.GenreIDs).Return(nil).Times(1)
+		service.EXPECT().AssignGenres(movie.ID, payload.GenreIDs, requestID).Return(nil).Times(1)
 
 		reqBody := fmt.Sprintf(`{"genre_ids": ["%s", "%s"]}`, payload.GenreIDs[0], payload.GenreIDs[1])
 
@@ -382,7 +421,7 @@ func TestMovieController_UpdateMovieGenres(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().AssignGenres(movie.ID, payload.GenreIDs).Return(errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().AssignGenres(movie.ID, payload.GenreIDs, requestID).Return(errors.InternalServerError("service error")).Times(1)
 
 		reqBody := fmt.Sprintf(`{"genre_ids": ["%s", "%s"]}`, payload.GenreIDs[0], payload.GenreIDs[1])
 
@@ -408,15 +447,16 @@ func TestMovieController_DeleteMovie(t *testing.T) {
 	movie := utils.GenerateMovie()
 	session := utils.GenerateUserSession()
 
+	requestID := uuid.New()
 	router := gin.Default()
 	router.Use(func(c *gin.Context) {
-		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		context.SetRequestContext(c, context.RequestContext{UserSession: session, RequestID: requestID})
 		c.Next()
 	})
 	router.DELETE("/movies/:id", controller.DeleteMovie)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().DeleteMovie(movie.ID, session.UserID).Return(nil).Times(1)
+		service.EXPECT().DeleteMovie(movie.ID, session.UserID, requestID).Return(nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/movies/%s", movie.ID), nil)
@@ -426,7 +466,7 @@ func TestMovieController_DeleteMovie(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().DeleteMovie(movie.ID, session.UserID).Return(errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().DeleteMovie(movie.ID, session.UserID, requestID).Return(errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/movies/%s", movie.ID), nil)
@@ -436,3 +476,263 @@ func TestMovieController_DeleteMovie(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "service error")
 	})
 }
+
+func TestMovieController_UpdateMovieCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockMovieService(ctrl)
+	controller := MovieController{
+		MovieService: service,
+	}
+
+	movie := utils.GenerateMovie()
+	credit := utils.GenerateMovieCredit()
+	credit.MovieID = movie.ID
+	payload := payloads.UpdateMovieCreditsRequest{
+		Credits: []payloads.MovieCreditRequest{
+			{PersonID: credit.PersonID, Role: credit.Role, CharacterName: credit.CharacterName, Position: credit.Position},
+		},
+	}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/movies/:id/credits", controller.UpdateMovieCredits)
+
+	errors.RegisterCustomValidators()
+
+	reqBody := fmt.Sprintf(`{"credits": [{"person_id": "%s", "role": "%s", "character_name": "%s", "position": %d}]}`, credit.PersonID, credit.Role, *credit.CharacterName, credit.Position)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateMovieCredits(movie.ID, payload, requestID).Return([]*models.MovieCredit{credit}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/credits", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), credit.PersonID.String())
+		assert.Contains(t, w.Body.String(), *credit.CharacterName)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		body := fmt.Sprintf(`{"credits": [{"person_id": "%s", "role": "GRIP"}]}`, credit.PersonID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/credits", movie.ID), bytes.NewBufferString(body))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be one of ACTOR, DIRECTOR, WRITER, PRODUCER, COMPOSER")
+	})
+
+	t.Run("invalid movie id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/movies/abc/credits", bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid movie id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateMovieCredits(movie.ID, payload, requestID).Return(nil, errors.BadRequestError("invalid person ids")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/credits", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid person ids")
+	})
+}
+
+func TestMovieController_UpdateMovieCertifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockMovieService(ctrl)
+	controller := MovieController{
+		MovieService: service,
+	}
+
+	movie := utils.GenerateMovie()
+	certification := utils.GenerateMovieCertification()
+	certification.MovieID = movie.ID
+	payload := payloads.UpdateMovieCertificationsRequest{
+		Certifications: []payloads.MovieCertificationRequest{
+			{CountryID: certification.CountryID, Rating: certification.Rating, MinAge: &certification.MinAge},
+		},
+	}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/movies/:id/certifications", controller.UpdateMovieCertifications)
+
+	errors.RegisterCustomValidators()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateMovieCertifications(movie.ID, payload, requestID).Return([]*models.MovieCertification{certification}, nil).Times(1)
+
+		reqBody := fmt.Sprintf(`{"certifications": [{"country_id": "%s", "rating": "%s", "min_age": %d}]}`, certification.CountryID, certification.Rating, certification.MinAge)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/certifications", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), certification.CountryID.String())
+		assert.Contains(t, w.Body.String(), certification.Rating)
+	})
+
+	t.Run("duplicate countries", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"certifications": [{"country_id": "%s", "rating": "PG", "min_age": 0}, {"country_id": "%s", "rating": "R", "min_age": 17}]}`, certification.CountryID, certification.CountryID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/certifications", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should not contain duplicate values")
+	})
+
+	t.Run("missing min age", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"certifications": [{"country_id": "%s", "rating": "PG"}]}`, certification.CountryID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/certifications", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "This field is required")
+	})
+}
+
+func TestMovieController_UpdateMovieLanguages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockMovieService(ctrl)
+	controller := MovieController{
+		MovieService: service,
+	}
+
+	movie := utils.GenerateMovie()
+	payload := payloads.UpdateMovieLanguagesRequest{Subtitles: []string{"en", "vi"}, Dubs: []string{"pt-BR"}}
+	languages := []*models.MovieLanguage{
+		{MovieID: movie.ID, Language: "en", Type: constants.Subtitle},
+		{MovieID: movie.ID, Language: "vi", Type: constants.Subtitle},
+		{MovieID: movie.ID, Language: "pt-BR", Type: constants.Dub},
+	}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/movies/:id/languages", controller.UpdateMovieLanguages)
+
+	errors.RegisterCustomValidators()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateMovieLanguages(movie.ID, payload, requestID).Return(languages, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/languages", movie.ID), bytes.NewBufferString(`{"subtitles": ["en", "vi"], "dubs": ["pt-BR"]}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "pt-BR")
+		assert.Contains(t, w.Body.String(), string(constants.Dub))
+	})
+
+	t.Run("invalid language tag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/languages", movie.ID), bytes.NewBufferString(`{"subtitles": ["not a language"]}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be a valid BCP 47 language tag")
+	})
+}
+
+func TestMovieController_UpdateMovieExternalIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockMovieService(ctrl)
+	controller := MovieController{
+		MovieService: service,
+	}
+
+	movie := utils.GenerateMovie()
+	externalID := utils.GenerateMovieExternalID()
+	externalID.MovieID = movie.ID
+	payload := payloads.UpdateMovieExternalIDsRequest{
+		ExternalIDs: []payloads.MovieExternalIDRequest{{Source: externalID.Source, ExternalID: externalID.ExternalID}},
+	}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/movies/:id/external-ids", controller.UpdateMovieExternalIDs)
+
+	errors.RegisterCustomValidators()
+
+	reqBody := fmt.Sprintf(`{"external_ids": [{"source": "%s", "external_id": "%s"}]}`, externalID.Source, externalID.ExternalID)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateMovieExternalIDs(movie.ID, payload, requestID).Return([]*models.MovieExternalID{externalID}, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/external-ids", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), externalID.ExternalID)
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/external-ids", movie.ID), bytes.NewBufferString(`{"external_ids": [{"source": "RT", "external_id": "x"}]}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be one of IMDB, TMDB")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateMovieExternalIDs(movie.ID, payload, requestID).Return(nil, errors.BadRequestError("already linked to another movie")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/movies/%s/external-ids", movie.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "already linked to another movie")
+	})
+}
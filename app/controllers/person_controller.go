@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/services"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"net/http"
+	"strconv"
+)
+
+type PersonController struct {
+	PersonService services.PersonService
+}
+
+func NewPersonController(personService *services.PersonService) *PersonController {
+	return &PersonController{PersonService: *personService}
+}
+
+func (c *PersonController) GetPerson(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	p, err := c.PersonService.GetPerson(id)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(p)})
+}
+
+func (c *PersonController) SearchPeople(ctx *gin.Context) {
+	limit, e := strconv.Atoi(ctx.DefaultQuery(constants.Limit, "10"))
+	if e != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, e := strconv.Atoi(ctx.DefaultQuery(constants.Offset, "0"))
+	if e != nil || offset < 0 {
+		offset = 0
+	}
+
+	people, err := c.PersonService.SearchPeople(ctx.Query(constants.SearchQuery), limit, offset)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(people)})
+}
+
+func (c *PersonController) CreatePerson(ctx *gin.Context) {
+	var req payloads.CreatePersonRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	p, err := c.PersonService.CreatePerson(req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(p)})
+}
+
+func (c *PersonController) UpdatePerson(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	var req payloads.UpdatePersonRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	p, err := c.PersonService.UpdatePerson(id, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(p)})
+}
+
+func (c *PersonController) DeletePerson(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	if err := c.PersonService.DeletePerson(id); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
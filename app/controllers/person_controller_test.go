@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPersonController_GetPerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockPersonService(ctrl)
+	controller := PersonController{
+		PersonService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/people/:id", controller.GetPerson)
+
+	person := utils.GeneratePerson()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetPerson(person.ID).Return(person, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/people/%s", person.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), person.Name)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/people/abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid person id")
+	})
+
+	t.Run("person not found", func(t *testing.T) {
+		service.EXPECT().GetPerson(person.ID).Return(nil, errors.NotFoundError("person not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/people/%s", person.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "person not found")
+	})
+}
+
+func TestPersonController_SearchPeople(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockPersonService(ctrl)
+	controller := PersonController{
+		PersonService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/people", controller.SearchPeople)
+
+	people := utils.GeneratePeople(3)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().SearchPeople("nolan", 5, 10).Return(people, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/people?%s=nolan&%s=5&%s=10", constants.SearchQuery, constants.Limit, constants.Offset), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		for _, p := range people {
+			assert.Contains(t, w.Body.String(), p.ID.String())
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().SearchPeople("", 10, 0).Return(nil, errors.InternalServerError("service error")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/people", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "service error")
+	})
+}
+
+func TestPersonController_CreatePerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockPersonService(ctrl)
+	controller := PersonController{
+		PersonService: service,
+	}
+
+	router := gin.Default()
+	router.POST("/people", controller.CreatePerson)
+
+	errors.RegisterCustomValidators()
+
+	person := utils.GeneratePerson()
+	payload := payloads.CreatePersonRequest{Name: person.Name, Biography: person.Biography}
+	reqBody := fmt.Sprintf(`{"name": "%s", "biography": "%s"}`, person.Name, *person.Biography)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().CreatePerson(payload).Return(person, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/people", bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), person.ID.String())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/people", bytes.NewBufferString(`{"biography": "bio"}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "This field is required")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().CreatePerson(payload).Return(nil, errors.InternalServerError("service error")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/people", bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "service error")
+	})
+}
+
+func TestPersonController_UpdatePerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockPersonService(ctrl)
+	controller := PersonController{
+		PersonService: service,
+	}
+
+	router := gin.Default()
+	router.PUT("/people/:id", controller.UpdatePerson)
+
+	errors.RegisterCustomValidators()
+
+	person := utils.GeneratePerson()
+	payload := payloads.UpdatePersonRequest{Name: person.Name}
+	reqBody := fmt.Sprintf(`{"name": "%s"}`, person.Name)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdatePerson(person.ID, payload).Return(person, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/people/%s", person.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), person.Name)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/people/abc", bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid person id")
+	})
+}
+
+func TestPersonController_DeletePerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockPersonService(ctrl)
+	controller := PersonController{
+		PersonService: service,
+	}
+
+	router := gin.Default()
+	router.DELETE("/people/:id", controller.DeletePerson)
+
+	person := utils.GeneratePerson()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeletePerson(person.ID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/people/%s", person.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("person has credits", func(t *testing.T) {
+		service.EXPECT().DeletePerson(person.ID).Return(errors.BadRequestError("person has movie credits")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/people/%s", person.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "person has movie credits")
+	})
+}
@@ -11,15 +11,18 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type ShowController struct {
-	ShowService services.ShowService
+	ShowService    services.ShowService
+	AgeGateService services.AgeGateService
 }
 
-func NewShowController(showService *services.ShowService) *ShowController {
+func NewShowController(showService *services.ShowService, ageGateService *services.AgeGateService) *ShowController {
 	return &ShowController{
-		ShowService: *showService,
+		ShowService:    *showService,
+		AgeGateService: *ageGateService,
 	}
 }
 
@@ -58,7 +61,13 @@ func (c *ShowController) GetActiveShows(ctx *gin.Context) {
 		offset = 0
 	}
 
-	shows, err := c.ShowService.GetShows(constants.Active, limit, offset)
+	search, err := getShowSearchFilter(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	shows, err := c.ShowService.GetShows(constants.Active, search, limit, offset)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -80,7 +89,13 @@ func (c *ShowController) GetScheduledShows(ctx *gin.Context) {
 		offset = 0
 	}
 
-	shows, err := c.ShowService.GetShows(constants.Scheduled, limit, offset)
+	search, err := getShowSearchFilter(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	shows, err := c.ShowService.GetShows(constants.Scheduled, search, limit, offset)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -96,7 +111,13 @@ func (c *ShowController) CreateShow(ctx *gin.Context) {
 		return
 	}
 
-	show, err := c.ShowService.CreateShow(req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	show, err := c.ShowService.CreateShow(req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -104,3 +125,136 @@ func (c *ShowController) CreateShow(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(show)})
 }
+
+func (c *ShowController) GetAvailableSeats(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid show id"})
+		return
+	}
+
+	var userEmail *string
+	reqContext, err := context.GetRequestContext(ctx)
+	if err == nil && reqContext.UserSession != nil {
+		userEmail = &reqContext.UserSession.Email
+	}
+
+	seats, err := c.ShowService.GetAvailableSeats(id, userEmail)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(seats)})
+}
+
+func (c *ShowController) BlockSeat(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid show id"})
+		return
+	}
+
+	seatId, e := uuid.Parse(ctx.Param("seatId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid seat id"})
+		return
+	}
+
+	var req payloads.BlockSeatRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if reqContext.UserSession == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	block, err := c.ShowService.BlockSeat(id, seatId, reqContext.UserSession.UserID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(block)})
+}
+
+func (c *ShowController) UnblockSeat(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid show id"})
+		return
+	}
+
+	seatId, e := uuid.Parse(ctx.Param("seatId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid seat id"})
+		return
+	}
+
+	if err := c.ShowService.UnblockSeat(id, seatId); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+func (c *ShowController) CheckAgeRestriction(ctx *gin.Context) {
+	id, e := uuid.Parse(ctx.Param("id"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid show id"})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if reqContext.UserSession == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	result, err := c.AgeGateService.CheckShowAccess(id, reqContext.UserSession.UserID, reqContext.UserSession.Email)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(result)})
+}
+
+func getShowSearchFilter(ctx *gin.Context) (payloads.ShowSearchFilter, *errors.ApiError) {
+	var search payloads.ShowSearchFilter
+	if theaterIdParam := ctx.Query(constants.TheaterID); theaterIdParam != "" {
+		theaterId, e := uuid.Parse(theaterIdParam)
+		if e != nil {
+			return search, errors.BadRequestError("invalid theater id")
+		}
+		search.TheaterID = &theaterId
+	}
+
+	// Dates are calendar days in the zone of each theater, so they are kept without a location here.
+	switch dateParam := ctx.Query(constants.ShowDate); dateParam {
+	case "":
+	case constants.Today:
+		search.Today = true
+	default:
+		date, e := time.Parse(time.DateOnly, dateParam)
+		if e != nil {
+			return search, errors.BadRequestError("invalid date")
+		}
+		search.Date = &date
+	}
+
+	return search, nil
+}
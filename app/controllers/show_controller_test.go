@@ -4,17 +4,20 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/context"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestShowController_GetShow(t *testing.T) {
@@ -76,7 +79,7 @@ func TestShowController_GetActiveShows(t *testing.T) {
 	shows := utils.GenerateShows(3)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetShows(constants.Active, limit, offset).Return(shows, nil).Times(1)
+		service.EXPECT().GetShows(constants.Active, payloads.ShowSearchFilter{}, limit, offset).Return(shows, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/active?%s=%d&%s=%d", constants.Limit, limit, constants.Offset, offset), nil)
@@ -89,7 +92,7 @@ func TestShowController_GetActiveShows(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetShows(constants.Active, limit, offset).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetShows(constants.Active, payloads.ShowSearchFilter{}, limit, offset).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/active?%s=%d&%s=%d", constants.Limit, limit, constants.Offset, offset), nil)
@@ -98,6 +101,51 @@ func TestShowController_GetActiveShows(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		assert.Contains(t, w.Body.String(), "service error")
 	})
+
+	t.Run("search by theater and date", func(t *testing.T) {
+		theaterId := uuid.New()
+		date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+		search := payloads.ShowSearchFilter{TheaterID: &theaterId, Date: &date}
+		service.EXPECT().GetShows(constants.Active, search, limit, offset).Return(shows, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf(
+			"/shows/active?%s=%d&%s=%d&%s=%s&%s=2026-10-19",
+			constants.Limit, limit, constants.Offset, offset, constants.TheaterID, theaterId, constants.ShowDate,
+		), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "local_start_time")
+	})
+
+	t.Run("search shows of today", func(t *testing.T) {
+		service.EXPECT().GetShows(constants.Active, payloads.ShowSearchFilter{Today: true}, limit, offset).Return(shows, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/active?%s=%d&%s=%d&%s=%s", constants.Limit, limit, constants.Offset, offset, constants.ShowDate, constants.Today), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid theater id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/active?%s=invalid", constants.TheaterID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid theater id")
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/active?%s=19-10-2026", constants.ShowDate), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid date")
+	})
 }
 
 func TestShowController_GetScheduledShows(t *testing.T) {
@@ -117,7 +165,7 @@ func TestShowController_GetScheduledShows(t *testing.T) {
 	shows := utils.GenerateShows(3)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetShows(constants.Scheduled, limit, offset).Return(shows, nil).Times(1)
+		service.EXPECT().GetShows(constants.Scheduled, payloads.ShowSearchFilter{}, limit, offset).Return(shows, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/scheduled?%s=%d&%s=%d", constants.Limit, limit, constants.Offset, offset), nil)
@@ -130,7 +178,7 @@ func TestShowController_GetScheduledShows(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetShows(constants.Scheduled, limit, offset).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetShows(constants.Scheduled, payloads.ShowSearchFilter{}, limit, offset).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/scheduled?%s=%d&%s=%d", constants.Limit, limit, constants.Offset, offset), nil)
@@ -150,7 +198,12 @@ func TestShowController_CreateShow(t *testing.T) {
 		ShowService: service,
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.POST("/shows", controller.CreateShow)
 
 	show := utils.GenerateShow()
@@ -163,7 +216,7 @@ func TestShowController_CreateShow(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().CreateShow(gomock.Any()).Return(show, nil).Times(1)
+		service.EXPECT().CreateShow(gomock.Any(), requestID).Return(show, nil).Times(1)
 
 		reqBody := fmt.Sprintf(
 			`{"movie_id": "%s", "theater_id": "%s", "start_time": "%s", "end_time": "%s", "status": "%s"}`,
@@ -194,7 +247,7 @@ func TestShowController_CreateShow(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreateShow(gomock.Any()).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().CreateShow(gomock.Any(), requestID).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		reqBody := fmt.Sprintf(
 			`{"movie_id": "%s", "theater_id": "%s", "start_time": "%s", "end_time": "%s", "status": "%s"}`,
@@ -209,3 +262,203 @@ func TestShowController_CreateShow(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "service error")
 	})
 }
+
+func TestShowController_GetAvailableSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockShowService(ctrl)
+	controller := ShowController{
+		ShowService: service,
+	}
+
+	show := utils.GenerateShow()
+	seats := []*models.Seat{utils.GenerateSeat(), utils.GenerateSeat()}
+
+	router := gin.Default()
+	router.GET("/shows/:id/seats", controller.GetAvailableSeats)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetAvailableSeats(show.Id, nil).Return(seats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/%s/seats", show.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), seats[0].Id.String())
+		assert.Contains(t, w.Body.String(), seats[1].Id.String())
+	})
+
+	t.Run("invalid show id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/shows/invalid/seats", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid show id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().GetAvailableSeats(show.Id, nil).Return(nil, errors.NotFoundError("show not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/%s/seats", show.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "show not found")
+	})
+}
+
+func TestShowController_BlockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockShowService(ctrl)
+	controller := ShowController{
+		ShowService: service,
+	}
+
+	show := utils.GenerateShow()
+	block := utils.GenerateSeatBlock()
+	block.ShowId = &show.Id
+	session := utils.GenerateUserSession()
+	payload := payloads.BlockSeatRequest{Reason: block.Reason}
+
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		c.Next()
+	})
+	router.POST("/shows/:id/seats/:seatId/block", controller.BlockSeat)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().BlockSeat(show.Id, *block.SeatId, session.UserID, payload).Return(block, nil).Times(1)
+
+		reqBody := fmt.Sprintf(`{"reason": "%s"}`, block.Reason)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/shows/%s/seats/%s/block", show.Id, block.SeatId), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), block.Id.String())
+		assert.Contains(t, w.Body.String(), show.Id.String())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/shows/%s/seats/%s/block", show.Id, block.SeatId), bytes.NewBufferString(`{}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().BlockSeat(show.Id, *block.SeatId, session.UserID, payload).Return(nil, errors.BadRequestError("show is no longer open")).Times(1)
+
+		reqBody := fmt.Sprintf(`{"reason": "%s"}`, block.Reason)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/shows/%s/seats/%s/block", show.Id, block.SeatId), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "show is no longer open")
+	})
+}
+
+func TestShowController_UnblockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockShowService(ctrl)
+	controller := ShowController{
+		ShowService: service,
+	}
+
+	show := utils.GenerateShow()
+	seat := utils.GenerateSeat()
+
+	router := gin.Default()
+	router.DELETE("/shows/:id/seats/:seatId/block", controller.UnblockSeat)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UnblockSeat(show.Id, seat.Id).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/shows/%s/seats/%s/block", show.Id, seat.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UnblockSeat(show.Id, seat.Id).Return(errors.NotFoundError("seat block not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/shows/%s/seats/%s/block", show.Id, seat.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "seat block not found")
+	})
+}
+
+func TestShowController_CheckAgeRestriction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockAgeGateService(ctrl)
+	controller := ShowController{
+		AgeGateService: service,
+	}
+
+	show := utils.GenerateShow()
+	session := utils.GenerateUserSession()
+
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		c.Next()
+	})
+	router.GET("/shows/:id/age-check", controller.CheckAgeRestriction)
+
+	t.Run("success", func(t *testing.T) {
+		result := &payloads.AgeGateResult{Rating: utils.GetPointerOf("R18"), MinAge: 18, RequiresIdCheck: true}
+		service.EXPECT().CheckShowAccess(show.Id, session.UserID, session.Email).Return(result, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/%s/age-check", show.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"rating":"R18"`)
+		assert.Contains(t, w.Body.String(), `"requires_id_check":true`)
+	})
+
+	t.Run("invalid show id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/shows/invalid/age-check", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid show id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().CheckShowAccess(show.Id, session.UserID, session.Email).Return(nil, errors.ForbiddenError("user is under the minimum age for this show")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/shows/%s/age-check", show.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "user is under the minimum age for this show")
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/context"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
 )
 
@@ -30,7 +33,8 @@ func (c *TheaterController) GetTheater(ctx *gin.Context) {
 	}
 
 	includeLocation := ctx.Query(constants.IncludeTheaterLocation) == "true"
-	theater, err := c.TheaterService.GetTheater(theaterID, includeLocation)
+	includeDetails := ctx.Query(constants.IncludeTheaterDetails) == "true"
+	theater, err := c.TheaterService.GetTheater(theaterID, includeLocation, includeDetails)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -53,8 +57,29 @@ func (c *TheaterController) GetTheaters(ctx *gin.Context) {
 	}
 
 	includeLocation := ctx.Query(constants.IncludeTheaterLocation) == "true"
+	includeDetails := ctx.Query(constants.IncludeTheaterDetails) == "true"
 
-	theaters, meta, err := c.TheaterService.GetTheaters(limit, offset, includeLocation)
+	var search payloads.TheaterSearchFilter
+	if cityIdParam := ctx.Query(constants.CityID); cityIdParam != "" {
+		cityID, e := uuid.Parse(cityIdParam)
+		if e != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid city id"})
+			return
+		}
+
+		search.CityID = &cityID
+	}
+	for _, amenityParam := range ctx.QueryArray(constants.Amenity) {
+		amenity := constants.TheaterAmenity(amenityParam)
+		if !slices.Contains(constants.TheaterAmenities, amenity) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid amenity"})
+			return
+		}
+
+		search.Amenities = append(search.Amenities, amenity)
+	}
+
+	theaters, meta, err := c.TheaterService.GetTheaters(search, limit, offset, includeLocation, includeDetails)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -66,11 +91,49 @@ func (c *TheaterController) GetTheaters(ctx *gin.Context) {
 func (c *TheaterController) GetNearbyTheaters(ctx *gin.Context) {
 	distanceParam := ctx.DefaultQuery(constants.MaxDistance, "5")
 	distance, e := strconv.ParseFloat(distanceParam, 64)
-	if e != nil || distance < 0 {
+	if e != nil || isNotFinite(distance) || distance < 0 {
 		distance = 5
 	}
 
-	theaters, err := c.TheaterService.GetNearbyTheaters(distance)
+	limitParam := ctx.DefaultQuery(constants.Limit, "10")
+	limit, e := strconv.Atoi(limitParam)
+	if e != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offsetParam := ctx.DefaultQuery(constants.Offset, "0")
+	offset, e := strconv.Atoi(offsetParam)
+	if e != nil || offset < 0 {
+		offset = 0
+	}
+
+	origin := payloads.NearbyTheatersOrigin{ClientIP: ctx.ClientIP()}
+	latParam, lonParam := ctx.Query(constants.Latitude), ctx.Query(constants.Longitude)
+	if latParam != "" || lonParam != "" {
+		lat, e := strconv.ParseFloat(latParam, 64)
+		if e != nil || isNotFinite(lat) || lat < -90 || lat > 90 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid lat"})
+			return
+		}
+
+		lon, e := strconv.ParseFloat(lonParam, 64)
+		if e != nil || isNotFinite(lon) || lon < -180 || lon > 180 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid lon"})
+			return
+		}
+
+		origin.Latitude, origin.Longitude = &lat, &lon
+	} else if cityIdParam := ctx.Query(constants.CityID); cityIdParam != "" {
+		cityID, e := uuid.Parse(cityIdParam)
+		if e != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid city id"})
+			return
+		}
+
+		origin.CityID = &cityID
+	}
+
+	theaters, err := c.TheaterService.GetNearbyTheaters(origin, distance, limit, offset)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -86,7 +149,13 @@ func (c *TheaterController) CreateTheater(ctx *gin.Context) {
 		return
 	}
 
-	theater, err := c.TheaterService.CreateTheater(req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	theater, err := c.TheaterService.CreateTheater(req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -95,6 +164,55 @@ func (c *TheaterController) CreateTheater(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(theater)})
 }
 
+func (c *TheaterController) UpdateTheater(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	var req payloads.UpdateTheaterRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	theater, err := c.TheaterService.UpdateTheater(theaterID, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(theater)})
+}
+
+func (c *TheaterController) DeleteTheater(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.TheaterService.DeleteTheater(theaterID, reqContext.RequestID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
 func (c *TheaterController) CreateTheaterLocation(ctx *gin.Context) {
 	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
 	if e != nil {
@@ -108,7 +226,13 @@ func (c *TheaterController) CreateTheaterLocation(ctx *gin.Context) {
 		return
 	}
 
-	location, err := c.TheaterService.CreateTheaterLocation(theaterID, req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	location, err := c.TheaterService.CreateTheaterLocation(theaterID, req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -117,6 +241,22 @@ func (c *TheaterController) CreateTheaterLocation(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(location)})
 }
 
+func (c *TheaterController) GetSeats(ctx *gin.Context) {
+	theaterId, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	seats, err := c.TheaterService.GetSeats(theaterId)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(seats)})
+}
+
 func (c *TheaterController) CreateSeat(ctx *gin.Context) {
 	theaterId, e := uuid.Parse(ctx.Param("theaterId"))
 	if e != nil {
@@ -139,6 +279,114 @@ func (c *TheaterController) CreateSeat(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(seat)})
 }
 
+func (c *TheaterController) UpdateSeat(ctx *gin.Context) {
+	theaterId, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	seatId, e := uuid.Parse(ctx.Param("seatId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid seat id"})
+		return
+	}
+
+	var req payloads.UpdateSeatRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	seat, err := c.TheaterService.UpdateSeat(theaterId, seatId, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(seat)})
+}
+
+func (c *TheaterController) DeleteSeat(ctx *gin.Context) {
+	theaterId, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	seatId, e := uuid.Parse(ctx.Param("seatId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid seat id"})
+		return
+	}
+
+	if err := c.TheaterService.DeleteSeat(theaterId, seatId); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+func (c *TheaterController) BlockSeat(ctx *gin.Context) {
+	theaterId, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	seatId, e := uuid.Parse(ctx.Param("seatId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid seat id"})
+		return
+	}
+
+	var req payloads.BlockSeatRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if reqContext.UserSession == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	block, err := c.TheaterService.BlockSeat(theaterId, seatId, reqContext.UserSession.UserID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(block)})
+}
+
+func (c *TheaterController) UnblockSeat(ctx *gin.Context) {
+	theaterId, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	seatId, e := uuid.Parse(ctx.Param("seatId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid seat id"})
+		return
+	}
+
+	if err := c.TheaterService.UnblockSeat(theaterId, seatId); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
 func (c *TheaterController) UpdateTheaterLocation(ctx *gin.Context) {
 	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
 	if e != nil {
@@ -152,7 +400,13 @@ func (c *TheaterController) UpdateTheaterLocation(ctx *gin.Context) {
 		return
 	}
 
-	location, err := c.TheaterService.UpdateTheaterLocation(theaterID, req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	location, err := c.TheaterService.UpdateTheaterLocation(theaterID, req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -160,3 +414,128 @@ func (c *TheaterController) UpdateTheaterLocation(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(location)})
 }
+
+func (c *TheaterController) DeleteTheaterLocation(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.TheaterService.DeleteTheaterLocation(theaterID, reqContext.RequestID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+func (c *TheaterController) UpdateTheaterAmenities(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	var req payloads.UpdateTheaterAmenitiesRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	amenities, err := c.TheaterService.UpdateTheaterAmenities(theaterID, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(amenities)})
+}
+
+func (c *TheaterController) UpdateTheaterOpeningHours(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	var req payloads.UpdateTheaterOpeningHoursRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	hours, err := c.TheaterService.UpdateTheaterOpeningHours(theaterID, req, reqContext.RequestID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.SliceToMaps(hours)})
+}
+
+func (c *TheaterController) CreateTheaterPhoto(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	var req payloads.CreateTheaterPhotoRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	photo, err := c.TheaterService.CreateTheaterPhoto(theaterID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(photo)})
+}
+
+func (c *TheaterController) DeleteTheaterPhoto(ctx *gin.Context) {
+	theaterID, e := uuid.Parse(ctx.Param("theaterId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid theater id"})
+		return
+	}
+
+	photoID, e := uuid.Parse(ctx.Param("photoId"))
+	if e != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid photo id"})
+		return
+	}
+
+	if err := c.TheaterService.DeleteTheaterPhoto(theaterID, photoID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, gin.H{})
+}
+
+// isNotFinite reports NaN and infinite values, which ParseFloat accepts and every range comparison lets through.
+func isNotFinite(x float64) bool {
+	return math.IsNaN(x) || math.IsInf(x, 0)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/context"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
@@ -34,7 +35,7 @@ func TestTheaterController_GetTheater(t *testing.T) {
 	router.GET("/theaters/:theaterId", controller.GetTheater)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetTheater(theater.ID, true).Return(theater, nil).Times(1)
+		service.EXPECT().GetTheater(theater.ID, true, false).Return(theater, nil).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters/%s?%s=%v", theater.ID, constants.IncludeTheaterLocation, true), nil)
@@ -45,7 +46,7 @@ func TestTheaterController_GetTheater(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetTheater(theater.ID, true).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetTheater(theater.ID, true, false).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters/%s?%s=%v", theater.ID, constants.IncludeTheaterLocation, true), nil)
@@ -68,16 +69,22 @@ func TestTheaterController_GetNearbyTheaters(t *testing.T) {
 
 	theaters := utils.GenerateTheaters(3)
 	distance := 10.0
+	origin := payloads.NearbyTheatersOrigin{ClientIP: "203.0.113.10"}
 
 	router := gin.Default()
 	router.GET("/theaters/nearby", controller.GetNearbyTheaters)
 
+	newNearbyRequest := func(query string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "/theaters/nearby?"+query, nil)
+		req.RemoteAddr = "203.0.113.10:54321"
+		return req
+	}
+
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetNearbyTheaters(distance).Return(theaters, nil).Times(1)
+		service.EXPECT().GetNearbyTheaters(origin, distance, 10, 0).Return(theaters, nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters/nearby?%s=%v.0", constants.MaxDistance, distance), nil)
-		router.ServeHTTP(w, req)
+		router.ServeHTTP(w, newNearbyRequest(fmt.Sprintf("%s=%v.0", constants.MaxDistance, distance)))
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		for _, theater := range theaters {
@@ -86,12 +93,80 @@ func TestTheaterController_GetNearbyTheaters(t *testing.T) {
 		}
 	})
 
+	t.Run("with pagination", func(t *testing.T) {
+		service.EXPECT().GetNearbyTheaters(origin, distance, 5, 20).Return(theaters, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newNearbyRequest(fmt.Sprintf("%s=%v.0&limit=5&offset=20", constants.MaxDistance, distance)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("with coordinates", func(t *testing.T) {
+		lat, lon := 10.8231, 106.6297
+		expected := payloads.NearbyTheatersOrigin{Latitude: &lat, Longitude: &lon, ClientIP: origin.ClientIP}
+		service.EXPECT().GetNearbyTheaters(expected, distance, 10, 0).Return(theaters, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newNearbyRequest(fmt.Sprintf("%s=%v.0&lat=%v&lon=%v", constants.MaxDistance, distance, lat, lon)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("with city", func(t *testing.T) {
+		cityID := uuid.New()
+		expected := payloads.NearbyTheatersOrigin{CityID: &cityID, ClientIP: origin.ClientIP}
+		service.EXPECT().GetNearbyTheaters(expected, distance, 10, 0).Return(theaters, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newNearbyRequest(fmt.Sprintf("%s=%v.0&cityId=%s", constants.MaxDistance, distance, cityID)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		for query, message := range map[string]string{
+			"lat=91&lon=10":   "invalid lat",
+			"lat=10":          "invalid lon",
+			"lon=10":          "invalid lat",
+			"lat=10&lon=east": "invalid lon",
+			"lat=NaN&lon=10":  "invalid lat",
+			"lat=10&lon=NaN":  "invalid lon",
+			"lat=Inf&lon=10":  "invalid lat",
+			"lat=10&lon=-Inf": "invalid lon",
+		} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newNearbyRequest(query))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), message)
+		}
+	})
+
+	t.Run("non finite distance falls back to default", func(t *testing.T) {
+		for _, value := range []string{"NaN", "Inf", "-Inf"} {
+			service.EXPECT().GetNearbyTheaters(origin, 5.0, 10, 0).Return(theaters, nil).Times(1)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newNearbyRequest(fmt.Sprintf("%s=%s", constants.MaxDistance, value)))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+
+	t.Run("invalid city id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newNearbyRequest("cityId=abc"))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid city id")
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetNearbyTheaters(distance).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().GetNearbyTheaters(origin, distance, 10, 0).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters/nearby?%s=%v.0", constants.MaxDistance, distance), nil)
-		router.ServeHTTP(w, req)
+		router.ServeHTTP(w, newNearbyRequest(fmt.Sprintf("%s=%v.0", constants.MaxDistance, distance)))
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		assert.Contains(t, w.Body.String(), "service error")
@@ -110,11 +185,18 @@ func TestTheaterController_CreateTheater(t *testing.T) {
 	theater := utils.GenerateTheater()
 	payload := payloads.CreateTheaterRequest{Name: theater.Name}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.POST("/theaters", controller.CreateTheater)
 
+	errors.RegisterCustomValidators()
+
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().CreateTheater(payload).Return(theater, nil).Times(1)
+		service.EXPECT().CreateTheater(payload, requestID).Return(theater, nil).Times(1)
 
 		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
 
@@ -139,8 +221,20 @@ func TestTheaterController_CreateTheater(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "Should be greater than or equal to 2")
 	})
 
+	t.Run("invalid time zone", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"name": "%s", "time_zone": "Mars/Olympus_Mons"}`, payload.Name)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/theaters", bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be a valid IANA time zone")
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreateTheater(payload).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().CreateTheater(payload, requestID).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
 
@@ -154,6 +248,119 @@ func TestTheaterController_CreateTheater(t *testing.T) {
 	})
 }
 
+func TestTheaterController_UpdateTheater(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	payload := payloads.UpdateTheaterRequest{Name: theater.Name}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/theaters/:theaterId", controller.UpdateTheater)
+
+	errors.RegisterCustomValidators()
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateTheater(theater.ID, payload, requestID).Return(theater, nil).Times(1)
+
+		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), theater.Name)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"name": "%s"}`, "A")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be greater than or equal to 2")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateTheater(theater.ID, payload, requestID).Return(nil, errors.BadRequestError("duplicate theater name")).Times(1)
+
+		reqBody := fmt.Sprintf(`{"name": "%s"}`, payload.Name)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "duplicate theater name")
+	})
+}
+
+func TestTheaterController_DeleteTheater(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.DELETE("/theaters/:theaterId", controller.DeleteTheater)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeleteTheater(theater.ID, requestID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid theater id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/theaters/invalid", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid theater id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DeleteTheater(theater.ID, requestID).Return(errors.BadRequestError("theater has upcoming shows")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "theater has upcoming shows")
+	})
+}
+
 func TestTheaterController_CreateTheaterLocation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -173,11 +380,16 @@ func TestTheaterController_CreateTheaterLocation(t *testing.T) {
 		Longitude:  100.0,
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.POST("/theaters/:theaterId/locations", controller.CreateTheaterLocation)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().CreateTheaterLocation(theater.ID, payload).Return(location, nil).Times(1)
+		service.EXPECT().CreateTheaterLocation(theater.ID, payload, requestID).Return(location, nil).Times(1)
 
 		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude, payload.Longitude)
 
@@ -203,8 +415,20 @@ func TestTheaterController_CreateTheaterLocation(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "Should be greater than or equal to 2")
 	})
 
+	t.Run("latitude out of range", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": 95, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Longitude)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be less than or equal to 90")
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreateTheaterLocation(theater.ID, payload).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().CreateTheaterLocation(theater.ID, payload, requestID).Return(nil, errors.InternalServerError("service error")).Times(1)
 
 		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude, payload.Longitude)
 
@@ -218,6 +442,45 @@ func TestTheaterController_CreateTheaterLocation(t *testing.T) {
 	})
 }
 
+func TestTheaterController_GetSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	seats := []*models.Seat{utils.GenerateSeat(), utils.GenerateSeat()}
+
+	router := gin.Default()
+	router.GET("/theaters/:theaterId/seats", controller.GetSeats)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetSeats(theater.ID).Return(seats, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters/%s/seats", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), seats[0].Id.String())
+		assert.Contains(t, w.Body.String(), seats[1].Id.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().GetSeats(theater.ID).Return(nil, errors.NotFoundError("theater not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters/%s/seats", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "theater not found")
+	})
+}
+
 func TestTheaterController_CreateSeat(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -263,7 +526,7 @@ func TestTheaterController_CreateSeat(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.Contains(t, w.Body.String(), fmt.Sprintf("Should be one of %s, %s", constants.Regular, constants.Vip))
+		assert.Contains(t, w.Body.String(), fmt.Sprintf("Should be one of %s, %s, %s, %s, %s", constants.Regular, constants.Vip, constants.Wheelchair, constants.Companion, constants.Couple))
 	})
 
 	t.Run("service error", func(t *testing.T) {
@@ -281,7 +544,7 @@ func TestTheaterController_CreateSeat(t *testing.T) {
 	})
 }
 
-func TestTheaterController_UpdateTheaterLocation(t *testing.T) {
+func TestTheaterController_UpdateSeat(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -290,62 +553,55 @@ func TestTheaterController_UpdateTheaterLocation(t *testing.T) {
 		TheaterService: service,
 	}
 
-	theater := utils.GenerateTheater()
-	location := utils.GenerateTheaterLocation()
-	payload := payloads.UpdateTheaterLocationRequest{
-		CityID:     uuid.New(),
-		Address:    "address",
-		PostalCode: "700000",
-		Latitude:   50.0,
-		Longitude:  100.0,
-	}
+	seat := utils.GenerateSeat()
+	seat.Type = constants.Vip
+	payload := payloads.UpdateSeatRequest{Type: seat.Type}
 
 	router := gin.Default()
-	router.PUT("/theaters/:theaterId/locations", controller.UpdateTheaterLocation)
+	router.PUT("/theaters/:theaterId/seats/:seatId", controller.UpdateSeat)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().UpdateTheaterLocation(theater.ID, payload).Return(location, nil).Times(1)
+		service.EXPECT().UpdateSeat(*seat.TheaterId, seat.Id, payload).Return(seat, nil).Times(1)
 
-		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude, payload.Longitude)
+		reqBody := fmt.Sprintf(`{"type": "%s"}`, seat.Type)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/seats/%s", seat.TheaterId, seat.Id), bytes.NewBufferString(reqBody))
 		req.Header.Set(constants.ContentType, constants.ApplicationJson)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-		assert.Contains(t, w.Body.String(), location.Address)
-		assert.Contains(t, w.Body.String(), location.PostalCode)
+		assert.Contains(t, w.Body.String(), string(seat.Type))
 	})
 
 	t.Run("validation error", func(t *testing.T) {
-		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, "A", payload.PostalCode, payload.Latitude, payload.Longitude)
+		reqBody := fmt.Sprintf(`{"type": "%s"}`, constants.Couple)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/seats/%s", seat.TheaterId, seat.Id), bytes.NewBufferString(reqBody))
 		req.Header.Set(constants.ContentType, constants.ApplicationJson)
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.Contains(t, w.Body.String(), "Should be greater than or equal to 2")
+		assert.Contains(t, w.Body.String(), fmt.Sprintf("Should be one of %s, %s, %s", constants.Regular, constants.Vip, constants.Wheelchair))
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().UpdateTheaterLocation(theater.ID, payload).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().UpdateSeat(*seat.TheaterId, seat.Id, payload).Return(nil, errors.BadRequestError("couple seats can not change type")).Times(1)
 
-		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude, payload.Longitude)
+		reqBody := fmt.Sprintf(`{"type": "%s"}`, seat.Type)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/seats/%s", seat.TheaterId, seat.Id), bytes.NewBufferString(reqBody))
 		req.Header.Set(constants.ContentType, constants.ApplicationJson)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), "service error")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "couple seats can not change type")
 	})
 }
 
-func TestTheaterController_GetTheaters(t *testing.T) {
+func TestTheaterController_DeleteSeat(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -354,47 +610,591 @@ func TestTheaterController_GetTheaters(t *testing.T) {
 		TheaterService: service,
 	}
 
-	theaters := utils.GenerateTheaters(3)
-	meta := &models.ResponseMeta{
-		Limit:   10,
-		Offset:  0,
-		Total:   20,
-		NextUrl: utils.GetPointerOf("/theaters"),
-		PrevUrl: utils.GetPointerOf("/theaters"),
-	}
-	includeLocation := true
-	limit := 3
-	offset := 1
+	seat := utils.GenerateSeat()
 
 	router := gin.Default()
-	router.GET("/theaters", controller.GetTheaters)
+	router.DELETE("/theaters/:theaterId/seats/:seatId", controller.DeleteSeat)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().GetTheaters(limit, offset, includeLocation).Return(theaters, meta, nil).Times(1)
+		service.EXPECT().DeleteSeat(*seat.TheaterId, seat.Id).Return(nil).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters?%s=%d&%s=%d&%s=%v", constants.Limit, limit, constants.Offset, offset, constants.IncludeTheaterLocation, includeLocation), nil)
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/seats/%s", seat.TheaterId, seat.Id), nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusOK, w.Code)
-		assert.Contains(t, w.Body.String(), fmt.Sprint(meta.Limit))
-		assert.Contains(t, w.Body.String(), fmt.Sprint(meta.Offset))
-		assert.Contains(t, w.Body.String(), fmt.Sprint(meta.Total))
-		assert.Contains(t, w.Body.String(), *meta.NextUrl)
-		assert.Contains(t, w.Body.String(), *meta.PrevUrl)
-		for _, theater := range theaters {
-			assert.Contains(t, w.Body.String(), theater.ID.String())
-		}
+		assert.Equal(t, http.StatusNoContent, w.Code)
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().GetTheaters(limit, offset, includeLocation).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().DeleteSeat(*seat.TheaterId, seat.Id).Return(errors.BadRequestError("theater has upcoming shows")).Times(1)
 
 		w := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters?%s=%d&%s=%d&%s=%v", constants.Limit, limit, constants.Offset, offset, constants.IncludeTheaterLocation, includeLocation), nil)
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/seats/%s", seat.TheaterId, seat.Id), nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), "service error")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "theater has upcoming shows")
+	})
+}
+
+func TestTheaterController_BlockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	seat := utils.GenerateSeat()
+	block := utils.GenerateSeatBlock()
+	block.SeatId = &seat.Id
+	session := utils.GenerateUserSession()
+	payload := payloads.BlockSeatRequest{Reason: block.Reason}
+
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		c.Next()
+	})
+	router.POST("/theaters/:theaterId/seats/:seatId/block", controller.BlockSeat)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().BlockSeat(*seat.TheaterId, seat.Id, session.UserID, payload).Return(block, nil).Times(1)
+
+		reqBody := fmt.Sprintf(`{"reason": "%s"}`, block.Reason)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/seats/%s/block", seat.TheaterId, seat.Id), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), block.Id.String())
+		assert.Contains(t, w.Body.String(), block.Reason)
+	})
+
+	t.Run("invalid seat id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/seats/%s/block", seat.TheaterId, "invalid"), bytes.NewBufferString(`{"reason": "broken"}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid seat id")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/seats/%s/block", seat.TheaterId, seat.Id), bytes.NewBufferString(`{}`))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().BlockSeat(*seat.TheaterId, seat.Id, session.UserID, payload).Return(nil, errors.BadRequestError("seat is already blocked")).Times(1)
+
+		reqBody := fmt.Sprintf(`{"reason": "%s"}`, block.Reason)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/seats/%s/block", seat.TheaterId, seat.Id), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "seat is already blocked")
+	})
+}
+
+func TestTheaterController_UnblockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	seat := utils.GenerateSeat()
+
+	router := gin.Default()
+	router.DELETE("/theaters/:theaterId/seats/:seatId/block", controller.UnblockSeat)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UnblockSeat(*seat.TheaterId, seat.Id).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/seats/%s/block", seat.TheaterId, seat.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UnblockSeat(*seat.TheaterId, seat.Id).Return(errors.NotFoundError("seat block not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/seats/%s/block", seat.TheaterId, seat.Id), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "seat block not found")
+	})
+}
+
+func TestTheaterController_UpdateTheaterLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	location := utils.GenerateTheaterLocation()
+	payload := payloads.UpdateTheaterLocationRequest{
+		CityID:     uuid.New(),
+		Address:    "address",
+		PostalCode: "700000",
+		Latitude:   50.0,
+		Longitude:  100.0,
+	}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/theaters/:theaterId/locations", controller.UpdateTheaterLocation)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateTheaterLocation(theater.ID, payload, requestID).Return(location, nil).Times(1)
+
+		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude, payload.Longitude)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), location.Address)
+		assert.Contains(t, w.Body.String(), location.PostalCode)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, "A", payload.PostalCode, payload.Latitude, payload.Longitude)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be greater than or equal to 2")
+	})
+
+	t.Run("longitude out of range", func(t *testing.T) {
+		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": -181}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be greater than or equal to -180")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateTheaterLocation(theater.ID, payload, requestID).Return(nil, errors.InternalServerError("service error")).Times(1)
+
+		reqBody := fmt.Sprintf(`{"city_id": "%s", "address": "%s", "postal_code": "%s", "latitude": %v, "longitude": %v}`, payload.CityID, payload.Address, payload.PostalCode, payload.Latitude, payload.Longitude)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/locations", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "service error")
+	})
+}
+
+func TestTheaterController_DeleteTheaterLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.DELETE("/theaters/:theaterId/locations", controller.DeleteTheaterLocation)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeleteTheaterLocation(theater.ID, requestID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/locations", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DeleteTheaterLocation(theater.ID, requestID).Return(errors.BadRequestError("location not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/locations", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "location not found")
+	})
+}
+
+func TestTheaterController_GetTheaters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theaters := utils.GenerateTheaters(3)
+	meta := &models.ResponseMeta{
+		Limit:   10,
+		Offset:  0,
+		Total:   20,
+		NextUrl: utils.GetPointerOf("/theaters"),
+		PrevUrl: utils.GetPointerOf("/theaters"),
+	}
+	includeLocation := true
+	limit := 3
+	offset := 1
+
+	router := gin.Default()
+	router.GET("/theaters", controller.GetTheaters)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().GetTheaters(payloads.TheaterSearchFilter{}, limit, offset, includeLocation, false).Return(theaters, meta, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters?%s=%d&%s=%d&%s=%v", constants.Limit, limit, constants.Offset, offset, constants.IncludeTheaterLocation, includeLocation), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), fmt.Sprint(meta.Limit))
+		assert.Contains(t, w.Body.String(), fmt.Sprint(meta.Offset))
+		assert.Contains(t, w.Body.String(), fmt.Sprint(meta.Total))
+		assert.Contains(t, w.Body.String(), *meta.NextUrl)
+		assert.Contains(t, w.Body.String(), *meta.PrevUrl)
+		for _, theater := range theaters {
+			assert.Contains(t, w.Body.String(), theater.ID.String())
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().GetTheaters(payloads.TheaterSearchFilter{}, limit, offset, includeLocation, false).Return(nil, nil, errors.InternalServerError("service error")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters?%s=%d&%s=%d&%s=%v", constants.Limit, limit, constants.Offset, offset, constants.IncludeTheaterLocation, includeLocation), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "service error")
+	})
+
+	t.Run("search by city and amenities", func(t *testing.T) {
+		cityId := uuid.New()
+		search := payloads.TheaterSearchFilter{
+			CityID:    &cityId,
+			Amenities: []constants.TheaterAmenity{constants.Imax, constants.Parking},
+		}
+		service.EXPECT().GetTheaters(search, limit, offset, false, true).Return(theaters, meta, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf(
+			"/theaters?%s=%d&%s=%d&%s=true&%s=%s&%s=%s&%s=%s",
+			constants.Limit, limit, constants.Offset, offset, constants.IncludeTheaterDetails,
+			constants.CityID, cityId, constants.Amenity, constants.Imax, constants.Amenity, constants.Parking,
+		), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid city id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters?%s=invalid", constants.CityID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid city id")
+	})
+
+	t.Run("invalid amenity", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/theaters?%s=POOL", constants.Amenity), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid amenity")
+	})
+}
+
+func TestTheaterController_UpdateTheaterAmenities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	payload := payloads.UpdateTheaterAmenitiesRequest{
+		Amenities: []constants.TheaterAmenity{constants.Imax, constants.Food},
+	}
+	amenities := []*models.TheaterAmenity{
+		{TheaterID: theater.ID, Amenity: constants.Imax},
+		{TheaterID: theater.ID, Amenity: constants.Food},
+	}
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/theaters/:theaterId/amenities", controller.UpdateTheaterAmenities)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateTheaterAmenities(theater.ID, payload, requestID).Return(amenities, nil).Times(1)
+
+		reqBody := `{"amenities": ["IMAX", "FOOD"]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/amenities", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), string(constants.Imax))
+		assert.Contains(t, w.Body.String(), string(constants.Food))
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		reqBody := `{"amenities": ["POOL"]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/amenities", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be one of")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateTheaterAmenities(theater.ID, payload, requestID).Return(nil, errors.NotFoundError("theater not found")).Times(1)
+
+		reqBody := `{"amenities": ["IMAX", "FOOD"]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/amenities", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "theater not found")
+	})
+}
+
+func TestTheaterController_UpdateTheaterOpeningHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	payload := payloads.UpdateTheaterOpeningHoursRequest{
+		OpeningHours: []payloads.TheaterOpeningHourRequest{
+			{Weekday: utils.GetPointerOf(0), OpenTime: "10:00", CloseTime: "02:00"},
+		},
+	}
+	hours := utils.GenerateTheaterOpeningHours(1)
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.PUT("/theaters/:theaterId/opening-hours", controller.UpdateTheaterOpeningHours)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().UpdateTheaterOpeningHours(theater.ID, payload, requestID).Return(hours, nil).Times(1)
+
+		reqBody := `{"opening_hours": [{"weekday": 0, "open_time": "10:00", "close_time": "02:00"}]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/opening-hours", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), hours[0].ID.String())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		reqBody := `{"opening_hours": [{"weekday": 7, "open_time": "10:00", "close_time": "02:00"}]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/opening-hours", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Should be less than or equal to 6")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().UpdateTheaterOpeningHours(theater.ID, payload, requestID).Return(nil, errors.BadRequestError("duplicate opening hours for weekday 0")).Times(1)
+
+		reqBody := `{"opening_hours": [{"weekday": 0, "open_time": "10:00", "close_time": "02:00"}]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/theaters/%s/opening-hours", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "duplicate opening hours for weekday 0")
+	})
+}
+
+func TestTheaterController_CreateTheaterPhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	photo := utils.GenerateTheaterPhoto()
+	payload := payloads.CreateTheaterPhotoRequest{
+		Url:      photo.Url,
+		Caption:  photo.Caption,
+		Position: photo.Position,
+	}
+
+	router := gin.Default()
+	router.POST("/theaters/:theaterId/photos", controller.CreateTheaterPhoto)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().CreateTheaterPhoto(theater.ID, payload).Return(photo, nil).Times(1)
+
+		reqBody := fmt.Sprintf(`{"url": "%s", "caption": "%s", "position": %d}`, payload.Url, *payload.Caption, payload.Position)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/photos", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), photo.Url)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		reqBody := `{"caption": "lobby"}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/photos", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "This field is required")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().CreateTheaterPhoto(theater.ID, payload).Return(nil, errors.InternalServerError("service error")).Times(1)
+
+		reqBody := fmt.Sprintf(`{"url": "%s", "caption": "%s", "position": %d}`, payload.Url, *payload.Caption, payload.Position)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("/theaters/%s/photos", theater.ID), bytes.NewBufferString(reqBody))
+		req.Header.Set(constants.ContentType, constants.ApplicationJson)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "service error")
+	})
+}
+
+func TestTheaterController_DeleteTheaterPhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockTheaterService(ctrl)
+	controller := TheaterController{
+		TheaterService: service,
+	}
+
+	theater := utils.GenerateTheater()
+	photo := utils.GenerateTheaterPhoto()
+
+	router := gin.Default()
+	router.DELETE("/theaters/:theaterId/photos/:photoId", controller.DeleteTheaterPhoto)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DeleteTheaterPhoto(theater.ID, photo.ID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/photos/%s", theater.ID, photo.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid photo id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/photos/invalid", theater.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid photo id")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DeleteTheaterPhoto(theater.ID, photo.ID).Return(errors.NotFoundError("photo not found")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/theaters/%s/photos/%s", theater.ID, photo.ID), nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "photo not found")
 	})
 }
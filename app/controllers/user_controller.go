@@ -82,7 +82,13 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 		return
 	}
 
-	u, err := c.UserService.CreateUser(req)
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	u, err := c.UserService.CreateUser(req, reqContext.RequestID)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -98,7 +104,27 @@ func (c *UserController) LoginUser(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.UserService.LoginUser(req)
+	token, challenge, err := c.UserService.LoginUser(req, ctx.ClientIP())
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if challenge != nil {
+		ctx.JSON(http.StatusAccepted, gin.H{"data": utils.StructToMap(challenge)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(token)})
+}
+
+func (c *UserController) VerifyTwoFactorLogin(ctx *gin.Context) {
+	var req payloads.VerifyTwoFactorLoginRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	token, err := c.UserService.VerifyTwoFactorLogin(req, ctx.ClientIP())
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
@@ -107,6 +133,36 @@ func (c *UserController) LoginUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(token)})
 }
 
+func (c *UserController) StartOidcLogin(ctx *gin.Context) {
+	authorization, err := c.UserService.StartOidcLogin(ctx.Param("provider"))
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(authorization)})
+}
+
+func (c *UserController) CompleteOidcLogin(ctx *gin.Context) {
+	var req payloads.OidcCallbackRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	token, challenge, err := c.UserService.CompleteOidcLogin(ctx.Param("provider"), req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if challenge != nil {
+		ctx.JSON(http.StatusAccepted, gin.H{"data": utils.StructToMap(challenge)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": utils.StructToMap(token)})
+}
+
 func (c *UserController) LogoutUser(ctx *gin.Context) {
 	t := utils.GetAuthorizationHeader(ctx.Request)
 	if err := c.UserService.LogoutUser(t); err != nil {
@@ -127,6 +183,27 @@ func (c *UserController) VerifyUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": "Verify user successfully"})
 }
 
+func (c *UserController) ResendVerificationEmail(ctx *gin.Context) {
+	var req payloads.ResendVerificationEmailRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.UserService.ResendVerificationEmail(req, reqContext.RequestID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"data": "Verification email will be sent if the account is pending verification"})
+}
+
 func (c *UserController) UpdateUserPassword(ctx *gin.Context) {
 	var req payloads.UpdatePasswordRequest
 	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
@@ -159,13 +236,18 @@ func (c *UserController) CreatePasswordResetToken(ctx *gin.Context) {
 		return
 	}
 
-	t, err := c.UserService.CreatePasswordResetToken(req)
+	reqContext, err := context.GetRequestContext(ctx)
 	if err != nil {
 		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(t)})
+	if err := c.UserService.CreatePasswordResetToken(req, reqContext.RequestID); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"data": "Password reset instructions will be sent if the email is registered"})
 }
 
 func (c *UserController) ResetPassword(ctx *gin.Context) {
@@ -182,3 +264,74 @@ func (c *UserController) ResetPassword(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"data": "Password reset successfully"})
 }
+
+func (c *UserController) EnrollTwoFactor(ctx *gin.Context) {
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if reqContext.UserSession == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	enrollment, err := c.UserService.EnrollTwoFactor(reqContext.UserSession.UserID)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": utils.StructToMap(enrollment)})
+}
+
+func (c *UserController) ConfirmTwoFactor(ctx *gin.Context) {
+	var req payloads.TwoFactorCodeRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if reqContext.UserSession == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	codes, err := c.UserService.ConfirmTwoFactor(reqContext.UserSession.UserID, req)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": gin.H{"recovery_codes": codes}})
+}
+
+func (c *UserController) DisableTwoFactor(ctx *gin.Context) {
+	var req payloads.TwoFactorCodeRequest
+	if errs := errors.BindAndValidate(ctx, &req); len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	reqContext, err := context.GetRequestContext(ctx)
+	if err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	if reqContext.UserSession == nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	if err := c.UserService.DisableTwoFactor(reqContext.UserSession.UserID, req); err != nil {
+		ctx.JSON(err.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": "Two-factor authentication is disabled successfully"})
+}
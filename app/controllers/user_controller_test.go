@@ -9,8 +9,10 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
@@ -180,11 +182,16 @@ func TestUserController_CreateUser(t *testing.T) {
 		},
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.POST("/user", controller.CreateUser)
 
 	t.Run("successful user creation", func(t *testing.T) {
-		service.EXPECT().CreateUser(payload).Return(&models.User{Email: payload.Email}, nil)
+		service.EXPECT().CreateUser(payload, requestID).Return(&models.User{Email: payload.Email}, nil)
 
 		reqBody := fmt.Sprintf(`{"email": "%s", "password": "%s", "profile": {"first_name": "%s", "last_name": "%s", "phone_number": "%s", "date_of_birth": "%s"}}`, payload.Email, payload.Password, payload.Profile.FirstName, payload.Profile.LastName, *payload.Profile.PhoneNumber, *payload.Profile.DateOfBirth)
 
@@ -210,7 +217,7 @@ func TestUserController_CreateUser(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreateUser(payload).Return(nil, errors.InternalServerError("Service error"))
+		service.EXPECT().CreateUser(payload, requestID).Return(nil, errors.InternalServerError("Service error"))
 
 		reqBody := fmt.Sprintf(`{"email": "%s", "password": "%s", "profile": {"first_name": "%s", "last_name": "%s", "phone_number": "%s", "date_of_birth": "%s"}}`, payload.Email, payload.Password, payload.Profile.FirstName, payload.Profile.LastName, *payload.Profile.PhoneNumber, *payload.Profile.DateOfBirth)
 		w := httptest.NewRecorder()
@@ -243,7 +250,7 @@ func TestUserController_LoginUser(t *testing.T) {
 	router.POST("/login", userController.LoginUser)
 
 	t.Run("successful login", func(t *testing.T) {
-		mockUserService.EXPECT().LoginUser(payload).Return(token, nil)
+		mockUserService.EXPECT().LoginUser(payload, gomock.Any()).Return(token, nil, nil)
 
 		reqBody := fmt.Sprintf(`{"email": "%s", "password": "%s"}`, payload.Email, payload.Password)
 
@@ -254,7 +261,23 @@ func TestUserController_LoginUser(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "token")
-		assert.Contains(t, w.Body.String(), token.TokenValue)
+		assert.Contains(t, w.Body.String(), token.Token)
+	})
+
+	t.Run("two-factor challenge", func(t *testing.T) {
+		challenge := &models.LoginChallenge{Token: uuid.NewString(), ExpiresAt: time.Now().UTC()}
+		mockUserService.EXPECT().LoginUser(payload, gomock.Any()).Return(nil, challenge, nil)
+
+		reqBody := fmt.Sprintf(`{"email": "%s", "password": "%s"}`, payload.Email, payload.Password)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), "challenge_token")
+		assert.Contains(t, w.Body.String(), challenge.Token)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
@@ -270,7 +293,7 @@ func TestUserController_LoginUser(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		mockUserService.EXPECT().LoginUser(payload).Return(nil, errors.UnauthorizedError("Invalid credentials"))
+		mockUserService.EXPECT().LoginUser(payload, gomock.Any()).Return(nil, nil, errors.UnauthorizedError("Invalid credentials"))
 
 		reqBody := fmt.Sprintf(`{"email": "%s", "password": "%s"}`, payload.Email, payload.Password)
 
@@ -284,6 +307,163 @@ func TestUserController_LoginUser(t *testing.T) {
 	})
 }
 
+func TestUserController_VerifyTwoFactorLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	token := utils.GenerateLoginToken()
+	payload := payloads.VerifyTwoFactorLoginRequest{
+		ChallengeToken: uuid.NewString(),
+		Code:           "123456",
+	}
+	reqBody := fmt.Sprintf(`{"challenge_token": "%s", "code": "%s"}`, payload.ChallengeToken, payload.Code)
+
+	router := gin.Default()
+	router.POST("/login/two-factor", controller.VerifyTwoFactorLogin)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().VerifyTwoFactorLogin(payload, gomock.Any()).Return(token, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/two-factor", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), token.Token)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/two-factor", bytes.NewBufferString(`{"code": ""}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().VerifyTwoFactorLogin(payload, gomock.Any()).Return(nil, errors.UnauthorizedError("invalid two-factor code"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/two-factor", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid two-factor code")
+	})
+}
+
+func TestUserController_StartOidcLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	router := gin.Default()
+	router.GET("/login/oidc/:provider", controller.StartOidcLogin)
+
+	t.Run("success", func(t *testing.T) {
+		authorization := &models.OidcAuthorization{AuthorizationUrl: "https://accounts.example.com/authorize?state=state"}
+		service.EXPECT().StartOidcLogin("google").Return(authorization, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/login/oidc/google", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "https://accounts.example.com/authorize")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().StartOidcLogin("unknown").Return(nil, errors.NotFoundError("identity provider unknown is not supported")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/login/oidc/unknown", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "identity provider unknown is not supported")
+	})
+}
+
+func TestUserController_CompleteOidcLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	payload := payloads.OidcCallbackRequest{
+		Code:  "authorization-code",
+		State: "state",
+	}
+	reqBody := fmt.Sprintf(`{"code": "%s", "state": "%s"}`, payload.Code, payload.State)
+
+	router := gin.Default()
+	router.POST("/login/oidc/:provider/callback", controller.CompleteOidcLogin)
+
+	t.Run("success", func(t *testing.T) {
+		token := utils.GenerateLoginToken()
+		service.EXPECT().CompleteOidcLogin("google", payload).Return(token, nil, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/oidc/google/callback", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), token.Token)
+	})
+
+	t.Run("two-factor challenge", func(t *testing.T) {
+		challenge := &models.LoginChallenge{Token: uuid.NewString(), ExpiresAt: time.Now().UTC()}
+		service.EXPECT().CompleteOidcLogin("google", payload).Return(nil, challenge, nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/oidc/google/callback", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), challenge.Token)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/oidc/google/callback", bytes.NewBufferString(`{"code": ""}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().CompleteOidcLogin("google", payload).Return(nil, nil, errors.UnauthorizedError("invalid or expired state")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login/oidc/google/callback", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid or expired state")
+	})
+}
+
 func TestUserController_VerifyUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -322,6 +502,60 @@ func TestUserController_VerifyUser(t *testing.T) {
 	})
 }
 
+func TestUserController_ResendVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	payload := payloads.ResendVerificationEmailRequest{Email: "example@example.com"}
+	reqBody := fmt.Sprintf(`{"email": "%s"}`, payload.Email)
+
+	requestID := uuid.New()
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
+	router.POST("/users/verification/resend", controller.ResendVerificationEmail)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().ResendVerificationEmail(payload, requestID).Return(nil).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/verification/resend", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/verification/resend", bytes.NewBufferString(`{"email": "invalid"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		service.EXPECT().ResendVerificationEmail(payload, requestID).Return(errors.TooManyRequestsError("too many verification emails requested, try again in 60 seconds")).Times(1)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/verification/resend", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), "too many verification emails requested")
+	})
+}
+
 func TestUserController_UpdateUserPassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -407,16 +641,20 @@ func TestUserController_CreatePasswordResetToken(t *testing.T) {
 		UserService: service,
 	}
 
-	token := utils.GeneratePasswordResetToken()
 	payload := payloads.CreatePasswordResetTokenRequest{
 		Email: "example@example.com",
 	}
 
+	requestID := uuid.New()
 	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{RequestID: requestID})
+		c.Next()
+	})
 	router.POST("/users/password-reset-token", controller.CreatePasswordResetToken)
 
 	t.Run("success", func(t *testing.T) {
-		service.EXPECT().CreatePasswordResetToken(payload).Return(token, nil).Times(1)
+		service.EXPECT().CreatePasswordResetToken(payload, requestID).Return(nil).Times(1)
 
 		reqBody := fmt.Sprintf(`{"email": "%s"}`, payload.Email)
 		w := httptest.NewRecorder()
@@ -424,8 +662,8 @@ func TestUserController_CreatePasswordResetToken(t *testing.T) {
 		req.Header.Set(constants.ContentType, constants.ApplicationJson)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusCreated, w.Code)
-		assert.Contains(t, w.Body.String(), token.TokenValue)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.NotContains(t, w.Body.String(), "token")
 	})
 
 	t.Run("validation error", func(t *testing.T) {
@@ -440,7 +678,7 @@ func TestUserController_CreatePasswordResetToken(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		service.EXPECT().CreatePasswordResetToken(payload).Return(nil, errors.InternalServerError("service error")).Times(1)
+		service.EXPECT().CreatePasswordResetToken(payload, requestID).Return(errors.InternalServerError("service error")).Times(1)
 
 		reqBody := fmt.Sprintf(`{"email": "%s"}`, payload.Email)
 		w := httptest.NewRecorder()
@@ -510,3 +748,161 @@ func TestUserController_ResetPassword(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "service error")
 	})
 }
+
+func TestUserController_EnrollTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	session := utils.GenerateUserSession()
+	enrollment := &models.TwoFactorEnrollment{
+		Secret:          "JBSWY3DPEHPK3PXP",
+		ProvisioningUri: "otpauth://totp/test",
+	}
+
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		c.Next()
+	})
+	router.POST("/users/me/two-factor", controller.EnrollTwoFactor)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().EnrollTwoFactor(session.UserID).Return(enrollment, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/me/two-factor", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), enrollment.Secret)
+		assert.Contains(t, w.Body.String(), enrollment.ProvisioningUri)
+	})
+
+	t.Run("session retrieval error", func(t *testing.T) {
+		routerErr := gin.Default()
+		routerErr.POST("/users/me/two-factor", controller.EnrollTwoFactor)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/me/two-factor", nil)
+		routerErr.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().EnrollTwoFactor(session.UserID).Return(nil, errors.BadRequestError("two-factor authentication is already enabled"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/me/two-factor", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "two-factor authentication is already enabled")
+	})
+}
+
+func TestUserController_ConfirmTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	session := utils.GenerateUserSession()
+	payload := payloads.TwoFactorCodeRequest{Code: "123456"}
+	reqBody := fmt.Sprintf(`{"code": "%s"}`, payload.Code)
+
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		c.Next()
+	})
+	router.POST("/users/me/two-factor/confirm", controller.ConfirmTwoFactor)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().ConfirmTwoFactor(session.UserID, payload).Return([]string{"aaaaa-bbbbb"}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/me/two-factor/confirm", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "recovery_codes")
+		assert.Contains(t, w.Body.String(), "aaaaa-bbbbb")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/me/two-factor/confirm", bytes.NewBufferString(`{"code": ""}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "errors")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().ConfirmTwoFactor(session.UserID, payload).Return(nil, errors.BadRequestError("invalid two-factor code"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/users/me/two-factor/confirm", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid two-factor code")
+	})
+}
+
+func TestUserController_DisableTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mock_services.NewMockUserService(ctrl)
+	controller := UserController{
+		UserService: service,
+	}
+
+	session := utils.GenerateUserSession()
+	payload := payloads.TwoFactorCodeRequest{Code: "123456"}
+	reqBody := fmt.Sprintf(`{"code": "%s"}`, payload.Code)
+
+	router := gin.Default()
+	router.Use(func(c *gin.Context) {
+		context.SetRequestContext(c, context.RequestContext{UserSession: session})
+		c.Next()
+	})
+	router.DELETE("/users/me/two-factor", controller.DisableTwoFactor)
+
+	t.Run("success", func(t *testing.T) {
+		service.EXPECT().DisableTwoFactor(session.UserID, payload).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/users/me/two-factor", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Two-factor authentication is disabled successfully")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		service.EXPECT().DisableTwoFactor(session.UserID, payload).Return(errors.BadRequestError("invalid two-factor code"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/users/me/two-factor", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid two-factor code")
+	})
+}
@@ -30,6 +30,10 @@ func NotFoundError(format string, args ...any) *ApiError {
 	return newError(http.StatusNotFound, fmt.Sprintf(format, args...))
 }
 
+func TooManyRequestsError(format string, args ...any) *ApiError {
+	return newError(http.StatusTooManyRequests, fmt.Sprintf(format, args...))
+}
+
 func InternalServerError(format string, args ...any) *ApiError {
 	return newError(http.StatusInternalServerError, fmt.Sprintf(format, args...))
 }
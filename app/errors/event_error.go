@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EventError is returned by event handlers for messages that can never be processed, so they are not retried.
+type EventError struct {
+	Message string
+}
+
+func (e *EventError) Error() string {
+	return e.Message
+}
+
+func MalformedEventError(format string, args ...any) *EventError {
+	return &EventError{Message: fmt.Sprintf(format, args...)}
+}
+
+func IsMalformedEventError(err error) bool {
+	var eventErr *EventError
+	return errors.As(err, &eventErr)
+}
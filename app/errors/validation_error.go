@@ -25,6 +25,7 @@ func RegisterCustomValidators() {
 		v.RegisterValidation("date", isValidDate)
 		v.RegisterValidation("beforeToday", isBeforeToday)
 		v.RegisterValidation("phoneNumber", isValidPhoneNumber)
+		v.RegisterValidation("timeZone", isValidTimeZone)
 	}
 }
 
@@ -109,6 +110,14 @@ func getErrorMsg(fe validator.FieldError) string {
 		return "Should be a valid date before today"
 	case "phoneNumber":
 		return "Should be a valid phone number"
+	case "timeZone":
+		return "Should be a valid IANA time zone"
+	case "url":
+		return "Should be a valid URL"
+	case "unique":
+		return "Should not contain duplicate values"
+	case "bcp47_language_tag":
+		return "Should be a valid BCP 47 language tag"
 	}
 	return fe.Error()
 }
@@ -133,3 +142,8 @@ func isValidPhoneNumber(fl validator.FieldLevel) bool {
 	phoneNumberRegex := regexp.MustCompile(`^\+?[\d\s-]{7,15}$`)
 	return phoneNumberRegex.MatchString(phone)
 }
+
+func isValidTimeZone(fl validator.FieldLevel) bool {
+	_, err := time.LoadLocation(fl.Field().String())
+	return err == nil
+}
@@ -36,6 +36,7 @@ type StateFilter struct {
 	ID        *Condition
 	CountryID *Condition
 	Name      *Condition
+	Code      *Condition
 }
 
 func (f *StateFilter) GetConditions() []FilterCondition {
@@ -53,6 +54,10 @@ func (f *StateFilter) GetConditions() []FilterCondition {
 		conditions = append(conditions, f.Name.ToFilterCondition("name"))
 	}
 
+	if f.Code != nil {
+		conditions = append(conditions, f.Code.ToFilterCondition("code"))
+	}
+
 	return conditions
 }
 
@@ -1,12 +1,48 @@
 package filters
 
-import "gorm.io/gorm"
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"gorm.io/gorm"
+)
 
 type MovieFilter struct {
 	Filter
 	ID        *Condition
 	IsActive  *Condition
 	IsDeleted *Condition
+	Credit    *MovieCreditCondition
+	Rating    *MovieCertificationCondition
+	Subtitle  *Condition
+	Dub       *Condition
+	External  *MovieExternalIDCondition
+}
+
+type MovieCreditCondition struct {
+	PersonID *Condition
+	Role     *Condition
+}
+
+type MovieCertificationCondition struct {
+	CountryID *Condition
+	Rating    *Condition
+	MinAge    *Condition
+}
+
+type MovieExternalIDCondition struct {
+	Source     *Condition
+	ExternalID *Condition
+}
+
+type PersonFilter struct {
+	Filter
+	ID *Condition
+}
+
+type MovieExternalIDFilter struct {
+	Filter
+	MovieID    *Condition
+	Source     *Condition
+	ExternalID *Condition
 }
 
 func (f *MovieFilter) GetConditions() []FilterCondition {
@@ -28,5 +64,100 @@ func (f *MovieFilter) GetConditions() []FilterCondition {
 }
 
 func (f *MovieFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
+	conditions := f.GetConditions()
+
+	// Metadata lives in other tables, so it is matched through subqueries on the movie id.
+	if f.Credit != nil {
+		var credit []FilterCondition
+		if f.Credit.PersonID != nil {
+			credit = append(credit, f.Credit.PersonID.ToFilterCondition("person_id"))
+		}
+		if f.Credit.Role != nil {
+			credit = append(credit, f.Credit.Role.ToFilterCondition("role"))
+		}
+		conditions = append(conditions, movieIDsIn(query, "movie_credits", credit))
+	}
+
+	if f.Rating != nil {
+		var rating []FilterCondition
+		if f.Rating.CountryID != nil {
+			rating = append(rating, f.Rating.CountryID.ToFilterCondition("country_id"))
+		}
+		if f.Rating.Rating != nil {
+			rating = append(rating, f.Rating.Rating.ToFilterCondition("rating"))
+		}
+		if f.Rating.MinAge != nil {
+			rating = append(rating, f.Rating.MinAge.ToFilterCondition("min_age"))
+		}
+		conditions = append(conditions, movieIDsIn(query, "movie_certifications", rating))
+	}
+
+	if f.Subtitle != nil {
+		conditions = append(conditions, movieIDsIn(query, "movie_languages", []FilterCondition{
+			f.Subtitle.ToFilterCondition("language"),
+			{Field: "type", Condition: Condition{Operator: OpEqual, Value: constants.Subtitle}},
+		}))
+	}
+
+	if f.Dub != nil {
+		conditions = append(conditions, movieIDsIn(query, "movie_languages", []FilterCondition{
+			f.Dub.ToFilterCondition("language"),
+			{Field: "type", Condition: Condition{Operator: OpEqual, Value: constants.Dub}},
+		}))
+	}
+
+	if f.External != nil {
+		var external []FilterCondition
+		if f.External.Source != nil {
+			external = append(external, f.External.Source.ToFilterCondition("source"))
+		}
+		if f.External.ExternalID != nil {
+			external = append(external, f.External.ExternalID.ToFilterCondition("external_id"))
+		}
+		conditions = append(conditions, movieIDsIn(query, "movie_external_ids", external))
+	}
+
+	return f.Filter.GetFilterQuery(query, conditions)
+}
+
+func (f *PersonFilter) GetConditions() []FilterCondition {
+	var conditions []FilterCondition
+
+	if f.ID != nil {
+		conditions = append(conditions, f.ID.ToFilterCondition("id"))
+	}
+
+	return conditions
+}
+
+func (f *PersonFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
 	return f.Filter.GetFilterQuery(query, f.GetConditions())
 }
+
+func (f *MovieExternalIDFilter) GetConditions() []FilterCondition {
+	var conditions []FilterCondition
+
+	if f.MovieID != nil {
+		conditions = append(conditions, f.MovieID.ToFilterCondition("movie_id"))
+	}
+
+	if f.Source != nil {
+		conditions = append(conditions, f.Source.ToFilterCondition("source"))
+	}
+
+	if f.ExternalID != nil {
+		conditions = append(conditions, f.ExternalID.ToFilterCondition("external_id"))
+	}
+
+	return conditions
+}
+
+func (f *MovieExternalIDFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
+	return f.Filter.GetFilterQuery(query, f.GetConditions())
+}
+
+func movieIDsIn(query *gorm.DB, table string, conditions []FilterCondition) FilterCondition {
+	movies := query.Session(&gorm.Session{NewDB: true}).Table(table).Select("movie_id")
+	movies = applyConditions(movies, conditions, And)
+	return FilterCondition{Field: "id", Condition: Condition{Operator: OpIn, Value: movies}}
+}
@@ -1,6 +1,22 @@
 package filters
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ShowTimeZone resolves the zone a show is played in: the theater's own zone, then the zone of its city, then UTC.
+const ShowTimeZone = `(
+	SELECT COALESCE(t.time_zone, c.time_zone, 'UTC')
+	FROM theaters t
+		LEFT JOIN theater_locations tl ON tl.theater_id = t.id
+		LEFT JOIN cities c ON c.id = tl.city_id
+	WHERE t.id = shows.theater_id
+)`
+
+// ShowLocalToday is the current date in the zone of each show, to be compared against LocalDate.
+var ShowLocalToday = clause.Expr{SQL: fmt.Sprintf("(NOW() AT TIME ZONE %s)::date", ShowTimeZone)}
 
 type ShowFilter struct {
 	Filter
@@ -10,6 +26,7 @@ type ShowFilter struct {
 	StartTime *Condition
 	EndTime   *Condition
 	Status    *Condition
+	LocalDate *Condition
 }
 
 func (f *ShowFilter) GetConditions() []FilterCondition {
@@ -39,6 +56,10 @@ func (f *ShowFilter) GetConditions() []FilterCondition {
 		conditions = append(conditions, f.Status.ToFilterCondition("status"))
 	}
 
+	if f.LocalDate != nil {
+		conditions = append(conditions, f.LocalDate.ToFilterCondition(fmt.Sprintf("(start_time AT TIME ZONE %s)::date", ShowTimeZone)))
+	}
+
 	return conditions
 }
 
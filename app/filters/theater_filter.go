@@ -4,8 +4,11 @@ import "gorm.io/gorm"
 
 type TheaterFilter struct {
 	Filter
-	ID   *Condition
-	Name *Condition
+	ID        *Condition
+	Name      *Condition
+	IsDeleted *Condition
+	CityID    *Condition
+	Amenities []*Condition
 }
 
 type TheaterLocationFilter struct {
@@ -13,12 +16,20 @@ type TheaterLocationFilter struct {
 	TheaterID *Condition
 }
 
+type TheaterPhotoFilter struct {
+	Filter
+	ID        *Condition
+	TheaterID *Condition
+}
+
 type SeatFilter struct {
 	Filter
+	Id        *Condition
 	TheaterId *Condition
 	Row       *Condition
 	Number    *Condition
 	Type      *Condition
+	IsDeleted *Condition
 }
 
 func (f *TheaterFilter) GetConditions() []FilterCondition {
@@ -32,11 +43,30 @@ func (f *TheaterFilter) GetConditions() []FilterCondition {
 		conditions = append(conditions, f.Name.ToFilterCondition("name"))
 	}
 
+	if f.IsDeleted != nil {
+		conditions = append(conditions, f.IsDeleted.ToFilterCondition("is_deleted"))
+	}
+
 	return conditions
 }
 
 func (f *TheaterFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
-	return f.Filter.GetFilterQuery(query, f.GetConditions())
+	conditions := f.GetConditions()
+
+	// City and amenities live in other tables, so they are matched through subqueries on the theater id.
+	if f.CityID != nil {
+		locations := query.Session(&gorm.Session{NewDB: true}).Table("theater_locations").Select("theater_id")
+		locations = applyConditions(locations, []FilterCondition{f.CityID.ToFilterCondition("city_id")}, And)
+		conditions = append(conditions, FilterCondition{Field: "id", Condition: Condition{Operator: OpIn, Value: locations}})
+	}
+
+	for _, amenity := range f.Amenities {
+		amenities := query.Session(&gorm.Session{NewDB: true}).Table("theater_amenities").Select("theater_id")
+		amenities = applyConditions(amenities, []FilterCondition{amenity.ToFilterCondition("amenity")}, And)
+		conditions = append(conditions, FilterCondition{Field: "id", Condition: Condition{Operator: OpIn, Value: amenities}})
+	}
+
+	return f.Filter.GetFilterQuery(query, conditions)
 }
 
 func (f *TheaterLocationFilter) GetConditions() []FilterCondition {
@@ -53,9 +83,31 @@ func (f *TheaterLocationFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
 	return f.Filter.GetFilterQuery(query, f.GetConditions())
 }
 
+func (f *TheaterPhotoFilter) GetConditions() []FilterCondition {
+	var conditions []FilterCondition
+
+	if f.ID != nil {
+		conditions = append(conditions, f.ID.ToFilterCondition("id"))
+	}
+
+	if f.TheaterID != nil {
+		conditions = append(conditions, f.TheaterID.ToFilterCondition("theater_id"))
+	}
+
+	return conditions
+}
+
+func (f *TheaterPhotoFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
+	return f.Filter.GetFilterQuery(query, f.GetConditions())
+}
+
 func (f *SeatFilter) GetConditions() []FilterCondition {
 	var conditions []FilterCondition
 
+	if f.Id != nil {
+		conditions = append(conditions, f.Id.ToFilterCondition("id"))
+	}
+
 	if f.TheaterId != nil {
 		conditions = append(conditions, f.TheaterId.ToFilterCondition("theater_id"))
 	}
@@ -72,6 +124,10 @@ func (f *SeatFilter) GetConditions() []FilterCondition {
 		conditions = append(conditions, f.Type.ToFilterCondition("type"))
 	}
 
+	if f.IsDeleted != nil {
+		conditions = append(conditions, f.IsDeleted.ToFilterCondition("is_deleted"))
+	}
+
 	return conditions
 }
 
@@ -33,6 +33,8 @@ func (f *UserFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
 
 type UserRegistrationTokenFilter struct {
 	Filter
+	ID         *Condition
+	UserID     *Condition
 	TokenValue *Condition
 	IsUsed     *Condition
 	ExpiresAt  *Condition
@@ -41,6 +43,14 @@ type UserRegistrationTokenFilter struct {
 func (f *UserRegistrationTokenFilter) GetConditions() []FilterCondition {
 	var conditions []FilterCondition
 
+	if f.ID != nil {
+		conditions = append(conditions, f.ID.ToFilterCondition("id"))
+	}
+
+	if f.UserID != nil {
+		conditions = append(conditions, f.UserID.ToFilterCondition("user_id"))
+	}
+
 	if f.TokenValue != nil {
 		conditions = append(conditions, f.TokenValue.ToFilterCondition("token_value"))
 	}
@@ -127,3 +137,85 @@ func (f *PasswordResetTokenFilter) GetConditions() []FilterCondition {
 func (f *PasswordResetTokenFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
 	return f.Filter.GetFilterQuery(query, f.GetConditions())
 }
+
+type UserTotpSecretFilter struct {
+	Filter
+	UserID    *Condition
+	IsEnabled *Condition
+}
+
+func (f *UserTotpSecretFilter) GetConditions() []FilterCondition {
+	var conditions []FilterCondition
+
+	if f.UserID != nil {
+		conditions = append(conditions, f.UserID.ToFilterCondition("user_id"))
+	}
+
+	if f.IsEnabled != nil {
+		conditions = append(conditions, f.IsEnabled.ToFilterCondition("is_enabled"))
+	}
+
+	return conditions
+}
+
+func (f *UserTotpSecretFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
+	return f.Filter.GetFilterQuery(query, f.GetConditions())
+}
+
+type UserRecoveryCodeFilter struct {
+	Filter
+	UserID   *Condition
+	CodeHash *Condition
+	IsUsed   *Condition
+}
+
+func (f *UserRecoveryCodeFilter) GetConditions() []FilterCondition {
+	var conditions []FilterCondition
+
+	if f.UserID != nil {
+		conditions = append(conditions, f.UserID.ToFilterCondition("user_id"))
+	}
+
+	if f.CodeHash != nil {
+		conditions = append(conditions, f.CodeHash.ToFilterCondition("code_hash"))
+	}
+
+	if f.IsUsed != nil {
+		conditions = append(conditions, f.IsUsed.ToFilterCondition("is_used"))
+	}
+
+	return conditions
+}
+
+func (f *UserRecoveryCodeFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
+	return f.Filter.GetFilterQuery(query, f.GetConditions())
+}
+
+type UserIdentityFilter struct {
+	Filter
+	UserID   *Condition
+	Provider *Condition
+	Subject  *Condition
+}
+
+func (f *UserIdentityFilter) GetConditions() []FilterCondition {
+	var conditions []FilterCondition
+
+	if f.UserID != nil {
+		conditions = append(conditions, f.UserID.ToFilterCondition("user_id"))
+	}
+
+	if f.Provider != nil {
+		conditions = append(conditions, f.Provider.ToFilterCondition("provider"))
+	}
+
+	if f.Subject != nil {
+		conditions = append(conditions, f.Subject.ToFilterCondition("subject"))
+	}
+
+	return conditions
+}
+
+func (f *UserIdentityFilter) GetFilterQuery(query *gorm.DB) *gorm.DB {
+	return f.Filter.GetFilterQuery(query, f.GetConditions())
+}
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/auth"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/context"
 	"net/http"
 
@@ -10,12 +11,13 @@ import (
 )
 
 type AuthMiddleware struct {
+	authenticator   auth.Authenticator
 	userSessionRepo repositories.UserSessionRepository
 	featureFlagRepo repositories.FeatureFlagRepository
 }
 
-func NewAuthMiddleware(userSessionRepo repositories.UserSessionRepository, featureFlagRepo repositories.FeatureFlagRepository) *AuthMiddleware {
-	return &AuthMiddleware{userSessionRepo: userSessionRepo, featureFlagRepo: featureFlagRepo}
+func NewAuthMiddleware(authenticator auth.Authenticator, userSessionRepo repositories.UserSessionRepository, featureFlagRepo repositories.FeatureFlagRepository) *AuthMiddleware {
+	return &AuthMiddleware{authenticator: authenticator, userSessionRepo: userSessionRepo, featureFlagRepo: featureFlagRepo}
 }
 
 func (m *AuthMiddleware) RequireAuthMiddleware() gin.HandlerFunc {
@@ -26,7 +28,7 @@ func (m *AuthMiddleware) RequireAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		s, err := m.userSessionRepo.GetUserSession(m.userSessionRepo.GetUserSessionID(tokenValue))
+		s, err := m.userSessionRepo.GetUserSession(m.userSessionRepo.GetUserSessionID(m.authenticator.HashToken(tokenValue)))
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -56,7 +58,7 @@ func (m *AuthMiddleware) OptionalAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		s, err := m.userSessionRepo.GetUserSession(m.userSessionRepo.GetUserSessionID(tokenValue))
+		s, err := m.userSessionRepo.GetUserSession(m.userSessionRepo.GetUserSessionID(m.authenticator.HashToken(tokenValue)))
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
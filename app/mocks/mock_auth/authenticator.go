@@ -11,6 +11,7 @@ package mock_auth
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -52,6 +53,20 @@ func (mr *MockAuthenticatorMockRecorder) DoPasswordsMatch(hashedPassword, rawPas
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoPasswordsMatch", reflect.TypeOf((*MockAuthenticator)(nil).DoPasswordsMatch), hashedPassword, rawPassword)
 }
 
+// DoTokensMatch mocks base method.
+func (m *MockAuthenticator) DoTokensMatch(tokenHash, rawToken string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoTokensMatch", tokenHash, rawToken)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DoTokensMatch indicates an expected call of DoTokensMatch.
+func (mr *MockAuthenticatorMockRecorder) DoTokensMatch(tokenHash, rawToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoTokensMatch", reflect.TypeOf((*MockAuthenticator)(nil).DoTokensMatch), tokenHash, rawToken)
+}
+
 // GenerateHashedPassword mocks base method.
 func (m *MockAuthenticator) GenerateHashedPassword(rawPassword string) (string, error) {
 	m.ctrl.T.Helper()
@@ -67,44 +82,104 @@ func (mr *MockAuthenticatorMockRecorder) GenerateHashedPassword(rawPassword any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateHashedPassword", reflect.TypeOf((*MockAuthenticator)(nil).GenerateHashedPassword), rawPassword)
 }
 
-// GenerateLoginToken mocks base method.
-func (m *MockAuthenticator) GenerateLoginToken() string {
+// GenerateRandomToken mocks base method.
+func (m *MockAuthenticator) GenerateRandomToken() (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateLoginToken")
+	ret := m.ctrl.Call(m, "GenerateRandomToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRandomToken indicates an expected call of GenerateRandomToken.
+func (mr *MockAuthenticatorMockRecorder) GenerateRandomToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRandomToken", reflect.TypeOf((*MockAuthenticator)(nil).GenerateRandomToken))
+}
+
+// GenerateRecoveryCodes mocks base method.
+func (m *MockAuthenticator) GenerateRecoveryCodes(count int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRecoveryCodes", count)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRecoveryCodes indicates an expected call of GenerateRecoveryCodes.
+func (mr *MockAuthenticatorMockRecorder) GenerateRecoveryCodes(count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRecoveryCodes", reflect.TypeOf((*MockAuthenticator)(nil).GenerateRecoveryCodes), count)
+}
+
+// GenerateTotpSecret mocks base method.
+func (m *MockAuthenticator) GenerateTotpSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTotpSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTotpSecret indicates an expected call of GenerateTotpSecret.
+func (mr *MockAuthenticatorMockRecorder) GenerateTotpSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTotpSecret", reflect.TypeOf((*MockAuthenticator)(nil).GenerateTotpSecret))
+}
+
+// GetPkceCodeChallenge mocks base method.
+func (m *MockAuthenticator) GetPkceCodeChallenge(codeVerifier string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPkceCodeChallenge", codeVerifier)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// GenerateLoginToken indicates an expected call of GenerateLoginToken.
-func (mr *MockAuthenticatorMockRecorder) GenerateLoginToken() *gomock.Call {
+// GetPkceCodeChallenge indicates an expected call of GetPkceCodeChallenge.
+func (mr *MockAuthenticatorMockRecorder) GetPkceCodeChallenge(codeVerifier any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateLoginToken", reflect.TypeOf((*MockAuthenticator)(nil).GenerateLoginToken))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPkceCodeChallenge", reflect.TypeOf((*MockAuthenticator)(nil).GetPkceCodeChallenge), codeVerifier)
 }
 
-// GeneratePasswordResetToken mocks base method.
-func (m *MockAuthenticator) GeneratePasswordResetToken() string {
+// GetTotpProvisioningUri mocks base method.
+func (m *MockAuthenticator) GetTotpProvisioningUri(secret, issuer, accountName string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GeneratePasswordResetToken")
+	ret := m.ctrl.Call(m, "GetTotpProvisioningUri", secret, issuer, accountName)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// GeneratePasswordResetToken indicates an expected call of GeneratePasswordResetToken.
-func (mr *MockAuthenticatorMockRecorder) GeneratePasswordResetToken() *gomock.Call {
+// GetTotpProvisioningUri indicates an expected call of GetTotpProvisioningUri.
+func (mr *MockAuthenticatorMockRecorder) GetTotpProvisioningUri(secret, issuer, accountName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePasswordResetToken", reflect.TypeOf((*MockAuthenticator)(nil).GeneratePasswordResetToken))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotpProvisioningUri", reflect.TypeOf((*MockAuthenticator)(nil).GetTotpProvisioningUri), secret, issuer, accountName)
 }
 
-// GenerateRegistrationToken mocks base method.
-func (m *MockAuthenticator) GenerateRegistrationToken() string {
+// HashToken mocks base method.
+func (m *MockAuthenticator) HashToken(token string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateRegistrationToken")
+	ret := m.ctrl.Call(m, "HashToken", token)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// GenerateRegistrationToken indicates an expected call of GenerateRegistrationToken.
-func (mr *MockAuthenticatorMockRecorder) GenerateRegistrationToken() *gomock.Call {
+// HashToken indicates an expected call of HashToken.
+func (mr *MockAuthenticatorMockRecorder) HashToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashToken", reflect.TypeOf((*MockAuthenticator)(nil).HashToken), token)
+}
+
+// ValidateTotpCode mocks base method.
+func (m *MockAuthenticator) ValidateTotpCode(secret, code string, at time.Time) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateTotpCode", secret, code, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ValidateTotpCode indicates an expected call of ValidateTotpCode.
+func (mr *MockAuthenticatorMockRecorder) ValidateTotpCode(secret, code, at any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRegistrationToken", reflect.TypeOf((*MockAuthenticator)(nil).GenerateRegistrationToken))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTotpCode", reflect.TypeOf((*MockAuthenticator)(nil).ValidateTotpCode), secret, code, at)
 }
@@ -14,6 +14,7 @@ import (
 
 	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	gomock "go.uber.org/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -41,6 +42,20 @@ func (m *MockCityRepository) EXPECT() *MockCityRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateCities mocks base method.
+func (m *MockCityRepository) CreateCities(tx *gorm.DB, cities []*models.City) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCities", tx, cities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCities indicates an expected call of CreateCities.
+func (mr *MockCityRepositoryMockRecorder) CreateCities(tx, cities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCities", reflect.TypeOf((*MockCityRepository)(nil).CreateCities), tx, cities)
+}
+
 // CreateCity mocks base method.
 func (m *MockCityRepository) CreateCity(tx *gorm.DB, city *models.City) error {
 	m.ctrl.T.Helper()
@@ -55,6 +70,20 @@ func (mr *MockCityRepositoryMockRecorder) CreateCity(tx, city any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCity", reflect.TypeOf((*MockCityRepository)(nil).CreateCity), tx, city)
 }
 
+// DeleteCity mocks base method.
+func (m *MockCityRepository) DeleteCity(tx *gorm.DB, city *models.City) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCity", tx, city)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCity indicates an expected call of DeleteCity.
+func (mr *MockCityRepositoryMockRecorder) DeleteCity(tx, city any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCity", reflect.TypeOf((*MockCityRepository)(nil).DeleteCity), tx, city)
+}
+
 // GetCities mocks base method.
 func (m *MockCityRepository) GetCities(filter filters.CityFilter) ([]*models.City, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +113,47 @@ func (mr *MockCityRepositoryMockRecorder) GetCity(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCity", reflect.TypeOf((*MockCityRepository)(nil).GetCity), filter)
 }
+
+// GetNumbersOfCity mocks base method.
+func (m *MockCityRepository) GetNumbersOfCity(filter filters.CityFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNumbersOfCity", filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNumbersOfCity indicates an expected call of GetNumbersOfCity.
+func (mr *MockCityRepositoryMockRecorder) GetNumbersOfCity(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNumbersOfCity", reflect.TypeOf((*MockCityRepository)(nil).GetNumbersOfCity), filter)
+}
+
+// SearchCities mocks base method.
+func (m *MockCityRepository) SearchCities(keyword string, limit, offset int) ([]*payloads.SearchCityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCities", keyword, limit, offset)
+	ret0, _ := ret[0].([]*payloads.SearchCityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCities indicates an expected call of SearchCities.
+func (mr *MockCityRepositoryMockRecorder) SearchCities(keyword, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCities", reflect.TypeOf((*MockCityRepository)(nil).SearchCities), keyword, limit, offset)
+}
+
+// UpdateCity mocks base method.
+func (m *MockCityRepository) UpdateCity(tx *gorm.DB, city *models.City) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCity", tx, city)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCity indicates an expected call of UpdateCity.
+func (mr *MockCityRepositoryMockRecorder) UpdateCity(tx, city any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCity", reflect.TypeOf((*MockCityRepository)(nil).UpdateCity), tx, city)
+}
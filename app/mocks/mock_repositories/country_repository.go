@@ -41,6 +41,20 @@ func (m *MockCountryRepository) EXPECT() *MockCountryRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateCountries mocks base method.
+func (m *MockCountryRepository) CreateCountries(tx *gorm.DB, countries []*models.Country) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCountries", tx, countries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCountries indicates an expected call of CreateCountries.
+func (mr *MockCountryRepositoryMockRecorder) CreateCountries(tx, countries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCountries", reflect.TypeOf((*MockCountryRepository)(nil).CreateCountries), tx, countries)
+}
+
 // CreateCountry mocks base method.
 func (m *MockCountryRepository) CreateCountry(tx *gorm.DB, country *models.Country) error {
 	m.ctrl.T.Helper()
@@ -55,6 +69,20 @@ func (mr *MockCountryRepositoryMockRecorder) CreateCountry(tx, country any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCountry", reflect.TypeOf((*MockCountryRepository)(nil).CreateCountry), tx, country)
 }
 
+// DeleteCountry mocks base method.
+func (m *MockCountryRepository) DeleteCountry(tx *gorm.DB, country *models.Country) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCountry", tx, country)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCountry indicates an expected call of DeleteCountry.
+func (mr *MockCountryRepositoryMockRecorder) DeleteCountry(tx, country any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCountry", reflect.TypeOf((*MockCountryRepository)(nil).DeleteCountry), tx, country)
+}
+
 // GetCountries mocks base method.
 func (m *MockCountryRepository) GetCountries(filter filters.CountryFilter) ([]*models.Country, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +112,32 @@ func (mr *MockCountryRepositoryMockRecorder) GetCountry(filter any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountry", reflect.TypeOf((*MockCountryRepository)(nil).GetCountry), filter)
 }
+
+// GetNumbersOfCountry mocks base method.
+func (m *MockCountryRepository) GetNumbersOfCountry(filter filters.CountryFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNumbersOfCountry", filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNumbersOfCountry indicates an expected call of GetNumbersOfCountry.
+func (mr *MockCountryRepositoryMockRecorder) GetNumbersOfCountry(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNumbersOfCountry", reflect.TypeOf((*MockCountryRepository)(nil).GetNumbersOfCountry), filter)
+}
+
+// UpdateCountry mocks base method.
+func (m *MockCountryRepository) UpdateCountry(tx *gorm.DB, country *models.Country) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCountry", tx, country)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCountry indicates an expected call of UpdateCountry.
+func (mr *MockCountryRepositoryMockRecorder) UpdateCountry(tx, country any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCountry", reflect.TypeOf((*MockCountryRepository)(nil).UpdateCountry), tx, country)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/email_bounce_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/email_bounce_repository.go -destination=app/mocks/mock_repositories/email_bounce_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockEmailBounceRepository is a mock of EmailBounceRepository interface.
+type MockEmailBounceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailBounceRepositoryMockRecorder
+}
+
+// MockEmailBounceRepositoryMockRecorder is the mock recorder for MockEmailBounceRepository.
+type MockEmailBounceRepositoryMockRecorder struct {
+	mock *MockEmailBounceRepository
+}
+
+// NewMockEmailBounceRepository creates a new mock instance.
+func NewMockEmailBounceRepository(ctrl *gomock.Controller) *MockEmailBounceRepository {
+	mock := &MockEmailBounceRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailBounceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailBounceRepository) EXPECT() *MockEmailBounceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEmailBounce mocks base method.
+func (m *MockEmailBounceRepository) CreateEmailBounce(tx *gorm.DB, bounce *models.EmailBounce) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailBounce", tx, bounce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailBounce indicates an expected call of CreateEmailBounce.
+func (mr *MockEmailBounceRepositoryMockRecorder) CreateEmailBounce(tx, bounce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailBounce", reflect.TypeOf((*MockEmailBounceRepository)(nil).CreateEmailBounce), tx, bounce)
+}
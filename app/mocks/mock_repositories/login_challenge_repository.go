@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/login_challenge_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/login_challenge_repository.go -destination=app/mocks/mock_repositories/login_challenge_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLoginChallengeRepository is a mock of LoginChallengeRepository interface.
+type MockLoginChallengeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginChallengeRepositoryMockRecorder
+}
+
+// MockLoginChallengeRepositoryMockRecorder is the mock recorder for MockLoginChallengeRepository.
+type MockLoginChallengeRepositoryMockRecorder struct {
+	mock *MockLoginChallengeRepository
+}
+
+// NewMockLoginChallengeRepository creates a new mock instance.
+func NewMockLoginChallengeRepository(ctrl *gomock.Controller) *MockLoginChallengeRepository {
+	mock := &MockLoginChallengeRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginChallengeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginChallengeRepository) EXPECT() *MockLoginChallengeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLoginChallenge mocks base method.
+func (m *MockLoginChallengeRepository) CreateLoginChallenge(token string, expiration time.Duration, session *models.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoginChallenge", token, expiration, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoginChallenge indicates an expected call of CreateLoginChallenge.
+func (mr *MockLoginChallengeRepositoryMockRecorder) CreateLoginChallenge(token, expiration, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginChallenge", reflect.TypeOf((*MockLoginChallengeRepository)(nil).CreateLoginChallenge), token, expiration, session)
+}
+
+// GetAndDeleteLoginChallenge mocks base method.
+func (m *MockLoginChallengeRepository) GetAndDeleteLoginChallenge(token string) (*models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAndDeleteLoginChallenge", token)
+	ret0, _ := ret[0].(*models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAndDeleteLoginChallenge indicates an expected call of GetAndDeleteLoginChallenge.
+func (mr *MockLoginChallengeRepositoryMockRecorder) GetAndDeleteLoginChallenge(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAndDeleteLoginChallenge", reflect.TypeOf((*MockLoginChallengeRepository)(nil).GetAndDeleteLoginChallenge), token)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/movie_certification_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/movie_certification_repository.go -destination=app/mocks/mock_repositories/movie_certification_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockMovieCertificationRepository is a mock of MovieCertificationRepository interface.
+type MockMovieCertificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieCertificationRepositoryMockRecorder
+}
+
+// MockMovieCertificationRepositoryMockRecorder is the mock recorder for MockMovieCertificationRepository.
+type MockMovieCertificationRepositoryMockRecorder struct {
+	mock *MockMovieCertificationRepository
+}
+
+// NewMockMovieCertificationRepository creates a new mock instance.
+func NewMockMovieCertificationRepository(ctrl *gomock.Controller) *MockMovieCertificationRepository {
+	mock := &MockMovieCertificationRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieCertificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieCertificationRepository) EXPECT() *MockMovieCertificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCertificationOfShow mocks base method.
+func (m *MockMovieCertificationRepository) GetCertificationOfShow(showID uuid.UUID) (*payloads.ShowCertification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificationOfShow", showID)
+	ret0, _ := ret[0].(*payloads.ShowCertification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificationOfShow indicates an expected call of GetCertificationOfShow.
+func (mr *MockMovieCertificationRepositoryMockRecorder) GetCertificationOfShow(showID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificationOfShow", reflect.TypeOf((*MockMovieCertificationRepository)(nil).GetCertificationOfShow), showID)
+}
+
+// UpdateCertificationsOfMovie mocks base method.
+func (m *MockMovieCertificationRepository) UpdateCertificationsOfMovie(tx *gorm.DB, movieID uuid.UUID, certifications []*models.MovieCertification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCertificationsOfMovie", tx, movieID, certifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCertificationsOfMovie indicates an expected call of UpdateCertificationsOfMovie.
+func (mr *MockMovieCertificationRepositoryMockRecorder) UpdateCertificationsOfMovie(tx, movieID, certifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCertificationsOfMovie", reflect.TypeOf((*MockMovieCertificationRepository)(nil).UpdateCertificationsOfMovie), tx, movieID, certifications)
+}
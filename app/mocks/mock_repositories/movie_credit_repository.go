@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/movie_credit_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/movie_credit_repository.go -destination=app/mocks/mock_repositories/movie_credit_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockMovieCreditRepository is a mock of MovieCreditRepository interface.
+type MockMovieCreditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieCreditRepositoryMockRecorder
+}
+
+// MockMovieCreditRepositoryMockRecorder is the mock recorder for MockMovieCreditRepository.
+type MockMovieCreditRepositoryMockRecorder struct {
+	mock *MockMovieCreditRepository
+}
+
+// NewMockMovieCreditRepository creates a new mock instance.
+func NewMockMovieCreditRepository(ctrl *gomock.Controller) *MockMovieCreditRepository {
+	mock := &MockMovieCreditRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieCreditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieCreditRepository) EXPECT() *MockMovieCreditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// HasCreditsOfPerson mocks base method.
+func (m *MockMovieCreditRepository) HasCreditsOfPerson(personID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasCreditsOfPerson", personID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasCreditsOfPerson indicates an expected call of HasCreditsOfPerson.
+func (mr *MockMovieCreditRepositoryMockRecorder) HasCreditsOfPerson(personID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasCreditsOfPerson", reflect.TypeOf((*MockMovieCreditRepository)(nil).HasCreditsOfPerson), personID)
+}
+
+// UpdateCreditsOfMovie mocks base method.
+func (m *MockMovieCreditRepository) UpdateCreditsOfMovie(tx *gorm.DB, movieID uuid.UUID, credits []*models.MovieCredit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreditsOfMovie", tx, movieID, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCreditsOfMovie indicates an expected call of UpdateCreditsOfMovie.
+func (mr *MockMovieCreditRepositoryMockRecorder) UpdateCreditsOfMovie(tx, movieID, credits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreditsOfMovie", reflect.TypeOf((*MockMovieCreditRepository)(nil).UpdateCreditsOfMovie), tx, movieID, credits)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/movie_external_id_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/movie_external_id_repository.go -destination=app/mocks/mock_repositories/movie_external_id_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockMovieExternalIDRepository is a mock of MovieExternalIDRepository interface.
+type MockMovieExternalIDRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieExternalIDRepositoryMockRecorder
+}
+
+// MockMovieExternalIDRepositoryMockRecorder is the mock recorder for MockMovieExternalIDRepository.
+type MockMovieExternalIDRepositoryMockRecorder struct {
+	mock *MockMovieExternalIDRepository
+}
+
+// NewMockMovieExternalIDRepository creates a new mock instance.
+func NewMockMovieExternalIDRepository(ctrl *gomock.Controller) *MockMovieExternalIDRepository {
+	mock := &MockMovieExternalIDRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieExternalIDRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieExternalIDRepository) EXPECT() *MockMovieExternalIDRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetExternalIDs mocks base method.
+func (m *MockMovieExternalIDRepository) GetExternalIDs(filter filters.MovieExternalIDFilter) ([]*models.MovieExternalID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalIDs", filter)
+	ret0, _ := ret[0].([]*models.MovieExternalID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalIDs indicates an expected call of GetExternalIDs.
+func (mr *MockMovieExternalIDRepositoryMockRecorder) GetExternalIDs(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalIDs", reflect.TypeOf((*MockMovieExternalIDRepository)(nil).GetExternalIDs), filter)
+}
+
+// UpdateExternalIDsOfMovie mocks base method.
+func (m *MockMovieExternalIDRepository) UpdateExternalIDsOfMovie(tx *gorm.DB, movieID uuid.UUID, externalIDs []*models.MovieExternalID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExternalIDsOfMovie", tx, movieID, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExternalIDsOfMovie indicates an expected call of UpdateExternalIDsOfMovie.
+func (mr *MockMovieExternalIDRepositoryMockRecorder) UpdateExternalIDsOfMovie(tx, movieID, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExternalIDsOfMovie", reflect.TypeOf((*MockMovieExternalIDRepository)(nil).UpdateExternalIDsOfMovie), tx, movieID, externalIDs)
+}
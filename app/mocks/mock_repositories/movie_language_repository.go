@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/movie_language_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/movie_language_repository.go -destination=app/mocks/mock_repositories/movie_language_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockMovieLanguageRepository is a mock of MovieLanguageRepository interface.
+type MockMovieLanguageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovieLanguageRepositoryMockRecorder
+}
+
+// MockMovieLanguageRepositoryMockRecorder is the mock recorder for MockMovieLanguageRepository.
+type MockMovieLanguageRepositoryMockRecorder struct {
+	mock *MockMovieLanguageRepository
+}
+
+// NewMockMovieLanguageRepository creates a new mock instance.
+func NewMockMovieLanguageRepository(ctrl *gomock.Controller) *MockMovieLanguageRepository {
+	mock := &MockMovieLanguageRepository{ctrl: ctrl}
+	mock.recorder = &MockMovieLanguageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovieLanguageRepository) EXPECT() *MockMovieLanguageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// UpdateLanguagesOfMovie mocks base method.
+func (m *MockMovieLanguageRepository) UpdateLanguagesOfMovie(tx *gorm.DB, movieID uuid.UUID, languages []*models.MovieLanguage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLanguagesOfMovie", tx, movieID, languages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLanguagesOfMovie indicates an expected call of UpdateLanguagesOfMovie.
+func (mr *MockMovieLanguageRepositoryMockRecorder) UpdateLanguagesOfMovie(tx, movieID, languages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLanguagesOfMovie", reflect.TypeOf((*MockMovieLanguageRepository)(nil).UpdateLanguagesOfMovie), tx, movieID, languages)
+}
@@ -71,48 +71,48 @@ func (mr *MockMovieRepositoryMockRecorder) DeleteMovie(tx, movie, deletedBy any)
 }
 
 // GetMovie mocks base method.
-func (m *MockMovieRepository) GetMovie(filter filters.MovieFilter, includeGenres bool) (*models.Movie, error) {
+func (m *MockMovieRepository) GetMovie(filter filters.MovieFilter, includeGenres, includeDetails bool) (*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovie", filter, includeGenres)
+	ret := m.ctrl.Call(m, "GetMovie", filter, includeGenres, includeDetails)
 	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMovie indicates an expected call of GetMovie.
-func (mr *MockMovieRepositoryMockRecorder) GetMovie(filter, includeGenres any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) GetMovie(filter, includeGenres, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetMovie), filter, includeGenres)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockMovieRepository)(nil).GetMovie), filter, includeGenres, includeDetails)
 }
 
 // GetMovies mocks base method.
-func (m *MockMovieRepository) GetMovies(filter filters.MovieFilter) ([]*models.Movie, error) {
+func (m *MockMovieRepository) GetMovies(filter filters.MovieFilter, includeDetails bool) ([]*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovies", filter)
+	ret := m.ctrl.Call(m, "GetMovies", filter, includeDetails)
 	ret0, _ := ret[0].([]*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMovies indicates an expected call of GetMovies.
-func (mr *MockMovieRepositoryMockRecorder) GetMovies(filter any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) GetMovies(filter, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockMovieRepository)(nil).GetMovies), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockMovieRepository)(nil).GetMovies), filter, includeDetails)
 }
 
 // GetMoviesWithGenres mocks base method.
-func (m *MockMovieRepository) GetMoviesWithGenres(filter filters.MovieFilter) ([]*models.Movie, error) {
+func (m *MockMovieRepository) GetMoviesWithGenres(filter filters.MovieFilter, includeDetails bool) ([]*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMoviesWithGenres", filter)
+	ret := m.ctrl.Call(m, "GetMoviesWithGenres", filter, includeDetails)
 	ret0, _ := ret[0].([]*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMoviesWithGenres indicates an expected call of GetMoviesWithGenres.
-func (mr *MockMovieRepositoryMockRecorder) GetMoviesWithGenres(filter any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) GetMoviesWithGenres(filter, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesWithGenres", reflect.TypeOf((*MockMovieRepository)(nil).GetMoviesWithGenres), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesWithGenres", reflect.TypeOf((*MockMovieRepository)(nil).GetMoviesWithGenres), filter, includeDetails)
 }
 
 // GetNumbersOfMovie mocks base method.
@@ -12,8 +12,10 @@ package mock_repositories
 import (
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
 )
 
 // MockNotificationRepository is a mock of NotificationRepository interface.
@@ -39,16 +41,86 @@ func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRec
 	return m.recorder
 }
 
+// SendGenreEvent mocks base method.
+func (m *MockNotificationRepository) SendGenreEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.GenreEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendGenreEvent", tx, requestID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendGenreEvent indicates an expected call of SendGenreEvent.
+func (mr *MockNotificationRepositoryMockRecorder) SendGenreEvent(tx, requestID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGenreEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendGenreEvent), tx, requestID, event)
+}
+
+// SendMovieEvent mocks base method.
+func (m *MockNotificationRepository) SendMovieEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.MovieEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMovieEvent", tx, requestID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMovieEvent indicates an expected call of SendMovieEvent.
+func (mr *MockNotificationRepositoryMockRecorder) SendMovieEvent(tx, requestID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMovieEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendMovieEvent), tx, requestID, event)
+}
+
+// SendPasswordResetRequestedEvent mocks base method.
+func (m *MockNotificationRepository) SendPasswordResetRequestedEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.PasswordResetRequestedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordResetRequestedEvent", tx, requestID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordResetRequestedEvent indicates an expected call of SendPasswordResetRequestedEvent.
+func (mr *MockNotificationRepositoryMockRecorder) SendPasswordResetRequestedEvent(tx, requestID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordResetRequestedEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendPasswordResetRequestedEvent), tx, requestID, event)
+}
+
+// SendShowEvent mocks base method.
+func (m *MockNotificationRepository) SendShowEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.ShowEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendShowEvent", tx, requestID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendShowEvent indicates an expected call of SendShowEvent.
+func (mr *MockNotificationRepositoryMockRecorder) SendShowEvent(tx, requestID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendShowEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendShowEvent), tx, requestID, event)
+}
+
+// SendTheaterEvent mocks base method.
+func (m *MockNotificationRepository) SendTheaterEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.TheaterEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTheaterEvent", tx, requestID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendTheaterEvent indicates an expected call of SendTheaterEvent.
+func (mr *MockNotificationRepositoryMockRecorder) SendTheaterEvent(tx, requestID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTheaterEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendTheaterEvent), tx, requestID, event)
+}
+
 // SendUserRegistrationEvent mocks base method.
-func (m *MockNotificationRepository) SendUserRegistrationEvent(event payloads.UserRegistrationEvent) error {
+func (m *MockNotificationRepository) SendUserRegistrationEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.UserRegistrationEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendUserRegistrationEvent", event)
+	ret := m.ctrl.Call(m, "SendUserRegistrationEvent", tx, requestID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendUserRegistrationEvent indicates an expected call of SendUserRegistrationEvent.
-func (mr *MockNotificationRepositoryMockRecorder) SendUserRegistrationEvent(event any) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) SendUserRegistrationEvent(tx, requestID, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendUserRegistrationEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendUserRegistrationEvent), event)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendUserRegistrationEvent", reflect.TypeOf((*MockNotificationRepository)(nil).SendUserRegistrationEvent), tx, requestID, event)
 }
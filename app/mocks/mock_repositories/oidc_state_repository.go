@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/oidc_state_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/oidc_state_repository.go -destination=app/mocks/mock_repositories/oidc_state_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOidcStateRepository is a mock of OidcStateRepository interface.
+type MockOidcStateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcStateRepositoryMockRecorder
+}
+
+// MockOidcStateRepositoryMockRecorder is the mock recorder for MockOidcStateRepository.
+type MockOidcStateRepositoryMockRecorder struct {
+	mock *MockOidcStateRepository
+}
+
+// NewMockOidcStateRepository creates a new mock instance.
+func NewMockOidcStateRepository(ctrl *gomock.Controller) *MockOidcStateRepository {
+	mock := &MockOidcStateRepository{ctrl: ctrl}
+	mock.recorder = &MockOidcStateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidcStateRepository) EXPECT() *MockOidcStateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeState mocks base method.
+func (m *MockOidcStateRepository) ConsumeState(state string) (*models.OidcState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeState", state)
+	ret0, _ := ret[0].(*models.OidcState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeState indicates an expected call of ConsumeState.
+func (mr *MockOidcStateRepositoryMockRecorder) ConsumeState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeState", reflect.TypeOf((*MockOidcStateRepository)(nil).ConsumeState), state)
+}
+
+// CreateState mocks base method.
+func (m *MockOidcStateRepository) CreateState(state string, expiration time.Duration, oidcState *models.OidcState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateState", state, expiration, oidcState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateState indicates an expected call of CreateState.
+func (mr *MockOidcStateRepositoryMockRecorder) CreateState(state, expiration, oidcState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateState", reflect.TypeOf((*MockOidcStateRepository)(nil).CreateState), state, expiration, oidcState)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/outbox_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/outbox_repository.go -destination=app/mocks/mock_repositories/outbox_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMessage mocks base method.
+func (m *MockOutboxRepository) CreateMessage(tx *gorm.DB, message *models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", tx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockOutboxRepositoryMockRecorder) CreateMessage(tx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockOutboxRepository)(nil).CreateMessage), tx, message)
+}
+
+// DeleteProcessedMessages mocks base method.
+func (m *MockOutboxRepository) DeleteProcessedMessages(tx *gorm.DB, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedMessages", tx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProcessedMessages indicates an expected call of DeleteProcessedMessages.
+func (mr *MockOutboxRepositoryMockRecorder) DeleteProcessedMessages(tx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedMessages", reflect.TypeOf((*MockOutboxRepository)(nil).DeleteProcessedMessages), tx, before)
+}
+
+// GetPendingMessages mocks base method.
+func (m *MockOutboxRepository) GetPendingMessages(tx *gorm.DB, limit int) ([]*models.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingMessages", tx, limit)
+	ret0, _ := ret[0].([]*models.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingMessages indicates an expected call of GetPendingMessages.
+func (mr *MockOutboxRepositoryMockRecorder) GetPendingMessages(tx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingMessages", reflect.TypeOf((*MockOutboxRepository)(nil).GetPendingMessages), tx, limit)
+}
+
+// TryLockRelay mocks base method.
+func (m *MockOutboxRepository) TryLockRelay(tx *gorm.DB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLockRelay", tx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLockRelay indicates an expected call of TryLockRelay.
+func (mr *MockOutboxRepositoryMockRecorder) TryLockRelay(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLockRelay", reflect.TypeOf((*MockOutboxRepository)(nil).TryLockRelay), tx)
+}
+
+// UpdateMessage mocks base method.
+func (m *MockOutboxRepository) UpdateMessage(tx *gorm.DB, message *models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessage", tx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMessage indicates an expected call of UpdateMessage.
+func (mr *MockOutboxRepositoryMockRecorder) UpdateMessage(tx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockOutboxRepository)(nil).UpdateMessage), tx, message)
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/person_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/person_repository.go -destination=app/mocks/mock_repositories/person_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockPersonRepository is a mock of PersonRepository interface.
+type MockPersonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonRepositoryMockRecorder
+}
+
+// MockPersonRepositoryMockRecorder is the mock recorder for MockPersonRepository.
+type MockPersonRepositoryMockRecorder struct {
+	mock *MockPersonRepository
+}
+
+// NewMockPersonRepository creates a new mock instance.
+func NewMockPersonRepository(ctrl *gomock.Controller) *MockPersonRepository {
+	mock := &MockPersonRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonRepository) EXPECT() *MockPersonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePerson mocks base method.
+func (m *MockPersonRepository) CreatePerson(tx *gorm.DB, person *models.Person) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePerson", tx, person)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePerson indicates an expected call of CreatePerson.
+func (mr *MockPersonRepositoryMockRecorder) CreatePerson(tx, person any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePerson", reflect.TypeOf((*MockPersonRepository)(nil).CreatePerson), tx, person)
+}
+
+// DeletePerson mocks base method.
+func (m *MockPersonRepository) DeletePerson(tx *gorm.DB, person *models.Person) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePerson", tx, person)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePerson indicates an expected call of DeletePerson.
+func (mr *MockPersonRepositoryMockRecorder) DeletePerson(tx, person any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePerson", reflect.TypeOf((*MockPersonRepository)(nil).DeletePerson), tx, person)
+}
+
+// GetPeople mocks base method.
+func (m *MockPersonRepository) GetPeople(filter filters.PersonFilter) ([]*models.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeople", filter)
+	ret0, _ := ret[0].([]*models.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeople indicates an expected call of GetPeople.
+func (mr *MockPersonRepositoryMockRecorder) GetPeople(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeople", reflect.TypeOf((*MockPersonRepository)(nil).GetPeople), filter)
+}
+
+// GetPerson mocks base method.
+func (m *MockPersonRepository) GetPerson(filter filters.PersonFilter) (*models.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerson", filter)
+	ret0, _ := ret[0].(*models.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPerson indicates an expected call of GetPerson.
+func (mr *MockPersonRepositoryMockRecorder) GetPerson(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerson", reflect.TypeOf((*MockPersonRepository)(nil).GetPerson), filter)
+}
+
+// SearchPeople mocks base method.
+func (m *MockPersonRepository) SearchPeople(keyword string, limit, offset int) ([]*models.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPeople", keyword, limit, offset)
+	ret0, _ := ret[0].([]*models.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPeople indicates an expected call of SearchPeople.
+func (mr *MockPersonRepositoryMockRecorder) SearchPeople(keyword, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPeople", reflect.TypeOf((*MockPersonRepository)(nil).SearchPeople), keyword, limit, offset)
+}
+
+// UpdatePerson mocks base method.
+func (m *MockPersonRepository) UpdatePerson(tx *gorm.DB, person *models.Person) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePerson", tx, person)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePerson indicates an expected call of UpdatePerson.
+func (mr *MockPersonRepositoryMockRecorder) UpdatePerson(tx, person any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePerson", reflect.TypeOf((*MockPersonRepository)(nil).UpdatePerson), tx, person)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/processed_event_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/processed_event_repository.go -destination=app/mocks/mock_repositories/processed_event_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockProcessedEventRepository is a mock of ProcessedEventRepository interface.
+type MockProcessedEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessedEventRepositoryMockRecorder
+}
+
+// MockProcessedEventRepositoryMockRecorder is the mock recorder for MockProcessedEventRepository.
+type MockProcessedEventRepositoryMockRecorder struct {
+	mock *MockProcessedEventRepository
+}
+
+// NewMockProcessedEventRepository creates a new mock instance.
+func NewMockProcessedEventRepository(ctrl *gomock.Controller) *MockProcessedEventRepository {
+	mock := &MockProcessedEventRepository{ctrl: ctrl}
+	mock.recorder = &MockProcessedEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessedEventRepository) EXPECT() *MockProcessedEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateProcessedEvent mocks base method.
+func (m *MockProcessedEventRepository) CreateProcessedEvent(tx *gorm.DB, event *models.ProcessedEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProcessedEvent", tx, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProcessedEvent indicates an expected call of CreateProcessedEvent.
+func (mr *MockProcessedEventRepositoryMockRecorder) CreateProcessedEvent(tx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProcessedEvent", reflect.TypeOf((*MockProcessedEventRepository)(nil).CreateProcessedEvent), tx, event)
+}
+
+// DeleteProcessedEvents mocks base method.
+func (m *MockProcessedEventRepository) DeleteProcessedEvents(tx *gorm.DB, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedEvents", tx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProcessedEvents indicates an expected call of DeleteProcessedEvents.
+func (mr *MockProcessedEventRepositoryMockRecorder) DeleteProcessedEvents(tx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedEvents", reflect.TypeOf((*MockProcessedEventRepository)(nil).DeleteProcessedEvents), tx, before)
+}
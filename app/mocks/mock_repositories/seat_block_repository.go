@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/seat_block_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/seat_block_repository.go -destination=app/mocks/mock_repositories/seat_block_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockSeatBlockRepository is a mock of SeatBlockRepository interface.
+type MockSeatBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeatBlockRepositoryMockRecorder
+}
+
+// MockSeatBlockRepositoryMockRecorder is the mock recorder for MockSeatBlockRepository.
+type MockSeatBlockRepositoryMockRecorder struct {
+	mock *MockSeatBlockRepository
+}
+
+// NewMockSeatBlockRepository creates a new mock instance.
+func NewMockSeatBlockRepository(ctrl *gomock.Controller) *MockSeatBlockRepository {
+	mock := &MockSeatBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockSeatBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeatBlockRepository) EXPECT() *MockSeatBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSeatBlock mocks base method.
+func (m *MockSeatBlockRepository) CreateSeatBlock(tx *gorm.DB, block *models.SeatBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatBlock", tx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSeatBlock indicates an expected call of CreateSeatBlock.
+func (mr *MockSeatBlockRepositoryMockRecorder) CreateSeatBlock(tx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatBlock", reflect.TypeOf((*MockSeatBlockRepository)(nil).CreateSeatBlock), tx, block)
+}
+
+// DeleteSeatBlock mocks base method.
+func (m *MockSeatBlockRepository) DeleteSeatBlock(tx *gorm.DB, block *models.SeatBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeatBlock", tx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeatBlock indicates an expected call of DeleteSeatBlock.
+func (mr *MockSeatBlockRepositoryMockRecorder) DeleteSeatBlock(tx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeatBlock", reflect.TypeOf((*MockSeatBlockRepository)(nil).DeleteSeatBlock), tx, block)
+}
+
+// GetSeatBlock mocks base method.
+func (m *MockSeatBlockRepository) GetSeatBlock(seatId uuid.UUID, showId *uuid.UUID) (*models.SeatBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeatBlock", seatId, showId)
+	ret0, _ := ret[0].(*models.SeatBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeatBlock indicates an expected call of GetSeatBlock.
+func (mr *MockSeatBlockRepositoryMockRecorder) GetSeatBlock(seatId, showId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeatBlock", reflect.TypeOf((*MockSeatBlockRepository)(nil).GetSeatBlock), seatId, showId)
+}
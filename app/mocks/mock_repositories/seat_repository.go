@@ -12,6 +12,7 @@ package mock_repositories
 import (
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,63 @@ func (mr *MockSeatRepositoryMockRecorder) CreateSeat(tx, seat any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeat", reflect.TypeOf((*MockSeatRepository)(nil).CreateSeat), tx, seat)
 }
 
+// CreateSeatPair mocks base method.
+func (m *MockSeatRepository) CreateSeatPair(tx *gorm.DB, seat, pair *models.Seat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatPair", tx, seat, pair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSeatPair indicates an expected call of CreateSeatPair.
+func (mr *MockSeatRepositoryMockRecorder) CreateSeatPair(tx, seat, pair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatPair", reflect.TypeOf((*MockSeatRepository)(nil).CreateSeatPair), tx, seat, pair)
+}
+
+// DeleteSeat mocks base method.
+func (m *MockSeatRepository) DeleteSeat(tx *gorm.DB, seat *models.Seat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeat", tx, seat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeat indicates an expected call of DeleteSeat.
+func (mr *MockSeatRepositoryMockRecorder) DeleteSeat(tx, seat any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeat", reflect.TypeOf((*MockSeatRepository)(nil).DeleteSeat), tx, seat)
+}
+
+// DeleteSeatsOfTheater mocks base method.
+func (m *MockSeatRepository) DeleteSeatsOfTheater(tx *gorm.DB, theaterId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeatsOfTheater", tx, theaterId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeatsOfTheater indicates an expected call of DeleteSeatsOfTheater.
+func (mr *MockSeatRepositoryMockRecorder) DeleteSeatsOfTheater(tx, theaterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeatsOfTheater", reflect.TypeOf((*MockSeatRepository)(nil).DeleteSeatsOfTheater), tx, theaterId)
+}
+
+// GetAvailableSeats mocks base method.
+func (m *MockSeatRepository) GetAvailableSeats(theaterId, showId uuid.UUID) ([]*models.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableSeats", theaterId, showId)
+	ret0, _ := ret[0].([]*models.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableSeats indicates an expected call of GetAvailableSeats.
+func (mr *MockSeatRepositoryMockRecorder) GetAvailableSeats(theaterId, showId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableSeats", reflect.TypeOf((*MockSeatRepository)(nil).GetAvailableSeats), theaterId, showId)
+}
+
 // GetSeat mocks base method.
 func (m *MockSeatRepository) GetSeat(filter filters.SeatFilter) (*models.Seat, error) {
 	m.ctrl.T.Helper()
@@ -69,3 +127,32 @@ func (mr *MockSeatRepositoryMockRecorder) GetSeat(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeat", reflect.TypeOf((*MockSeatRepository)(nil).GetSeat), filter)
 }
+
+// GetSeats mocks base method.
+func (m *MockSeatRepository) GetSeats(filter filters.SeatFilter) ([]*models.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeats", filter)
+	ret0, _ := ret[0].([]*models.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeats indicates an expected call of GetSeats.
+func (mr *MockSeatRepositoryMockRecorder) GetSeats(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeats", reflect.TypeOf((*MockSeatRepository)(nil).GetSeats), filter)
+}
+
+// UpdateSeat mocks base method.
+func (m *MockSeatRepository) UpdateSeat(tx *gorm.DB, seat *models.Seat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeat", tx, seat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeat indicates an expected call of UpdateSeat.
+func (mr *MockSeatRepositoryMockRecorder) UpdateSeat(tx, seat any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeat", reflect.TypeOf((*MockSeatRepository)(nil).UpdateSeat), tx, seat)
+}
@@ -88,47 +88,64 @@ func (mr *MockShowRepositoryMockRecorder) GetShows(filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShows", reflect.TypeOf((*MockShowRepository)(nil).GetShows), filter)
 }
 
+// HasUpcomingShows mocks base method.
+func (m *MockShowRepository) HasUpcomingShows(tx *gorm.DB, theaterId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUpcomingShows", tx, theaterId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUpcomingShows indicates an expected call of HasUpcomingShows.
+func (mr *MockShowRepositoryMockRecorder) HasUpcomingShows(tx, theaterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUpcomingShows", reflect.TypeOf((*MockShowRepository)(nil).HasUpcomingShows), tx, theaterId)
+}
+
 // IsShowInValidTimeRange mocks base method.
-func (m *MockShowRepository) IsShowInValidTimeRange(theaterId uuid.UUID, startTime, endTime time.Time) (bool, error) {
+func (m *MockShowRepository) IsShowInValidTimeRange(tx *gorm.DB, theaterId uuid.UUID, startTime, endTime time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsShowInValidTimeRange", theaterId, startTime, endTime)
+	ret := m.ctrl.Call(m, "IsShowInValidTimeRange", tx, theaterId, startTime, endTime)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsShowInValidTimeRange indicates an expected call of IsShowInValidTimeRange.
-func (mr *MockShowRepositoryMockRecorder) IsShowInValidTimeRange(theaterId, startTime, endTime any) *gomock.Call {
+func (mr *MockShowRepositoryMockRecorder) IsShowInValidTimeRange(tx, theaterId, startTime, endTime any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsShowInValidTimeRange", reflect.TypeOf((*MockShowRepository)(nil).IsShowInValidTimeRange), theaterId, startTime, endTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsShowInValidTimeRange", reflect.TypeOf((*MockShowRepository)(nil).IsShowInValidTimeRange), tx, theaterId, startTime, endTime)
 }
 
 // ScheduleActivateShows mocks base method.
-func (m *MockShowRepository) ScheduleActivateShows(tx *gorm.DB, beforeStart time.Duration) error {
+func (m *MockShowRepository) ScheduleActivateShows(tx *gorm.DB, now time.Time, beforeStart time.Duration) ([]*models.Show, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ScheduleActivateShows", tx, beforeStart)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ScheduleActivateShows", tx, now, beforeStart)
+	ret0, _ := ret[0].([]*models.Show)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ScheduleActivateShows indicates an expected call of ScheduleActivateShows.
-func (mr *MockShowRepositoryMockRecorder) ScheduleActivateShows(tx, beforeStart any) *gomock.Call {
+func (mr *MockShowRepositoryMockRecorder) ScheduleActivateShows(tx, now, beforeStart any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleActivateShows", reflect.TypeOf((*MockShowRepository)(nil).ScheduleActivateShows), tx, beforeStart)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleActivateShows", reflect.TypeOf((*MockShowRepository)(nil).ScheduleActivateShows), tx, now, beforeStart)
 }
 
 // ScheduleCompleteShows mocks base method.
-func (m *MockShowRepository) ScheduleCompleteShows(tx *gorm.DB) error {
+func (m *MockShowRepository) ScheduleCompleteShows(tx *gorm.DB, now time.Time) ([]*models.Show, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ScheduleCompleteShows", tx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ScheduleCompleteShows", tx, now)
+	ret0, _ := ret[0].([]*models.Show)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ScheduleCompleteShows indicates an expected call of ScheduleCompleteShows.
-func (mr *MockShowRepositoryMockRecorder) ScheduleCompleteShows(tx any) *gomock.Call {
+func (mr *MockShowRepositoryMockRecorder) ScheduleCompleteShows(tx, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleCompleteShows", reflect.TypeOf((*MockShowRepository)(nil).ScheduleCompleteShows), tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleCompleteShows", reflect.TypeOf((*MockShowRepository)(nil).ScheduleCompleteShows), tx, now)
 }
 
 // UpdateShowStatus mocks base method.
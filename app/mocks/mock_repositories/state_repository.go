@@ -55,6 +55,49 @@ func (mr *MockStateRepositoryMockRecorder) CreateState(tx, state any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateState", reflect.TypeOf((*MockStateRepository)(nil).CreateState), tx, state)
 }
 
+// CreateStates mocks base method.
+func (m *MockStateRepository) CreateStates(tx *gorm.DB, states []*models.State) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStates", tx, states)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStates indicates an expected call of CreateStates.
+func (mr *MockStateRepositoryMockRecorder) CreateStates(tx, states any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStates", reflect.TypeOf((*MockStateRepository)(nil).CreateStates), tx, states)
+}
+
+// DeleteState mocks base method.
+func (m *MockStateRepository) DeleteState(tx *gorm.DB, state *models.State) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteState", tx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteState indicates an expected call of DeleteState.
+func (mr *MockStateRepositoryMockRecorder) DeleteState(tx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteState", reflect.TypeOf((*MockStateRepository)(nil).DeleteState), tx, state)
+}
+
+// GetNumbersOfState mocks base method.
+func (m *MockStateRepository) GetNumbersOfState(filter filters.StateFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNumbersOfState", filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNumbersOfState indicates an expected call of GetNumbersOfState.
+func (mr *MockStateRepositoryMockRecorder) GetNumbersOfState(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNumbersOfState", reflect.TypeOf((*MockStateRepository)(nil).GetNumbersOfState), filter)
+}
+
 // GetState mocks base method.
 func (m *MockStateRepository) GetState(filter filters.StateFilter) (*models.State, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +127,17 @@ func (mr *MockStateRepositoryMockRecorder) GetStates(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStates", reflect.TypeOf((*MockStateRepository)(nil).GetStates), filter)
 }
+
+// UpdateState mocks base method.
+func (m *MockStateRepository) UpdateState(tx *gorm.DB, state *models.State) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", tx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockStateRepositoryMockRecorder) UpdateState(tx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockStateRepository)(nil).UpdateState), tx, state)
+}
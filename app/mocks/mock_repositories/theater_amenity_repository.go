@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/theater_amenity_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/theater_amenity_repository.go -destination=app/mocks/mock_repositories/theater_amenity_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	constants "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockTheaterAmenityRepository is a mock of TheaterAmenityRepository interface.
+type MockTheaterAmenityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTheaterAmenityRepositoryMockRecorder
+}
+
+// MockTheaterAmenityRepositoryMockRecorder is the mock recorder for MockTheaterAmenityRepository.
+type MockTheaterAmenityRepositoryMockRecorder struct {
+	mock *MockTheaterAmenityRepository
+}
+
+// NewMockTheaterAmenityRepository creates a new mock instance.
+func NewMockTheaterAmenityRepository(ctrl *gomock.Controller) *MockTheaterAmenityRepository {
+	mock := &MockTheaterAmenityRepository{ctrl: ctrl}
+	mock.recorder = &MockTheaterAmenityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTheaterAmenityRepository) EXPECT() *MockTheaterAmenityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// UpdateAmenitiesOfTheater mocks base method.
+func (m *MockTheaterAmenityRepository) UpdateAmenitiesOfTheater(tx *gorm.DB, theaterID uuid.UUID, amenities []constants.TheaterAmenity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAmenitiesOfTheater", tx, theaterID, amenities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAmenitiesOfTheater indicates an expected call of UpdateAmenitiesOfTheater.
+func (mr *MockTheaterAmenityRepositoryMockRecorder) UpdateAmenitiesOfTheater(tx, theaterID, amenities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAmenitiesOfTheater", reflect.TypeOf((*MockTheaterAmenityRepository)(nil).UpdateAmenitiesOfTheater), tx, theaterID, amenities)
+}
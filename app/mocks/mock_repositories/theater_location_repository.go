@@ -12,6 +12,7 @@ package mock_repositories
 import (
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,20 @@ func (mr *MockTheaterLocationRepositoryMockRecorder) CreateTheaterLocation(tx, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheaterLocation", reflect.TypeOf((*MockTheaterLocationRepository)(nil).CreateTheaterLocation), tx, location)
 }
 
+// DeleteTheaterLocation mocks base method.
+func (m *MockTheaterLocationRepository) DeleteTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTheaterLocation", tx, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTheaterLocation indicates an expected call of DeleteTheaterLocation.
+func (mr *MockTheaterLocationRepositoryMockRecorder) DeleteTheaterLocation(tx, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTheaterLocation", reflect.TypeOf((*MockTheaterLocationRepository)(nil).DeleteTheaterLocation), tx, location)
+}
+
 // GetLocation mocks base method.
 func (m *MockTheaterLocationRepository) GetLocation(filter filters.TheaterLocationFilter) (*models.TheaterLocation, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +85,51 @@ func (mr *MockTheaterLocationRepositoryMockRecorder) GetLocation(filter any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocation", reflect.TypeOf((*MockTheaterLocationRepository)(nil).GetLocation), filter)
 }
 
+// HasLocationsInCity mocks base method.
+func (m *MockTheaterLocationRepository) HasLocationsInCity(cityId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLocationsInCity", cityId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLocationsInCity indicates an expected call of HasLocationsInCity.
+func (mr *MockTheaterLocationRepositoryMockRecorder) HasLocationsInCity(cityId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLocationsInCity", reflect.TypeOf((*MockTheaterLocationRepository)(nil).HasLocationsInCity), cityId)
+}
+
+// HasLocationsInCountry mocks base method.
+func (m *MockTheaterLocationRepository) HasLocationsInCountry(countryId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLocationsInCountry", countryId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLocationsInCountry indicates an expected call of HasLocationsInCountry.
+func (mr *MockTheaterLocationRepositoryMockRecorder) HasLocationsInCountry(countryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLocationsInCountry", reflect.TypeOf((*MockTheaterLocationRepository)(nil).HasLocationsInCountry), countryId)
+}
+
+// HasLocationsInState mocks base method.
+func (m *MockTheaterLocationRepository) HasLocationsInState(stateId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLocationsInState", stateId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLocationsInState indicates an expected call of HasLocationsInState.
+func (mr *MockTheaterLocationRepositoryMockRecorder) HasLocationsInState(stateId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLocationsInState", reflect.TypeOf((*MockTheaterLocationRepository)(nil).HasLocationsInState), stateId)
+}
+
 // UpdateTheaterLocation mocks base method.
 func (m *MockTheaterLocationRepository) UpdateTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error {
 	m.ctrl.T.Helper()
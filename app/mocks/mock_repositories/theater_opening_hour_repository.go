@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/theater_opening_hour_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/theater_opening_hour_repository.go -destination=app/mocks/mock_repositories/theater_opening_hour_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockTheaterOpeningHourRepository is a mock of TheaterOpeningHourRepository interface.
+type MockTheaterOpeningHourRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTheaterOpeningHourRepositoryMockRecorder
+}
+
+// MockTheaterOpeningHourRepositoryMockRecorder is the mock recorder for MockTheaterOpeningHourRepository.
+type MockTheaterOpeningHourRepositoryMockRecorder struct {
+	mock *MockTheaterOpeningHourRepository
+}
+
+// NewMockTheaterOpeningHourRepository creates a new mock instance.
+func NewMockTheaterOpeningHourRepository(ctrl *gomock.Controller) *MockTheaterOpeningHourRepository {
+	mock := &MockTheaterOpeningHourRepository{ctrl: ctrl}
+	mock.recorder = &MockTheaterOpeningHourRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTheaterOpeningHourRepository) EXPECT() *MockTheaterOpeningHourRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetOpeningHours mocks base method.
+func (m *MockTheaterOpeningHourRepository) GetOpeningHours(theaterID uuid.UUID) ([]*models.TheaterOpeningHour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpeningHours", theaterID)
+	ret0, _ := ret[0].([]*models.TheaterOpeningHour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpeningHours indicates an expected call of GetOpeningHours.
+func (mr *MockTheaterOpeningHourRepositoryMockRecorder) GetOpeningHours(theaterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningHours", reflect.TypeOf((*MockTheaterOpeningHourRepository)(nil).GetOpeningHours), theaterID)
+}
+
+// UpdateOpeningHoursOfTheater mocks base method.
+func (m *MockTheaterOpeningHourRepository) UpdateOpeningHoursOfTheater(tx *gorm.DB, theaterID uuid.UUID, hours []*models.TheaterOpeningHour) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOpeningHoursOfTheater", tx, theaterID, hours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOpeningHoursOfTheater indicates an expected call of UpdateOpeningHoursOfTheater.
+func (mr *MockTheaterOpeningHourRepositoryMockRecorder) UpdateOpeningHoursOfTheater(tx, theaterID, hours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOpeningHoursOfTheater", reflect.TypeOf((*MockTheaterOpeningHourRepository)(nil).UpdateOpeningHoursOfTheater), tx, theaterID, hours)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/theater_photo_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/theater_photo_repository.go -destination=app/mocks/mock_repositories/theater_photo_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockTheaterPhotoRepository is a mock of TheaterPhotoRepository interface.
+type MockTheaterPhotoRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTheaterPhotoRepositoryMockRecorder
+}
+
+// MockTheaterPhotoRepositoryMockRecorder is the mock recorder for MockTheaterPhotoRepository.
+type MockTheaterPhotoRepositoryMockRecorder struct {
+	mock *MockTheaterPhotoRepository
+}
+
+// NewMockTheaterPhotoRepository creates a new mock instance.
+func NewMockTheaterPhotoRepository(ctrl *gomock.Controller) *MockTheaterPhotoRepository {
+	mock := &MockTheaterPhotoRepository{ctrl: ctrl}
+	mock.recorder = &MockTheaterPhotoRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTheaterPhotoRepository) EXPECT() *MockTheaterPhotoRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePhoto mocks base method.
+func (m *MockTheaterPhotoRepository) CreatePhoto(tx *gorm.DB, photo *models.TheaterPhoto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePhoto", tx, photo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePhoto indicates an expected call of CreatePhoto.
+func (mr *MockTheaterPhotoRepositoryMockRecorder) CreatePhoto(tx, photo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePhoto", reflect.TypeOf((*MockTheaterPhotoRepository)(nil).CreatePhoto), tx, photo)
+}
+
+// DeletePhoto mocks base method.
+func (m *MockTheaterPhotoRepository) DeletePhoto(tx *gorm.DB, photo *models.TheaterPhoto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePhoto", tx, photo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePhoto indicates an expected call of DeletePhoto.
+func (mr *MockTheaterPhotoRepositoryMockRecorder) DeletePhoto(tx, photo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePhoto", reflect.TypeOf((*MockTheaterPhotoRepository)(nil).DeletePhoto), tx, photo)
+}
+
+// GetPhoto mocks base method.
+func (m *MockTheaterPhotoRepository) GetPhoto(filter filters.TheaterPhotoFilter) (*models.TheaterPhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPhoto", filter)
+	ret0, _ := ret[0].(*models.TheaterPhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPhoto indicates an expected call of GetPhoto.
+func (mr *MockTheaterPhotoRepositoryMockRecorder) GetPhoto(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhoto", reflect.TypeOf((*MockTheaterPhotoRepository)(nil).GetPhoto), filter)
+}
@@ -12,6 +12,7 @@ package mock_repositories
 import (
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
@@ -56,19 +57,33 @@ func (mr *MockTheaterRepositoryMockRecorder) CreateTheater(tx, theater any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheater", reflect.TypeOf((*MockTheaterRepository)(nil).CreateTheater), tx, theater)
 }
 
+// DeleteTheater mocks base method.
+func (m *MockTheaterRepository) DeleteTheater(tx *gorm.DB, theater *models.Theater) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTheater", tx, theater)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTheater indicates an expected call of DeleteTheater.
+func (mr *MockTheaterRepositoryMockRecorder) DeleteTheater(tx, theater any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTheater", reflect.TypeOf((*MockTheaterRepository)(nil).DeleteTheater), tx, theater)
+}
+
 // GetNearbyTheatersWithLocations mocks base method.
-func (m *MockTheaterRepository) GetNearbyTheatersWithLocations(lat, lon, distance float64) ([]*payloads.GetTheaterWithLocationResult, error) {
+func (m *MockTheaterRepository) GetNearbyTheatersWithLocations(lat, lon, distance float64, limit, offset int) ([]*payloads.GetTheaterWithLocationResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNearbyTheatersWithLocations", lat, lon, distance)
+	ret := m.ctrl.Call(m, "GetNearbyTheatersWithLocations", lat, lon, distance, limit, offset)
 	ret0, _ := ret[0].([]*payloads.GetTheaterWithLocationResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNearbyTheatersWithLocations indicates an expected call of GetNearbyTheatersWithLocations.
-func (mr *MockTheaterRepositoryMockRecorder) GetNearbyTheatersWithLocations(lat, lon, distance any) *gomock.Call {
+func (mr *MockTheaterRepositoryMockRecorder) GetNearbyTheatersWithLocations(lat, lon, distance, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyTheatersWithLocations", reflect.TypeOf((*MockTheaterRepository)(nil).GetNearbyTheatersWithLocations), lat, lon, distance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyTheatersWithLocations", reflect.TypeOf((*MockTheaterRepository)(nil).GetNearbyTheatersWithLocations), lat, lon, distance, limit, offset)
 }
 
 // GetNumbersOfTheater mocks base method.
@@ -87,31 +102,75 @@ func (mr *MockTheaterRepositoryMockRecorder) GetNumbersOfTheater(filter any) *go
 }
 
 // GetTheater mocks base method.
-func (m *MockTheaterRepository) GetTheater(filter filters.TheaterFilter, includeLocation bool) (*models.Theater, error) {
+func (m *MockTheaterRepository) GetTheater(filter filters.TheaterFilter, includeLocation, includeDetails bool) (*models.Theater, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTheater", filter, includeLocation)
+	ret := m.ctrl.Call(m, "GetTheater", filter, includeLocation, includeDetails)
 	ret0, _ := ret[0].(*models.Theater)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTheater indicates an expected call of GetTheater.
-func (mr *MockTheaterRepositoryMockRecorder) GetTheater(filter, includeLocation any) *gomock.Call {
+func (mr *MockTheaterRepositoryMockRecorder) GetTheater(filter, includeLocation, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheater", reflect.TypeOf((*MockTheaterRepository)(nil).GetTheater), filter, includeLocation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheater", reflect.TypeOf((*MockTheaterRepository)(nil).GetTheater), filter, includeLocation, includeDetails)
 }
 
 // GetTheaters mocks base method.
-func (m *MockTheaterRepository) GetTheaters(filter filters.TheaterFilter, includeLocation bool) ([]*models.Theater, error) {
+func (m *MockTheaterRepository) GetTheaters(filter filters.TheaterFilter, includeLocation, includeDetails bool) ([]*models.Theater, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTheaters", filter, includeLocation)
+	ret := m.ctrl.Call(m, "GetTheaters", filter, includeLocation, includeDetails)
 	ret0, _ := ret[0].([]*models.Theater)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTheaters indicates an expected call of GetTheaters.
-func (mr *MockTheaterRepositoryMockRecorder) GetTheaters(filter, includeLocation any) *gomock.Call {
+func (mr *MockTheaterRepositoryMockRecorder) GetTheaters(filter, includeLocation, includeDetails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheaters", reflect.TypeOf((*MockTheaterRepository)(nil).GetTheaters), filter, includeLocation, includeDetails)
+}
+
+// GetTimeZone mocks base method.
+func (m *MockTheaterRepository) GetTimeZone(theaterID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeZone", theaterID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeZone indicates an expected call of GetTimeZone.
+func (mr *MockTheaterRepositoryMockRecorder) GetTimeZone(theaterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeZone", reflect.TypeOf((*MockTheaterRepository)(nil).GetTimeZone), theaterID)
+}
+
+// LockTheater mocks base method.
+func (m *MockTheaterRepository) LockTheater(tx *gorm.DB, id uuid.UUID) (*models.Theater, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTheater", tx, id)
+	ret0, _ := ret[0].(*models.Theater)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTheater indicates an expected call of LockTheater.
+func (mr *MockTheaterRepositoryMockRecorder) LockTheater(tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTheater", reflect.TypeOf((*MockTheaterRepository)(nil).LockTheater), tx, id)
+}
+
+// UpdateTheater mocks base method.
+func (m *MockTheaterRepository) UpdateTheater(tx *gorm.DB, theater *models.Theater) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTheater", tx, theater)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTheater indicates an expected call of UpdateTheater.
+func (mr *MockTheaterRepositoryMockRecorder) UpdateTheater(tx, theater any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheaters", reflect.TypeOf((*MockTheaterRepository)(nil).GetTheaters), filter, includeLocation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTheater", reflect.TypeOf((*MockTheaterRepository)(nil).UpdateTheater), tx, theater)
 }
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/user_identity_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/user_identity_repository.go -destination=app/mocks/mock_repositories/user_identity_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockUserIdentityRepository is a mock of UserIdentityRepository interface.
+type MockUserIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityRepositoryMockRecorder
+}
+
+// MockUserIdentityRepositoryMockRecorder is the mock recorder for MockUserIdentityRepository.
+type MockUserIdentityRepositoryMockRecorder struct {
+	mock *MockUserIdentityRepository
+}
+
+// NewMockUserIdentityRepository creates a new mock instance.
+func NewMockUserIdentityRepository(ctrl *gomock.Controller) *MockUserIdentityRepository {
+	mock := &MockUserIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentityRepository) EXPECT() *MockUserIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIdentity mocks base method.
+func (m *MockUserIdentityRepository) CreateIdentity(tx *gorm.DB, identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", tx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockUserIdentityRepositoryMockRecorder) CreateIdentity(tx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockUserIdentityRepository)(nil).CreateIdentity), tx, identity)
+}
+
+// GetIdentity mocks base method.
+func (m *MockUserIdentityRepository) GetIdentity(filter filters.UserIdentityFilter) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", filter)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockUserIdentityRepositoryMockRecorder) GetIdentity(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockUserIdentityRepository)(nil).GetIdentity), filter)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/user_location_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/user_location_repository.go -destination=app/mocks/mock_repositories/user_location_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserLocationRepository is a mock of UserLocationRepository interface.
+type MockUserLocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserLocationRepositoryMockRecorder
+}
+
+// MockUserLocationRepositoryMockRecorder is the mock recorder for MockUserLocationRepository.
+type MockUserLocationRepositoryMockRecorder struct {
+	mock *MockUserLocationRepository
+}
+
+// NewMockUserLocationRepository creates a new mock instance.
+func NewMockUserLocationRepository(ctrl *gomock.Controller) *MockUserLocationRepository {
+	mock := &MockUserLocationRepository{ctrl: ctrl}
+	mock.recorder = &MockUserLocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserLocationRepository) EXPECT() *MockUserLocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUserLocation mocks base method.
+func (m *MockUserLocationRepository) CreateUserLocation(ip string, expiration time.Duration, location *models.UserLocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserLocation", ip, expiration, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserLocation indicates an expected call of CreateUserLocation.
+func (mr *MockUserLocationRepositoryMockRecorder) CreateUserLocation(ip, expiration, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserLocation", reflect.TypeOf((*MockUserLocationRepository)(nil).CreateUserLocation), ip, expiration, location)
+}
+
+// GetUserLocation mocks base method.
+func (m *MockUserLocationRepository) GetUserLocation(ip string) (*models.UserLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLocation", ip)
+	ret0, _ := ret[0].(*models.UserLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLocation indicates an expected call of GetUserLocation.
+func (mr *MockUserLocationRepositoryMockRecorder) GetUserLocation(ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLocation", reflect.TypeOf((*MockUserLocationRepository)(nil).GetUserLocation), ip)
+}
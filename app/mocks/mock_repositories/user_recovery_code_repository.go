@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/user_recovery_code_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/user_recovery_code_repository.go -destination=app/mocks/mock_repositories/user_recovery_code_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockUserRecoveryCodeRepository is a mock of UserRecoveryCodeRepository interface.
+type MockUserRecoveryCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRecoveryCodeRepositoryMockRecorder
+}
+
+// MockUserRecoveryCodeRepositoryMockRecorder is the mock recorder for MockUserRecoveryCodeRepository.
+type MockUserRecoveryCodeRepositoryMockRecorder struct {
+	mock *MockUserRecoveryCodeRepository
+}
+
+// NewMockUserRecoveryCodeRepository creates a new mock instance.
+func NewMockUserRecoveryCodeRepository(ctrl *gomock.Controller) *MockUserRecoveryCodeRepository {
+	mock := &MockUserRecoveryCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockUserRecoveryCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRecoveryCodeRepository) EXPECT() *MockUserRecoveryCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCodes mocks base method.
+func (m *MockUserRecoveryCodeRepository) CreateCodes(tx *gorm.DB, codes []*models.UserRecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCodes", tx, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCodes indicates an expected call of CreateCodes.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) CreateCodes(tx, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCodes", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).CreateCodes), tx, codes)
+}
+
+// DeleteUserCodes mocks base method.
+func (m *MockUserRecoveryCodeRepository) DeleteUserCodes(tx *gorm.DB, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserCodes", tx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserCodes indicates an expected call of DeleteUserCodes.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) DeleteUserCodes(tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserCodes", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).DeleteUserCodes), tx, userID)
+}
+
+// GetCode mocks base method.
+func (m *MockUserRecoveryCodeRepository) GetCode(filter filters.UserRecoveryCodeFilter) (*models.UserRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCode", filter)
+	ret0, _ := ret[0].(*models.UserRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCode indicates an expected call of GetCode.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) GetCode(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCode", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).GetCode), filter)
+}
+
+// UseCode mocks base method.
+func (m *MockUserRecoveryCodeRepository) UseCode(tx *gorm.DB, code *models.UserRecoveryCode) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCode", tx, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseCode indicates an expected call of UseCode.
+func (mr *MockUserRecoveryCodeRepositoryMockRecorder) UseCode(tx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCode", reflect.TypeOf((*MockUserRecoveryCodeRepository)(nil).UseCode), tx, code)
+}
@@ -70,6 +70,35 @@ func (mr *MockUserRegistrationTokenRepositoryMockRecorder) GetToken(filter any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockUserRegistrationTokenRepository)(nil).GetToken), filter)
 }
 
+// GetTokens mocks base method.
+func (m *MockUserRegistrationTokenRepository) GetTokens(filter filters.UserRegistrationTokenFilter) ([]*models.UserRegistrationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokens", filter)
+	ret0, _ := ret[0].([]*models.UserRegistrationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokens indicates an expected call of GetTokens.
+func (mr *MockUserRegistrationTokenRepositoryMockRecorder) GetTokens(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokens", reflect.TypeOf((*MockUserRegistrationTokenRepository)(nil).GetTokens), filter)
+}
+
+// RevokeTokens mocks base method.
+func (m *MockUserRegistrationTokenRepository) RevokeTokens(tx *gorm.DB, tokens []*models.UserRegistrationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokens", tx, tokens)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokens indicates an expected call of RevokeTokens.
+func (mr *MockUserRegistrationTokenRepositoryMockRecorder) RevokeTokens(tx, tokens any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokens", reflect.TypeOf((*MockUserRegistrationTokenRepository)(nil).RevokeTokens), tx, tokens)
+}
+
 // UseToken mocks base method.
 func (m *MockUserRegistrationTokenRepository) UseToken(tx *gorm.DB, token *models.UserRegistrationToken) error {
 	m.ctrl.T.Helper()
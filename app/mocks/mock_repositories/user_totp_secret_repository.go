@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/repositories/user_totp_secret_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/repositories/user_totp_secret_repository.go -destination=app/mocks/mock_repositories/user_totp_secret_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	filters "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockUserTotpSecretRepository is a mock of UserTotpSecretRepository interface.
+type MockUserTotpSecretRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTotpSecretRepositoryMockRecorder
+}
+
+// MockUserTotpSecretRepositoryMockRecorder is the mock recorder for MockUserTotpSecretRepository.
+type MockUserTotpSecretRepositoryMockRecorder struct {
+	mock *MockUserTotpSecretRepository
+}
+
+// NewMockUserTotpSecretRepository creates a new mock instance.
+func NewMockUserTotpSecretRepository(ctrl *gomock.Controller) *MockUserTotpSecretRepository {
+	mock := &MockUserTotpSecretRepository{ctrl: ctrl}
+	mock.recorder = &MockUserTotpSecretRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserTotpSecretRepository) EXPECT() *MockUserTotpSecretRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSecret mocks base method.
+func (m *MockUserTotpSecretRepository) CreateSecret(tx *gorm.DB, secret *models.UserTotpSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecret", tx, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecret indicates an expected call of CreateSecret.
+func (mr *MockUserTotpSecretRepositoryMockRecorder) CreateSecret(tx, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockUserTotpSecretRepository)(nil).CreateSecret), tx, secret)
+}
+
+// DeleteUserSecret mocks base method.
+func (m *MockUserTotpSecretRepository) DeleteUserSecret(tx *gorm.DB, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSecret", tx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSecret indicates an expected call of DeleteUserSecret.
+func (mr *MockUserTotpSecretRepositoryMockRecorder) DeleteUserSecret(tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSecret", reflect.TypeOf((*MockUserTotpSecretRepository)(nil).DeleteUserSecret), tx, userID)
+}
+
+// EnableSecret mocks base method.
+func (m *MockUserTotpSecretRepository) EnableSecret(tx *gorm.DB, secret *models.UserTotpSecret, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableSecret", tx, secret, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableSecret indicates an expected call of EnableSecret.
+func (mr *MockUserTotpSecretRepositoryMockRecorder) EnableSecret(tx, secret, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableSecret", reflect.TypeOf((*MockUserTotpSecretRepository)(nil).EnableSecret), tx, secret, step)
+}
+
+// GetSecret mocks base method.
+func (m *MockUserTotpSecretRepository) GetSecret(filter filters.UserTotpSecretFilter) (*models.UserTotpSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", filter)
+	ret0, _ := ret[0].(*models.UserTotpSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockUserTotpSecretRepositoryMockRecorder) GetSecret(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockUserTotpSecretRepository)(nil).GetSecret), filter)
+}
+
+// UpdateLastUsedStep mocks base method.
+func (m *MockUserTotpSecretRepository) UpdateLastUsedStep(tx *gorm.DB, secret *models.UserTotpSecret, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedStep", tx, secret, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockUserTotpSecretRepositoryMockRecorder) UpdateLastUsedStep(tx, secret, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockUserTotpSecretRepository)(nil).UpdateLastUsedStep), tx, secret, step)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/age_gate_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/age_gate_service.go -destination=app/mocks/mock_services/age_gate_service.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	errors "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAgeGateService is a mock of AgeGateService interface.
+type MockAgeGateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgeGateServiceMockRecorder
+}
+
+// MockAgeGateServiceMockRecorder is the mock recorder for MockAgeGateService.
+type MockAgeGateServiceMockRecorder struct {
+	mock *MockAgeGateService
+}
+
+// NewMockAgeGateService creates a new mock instance.
+func NewMockAgeGateService(ctrl *gomock.Controller) *MockAgeGateService {
+	mock := &MockAgeGateService{ctrl: ctrl}
+	mock.recorder = &MockAgeGateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgeGateService) EXPECT() *MockAgeGateServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckShowAccess mocks base method.
+func (m *MockAgeGateService) CheckShowAccess(showID, userID uuid.UUID, userEmail string) (*payloads.AgeGateResult, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckShowAccess", showID, userID, userEmail)
+	ret0, _ := ret[0].(*payloads.AgeGateResult)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// CheckShowAccess indicates an expected call of CheckShowAccess.
+func (mr *MockAgeGateServiceMockRecorder) CheckShowAccess(showID, userID, userEmail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckShowAccess", reflect.TypeOf((*MockAgeGateService)(nil).CheckShowAccess), showID, userID, userEmail)
+}
@@ -43,32 +43,32 @@ func (m *MockGenreService) EXPECT() *MockGenreServiceMockRecorder {
 }
 
 // CreateGenre mocks base method.
-func (m *MockGenreService) CreateGenre(req payloads.CreateGenreRequest) (*models.Genre, *errors.ApiError) {
+func (m *MockGenreService) CreateGenre(req payloads.CreateGenreRequest, requestID uuid.UUID) (*models.Genre, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGenre", req)
+	ret := m.ctrl.Call(m, "CreateGenre", req, requestID)
 	ret0, _ := ret[0].(*models.Genre)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // CreateGenre indicates an expected call of CreateGenre.
-func (mr *MockGenreServiceMockRecorder) CreateGenre(req any) *gomock.Call {
+func (mr *MockGenreServiceMockRecorder) CreateGenre(req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenreService)(nil).CreateGenre), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenreService)(nil).CreateGenre), req, requestID)
 }
 
 // DeleteGenre mocks base method.
-func (m *MockGenreService) DeleteGenre(id uuid.UUID) *errors.ApiError {
+func (m *MockGenreService) DeleteGenre(id, requestID uuid.UUID) *errors.ApiError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGenre", id)
+	ret := m.ctrl.Call(m, "DeleteGenre", id, requestID)
 	ret0, _ := ret[0].(*errors.ApiError)
 	return ret0
 }
 
 // DeleteGenre indicates an expected call of DeleteGenre.
-func (mr *MockGenreServiceMockRecorder) DeleteGenre(id any) *gomock.Call {
+func (mr *MockGenreServiceMockRecorder) DeleteGenre(id, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreService)(nil).DeleteGenre), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreService)(nil).DeleteGenre), id, requestID)
 }
 
 // GetGenre mocks base method.
@@ -102,16 +102,16 @@ func (mr *MockGenreServiceMockRecorder) GetGenres() *gomock.Call {
 }
 
 // UpdateGenre mocks base method.
-func (m *MockGenreService) UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest) (*models.Genre, *errors.ApiError) {
+func (m *MockGenreService) UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest, requestID uuid.UUID) (*models.Genre, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGenre", id, req)
+	ret := m.ctrl.Call(m, "UpdateGenre", id, req, requestID)
 	ret0, _ := ret[0].(*models.Genre)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // UpdateGenre indicates an expected call of UpdateGenre.
-func (mr *MockGenreServiceMockRecorder) UpdateGenre(id, req any) *gomock.Call {
+func (mr *MockGenreServiceMockRecorder) UpdateGenre(id, req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreService)(nil).UpdateGenre), id, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreService)(nil).UpdateGenre), id, req, requestID)
 }
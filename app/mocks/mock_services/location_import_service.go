@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/location_import_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/location_import_service.go -destination=app/mocks/mock_services/location_import_service.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	reflect "reflect"
+
+	errors "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLocationImportService is a mock of LocationImportService interface.
+type MockLocationImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationImportServiceMockRecorder
+}
+
+// MockLocationImportServiceMockRecorder is the mock recorder for MockLocationImportService.
+type MockLocationImportServiceMockRecorder struct {
+	mock *MockLocationImportService
+}
+
+// NewMockLocationImportService creates a new mock instance.
+func NewMockLocationImportService(ctrl *gomock.Controller) *MockLocationImportService {
+	mock := &MockLocationImportService{ctrl: ctrl}
+	mock.recorder = &MockLocationImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationImportService) EXPECT() *MockLocationImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportLocations mocks base method.
+func (m *MockLocationImportService) ImportLocations(source payloads.LocationImportSource, onProgress func(*payloads.LocationImportReport)) (*payloads.LocationImportReport, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLocations", source, onProgress)
+	ret0, _ := ret[0].(*payloads.LocationImportReport)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// ImportLocations indicates an expected call of ImportLocations.
+func (mr *MockLocationImportServiceMockRecorder) ImportLocations(source, onProgress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLocations", reflect.TypeOf((*MockLocationImportService)(nil).ImportLocations), source, onProgress)
+}
@@ -87,47 +87,197 @@ func (mr *MockLocationServiceMockRecorder) CreateState(countryID, req any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateState", reflect.TypeOf((*MockLocationService)(nil).CreateState), countryID, req)
 }
 
+// DeleteCity mocks base method.
+func (m *MockLocationService) DeleteCity(countryID, stateID, cityID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCity", countryID, stateID, cityID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteCity indicates an expected call of DeleteCity.
+func (mr *MockLocationServiceMockRecorder) DeleteCity(countryID, stateID, cityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCity", reflect.TypeOf((*MockLocationService)(nil).DeleteCity), countryID, stateID, cityID)
+}
+
+// DeleteCountry mocks base method.
+func (m *MockLocationService) DeleteCountry(countryID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCountry", countryID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteCountry indicates an expected call of DeleteCountry.
+func (mr *MockLocationServiceMockRecorder) DeleteCountry(countryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCountry", reflect.TypeOf((*MockLocationService)(nil).DeleteCountry), countryID)
+}
+
+// DeleteState mocks base method.
+func (m *MockLocationService) DeleteState(countryID, stateID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteState", countryID, stateID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteState indicates an expected call of DeleteState.
+func (mr *MockLocationServiceMockRecorder) DeleteState(countryID, stateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteState", reflect.TypeOf((*MockLocationService)(nil).DeleteState), countryID, stateID)
+}
+
 // GetCitiesByState mocks base method.
-func (m *MockLocationService) GetCitiesByState(countryID, stateID uuid.UUID) ([]*models.City, *errors.ApiError) {
+func (m *MockLocationService) GetCitiesByState(countryID, stateID uuid.UUID, limit, offset int) ([]*models.City, *models.ResponseMeta, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCitiesByState", countryID, stateID)
+	ret := m.ctrl.Call(m, "GetCitiesByState", countryID, stateID, limit, offset)
 	ret0, _ := ret[0].([]*models.City)
+	ret1, _ := ret[1].(*models.ResponseMeta)
+	ret2, _ := ret[2].(*errors.ApiError)
+	return ret0, ret1, ret2
+}
+
+// GetCitiesByState indicates an expected call of GetCitiesByState.
+func (mr *MockLocationServiceMockRecorder) GetCitiesByState(countryID, stateID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCitiesByState", reflect.TypeOf((*MockLocationService)(nil).GetCitiesByState), countryID, stateID, limit, offset)
+}
+
+// GetCity mocks base method.
+func (m *MockLocationService) GetCity(countryID, stateID, cityID uuid.UUID) (*models.City, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCity", countryID, stateID, cityID)
+	ret0, _ := ret[0].(*models.City)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
-// GetCitiesByState indicates an expected call of GetCitiesByState.
-func (mr *MockLocationServiceMockRecorder) GetCitiesByState(countryID, stateID any) *gomock.Call {
+// GetCity indicates an expected call of GetCity.
+func (mr *MockLocationServiceMockRecorder) GetCity(countryID, stateID, cityID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCitiesByState", reflect.TypeOf((*MockLocationService)(nil).GetCitiesByState), countryID, stateID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCity", reflect.TypeOf((*MockLocationService)(nil).GetCity), countryID, stateID, cityID)
 }
 
 // GetCountries mocks base method.
-func (m *MockLocationService) GetCountries() ([]*models.Country, *errors.ApiError) {
+func (m *MockLocationService) GetCountries(limit, offset int) ([]*models.Country, *models.ResponseMeta, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountries")
+	ret := m.ctrl.Call(m, "GetCountries", limit, offset)
 	ret0, _ := ret[0].([]*models.Country)
+	ret1, _ := ret[1].(*models.ResponseMeta)
+	ret2, _ := ret[2].(*errors.ApiError)
+	return ret0, ret1, ret2
+}
+
+// GetCountries indicates an expected call of GetCountries.
+func (mr *MockLocationServiceMockRecorder) GetCountries(limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountries", reflect.TypeOf((*MockLocationService)(nil).GetCountries), limit, offset)
+}
+
+// GetCountry mocks base method.
+func (m *MockLocationService) GetCountry(countryID uuid.UUID) (*models.Country, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountry", countryID)
+	ret0, _ := ret[0].(*models.Country)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
-// GetCountries indicates an expected call of GetCountries.
-func (mr *MockLocationServiceMockRecorder) GetCountries() *gomock.Call {
+// GetCountry indicates an expected call of GetCountry.
+func (mr *MockLocationServiceMockRecorder) GetCountry(countryID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountries", reflect.TypeOf((*MockLocationService)(nil).GetCountries))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountry", reflect.TypeOf((*MockLocationService)(nil).GetCountry), countryID)
+}
+
+// GetState mocks base method.
+func (m *MockLocationService) GetState(countryID, stateID uuid.UUID) (*models.State, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", countryID, stateID)
+	ret0, _ := ret[0].(*models.State)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockLocationServiceMockRecorder) GetState(countryID, stateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockLocationService)(nil).GetState), countryID, stateID)
 }
 
 // GetStatesByCountry mocks base method.
-func (m *MockLocationService) GetStatesByCountry(countryID uuid.UUID) ([]*models.State, *errors.ApiError) {
+func (m *MockLocationService) GetStatesByCountry(countryID uuid.UUID, limit, offset int) ([]*models.State, *models.ResponseMeta, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStatesByCountry", countryID)
+	ret := m.ctrl.Call(m, "GetStatesByCountry", countryID, limit, offset)
 	ret0, _ := ret[0].([]*models.State)
+	ret1, _ := ret[1].(*models.ResponseMeta)
+	ret2, _ := ret[2].(*errors.ApiError)
+	return ret0, ret1, ret2
+}
+
+// GetStatesByCountry indicates an expected call of GetStatesByCountry.
+func (mr *MockLocationServiceMockRecorder) GetStatesByCountry(countryID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatesByCountry", reflect.TypeOf((*MockLocationService)(nil).GetStatesByCountry), countryID, limit, offset)
+}
+
+// SearchCities mocks base method.
+func (m *MockLocationService) SearchCities(keyword string, limit, offset int) ([]*models.City, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCities", keyword, limit, offset)
+	ret0, _ := ret[0].([]*models.City)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
-// GetStatesByCountry indicates an expected call of GetStatesByCountry.
-func (mr *MockLocationServiceMockRecorder) GetStatesByCountry(countryID any) *gomock.Call {
+// SearchCities indicates an expected call of SearchCities.
+func (mr *MockLocationServiceMockRecorder) SearchCities(keyword, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCities", reflect.TypeOf((*MockLocationService)(nil).SearchCities), keyword, limit, offset)
+}
+
+// UpdateCity mocks base method.
+func (m *MockLocationService) UpdateCity(countryID, stateID, cityID uuid.UUID, req payloads.UpdateCityRequest) (*models.City, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCity", countryID, stateID, cityID, req)
+	ret0, _ := ret[0].(*models.City)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateCity indicates an expected call of UpdateCity.
+func (mr *MockLocationServiceMockRecorder) UpdateCity(countryID, stateID, cityID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCity", reflect.TypeOf((*MockLocationService)(nil).UpdateCity), countryID, stateID, cityID, req)
+}
+
+// UpdateCountry mocks base method.
+func (m *MockLocationService) UpdateCountry(countryID uuid.UUID, req payloads.UpdateCountryRequest) (*models.Country, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCountry", countryID, req)
+	ret0, _ := ret[0].(*models.Country)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateCountry indicates an expected call of UpdateCountry.
+func (mr *MockLocationServiceMockRecorder) UpdateCountry(countryID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCountry", reflect.TypeOf((*MockLocationService)(nil).UpdateCountry), countryID, req)
+}
+
+// UpdateState mocks base method.
+func (m *MockLocationService) UpdateState(countryID, stateID uuid.UUID, req payloads.UpdateStateRequest) (*models.State, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", countryID, stateID, req)
+	ret0, _ := ret[0].(*models.State)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockLocationServiceMockRecorder) UpdateState(countryID, stateID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatesByCountry", reflect.TypeOf((*MockLocationService)(nil).GetStatesByCountry), countryID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockLocationService)(nil).UpdateState), countryID, stateID, req)
 }
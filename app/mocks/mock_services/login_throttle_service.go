@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/login_throttle_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/login_throttle_service.go -destination=app/mocks/mock_services/login_throttle_service.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLoginThrottleService is a mock of LoginThrottleService interface.
+type MockLoginThrottleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleServiceMockRecorder
+}
+
+// MockLoginThrottleServiceMockRecorder is the mock recorder for MockLoginThrottleService.
+type MockLoginThrottleServiceMockRecorder struct {
+	mock *MockLoginThrottleService
+}
+
+// NewMockLoginThrottleService creates a new mock instance.
+func NewMockLoginThrottleService(ctrl *gomock.Controller) *MockLoginThrottleService {
+	mock := &MockLoginThrottleService{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleService) EXPECT() *MockLoginThrottleServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLockout mocks base method.
+func (m *MockLoginThrottleService) GetLockout(email, clientIp string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLockout", email, clientIp)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLockout indicates an expected call of GetLockout.
+func (mr *MockLoginThrottleServiceMockRecorder) GetLockout(email, clientIp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockout", reflect.TypeOf((*MockLoginThrottleService)(nil).GetLockout), email, clientIp)
+}
+
+// RegisterFailedAttempt mocks base method.
+func (m *MockLoginThrottleService) RegisterFailedAttempt(email, clientIp string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailedAttempt", email, clientIp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailedAttempt indicates an expected call of RegisterFailedAttempt.
+func (mr *MockLoginThrottleServiceMockRecorder) RegisterFailedAttempt(email, clientIp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailedAttempt", reflect.TypeOf((*MockLoginThrottleService)(nil).RegisterFailedAttempt), email, clientIp)
+}
+
+// ResetFailedAttempts mocks base method.
+func (m *MockLoginThrottleService) ResetFailedAttempts(email, clientIp string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedAttempts", email, clientIp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedAttempts indicates an expected call of ResetFailedAttempts.
+func (mr *MockLoginThrottleServiceMockRecorder) ResetFailedAttempts(email, clientIp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedAttempts", reflect.TypeOf((*MockLoginThrottleService)(nil).ResetFailedAttempts), email, clientIp)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockLoginThrottleService) UnlockAccount(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockLoginThrottleServiceMockRecorder) UnlockAccount(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockLoginThrottleService)(nil).UnlockAccount), email)
+}
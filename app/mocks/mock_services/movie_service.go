@@ -43,67 +43,67 @@ func (m *MockMovieService) EXPECT() *MockMovieServiceMockRecorder {
 }
 
 // AssignGenres mocks base method.
-func (m *MockMovieService) AssignGenres(id uuid.UUID, genreIDs []uuid.UUID) *errors.ApiError {
+func (m *MockMovieService) AssignGenres(id uuid.UUID, genreIDs []uuid.UUID, requestID uuid.UUID) *errors.ApiError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignGenres", id, genreIDs)
+	ret := m.ctrl.Call(m, "AssignGenres", id, genreIDs, requestID)
 	ret0, _ := ret[0].(*errors.ApiError)
 	return ret0
 }
 
 // AssignGenres indicates an expected call of AssignGenres.
-func (mr *MockMovieServiceMockRecorder) AssignGenres(id, genreIDs any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) AssignGenres(id, genreIDs, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignGenres", reflect.TypeOf((*MockMovieService)(nil).AssignGenres), id, genreIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignGenres", reflect.TypeOf((*MockMovieService)(nil).AssignGenres), id, genreIDs, requestID)
 }
 
 // CreateMovie mocks base method.
-func (m *MockMovieService) CreateMovie(req payloads.CreateMovieRequest, createdBy uuid.UUID) (*models.Movie, *errors.ApiError) {
+func (m *MockMovieService) CreateMovie(req payloads.CreateMovieRequest, createdBy, requestID uuid.UUID) (*models.Movie, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMovie", req, createdBy)
+	ret := m.ctrl.Call(m, "CreateMovie", req, createdBy, requestID)
 	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // CreateMovie indicates an expected call of CreateMovie.
-func (mr *MockMovieServiceMockRecorder) CreateMovie(req, createdBy any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) CreateMovie(req, createdBy, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockMovieService)(nil).CreateMovie), req, createdBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovie", reflect.TypeOf((*MockMovieService)(nil).CreateMovie), req, createdBy, requestID)
 }
 
 // DeleteMovie mocks base method.
-func (m *MockMovieService) DeleteMovie(id, deletedBy uuid.UUID) *errors.ApiError {
+func (m *MockMovieService) DeleteMovie(id, deletedBy, requestID uuid.UUID) *errors.ApiError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMovie", id, deletedBy)
+	ret := m.ctrl.Call(m, "DeleteMovie", id, deletedBy, requestID)
 	ret0, _ := ret[0].(*errors.ApiError)
 	return ret0
 }
 
 // DeleteMovie indicates an expected call of DeleteMovie.
-func (mr *MockMovieServiceMockRecorder) DeleteMovie(id, deletedBy any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) DeleteMovie(id, deletedBy, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieService)(nil).DeleteMovie), id, deletedBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieService)(nil).DeleteMovie), id, deletedBy, requestID)
 }
 
 // GetMovie mocks base method.
-func (m *MockMovieService) GetMovie(id uuid.UUID, userEmail *string, includeGenres bool) (*models.Movie, *errors.ApiError) {
+func (m *MockMovieService) GetMovie(id uuid.UUID, userEmail *string, includeGenres, includeDetails bool) (*models.Movie, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovie", id, userEmail, includeGenres)
+	ret := m.ctrl.Call(m, "GetMovie", id, userEmail, includeGenres, includeDetails)
 	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // GetMovie indicates an expected call of GetMovie.
-func (mr *MockMovieServiceMockRecorder) GetMovie(id, userEmail, includeGenres any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) GetMovie(id, userEmail, includeGenres, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockMovieService)(nil).GetMovie), id, userEmail, includeGenres)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovie", reflect.TypeOf((*MockMovieService)(nil).GetMovie), id, userEmail, includeGenres, includeDetails)
 }
 
 // GetMovies mocks base method.
-func (m *MockMovieService) GetMovies(limit, offset int, userEmail *string, includeGenres bool) ([]*models.Movie, *models.ResponseMeta, *errors.ApiError) {
+func (m *MockMovieService) GetMovies(search payloads.MovieSearchFilter, limit, offset int, userEmail *string, includeGenres, includeDetails bool) ([]*models.Movie, *models.ResponseMeta, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMovies", limit, offset, userEmail, includeGenres)
+	ret := m.ctrl.Call(m, "GetMovies", search, limit, offset, userEmail, includeGenres, includeDetails)
 	ret0, _ := ret[0].([]*models.Movie)
 	ret1, _ := ret[1].(*models.ResponseMeta)
 	ret2, _ := ret[2].(*errors.ApiError)
@@ -111,22 +111,82 @@ func (m *MockMovieService) GetMovies(limit, offset int, userEmail *string, inclu
 }
 
 // GetMovies indicates an expected call of GetMovies.
-func (mr *MockMovieServiceMockRecorder) GetMovies(limit, offset, userEmail, includeGenres any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) GetMovies(search, limit, offset, userEmail, includeGenres, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockMovieService)(nil).GetMovies), limit, offset, userEmail, includeGenres)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovies", reflect.TypeOf((*MockMovieService)(nil).GetMovies), search, limit, offset, userEmail, includeGenres, includeDetails)
 }
 
 // UpdateMovie mocks base method.
-func (m *MockMovieService) UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateMovieRequest) (*models.Movie, *errors.ApiError) {
+func (m *MockMovieService) UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateMovieRequest, requestID uuid.UUID) (*models.Movie, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMovie", id, updatedBy, req)
+	ret := m.ctrl.Call(m, "UpdateMovie", id, updatedBy, req, requestID)
 	ret0, _ := ret[0].(*models.Movie)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // UpdateMovie indicates an expected call of UpdateMovie.
-func (mr *MockMovieServiceMockRecorder) UpdateMovie(id, updatedBy, req any) *gomock.Call {
+func (mr *MockMovieServiceMockRecorder) UpdateMovie(id, updatedBy, req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieService)(nil).UpdateMovie), id, updatedBy, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovie", reflect.TypeOf((*MockMovieService)(nil).UpdateMovie), id, updatedBy, req, requestID)
+}
+
+// UpdateMovieCertifications mocks base method.
+func (m *MockMovieService) UpdateMovieCertifications(id uuid.UUID, req payloads.UpdateMovieCertificationsRequest, requestID uuid.UUID) ([]*models.MovieCertification, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieCertifications", id, req, requestID)
+	ret0, _ := ret[0].([]*models.MovieCertification)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateMovieCertifications indicates an expected call of UpdateMovieCertifications.
+func (mr *MockMovieServiceMockRecorder) UpdateMovieCertifications(id, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieCertifications", reflect.TypeOf((*MockMovieService)(nil).UpdateMovieCertifications), id, req, requestID)
+}
+
+// UpdateMovieCredits mocks base method.
+func (m *MockMovieService) UpdateMovieCredits(id uuid.UUID, req payloads.UpdateMovieCreditsRequest, requestID uuid.UUID) ([]*models.MovieCredit, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieCredits", id, req, requestID)
+	ret0, _ := ret[0].([]*models.MovieCredit)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateMovieCredits indicates an expected call of UpdateMovieCredits.
+func (mr *MockMovieServiceMockRecorder) UpdateMovieCredits(id, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieCredits", reflect.TypeOf((*MockMovieService)(nil).UpdateMovieCredits), id, req, requestID)
+}
+
+// UpdateMovieExternalIDs mocks base method.
+func (m *MockMovieService) UpdateMovieExternalIDs(id uuid.UUID, req payloads.UpdateMovieExternalIDsRequest, requestID uuid.UUID) ([]*models.MovieExternalID, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieExternalIDs", id, req, requestID)
+	ret0, _ := ret[0].([]*models.MovieExternalID)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateMovieExternalIDs indicates an expected call of UpdateMovieExternalIDs.
+func (mr *MockMovieServiceMockRecorder) UpdateMovieExternalIDs(id, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieExternalIDs", reflect.TypeOf((*MockMovieService)(nil).UpdateMovieExternalIDs), id, req, requestID)
+}
+
+// UpdateMovieLanguages mocks base method.
+func (m *MockMovieService) UpdateMovieLanguages(id uuid.UUID, req payloads.UpdateMovieLanguagesRequest, requestID uuid.UUID) ([]*models.MovieLanguage, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovieLanguages", id, req, requestID)
+	ret0, _ := ret[0].([]*models.MovieLanguage)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateMovieLanguages indicates an expected call of UpdateMovieLanguages.
+func (mr *MockMovieServiceMockRecorder) UpdateMovieLanguages(id, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovieLanguages", reflect.TypeOf((*MockMovieService)(nil).UpdateMovieLanguages), id, req, requestID)
 }
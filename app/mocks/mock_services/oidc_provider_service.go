@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/oidc_provider_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/oidc_provider_service.go -destination=app/mocks/mock_services/oidc_provider_service.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	reflect "reflect"
+
+	errors "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOidcProviderService is a mock of OidcProviderService interface.
+type MockOidcProviderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcProviderServiceMockRecorder
+}
+
+// MockOidcProviderServiceMockRecorder is the mock recorder for MockOidcProviderService.
+type MockOidcProviderServiceMockRecorder struct {
+	mock *MockOidcProviderService
+}
+
+// NewMockOidcProviderService creates a new mock instance.
+func NewMockOidcProviderService(ctrl *gomock.Controller) *MockOidcProviderService {
+	mock := &MockOidcProviderService{ctrl: ctrl}
+	mock.recorder = &MockOidcProviderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidcProviderService) EXPECT() *MockOidcProviderServiceMockRecorder {
+	return m.recorder
+}
+
+// ExchangeCode mocks base method.
+func (m *MockOidcProviderService) ExchangeCode(code, codeVerifier string) (*models.OidcClaims, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeCode", code, codeVerifier)
+	ret0, _ := ret[0].(*models.OidcClaims)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// ExchangeCode indicates an expected call of ExchangeCode.
+func (mr *MockOidcProviderServiceMockRecorder) ExchangeCode(code, codeVerifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeCode", reflect.TypeOf((*MockOidcProviderService)(nil).ExchangeCode), code, codeVerifier)
+}
+
+// GetAuthorizationUrl mocks base method.
+func (m *MockOidcProviderService) GetAuthorizationUrl(state, nonce, codeChallenge string) (string, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationUrl", state, nonce, codeChallenge)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// GetAuthorizationUrl indicates an expected call of GetAuthorizationUrl.
+func (mr *MockOidcProviderServiceMockRecorder) GetAuthorizationUrl(state, nonce, codeChallenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationUrl", reflect.TypeOf((*MockOidcProviderService)(nil).GetAuthorizationUrl), state, nonce, codeChallenge)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/outbox_relay_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/outbox_relay_service.go -destination=app/mocks/mock_services/outbox_relay_service.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRelayService is a mock of OutboxRelayService interface.
+type MockOutboxRelayService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRelayServiceMockRecorder
+}
+
+// MockOutboxRelayServiceMockRecorder is the mock recorder for MockOutboxRelayService.
+type MockOutboxRelayServiceMockRecorder struct {
+	mock *MockOutboxRelayService
+}
+
+// NewMockOutboxRelayService creates a new mock instance.
+func NewMockOutboxRelayService(ctrl *gomock.Controller) *MockOutboxRelayService {
+	mock := &MockOutboxRelayService{ctrl: ctrl}
+	mock.recorder = &MockOutboxRelayServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRelayService) EXPECT() *MockOutboxRelayServiceMockRecorder {
+	return m.recorder
+}
+
+// PurgeProcessedMessages mocks base method.
+func (m *MockOutboxRelayService) PurgeProcessedMessages() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeProcessedMessages")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeProcessedMessages indicates an expected call of PurgeProcessedMessages.
+func (mr *MockOutboxRelayServiceMockRecorder) PurgeProcessedMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeProcessedMessages", reflect.TypeOf((*MockOutboxRelayService)(nil).PurgeProcessedMessages))
+}
+
+// RelayPendingMessages mocks base method.
+func (m *MockOutboxRelayService) RelayPendingMessages() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayPendingMessages")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RelayPendingMessages indicates an expected call of RelayPendingMessages.
+func (mr *MockOutboxRelayServiceMockRecorder) RelayPendingMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayPendingMessages", reflect.TypeOf((*MockOutboxRelayService)(nil).RelayPendingMessages))
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/person_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/person_service.go -destination=app/mocks/mock_services/person_service.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	errors "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	payloads "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPersonService is a mock of PersonService interface.
+type MockPersonService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonServiceMockRecorder
+}
+
+// MockPersonServiceMockRecorder is the mock recorder for MockPersonService.
+type MockPersonServiceMockRecorder struct {
+	mock *MockPersonService
+}
+
+// NewMockPersonService creates a new mock instance.
+func NewMockPersonService(ctrl *gomock.Controller) *MockPersonService {
+	mock := &MockPersonService{ctrl: ctrl}
+	mock.recorder = &MockPersonServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonService) EXPECT() *MockPersonServiceMockRecorder {
+	return m.recorder
+}
+
+// CreatePerson mocks base method.
+func (m *MockPersonService) CreatePerson(req payloads.CreatePersonRequest) (*models.Person, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePerson", req)
+	ret0, _ := ret[0].(*models.Person)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// CreatePerson indicates an expected call of CreatePerson.
+func (mr *MockPersonServiceMockRecorder) CreatePerson(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePerson", reflect.TypeOf((*MockPersonService)(nil).CreatePerson), req)
+}
+
+// DeletePerson mocks base method.
+func (m *MockPersonService) DeletePerson(id uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePerson", id)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeletePerson indicates an expected call of DeletePerson.
+func (mr *MockPersonServiceMockRecorder) DeletePerson(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePerson", reflect.TypeOf((*MockPersonService)(nil).DeletePerson), id)
+}
+
+// GetPerson mocks base method.
+func (m *MockPersonService) GetPerson(id uuid.UUID) (*models.Person, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerson", id)
+	ret0, _ := ret[0].(*models.Person)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// GetPerson indicates an expected call of GetPerson.
+func (mr *MockPersonServiceMockRecorder) GetPerson(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerson", reflect.TypeOf((*MockPersonService)(nil).GetPerson), id)
+}
+
+// SearchPeople mocks base method.
+func (m *MockPersonService) SearchPeople(keyword string, limit, offset int) ([]*models.Person, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPeople", keyword, limit, offset)
+	ret0, _ := ret[0].([]*models.Person)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// SearchPeople indicates an expected call of SearchPeople.
+func (mr *MockPersonServiceMockRecorder) SearchPeople(keyword, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPeople", reflect.TypeOf((*MockPersonService)(nil).SearchPeople), keyword, limit, offset)
+}
+
+// UpdatePerson mocks base method.
+func (m *MockPersonService) UpdatePerson(id uuid.UUID, req payloads.UpdatePersonRequest) (*models.Person, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePerson", id, req)
+	ret0, _ := ret[0].(*models.Person)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdatePerson indicates an expected call of UpdatePerson.
+func (mr *MockPersonServiceMockRecorder) UpdatePerson(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePerson", reflect.TypeOf((*MockPersonService)(nil).UpdatePerson), id, req)
+}
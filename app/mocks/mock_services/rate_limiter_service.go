@@ -40,16 +40,16 @@ func (m *MockRateLimiterService) EXPECT() *MockRateLimiterServiceMockRecorder {
 }
 
 // Allow mocks base method.
-func (m *MockRateLimiterService) Allow(clientIp string) (bool, time.Duration) {
+func (m *MockRateLimiterService) Allow(key string) (bool, time.Duration) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Allow", clientIp)
+	ret := m.ctrl.Call(m, "Allow", key)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(time.Duration)
 	return ret0, ret1
 }
 
 // Allow indicates an expected call of Allow.
-func (mr *MockRateLimiterServiceMockRecorder) Allow(clientIp any) *gomock.Call {
+func (mr *MockRateLimiterServiceMockRecorder) Allow(key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimiterService)(nil).Allow), clientIp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimiterService)(nil).Allow), key)
 }
@@ -43,19 +43,49 @@ func (m *MockShowService) EXPECT() *MockShowServiceMockRecorder {
 	return m.recorder
 }
 
+// BlockSeat mocks base method.
+func (m *MockShowService) BlockSeat(id, seatId, blockedBy uuid.UUID, req payloads.BlockSeatRequest) (*models.SeatBlock, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSeat", id, seatId, blockedBy, req)
+	ret0, _ := ret[0].(*models.SeatBlock)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// BlockSeat indicates an expected call of BlockSeat.
+func (mr *MockShowServiceMockRecorder) BlockSeat(id, seatId, blockedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSeat", reflect.TypeOf((*MockShowService)(nil).BlockSeat), id, seatId, blockedBy, req)
+}
+
 // CreateShow mocks base method.
-func (m *MockShowService) CreateShow(req payloads.CreateShowRequest) (*models.Show, *errors.ApiError) {
+func (m *MockShowService) CreateShow(req payloads.CreateShowRequest, requestID uuid.UUID) (*models.Show, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateShow", req)
+	ret := m.ctrl.Call(m, "CreateShow", req, requestID)
 	ret0, _ := ret[0].(*models.Show)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // CreateShow indicates an expected call of CreateShow.
-func (mr *MockShowServiceMockRecorder) CreateShow(req any) *gomock.Call {
+func (mr *MockShowServiceMockRecorder) CreateShow(req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShow", reflect.TypeOf((*MockShowService)(nil).CreateShow), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShow", reflect.TypeOf((*MockShowService)(nil).CreateShow), req, requestID)
+}
+
+// GetAvailableSeats mocks base method.
+func (m *MockShowService) GetAvailableSeats(id uuid.UUID, userEmail *string) ([]*models.Seat, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableSeats", id, userEmail)
+	ret0, _ := ret[0].([]*models.Seat)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// GetAvailableSeats indicates an expected call of GetAvailableSeats.
+func (mr *MockShowServiceMockRecorder) GetAvailableSeats(id, userEmail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableSeats", reflect.TypeOf((*MockShowService)(nil).GetAvailableSeats), id, userEmail)
 }
 
 // GetShow mocks base method.
@@ -74,18 +104,18 @@ func (mr *MockShowServiceMockRecorder) GetShow(id, userEmail any) *gomock.Call {
 }
 
 // GetShows mocks base method.
-func (m *MockShowService) GetShows(status constants.ShowStatus, limit, offset int) ([]*models.Show, *errors.ApiError) {
+func (m *MockShowService) GetShows(status constants.ShowStatus, search payloads.ShowSearchFilter, limit, offset int) ([]*models.Show, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetShows", status, limit, offset)
+	ret := m.ctrl.Call(m, "GetShows", status, search, limit, offset)
 	ret0, _ := ret[0].([]*models.Show)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // GetShows indicates an expected call of GetShows.
-func (mr *MockShowServiceMockRecorder) GetShows(status, limit, offset any) *gomock.Call {
+func (mr *MockShowServiceMockRecorder) GetShows(status, search, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShows", reflect.TypeOf((*MockShowService)(nil).GetShows), status, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShows", reflect.TypeOf((*MockShowService)(nil).GetShows), status, search, limit, offset)
 }
 
 // ScheduleUpdateShowStatus mocks base method.
@@ -101,3 +131,17 @@ func (mr *MockShowServiceMockRecorder) ScheduleUpdateShowStatus() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleUpdateShowStatus", reflect.TypeOf((*MockShowService)(nil).ScheduleUpdateShowStatus))
 }
+
+// UnblockSeat mocks base method.
+func (m *MockShowService) UnblockSeat(id, seatId uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockSeat", id, seatId)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// UnblockSeat indicates an expected call of UnblockSeat.
+func (mr *MockShowServiceMockRecorder) UnblockSeat(id, seatId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockSeat", reflect.TypeOf((*MockShowService)(nil).UnblockSeat), id, seatId)
+}
@@ -42,6 +42,21 @@ func (m *MockTheaterService) EXPECT() *MockTheaterServiceMockRecorder {
 	return m.recorder
 }
 
+// BlockSeat mocks base method.
+func (m *MockTheaterService) BlockSeat(theaterId, seatId, blockedBy uuid.UUID, req payloads.BlockSeatRequest) (*models.SeatBlock, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSeat", theaterId, seatId, blockedBy, req)
+	ret0, _ := ret[0].(*models.SeatBlock)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// BlockSeat indicates an expected call of BlockSeat.
+func (mr *MockTheaterServiceMockRecorder) BlockSeat(theaterId, seatId, blockedBy, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSeat", reflect.TypeOf((*MockTheaterService)(nil).BlockSeat), theaterId, seatId, blockedBy, req)
+}
+
 // CreateSeat mocks base method.
 func (m *MockTheaterService) CreateSeat(theaterId uuid.UUID, req payloads.CreateSeatPayload) (*models.Seat, *errors.ApiError) {
 	m.ctrl.T.Helper()
@@ -58,69 +73,155 @@ func (mr *MockTheaterServiceMockRecorder) CreateSeat(theaterId, req any) *gomock
 }
 
 // CreateTheater mocks base method.
-func (m *MockTheaterService) CreateTheater(req payloads.CreateTheaterRequest) (*models.Theater, *errors.ApiError) {
+func (m *MockTheaterService) CreateTheater(req payloads.CreateTheaterRequest, requestID uuid.UUID) (*models.Theater, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTheater", req)
+	ret := m.ctrl.Call(m, "CreateTheater", req, requestID)
 	ret0, _ := ret[0].(*models.Theater)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // CreateTheater indicates an expected call of CreateTheater.
-func (mr *MockTheaterServiceMockRecorder) CreateTheater(req any) *gomock.Call {
+func (mr *MockTheaterServiceMockRecorder) CreateTheater(req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheater", reflect.TypeOf((*MockTheaterService)(nil).CreateTheater), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheater", reflect.TypeOf((*MockTheaterService)(nil).CreateTheater), req, requestID)
 }
 
 // CreateTheaterLocation mocks base method.
-func (m *MockTheaterService) CreateTheaterLocation(theaterID uuid.UUID, req payloads.CreateTheaterLocationRequest) (*models.TheaterLocation, *errors.ApiError) {
+func (m *MockTheaterService) CreateTheaterLocation(theaterID uuid.UUID, req payloads.CreateTheaterLocationRequest, requestID uuid.UUID) (*models.TheaterLocation, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTheaterLocation", theaterID, req)
+	ret := m.ctrl.Call(m, "CreateTheaterLocation", theaterID, req, requestID)
 	ret0, _ := ret[0].(*models.TheaterLocation)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // CreateTheaterLocation indicates an expected call of CreateTheaterLocation.
-func (mr *MockTheaterServiceMockRecorder) CreateTheaterLocation(theaterID, req any) *gomock.Call {
+func (mr *MockTheaterServiceMockRecorder) CreateTheaterLocation(theaterID, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheaterLocation", reflect.TypeOf((*MockTheaterService)(nil).CreateTheaterLocation), theaterID, req, requestID)
+}
+
+// CreateTheaterPhoto mocks base method.
+func (m *MockTheaterService) CreateTheaterPhoto(theaterId uuid.UUID, req payloads.CreateTheaterPhotoRequest) (*models.TheaterPhoto, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTheaterPhoto", theaterId, req)
+	ret0, _ := ret[0].(*models.TheaterPhoto)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// CreateTheaterPhoto indicates an expected call of CreateTheaterPhoto.
+func (mr *MockTheaterServiceMockRecorder) CreateTheaterPhoto(theaterId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheaterPhoto", reflect.TypeOf((*MockTheaterService)(nil).CreateTheaterPhoto), theaterId, req)
+}
+
+// DeleteSeat mocks base method.
+func (m *MockTheaterService) DeleteSeat(theaterId, seatId uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeat", theaterId, seatId)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteSeat indicates an expected call of DeleteSeat.
+func (mr *MockTheaterServiceMockRecorder) DeleteSeat(theaterId, seatId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTheaterLocation", reflect.TypeOf((*MockTheaterService)(nil).CreateTheaterLocation), theaterID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeat", reflect.TypeOf((*MockTheaterService)(nil).DeleteSeat), theaterId, seatId)
+}
+
+// DeleteTheater mocks base method.
+func (m *MockTheaterService) DeleteTheater(id, requestID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTheater", id, requestID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteTheater indicates an expected call of DeleteTheater.
+func (mr *MockTheaterServiceMockRecorder) DeleteTheater(id, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTheater", reflect.TypeOf((*MockTheaterService)(nil).DeleteTheater), id, requestID)
+}
+
+// DeleteTheaterLocation mocks base method.
+func (m *MockTheaterService) DeleteTheaterLocation(theaterId, requestID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTheaterLocation", theaterId, requestID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteTheaterLocation indicates an expected call of DeleteTheaterLocation.
+func (mr *MockTheaterServiceMockRecorder) DeleteTheaterLocation(theaterId, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTheaterLocation", reflect.TypeOf((*MockTheaterService)(nil).DeleteTheaterLocation), theaterId, requestID)
+}
+
+// DeleteTheaterPhoto mocks base method.
+func (m *MockTheaterService) DeleteTheaterPhoto(theaterId, photoId uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTheaterPhoto", theaterId, photoId)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DeleteTheaterPhoto indicates an expected call of DeleteTheaterPhoto.
+func (mr *MockTheaterServiceMockRecorder) DeleteTheaterPhoto(theaterId, photoId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTheaterPhoto", reflect.TypeOf((*MockTheaterService)(nil).DeleteTheaterPhoto), theaterId, photoId)
 }
 
 // GetNearbyTheaters mocks base method.
-func (m *MockTheaterService) GetNearbyTheaters(distance float64) ([]*models.Theater, *errors.ApiError) {
+func (m *MockTheaterService) GetNearbyTheaters(origin payloads.NearbyTheatersOrigin, distance float64, limit, offset int) ([]*models.Theater, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNearbyTheaters", distance)
+	ret := m.ctrl.Call(m, "GetNearbyTheaters", origin, distance, limit, offset)
 	ret0, _ := ret[0].([]*models.Theater)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // GetNearbyTheaters indicates an expected call of GetNearbyTheaters.
-func (mr *MockTheaterServiceMockRecorder) GetNearbyTheaters(distance any) *gomock.Call {
+func (mr *MockTheaterServiceMockRecorder) GetNearbyTheaters(origin, distance, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyTheaters", reflect.TypeOf((*MockTheaterService)(nil).GetNearbyTheaters), origin, distance, limit, offset)
+}
+
+// GetSeats mocks base method.
+func (m *MockTheaterService) GetSeats(theaterId uuid.UUID) ([]*models.Seat, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeats", theaterId)
+	ret0, _ := ret[0].([]*models.Seat)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// GetSeats indicates an expected call of GetSeats.
+func (mr *MockTheaterServiceMockRecorder) GetSeats(theaterId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyTheaters", reflect.TypeOf((*MockTheaterService)(nil).GetNearbyTheaters), distance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeats", reflect.TypeOf((*MockTheaterService)(nil).GetSeats), theaterId)
 }
 
 // GetTheater mocks base method.
-func (m *MockTheaterService) GetTheater(id uuid.UUID, includeLocation bool) (*models.Theater, *errors.ApiError) {
+func (m *MockTheaterService) GetTheater(id uuid.UUID, includeLocation, includeDetails bool) (*models.Theater, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTheater", id, includeLocation)
+	ret := m.ctrl.Call(m, "GetTheater", id, includeLocation, includeDetails)
 	ret0, _ := ret[0].(*models.Theater)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // GetTheater indicates an expected call of GetTheater.
-func (mr *MockTheaterServiceMockRecorder) GetTheater(id, includeLocation any) *gomock.Call {
+func (mr *MockTheaterServiceMockRecorder) GetTheater(id, includeLocation, includeDetails any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheater", reflect.TypeOf((*MockTheaterService)(nil).GetTheater), id, includeLocation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheater", reflect.TypeOf((*MockTheaterService)(nil).GetTheater), id, includeLocation, includeDetails)
 }
 
 // GetTheaters mocks base method.
-func (m *MockTheaterService) GetTheaters(limit, offset int, includeLocation bool) ([]*models.Theater, *models.ResponseMeta, *errors.ApiError) {
+func (m *MockTheaterService) GetTheaters(search payloads.TheaterSearchFilter, limit, offset int, includeLocation, includeDetails bool) ([]*models.Theater, *models.ResponseMeta, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTheaters", limit, offset, includeLocation)
+	ret := m.ctrl.Call(m, "GetTheaters", search, limit, offset, includeLocation, includeDetails)
 	ret0, _ := ret[0].([]*models.Theater)
 	ret1, _ := ret[1].(*models.ResponseMeta)
 	ret2, _ := ret[2].(*errors.ApiError)
@@ -128,22 +229,96 @@ func (m *MockTheaterService) GetTheaters(limit, offset int, includeLocation bool
 }
 
 // GetTheaters indicates an expected call of GetTheaters.
-func (mr *MockTheaterServiceMockRecorder) GetTheaters(limit, offset, includeLocation any) *gomock.Call {
+func (mr *MockTheaterServiceMockRecorder) GetTheaters(search, limit, offset, includeLocation, includeDetails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheaters", reflect.TypeOf((*MockTheaterService)(nil).GetTheaters), search, limit, offset, includeLocation, includeDetails)
+}
+
+// UnblockSeat mocks base method.
+func (m *MockTheaterService) UnblockSeat(theaterId, seatId uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockSeat", theaterId, seatId)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// UnblockSeat indicates an expected call of UnblockSeat.
+func (mr *MockTheaterServiceMockRecorder) UnblockSeat(theaterId, seatId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockSeat", reflect.TypeOf((*MockTheaterService)(nil).UnblockSeat), theaterId, seatId)
+}
+
+// UpdateSeat mocks base method.
+func (m *MockTheaterService) UpdateSeat(theaterId, seatId uuid.UUID, req payloads.UpdateSeatRequest) (*models.Seat, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeat", theaterId, seatId, req)
+	ret0, _ := ret[0].(*models.Seat)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateSeat indicates an expected call of UpdateSeat.
+func (mr *MockTheaterServiceMockRecorder) UpdateSeat(theaterId, seatId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeat", reflect.TypeOf((*MockTheaterService)(nil).UpdateSeat), theaterId, seatId, req)
+}
+
+// UpdateTheater mocks base method.
+func (m *MockTheaterService) UpdateTheater(id uuid.UUID, req payloads.UpdateTheaterRequest, requestID uuid.UUID) (*models.Theater, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTheater", id, req, requestID)
+	ret0, _ := ret[0].(*models.Theater)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateTheater indicates an expected call of UpdateTheater.
+func (mr *MockTheaterServiceMockRecorder) UpdateTheater(id, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTheater", reflect.TypeOf((*MockTheaterService)(nil).UpdateTheater), id, req, requestID)
+}
+
+// UpdateTheaterAmenities mocks base method.
+func (m *MockTheaterService) UpdateTheaterAmenities(theaterId uuid.UUID, req payloads.UpdateTheaterAmenitiesRequest, requestID uuid.UUID) ([]*models.TheaterAmenity, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTheaterAmenities", theaterId, req, requestID)
+	ret0, _ := ret[0].([]*models.TheaterAmenity)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateTheaterAmenities indicates an expected call of UpdateTheaterAmenities.
+func (mr *MockTheaterServiceMockRecorder) UpdateTheaterAmenities(theaterId, req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTheaters", reflect.TypeOf((*MockTheaterService)(nil).GetTheaters), limit, offset, includeLocation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTheaterAmenities", reflect.TypeOf((*MockTheaterService)(nil).UpdateTheaterAmenities), theaterId, req, requestID)
 }
 
 // UpdateTheaterLocation mocks base method.
-func (m *MockTheaterService) UpdateTheaterLocation(theaterId uuid.UUID, req payloads.UpdateTheaterLocationRequest) (*models.TheaterLocation, *errors.ApiError) {
+func (m *MockTheaterService) UpdateTheaterLocation(theaterId uuid.UUID, req payloads.UpdateTheaterLocationRequest, requestID uuid.UUID) (*models.TheaterLocation, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTheaterLocation", theaterId, req)
+	ret := m.ctrl.Call(m, "UpdateTheaterLocation", theaterId, req, requestID)
 	ret0, _ := ret[0].(*models.TheaterLocation)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // UpdateTheaterLocation indicates an expected call of UpdateTheaterLocation.
-func (mr *MockTheaterServiceMockRecorder) UpdateTheaterLocation(theaterId, req any) *gomock.Call {
+func (mr *MockTheaterServiceMockRecorder) UpdateTheaterLocation(theaterId, req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTheaterLocation", reflect.TypeOf((*MockTheaterService)(nil).UpdateTheaterLocation), theaterId, req, requestID)
+}
+
+// UpdateTheaterOpeningHours mocks base method.
+func (m *MockTheaterService) UpdateTheaterOpeningHours(theaterId uuid.UUID, req payloads.UpdateTheaterOpeningHoursRequest, requestID uuid.UUID) ([]*models.TheaterOpeningHour, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTheaterOpeningHours", theaterId, req, requestID)
+	ret0, _ := ret[0].([]*models.TheaterOpeningHour)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// UpdateTheaterOpeningHours indicates an expected call of UpdateTheaterOpeningHours.
+func (mr *MockTheaterServiceMockRecorder) UpdateTheaterOpeningHours(theaterId, req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTheaterLocation", reflect.TypeOf((*MockTheaterService)(nil).UpdateTheaterLocation), theaterId, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTheaterOpeningHours", reflect.TypeOf((*MockTheaterService)(nil).UpdateTheaterOpeningHours), theaterId, req, requestID)
 }
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: app/services/user_location_provider.go
+//
+// Generated by this command:
+//
+//	mockgen -source=app/services/user_location_provider.go -destination=app/mocks/mock_services/user_location_provider.go -package=mock_services
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	netip "net/netip"
+	reflect "reflect"
+
+	models "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserLocationProvider is a mock of UserLocationProvider interface.
+type MockUserLocationProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserLocationProviderMockRecorder
+}
+
+// MockUserLocationProviderMockRecorder is the mock recorder for MockUserLocationProvider.
+type MockUserLocationProviderMockRecorder struct {
+	mock *MockUserLocationProvider
+}
+
+// NewMockUserLocationProvider creates a new mock instance.
+func NewMockUserLocationProvider(ctrl *gomock.Controller) *MockUserLocationProvider {
+	mock := &MockUserLocationProvider{ctrl: ctrl}
+	mock.recorder = &MockUserLocationProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserLocationProvider) EXPECT() *MockUserLocationProviderMockRecorder {
+	return m.recorder
+}
+
+// GetLocation mocks base method.
+func (m *MockUserLocationProvider) GetLocation(ip netip.Addr) (*models.UserLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocation", ip)
+	ret0, _ := ret[0].(*models.UserLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocation indicates an expected call of GetLocation.
+func (mr *MockUserLocationProviderMockRecorder) GetLocation(ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocation", reflect.TypeOf((*MockUserLocationProvider)(nil).GetLocation), ip)
+}
@@ -40,17 +40,17 @@ func (m *MockUserLocationService) EXPECT() *MockUserLocationServiceMockRecorder
 	return m.recorder
 }
 
-// GetCurrentUserLocation mocks base method.
-func (m *MockUserLocationService) GetCurrentUserLocation() (*models.UserLocation, *errors.ApiError) {
+// GetUserLocation mocks base method.
+func (m *MockUserLocationService) GetUserLocation(ip string) (*models.UserLocation, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCurrentUserLocation")
+	ret := m.ctrl.Call(m, "GetUserLocation", ip)
 	ret0, _ := ret[0].(*models.UserLocation)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
-// GetCurrentUserLocation indicates an expected call of GetCurrentUserLocation.
-func (mr *MockUserLocationServiceMockRecorder) GetCurrentUserLocation() *gomock.Call {
+// GetUserLocation indicates an expected call of GetUserLocation.
+func (mr *MockUserLocationServiceMockRecorder) GetUserLocation(ip any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUserLocation", reflect.TypeOf((*MockUserLocationService)(nil).GetCurrentUserLocation))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLocation", reflect.TypeOf((*MockUserLocationService)(nil).GetUserLocation), ip)
 }
@@ -42,34 +42,93 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
-// CreatePasswordResetToken mocks base method.
-func (m *MockUserService) CreatePasswordResetToken(req payloads.CreatePasswordResetTokenRequest) (*models.PasswordResetToken, *errors.ApiError) {
+// CompleteOidcLogin mocks base method.
+func (m *MockUserService) CompleteOidcLogin(provider string, req payloads.OidcCallbackRequest) (*models.LoginToken, *models.LoginChallenge, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOidcLogin", provider, req)
+	ret0, _ := ret[0].(*models.LoginToken)
+	ret1, _ := ret[1].(*models.LoginChallenge)
+	ret2, _ := ret[2].(*errors.ApiError)
+	return ret0, ret1, ret2
+}
+
+// CompleteOidcLogin indicates an expected call of CompleteOidcLogin.
+func (mr *MockUserServiceMockRecorder) CompleteOidcLogin(provider, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOidcLogin", reflect.TypeOf((*MockUserService)(nil).CompleteOidcLogin), provider, req)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockUserService) ConfirmTwoFactor(userID uuid.UUID, req payloads.TwoFactorCodeRequest) ([]string, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePasswordResetToken", req)
-	ret0, _ := ret[0].(*models.PasswordResetToken)
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", userID, req)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockUserServiceMockRecorder) ConfirmTwoFactor(userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockUserService)(nil).ConfirmTwoFactor), userID, req)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockUserService) CreatePasswordResetToken(req payloads.CreatePasswordResetTokenRequest, requestID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", req, requestID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
 // CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
-func (mr *MockUserServiceMockRecorder) CreatePasswordResetToken(req any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) CreatePasswordResetToken(req, requestID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockUserService)(nil).CreatePasswordResetToken), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockUserService)(nil).CreatePasswordResetToken), req, requestID)
 }
 
 // CreateUser mocks base method.
-func (m *MockUserService) CreateUser(req payloads.CreateUserRequest) (*models.User, *errors.ApiError) {
+func (m *MockUserService) CreateUser(req payloads.CreateUserRequest, requestID uuid.UUID) (*models.User, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", req)
+	ret := m.ctrl.Call(m, "CreateUser", req, requestID)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(*errors.ApiError)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserServiceMockRecorder) CreateUser(req any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) CreateUser(req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), req, requestID)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockUserService) DisableTwoFactor(userID uuid.UUID, req payloads.TwoFactorCodeRequest) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", userID, req)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockUserServiceMockRecorder) DisableTwoFactor(userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockUserService)(nil).DisableTwoFactor), userID, req)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockUserService) EnrollTwoFactor(userID uuid.UUID) (*models.TwoFactorEnrollment, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", userID)
+	ret0, _ := ret[0].(*models.TwoFactorEnrollment)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockUserServiceMockRecorder) EnrollTwoFactor(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockUserService)(nil).EnrollTwoFactor), userID)
 }
 
 // GetUser mocks base method.
@@ -88,18 +147,19 @@ func (mr *MockUserServiceMockRecorder) GetUser(id, includeProfile any) *gomock.C
 }
 
 // LoginUser mocks base method.
-func (m *MockUserService) LoginUser(req payloads.LoginUserRequest) (*models.LoginToken, *errors.ApiError) {
+func (m *MockUserService) LoginUser(req payloads.LoginUserRequest, clientIp string) (*models.LoginToken, *models.LoginChallenge, *errors.ApiError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginUser", req)
+	ret := m.ctrl.Call(m, "LoginUser", req, clientIp)
 	ret0, _ := ret[0].(*models.LoginToken)
-	ret1, _ := ret[1].(*errors.ApiError)
-	return ret0, ret1
+	ret1, _ := ret[1].(*models.LoginChallenge)
+	ret2, _ := ret[2].(*errors.ApiError)
+	return ret0, ret1, ret2
 }
 
 // LoginUser indicates an expected call of LoginUser.
-func (mr *MockUserServiceMockRecorder) LoginUser(req any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) LoginUser(req, clientIp any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserService)(nil).LoginUser), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserService)(nil).LoginUser), req, clientIp)
 }
 
 // LogoutUser mocks base method.
@@ -116,6 +176,20 @@ func (mr *MockUserServiceMockRecorder) LogoutUser(tokenValue any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockUserService)(nil).LogoutUser), tokenValue)
 }
 
+// ResendVerificationEmail mocks base method.
+func (m *MockUserService) ResendVerificationEmail(req payloads.ResendVerificationEmailRequest, requestID uuid.UUID) *errors.ApiError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", req, requestID)
+	ret0, _ := ret[0].(*errors.ApiError)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockUserServiceMockRecorder) ResendVerificationEmail(req, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockUserService)(nil).ResendVerificationEmail), req, requestID)
+}
+
 // ResetUserPassword mocks base method.
 func (m *MockUserService) ResetUserPassword(resetToken string, request payloads.ResetPasswordRequest) *errors.ApiError {
 	m.ctrl.T.Helper()
@@ -130,6 +204,21 @@ func (mr *MockUserServiceMockRecorder) ResetUserPassword(resetToken, request any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPassword", reflect.TypeOf((*MockUserService)(nil).ResetUserPassword), resetToken, request)
 }
 
+// StartOidcLogin mocks base method.
+func (m *MockUserService) StartOidcLogin(provider string) (*models.OidcAuthorization, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOidcLogin", provider)
+	ret0, _ := ret[0].(*models.OidcAuthorization)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// StartOidcLogin indicates an expected call of StartOidcLogin.
+func (mr *MockUserServiceMockRecorder) StartOidcLogin(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOidcLogin", reflect.TypeOf((*MockUserService)(nil).StartOidcLogin), provider)
+}
+
 // UpdateUserPassword mocks base method.
 func (m *MockUserService) UpdateUserPassword(userID uuid.UUID, req payloads.UpdatePasswordRequest) *errors.ApiError {
 	m.ctrl.T.Helper()
@@ -159,6 +248,21 @@ func (mr *MockUserServiceMockRecorder) UserExistsByEmail(email any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserExistsByEmail", reflect.TypeOf((*MockUserService)(nil).UserExistsByEmail), email)
 }
 
+// VerifyTwoFactorLogin mocks base method.
+func (m *MockUserService) VerifyTwoFactorLogin(req payloads.VerifyTwoFactorLoginRequest, clientIp string) (*models.LoginToken, *errors.ApiError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactorLogin", req, clientIp)
+	ret0, _ := ret[0].(*models.LoginToken)
+	ret1, _ := ret[1].(*errors.ApiError)
+	return ret0, ret1
+}
+
+// VerifyTwoFactorLogin indicates an expected call of VerifyTwoFactorLogin.
+func (mr *MockUserServiceMockRecorder) VerifyTwoFactorLogin(req, clientIp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactorLogin", reflect.TypeOf((*MockUserService)(nil).VerifyTwoFactorLogin), req, clientIp)
+}
+
 // VerifyUser mocks base method.
 func (m *MockUserService) VerifyUser(token string) *errors.ApiError {
 	m.ctrl.T.Helper()
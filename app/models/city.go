@@ -3,7 +3,11 @@ package models
 import "github.com/google/uuid"
 
 type City struct {
-	ID      uuid.UUID `json:"id" gorm:"column:id"`
-	Name    string    `json:"name" gorm:"column:name"`
-	StateID uuid.UUID `json:"state_id" gorm:"column:state_id"`
+	ID        uuid.UUID `json:"id" gorm:"column:id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	StateID   uuid.UUID `json:"state_id" gorm:"column:state_id"`
+	Latitude  *float64  `json:"latitude,omitempty" gorm:"column:latitude"`
+	Longitude *float64  `json:"longitude,omitempty" gorm:"column:longitude"`
+	TimeZone  *string   `json:"time_zone,omitempty" gorm:"column:time_zone"`
+	State     *State    `json:"state,omitempty" gorm:"foreignKey:StateID"`
 }
@@ -3,7 +3,8 @@ package models
 import "github.com/google/uuid"
 
 type Country struct {
-	ID   uuid.UUID ` json:"id" gorm:"column:id"`
-	Name string    `json:"name" gorm:"column:name"`
-	Code string    `json:"code" gorm:"column:code"`
+	ID            uuid.UUID ` json:"id" gorm:"column:id"`
+	Name          string    `json:"name" gorm:"column:name"`
+	Code          string    `json:"code" gorm:"column:code"`
+	IdCheckMinAge *int      `json:"id_check_min_age,omitempty" gorm:"column:id_check_min_age"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"time"
+)
+
+type EmailBounce struct {
+	ID         uuid.UUID                 `json:"id" gorm:"column:id"`
+	Email      string                    `json:"email" gorm:"column:email"`
+	BounceType constants.EmailBounceType `json:"bounce_type" gorm:"column:bounce_type"`
+	Reason     *string                   `json:"reason,omitempty" gorm:"column:reason"`
+	BouncedAt  time.Time                 `json:"bounced_at" gorm:"column:bounced_at"`
+	CreatedAt  time.Time                 `json:"created_at" gorm:"column:created_at"`
+}
@@ -0,0 +1,8 @@
+package models
+
+import "time"
+
+type LoginChallenge struct {
+	Token     string    `json:"challenge_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
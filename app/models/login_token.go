@@ -9,7 +9,8 @@ import (
 type LoginToken struct {
 	ID         uuid.UUID `json:"-" gorm:"column:id"`
 	UserID     uuid.UUID `json:"-" gorm:"column:user_id"`
-	TokenValue string    `json:"token" gorm:"column:token_value"`
+	TokenValue string    `json:"-" gorm:"column:token_value"`
+	Token      string    `json:"token" gorm:"-"`
 	CreatedAt  time.Time `json:"-" gorm:"column:created_at"`
 	ExpiresAt  time.Time `json:"-" gorm:"column:expires_at"`
 }
@@ -7,18 +7,23 @@ import (
 )
 
 type Movie struct {
-	ID              uuid.UUID `json:"id" gorm:"column:id"`
-	Title           string    `json:"title" gorm:"column:title"`
-	Description     *string   `json:"description,omitempty" gorm:"column:description"`
-	ReleaseDate     string    `json:"release_date" gorm:"column:release_date"`
-	DurationMinutes int       `json:"duration_minutes" gorm:"column:duration_minutes"`
-	Language        *string   `json:"language,omitempty" gorm:"column:language"`
-	Rating          *float64  `json:"rating,omitempty" gorm:"column:rating"`
-	IsActive        bool      `json:"is_active" gorm:"column:is_active"`
-	CreatedAt       time.Time `json:"-" gorm:"column:created_at"`
-	UpdatedAt       time.Time `json:"-" gorm:"column:updated_at"`
-	IsDeleted       bool      `json:"is_deleted" gorm:"column:is_deleted"`
-	CreatedBy       uuid.UUID `json:"created_by" gorm:"column:created_by"`
-	LastUpdatedBy   uuid.UUID `json:"last_updated_by" gorm:"column:last_updated_by"`
-	Genres          []Genre   `json:"genres,omitempty" gorm:"many2many:movie_genres"`
+	ID              uuid.UUID             `json:"id" gorm:"column:id"`
+	Title           string                `json:"title" gorm:"column:title"`
+	Description     *string               `json:"description,omitempty" gorm:"column:description"`
+	ReleaseDate     string                `json:"release_date" gorm:"column:release_date"`
+	DurationMinutes int                   `json:"duration_minutes" gorm:"column:duration_minutes"`
+	Language        *string               `json:"language,omitempty" gorm:"column:language"`
+	Rating          *float64              `json:"rating,omitempty" gorm:"column:rating"`
+	TrailerUrl      *string               `json:"trailer_url,omitempty" gorm:"column:trailer_url"`
+	IsActive        bool                  `json:"is_active" gorm:"column:is_active"`
+	CreatedAt       time.Time             `json:"-" gorm:"column:created_at"`
+	UpdatedAt       time.Time             `json:"-" gorm:"column:updated_at"`
+	IsDeleted       bool                  `json:"is_deleted" gorm:"column:is_deleted"`
+	CreatedBy       uuid.UUID             `json:"created_by" gorm:"column:created_by"`
+	LastUpdatedBy   uuid.UUID             `json:"last_updated_by" gorm:"column:last_updated_by"`
+	Genres          []Genre               `json:"genres,omitempty" gorm:"many2many:movie_genres"`
+	Credits         []*MovieCredit        `json:"credits,omitempty" gorm:"foreignKey:MovieID"`
+	Certifications  []*MovieCertification `json:"certifications,omitempty" gorm:"foreignKey:MovieID"`
+	Languages       []*MovieLanguage      `json:"languages,omitempty" gorm:"foreignKey:MovieID"`
+	ExternalIDs     []*MovieExternalID    `json:"external_ids,omitempty" gorm:"foreignKey:MovieID"`
 }
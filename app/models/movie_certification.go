@@ -0,0 +1,10 @@
+package models
+
+import "github.com/google/uuid"
+
+type MovieCertification struct {
+	MovieID   uuid.UUID `json:"movie_id" gorm:"column:movie_id"`
+	CountryID uuid.UUID `json:"country_id" gorm:"column:country_id"`
+	Rating    string    `json:"rating" gorm:"column:rating"`
+	MinAge    int       `json:"min_age" gorm:"column:min_age"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+)
+
+type MovieCredit struct {
+	MovieID       uuid.UUID            `json:"movie_id" gorm:"column:movie_id"`
+	PersonID      uuid.UUID            `json:"person_id" gorm:"column:person_id"`
+	Role          constants.CreditRole `json:"role" gorm:"column:role"`
+	CharacterName *string              `json:"character_name,omitempty" gorm:"column:character_name"`
+	Position      int                  `json:"position" gorm:"column:position"`
+	Person        *Person              `json:"person,omitempty" gorm:"foreignKey:PersonID"`
+}
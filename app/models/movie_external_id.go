@@ -0,0 +1,12 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+)
+
+type MovieExternalID struct {
+	MovieID    uuid.UUID                  `json:"movie_id" gorm:"column:movie_id"`
+	Source     constants.ExternalIDSource `json:"source" gorm:"column:source"`
+	ExternalID string                     `json:"external_id" gorm:"column:external_id"`
+}
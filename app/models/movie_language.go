@@ -0,0 +1,12 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+)
+
+type MovieLanguage struct {
+	MovieID  uuid.UUID                   `json:"movie_id" gorm:"column:movie_id"`
+	Language string                      `json:"language" gorm:"column:language"`
+	Type     constants.MovieLanguageType `json:"type" gorm:"column:type"`
+}
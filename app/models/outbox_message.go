@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"time"
+)
+
+type OutboxMessage struct {
+	ID            uuid.UUID                     `json:"id" gorm:"column:id"`
+	Topic         string                        `json:"topic" gorm:"column:topic"`
+	Key           string                        `json:"key" gorm:"column:message_key"`
+	Headers       string                        `json:"-" gorm:"column:headers"`
+	Payload       string                        `json:"-" gorm:"column:payload"`
+	Status        constants.OutboxMessageStatus `json:"status" gorm:"column:status"`
+	Attempts      int                           `json:"attempts" gorm:"column:attempts"`
+	LastError     *string                       `json:"last_error,omitempty" gorm:"column:last_error"`
+	NextAttemptAt time.Time                     `json:"next_attempt_at" gorm:"column:next_attempt_at"`
+	CreatedAt     time.Time                     `json:"created_at" gorm:"column:created_at"`
+	SentAt        *time.Time                    `json:"sent_at,omitempty" gorm:"column:sent_at"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Person struct {
+	ID        uuid.UUID `json:"id" gorm:"column:id"`
+	Name      string    `json:"name" gorm:"column:name"`
+	Biography *string   `json:"biography,omitempty" gorm:"column:biography"`
+	CreatedAt time.Time `json:"-" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"-" gorm:"column:updated_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type ProcessedEvent struct {
+	ConsumerGroup  string    `json:"consumer_group" gorm:"column:consumer_group"`
+	IdempotencyKey string    `json:"idempotency_key" gorm:"column:idempotency_key"`
+	Topic          string    `json:"topic" gorm:"column:topic"`
+	ProcessedAt    time.Time `json:"processed_at" gorm:"column:processed_at"`
+}
@@ -6,9 +6,11 @@ import (
 )
 
 type Seat struct {
-	Id        uuid.UUID          `json:"id" gorm:"column:id"`
-	TheaterId *uuid.UUID         `json:"theater_id" gorm:"column:theater_id"`
-	Row       string             `json:"row" gorm:"column:row"`
-	Number    int                `json:"number" gorm:"column:number"`
-	Type      constants.SeatType `json:"type" gorm:"column:type"`
+	Id           uuid.UUID          `json:"id" gorm:"column:id"`
+	TheaterId    *uuid.UUID         `json:"theater_id" gorm:"column:theater_id"`
+	Row          string             `json:"row" gorm:"column:row"`
+	Number       int                `json:"number" gorm:"column:number"`
+	Type         constants.SeatType `json:"type" gorm:"column:type"`
+	PairedSeatId *uuid.UUID         `json:"paired_seat_id" gorm:"column:paired_seat_id"`
+	IsDeleted    bool               `json:"is_deleted" gorm:"column:is_deleted"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type SeatBlock struct {
+	Id        uuid.UUID  `json:"id" gorm:"column:id"`
+	SeatId    *uuid.UUID `json:"seat_id" gorm:"column:seat_id"`
+	ShowId    *uuid.UUID `json:"show_id" gorm:"column:show_id"`
+	Reason    string     `json:"reason" gorm:"column:reason"`
+	BlockedBy uuid.UUID  `json:"blocked_by" gorm:"column:blocked_by"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at"`
+}
@@ -7,13 +7,16 @@ import (
 )
 
 type Show struct {
-	Id        uuid.UUID            `json:"id" gorm:"column:id"`
-	MovieId   *uuid.UUID           `json:"movie_id" gorm:"column:movie_id"`
-	TheaterId *uuid.UUID           `json:"theater_id" gorm:"column:theater_id"`
-	StartTime time.Time            `json:"start_time" gorm:"column:start_time"`
-	EndTime   time.Time            `json:"end_time" gorm:"column:end_time"`
-	Status    constants.ShowStatus `json:"status" gorm:"column:status"`
-	CreatedAt time.Time            `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt time.Time            `json:"updated_at" gorm:"column:updated_at"`
-	Movie     *Movie               `json:"movie,omitempty" gorm:"foreignKey:MovieId;references:ID"`
+	Id             uuid.UUID            `json:"id" gorm:"column:id"`
+	MovieId        *uuid.UUID           `json:"movie_id" gorm:"column:movie_id"`
+	TheaterId      *uuid.UUID           `json:"theater_id" gorm:"column:theater_id"`
+	StartTime      time.Time            `json:"start_time" gorm:"column:start_time"`
+	EndTime        time.Time            `json:"end_time" gorm:"column:end_time"`
+	Status         constants.ShowStatus `json:"status" gorm:"column:status"`
+	CreatedAt      time.Time            `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time            `json:"updated_at" gorm:"column:updated_at"`
+	TimeZone       string               `json:"time_zone" gorm:"->;column:time_zone"`
+	LocalStartTime time.Time            `json:"local_start_time" gorm:"-"`
+	LocalEndTime   time.Time            `json:"local_end_time" gorm:"-"`
+	Movie          *Movie               `json:"movie,omitempty" gorm:"foreignKey:MovieId;references:ID"`
 }
@@ -7,4 +7,5 @@ type State struct {
 	Name      string    `json:"name" gorm:"column:name"`
 	Code      *string   `json:"code" gorm:"column:code"`
 	CountryID uuid.UUID `json:"country_id" gorm:"column:country_id"`
+	Country   *Country  `json:"country,omitempty" gorm:"foreignKey:CountryID"`
 }
@@ -3,7 +3,15 @@ package models
 import "github.com/google/uuid"
 
 type Theater struct {
-	ID       uuid.UUID        `json:"id" gorm:"column:id"`
-	Name     string           `json:"name" gorm:"column:name"`
-	Location *TheaterLocation `json:"location,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ID           uuid.UUID             `json:"id" gorm:"column:id"`
+	Name         string                `json:"name" gorm:"column:name"`
+	Phone        *string               `json:"phone,omitempty" gorm:"column:phone"`
+	Website      *string               `json:"website,omitempty" gorm:"column:website"`
+	TimeZone     *string               `json:"time_zone,omitempty" gorm:"column:time_zone"`
+	IsDeleted    bool                  `json:"is_deleted" gorm:"column:is_deleted"`
+	Location     *TheaterLocation      `json:"location,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Amenities    []*TheaterAmenity     `json:"amenities,omitempty" gorm:"foreignKey:TheaterID"`
+	OpeningHours []*TheaterOpeningHour `json:"opening_hours,omitempty" gorm:"foreignKey:TheaterID"`
+	Photos       []*TheaterPhoto       `json:"photos,omitempty" gorm:"foreignKey:TheaterID"`
+	Distance     *float64              `json:"distance,omitempty" gorm:"-"`
 }
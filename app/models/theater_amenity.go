@@ -0,0 +1,11 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+)
+
+type TheaterAmenity struct {
+	TheaterID uuid.UUID                `json:"theater_id" gorm:"column:theater_id"`
+	Amenity   constants.TheaterAmenity `json:"amenity" gorm:"column:amenity"`
+}
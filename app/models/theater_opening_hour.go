@@ -0,0 +1,11 @@
+package models
+
+import "github.com/google/uuid"
+
+type TheaterOpeningHour struct {
+	ID        uuid.UUID `json:"id" gorm:"column:id"`
+	TheaterID uuid.UUID `json:"theater_id" gorm:"column:theater_id"`
+	Weekday   int       `json:"weekday" gorm:"column:weekday"`
+	OpenTime  string    `json:"open_time" gorm:"column:open_time"`
+	CloseTime string    `json:"close_time" gorm:"column:close_time"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TheaterPhoto struct {
+	ID        uuid.UUID `json:"id" gorm:"column:id"`
+	TheaterID uuid.UUID `json:"theater_id" gorm:"column:theater_id"`
+	Url       string    `json:"url" gorm:"column:url"`
+	Caption   *string   `json:"caption,omitempty" gorm:"column:caption"`
+	Position  int       `json:"position" gorm:"column:position"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
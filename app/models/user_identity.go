@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type UserIdentity struct {
+	ID        uuid.UUID `json:"-" gorm:"column:id"`
+	UserID    uuid.UUID `json:"-" gorm:"column:user_id"`
+	Provider  string    `json:"provider" gorm:"column:provider"`
+	Subject   string    `json:"-" gorm:"column:subject"`
+	Email     *string   `json:"email,omitempty" gorm:"column:email"`
+	CreatedAt time.Time `json:"-" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"-" gorm:"column:updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID `json:"-" gorm:"column:id"`
+	UserID    uuid.UUID `json:"-" gorm:"column:user_id"`
+	CodeHash  string    `json:"-" gorm:"column:code_hash"`
+	IsUsed    bool      `json:"-" gorm:"column:is_used"`
+	CreatedAt time.Time `json:"-" gorm:"column:created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type UserTotpSecret struct {
+	ID           uuid.UUID `json:"-" gorm:"column:id"`
+	UserID       uuid.UUID `json:"-" gorm:"column:user_id"`
+	Secret       string    `json:"-" gorm:"column:secret"`
+	IsEnabled    bool      `json:"is_enabled" gorm:"column:is_enabled"`
+	LastUsedStep int64     `json:"-" gorm:"column:last_used_step"`
+	CreatedAt    time.Time `json:"-" gorm:"column:created_at"`
+	UpdatedAt    time.Time `json:"-" gorm:"column:updated_at"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"provisioning_uri"`
+}
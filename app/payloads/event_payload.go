@@ -0,0 +1,101 @@
+package payloads
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"time"
+)
+
+type Event interface {
+	GetEventType() constants.EventType
+	GetSchemaVersion() int
+	GetKey() string
+}
+
+type EventEnvelope struct {
+	EventID       uuid.UUID           `json:"event_id"`
+	EventType     constants.EventType `json:"event_type"`
+	SchemaVersion int                 `json:"schema_version"`
+	OccurredAt    time.Time           `json:"occurred_at"`
+	Producer      string              `json:"producer"`
+	RequestID     *uuid.UUID          `json:"request_id"`
+	Data          Event               `json:"data"`
+}
+
+func NewEventEnvelope(producer string, requestID uuid.UUID, event Event) EventEnvelope {
+	e := EventEnvelope{
+		EventID:       uuid.New(),
+		EventType:     event.GetEventType(),
+		SchemaVersion: event.GetSchemaVersion(),
+		OccurredAt:    time.Now().UTC(),
+		Producer:      producer,
+		Data:          event,
+	}
+	if requestID != uuid.Nil {
+		e.RequestID = &requestID
+	}
+
+	return e
+}
+
+// CatalogEvent describes a change of a catalog aggregate, Before is nil on creation and After is nil on deletion.
+type CatalogEvent[T any] struct {
+	Type   constants.EventType `json:"-"`
+	ID     uuid.UUID           `json:"id"`
+	Before *T                  `json:"before"`
+	After  *T                  `json:"after"`
+}
+
+type MovieEvent = CatalogEvent[models.Movie]
+type GenreEvent = CatalogEvent[models.Genre]
+type TheaterEvent = CatalogEvent[models.Theater]
+type ShowEvent = CatalogEvent[models.Show]
+
+func NewCatalogEvent[T any](eventType constants.EventType, id uuid.UUID, before, after *T) CatalogEvent[T] {
+	return CatalogEvent[T]{
+		Type:   eventType,
+		ID:     id,
+		Before: before,
+		After:  after,
+	}
+}
+
+func (e CatalogEvent[T]) GetEventType() constants.EventType {
+	return e.Type
+}
+
+func (e CatalogEvent[T]) GetSchemaVersion() int {
+	return 1
+}
+
+func (e CatalogEvent[T]) GetKey() string {
+	return e.ID.String()
+}
+
+// ConsumedEventEnvelope is the envelope of events consumed from other services.
+type ConsumedEventEnvelope[T any] struct {
+	EventID       uuid.UUID  `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	SchemaVersion int        `json:"schema_version"`
+	OccurredAt    time.Time  `json:"occurred_at"`
+	Producer      string     `json:"producer"`
+	RequestID     *uuid.UUID `json:"request_id"`
+	Data          T          `json:"data"`
+}
+
+type PaymentResultEvent struct {
+	PaymentID     uuid.UUID               `json:"payment_id"`
+	ReservationID uuid.UUID               `json:"reservation_id"`
+	Status        constants.PaymentStatus `json:"status"`
+	Amount        float64                 `json:"amount"`
+	Currency      string                  `json:"currency"`
+	FailureReason *string                 `json:"failure_reason"`
+}
+
+type EmailBounceEvent struct {
+	Email      string                    `json:"email"`
+	BounceType constants.EmailBounceType `json:"bounce_type"`
+	Reason     *string                   `json:"reason"`
+	BouncedAt  time.Time                 `json:"bounced_at"`
+}
@@ -1,8 +1,32 @@
 package payloads
 
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"io"
+)
+
+type SearchCityResult struct {
+	CityId      uuid.UUID `json:"city_id"`
+	CityName    string    `json:"city_name"`
+	StateId     uuid.UUID `json:"state_id"`
+	StateName   string    `json:"state_name"`
+	StateCode   *string   `json:"state_code"`
+	CountryId   uuid.UUID `json:"country_id"`
+	CountryName string    `json:"country_name"`
+	CountryCode string    `json:"country_code"`
+}
+
 type CreateCountryRequest struct {
-	Name string `json:"name" binding:"required,min=2,max=100"`
-	Code string `json:"code" binding:"required,len=2"`
+	Name          string `json:"name" binding:"required,min=2,max=100"`
+	Code          string `json:"code" binding:"required,len=2"`
+	IdCheckMinAge *int   `json:"id_check_min_age" binding:"omitempty,min=0,max=21"`
+}
+
+type UpdateCountryRequest struct {
+	Name          string `json:"name" binding:"required,min=2,max=100"`
+	Code          string `json:"code" binding:"required,len=2"`
+	IdCheckMinAge *int   `json:"id_check_min_age" binding:"omitempty,min=0,max=21"`
 }
 
 type CreateStateRequest struct {
@@ -10,6 +34,47 @@ type CreateStateRequest struct {
 	Code *string `json:"code" binding:"min=2,max=10"`
 }
 
+type UpdateStateRequest struct {
+	Name string  `json:"name" binding:"required,min=2,max=100"`
+	Code *string `json:"code" binding:"omitempty,min=2,max=10"`
+}
+
 type CreateCityRequest struct {
-	Name string `json:"name" binding:"required,min=2,max=100"`
+	Name      string   `json:"name" binding:"required,min=2,max=100"`
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	TimeZone  *string  `json:"time_zone" binding:"omitempty,max=64,timeZone"`
+}
+
+type UpdateCityRequest struct {
+	Name      string   `json:"name" binding:"required,min=2,max=100"`
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	TimeZone  *string  `json:"time_zone" binding:"omitempty,max=64,timeZone"`
+}
+
+type LocationImportSource struct {
+	Format      constants.LocationImportFormat
+	Data        io.Reader
+	CountryInfo io.Reader
+	Admin1Codes io.Reader
+}
+
+type LocationImportReport struct {
+	RowsProcessed    int                   `json:"rows_processed"`
+	RowsImported     int                   `json:"rows_imported"`
+	RowsFailed       int                   `json:"rows_failed"`
+	CountriesCreated int                   `json:"countries_created"`
+	CountriesUpdated int                   `json:"countries_updated"`
+	StatesCreated    int                   `json:"states_created"`
+	StatesUpdated    int                   `json:"states_updated"`
+	CitiesCreated    int                   `json:"cities_created"`
+	CitiesUpdated    int                   `json:"cities_updated"`
+	Errors           []LocationImportError `json:"errors"`
+	ErrorsTruncated  bool                  `json:"errors_truncated"`
+}
+
+type LocationImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
 }
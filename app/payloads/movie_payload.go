@@ -2,8 +2,21 @@ package payloads
 
 import (
 	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 )
 
+type MovieSearchFilter struct {
+	PersonID       *uuid.UUID
+	Role           *constants.CreditRole
+	CountryID      *uuid.UUID
+	Certification  *string
+	MaxAge         *int
+	Subtitle       *string
+	Dub            *string
+	ExternalSource *constants.ExternalIDSource
+	ExternalID     *string
+}
+
 type MovieGenre struct {
 	MovieId   uuid.UUID `json:"movie_id"`
 	GenreId   uuid.UUID `json:"genre_id"`
@@ -17,6 +30,7 @@ type CreateMovieRequest struct {
 	DurationMinutes int      `json:"duration_minutes" binding:"required,min=1"`
 	Language        *string  `json:"language" binding:"omitempty,min=1,max=50"`
 	Rating          *float64 `json:"rating"  binding:"omitempty,min=0,max=5"`
+	TrailerUrl      *string  `json:"trailer_url" binding:"omitempty,url,max=255"`
 	IsActive        *bool    `json:"is_active" binding:"required"`
 }
 
@@ -27,9 +41,45 @@ type UpdateMovieRequest struct {
 	DurationMinutes int      `json:"duration_minutes" binding:"required,min=1"`
 	Language        *string  `json:"language" binding:"omitempty,min=1,max=50"`
 	Rating          *float64 `json:"rating"  binding:"omitempty,min=0,max=5"`
+	TrailerUrl      *string  `json:"trailer_url" binding:"omitempty,url,max=255"`
 	IsActive        *bool    `json:"is_active" binding:"required"`
 }
 
 type UpdateMovieGenresRequest struct {
 	GenreIDs []uuid.UUID `json:"genre_ids" binding:"required"`
 }
+
+type MovieCreditRequest struct {
+	PersonID      uuid.UUID            `json:"person_id" binding:"required"`
+	Role          constants.CreditRole `json:"role" binding:"required,oneof=ACTOR DIRECTOR WRITER PRODUCER COMPOSER"`
+	CharacterName *string              `json:"character_name" binding:"omitempty,min=1,max=255"`
+	Position      int                  `json:"position" binding:"omitempty,min=0"`
+}
+
+type UpdateMovieCreditsRequest struct {
+	Credits []MovieCreditRequest `json:"credits" binding:"omitempty,dive"`
+}
+
+type MovieCertificationRequest struct {
+	CountryID uuid.UUID `json:"country_id" binding:"required"`
+	Rating    string    `json:"rating" binding:"required,min=1,max=10"`
+	MinAge    *int      `json:"min_age" binding:"required,min=0,max=21"`
+}
+
+type UpdateMovieCertificationsRequest struct {
+	Certifications []MovieCertificationRequest `json:"certifications" binding:"omitempty,unique=CountryID,dive"`
+}
+
+type UpdateMovieLanguagesRequest struct {
+	Subtitles []string `json:"subtitles" binding:"omitempty,unique,dive,max=35,bcp47_language_tag"`
+	Dubs      []string `json:"dubs" binding:"omitempty,unique,dive,max=35,bcp47_language_tag"`
+}
+
+type MovieExternalIDRequest struct {
+	Source     constants.ExternalIDSource `json:"source" binding:"required,oneof=IMDB TMDB"`
+	ExternalID string                     `json:"external_id" binding:"required,min=1,max=50"`
+}
+
+type UpdateMovieExternalIDsRequest struct {
+	ExternalIDs []MovieExternalIDRequest `json:"external_ids" binding:"omitempty,unique=Source,dive"`
+}
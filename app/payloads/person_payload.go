@@ -0,0 +1,11 @@
+package payloads
+
+type CreatePersonRequest struct {
+	Name      string  `json:"name" binding:"required,min=1,max=255"`
+	Biography *string `json:"biography" binding:"omitempty"`
+}
+
+type UpdatePersonRequest struct {
+	Name      string  `json:"name" binding:"required,min=1,max=255"`
+	Biography *string `json:"biography" binding:"omitempty"`
+}
@@ -6,10 +6,32 @@ import (
 	"time"
 )
 
+type ShowSearchFilter struct {
+	TheaterID *uuid.UUID
+	Date      *time.Time
+	Today     bool
+}
+
 type CreateShowRequest struct {
 	MovieId   uuid.UUID            `json:"movie_id" binding:"required"`
 	TheaterId uuid.UUID            `json:"theater_id" binding:"required"`
 	StartTime time.Time            `json:"start_time" binding:"required"`
 	EndTime   time.Time            `json:"end_time" binding:"required"`
 	Status    constants.ShowStatus `json:"status" binding:"required,oneof=ACTIVE CANCELLED COMPLETED EXPIRED SCHEDULED ON-HOLD"`
+
+	AllowOutsideOpeningHours bool `json:"allow_outside_opening_hours"`
+}
+
+type ShowCertification struct {
+	CountryID     *uuid.UUID `gorm:"column:country_id"`
+	Rating        *string    `gorm:"column:rating"`
+	MinAge        int        `gorm:"column:min_age"`
+	IdCheckMinAge *int       `gorm:"column:id_check_min_age"`
+}
+
+type AgeGateResult struct {
+	Rating          *string `json:"rating,omitempty"`
+	MinAge          int     `json:"min_age"`
+	RequiresIdCheck bool    `json:"requires_id_check"`
+	Unrated         bool    `json:"unrated"`
 }
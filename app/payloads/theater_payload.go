@@ -14,30 +14,82 @@ type GetTheaterWithLocationResult struct {
 	PostalCode string    `json:"postal_code"`
 	Latitude   float64   `json:"latitude"`
 	Longitude  float64   `json:"longitude"`
+	Distance   float64   `json:"distance"`
+}
+
+type NearbyTheatersOrigin struct {
+	Latitude  *float64
+	Longitude *float64
+	CityID    *uuid.UUID
+	ClientIP  string
+}
+
+type TheaterSearchFilter struct {
+	CityID    *uuid.UUID
+	Amenities []constants.TheaterAmenity
 }
 
 type CreateTheaterRequest struct {
-	Name string `json:"name" binding:"required,min=2,max=255"`
+	Name     string  `json:"name" binding:"required,min=2,max=255"`
+	Phone    *string `json:"phone" binding:"omitempty,phoneNumber"`
+	Website  *string `json:"website" binding:"omitempty,url,max=255"`
+	TimeZone *string `json:"time_zone" binding:"omitempty,max=64,timeZone"`
+}
+
+type UpdateTheaterRequest struct {
+	Name     string  `json:"name" binding:"required,min=2,max=255"`
+	Phone    *string `json:"phone" binding:"omitempty,phoneNumber"`
+	Website  *string `json:"website" binding:"omitempty,url,max=255"`
+	TimeZone *string `json:"time_zone" binding:"omitempty,max=64,timeZone"`
+}
+
+type UpdateTheaterAmenitiesRequest struct {
+	Amenities []constants.TheaterAmenity `json:"amenities" binding:"omitempty,unique,dive,oneof=PARKING FOOD IMAX DOLBY_ATMOS WHEELCHAIR_ACCESS HEARING_LOOP"`
+}
+
+type TheaterOpeningHourRequest struct {
+	Weekday   *int   `json:"weekday" binding:"required,min=0,max=6"`
+	OpenTime  string `json:"open_time" binding:"required,datetime=15:04"`
+	CloseTime string `json:"close_time" binding:"required,datetime=15:04"`
+}
+
+type UpdateTheaterOpeningHoursRequest struct {
+	OpeningHours []TheaterOpeningHourRequest `json:"opening_hours" binding:"omitempty,max=7,dive"`
+}
+
+type CreateTheaterPhotoRequest struct {
+	Url      string  `json:"url" binding:"required,url,max=255"`
+	Caption  *string `json:"caption" binding:"omitempty,max=255"`
+	Position int     `json:"position" binding:"omitempty,min=0"`
 }
 
 type CreateTheaterLocationRequest struct {
 	CityID     uuid.UUID `json:"city_id" binding:"required"`
 	Address    string    `json:"address" binding:"required,min=2,max=255"`
 	PostalCode string    `json:"postal_code" binding:"required,min=2,max=10"`
-	Latitude   float64   `json:"latitude" binding:"required"`
-	Longitude  float64   `json:"longitude" binding:"required"`
+	Latitude   float64   `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude  float64   `json:"longitude" binding:"required,min=-180,max=180"`
 }
 
 type CreateSeatPayload struct {
-	Row    string             `json:"row" binding:"required,uppercase,len=1"`
-	Number int                `json:"number" binding:"required,min=1,max=50"`
-	Type   constants.SeatType `json:"type" binding:"required,oneof=REGULAR VIP"`
+	Row          string             `json:"row" binding:"required,uppercase,len=1"`
+	Number       int                `json:"number" binding:"required,min=1,max=50"`
+	Type         constants.SeatType `json:"type" binding:"required,oneof=REGULAR VIP WHEELCHAIR COMPANION COUPLE"`
+	PairedNumber *int               `json:"paired_number" binding:"omitempty,min=1,max=50"`
+}
+
+type UpdateSeatRequest struct {
+	Type constants.SeatType `json:"type" binding:"required,oneof=REGULAR VIP WHEELCHAIR"`
+}
+
+type BlockSeatRequest struct {
+	Reason string `json:"reason" binding:"required,min=2,max=255"`
 }
 
 type UpdateTheaterLocationRequest struct {
 	CityID     uuid.UUID `json:"city_id" binding:"required"`
 	Address    string    `json:"address" binding:"required,min=2,max=255"`
 	PostalCode string    `json:"postal_code" binding:"required,min=2,max=10"`
-	Latitude   float64   `json:"latitude" binding:"required"`
-	Longitude  float64   `json:"longitude" binding:"required"`
+	Latitude   float64   `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude  float64   `json:"longitude" binding:"required,min=-180,max=180"`
 }
@@ -1,6 +1,10 @@
 package payloads
 
-import "time"
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"time"
+)
 
 type CreateUserRequest struct {
 	Email    string                   `json:"email" binding:"required,email"`
@@ -13,10 +17,28 @@ type LoginUserRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type VerifyTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type OidcCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
 type UpdatePasswordRequest struct {
 	Password string `json:"password" binding:"required,min=8,max=32"`
 }
 
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 type CreatePasswordResetTokenRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
@@ -25,10 +47,45 @@ type ResetPasswordRequest struct {
 	Password string `json:"password" binding:"required,min=8,max=32"`
 }
 
+type PasswordResetRequestedEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Email      string    `json:"email"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	ResetToken string    `json:"reset_token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (e PasswordResetRequestedEvent) GetEventType() constants.EventType {
+	return constants.PasswordResetRequested
+}
+
+func (e PasswordResetRequestedEvent) GetSchemaVersion() int {
+	return 1
+}
+
+func (e PasswordResetRequestedEvent) GetKey() string {
+	return e.UserID.String()
+}
+
 type UserRegistrationEvent struct {
+	UserID            uuid.UUID `json:"user_id"`
 	Email             string    `json:"email"`
 	FirstName         string    `json:"first_name"`
 	LastName          string    `json:"last_name"`
 	VerificationToken string    `json:"verification_token"`
 	CreatedAt         time.Time `json:"created_at"`
 }
+
+func (e UserRegistrationEvent) GetEventType() constants.EventType {
+	return constants.UserRegistered
+}
+
+func (e UserRegistrationEvent) GetSchemaVersion() int {
+	return 1
+}
+
+func (e UserRegistrationEvent) GetKey() string {
+	return e.UserID.String()
+}
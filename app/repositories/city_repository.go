@@ -4,13 +4,22 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"gorm.io/gorm"
+	"strings"
 )
 
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type CityRepository interface {
 	GetCity(filter filters.CityFilter) (*models.City, error)
 	GetCities(filter filters.CityFilter) ([]*models.City, error)
+	GetNumbersOfCity(filter filters.CityFilter) (int, error)
+	SearchCities(keyword string, limit, offset int) ([]*payloads.SearchCityResult, error)
 	CreateCity(tx *gorm.DB, city *models.City) error
+	CreateCities(tx *gorm.DB, cities []*models.City) error
+	UpdateCity(tx *gorm.DB, city *models.City) error
+	DeleteCity(tx *gorm.DB, city *models.City) error
 }
 
 func NewCityRepository(db *gorm.DB) CityRepository {
@@ -43,6 +52,60 @@ func (r *cityRepository) GetCities(filter filters.CityFilter) ([]*models.City, e
 	return cities, nil
 }
 
+func (r *cityRepository) GetNumbersOfCity(filter filters.CityFilter) (int, error) {
+	var count int64
+	if err := filter.GetFilterQuery(r.db).Model(&models.City{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *cityRepository) SearchCities(keyword string, limit, offset int) ([]*payloads.SearchCityResult, error) {
+	var results []*payloads.SearchCityResult
+	prefix := likePatternEscaper.Replace(keyword) + "%"
+	query := `
+		SELECT
+			ci.id AS city_id, ci.name AS city_name,
+			s.id AS state_id, s.name AS state_name, s.code AS state_code,
+			co.id AS country_id, co.name AS country_name, co.code AS country_code
+		FROM cities ci
+		JOIN states s ON s.id = ci.state_id
+		JOIN countries co ON co.id = s.country_id
+		WHERE ci.name ILIKE ? OR s.name ILIKE ? OR co.name ILIKE ?
+			OR ci.name % ? OR s.name % ? OR co.name % ?
+		ORDER BY
+			ci.name ILIKE ? DESC,
+			GREATEST(similarity(ci.name, ?), similarity(s.name, ?), similarity(co.name, ?)) DESC,
+			ci.name
+		LIMIT ? OFFSET ?
+	`
+	if err := r.db.Raw(
+		query,
+		prefix, prefix, prefix,
+		keyword, keyword, keyword,
+		prefix,
+		keyword, keyword, keyword,
+		limit, offset,
+	).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (r *cityRepository) CreateCity(tx *gorm.DB, city *models.City) error {
 	return tx.Create(city).Error
 }
+
+func (r *cityRepository) CreateCities(tx *gorm.DB, cities []*models.City) error {
+	return tx.Omit("State").Create(cities).Error
+}
+
+func (r *cityRepository) UpdateCity(tx *gorm.DB, city *models.City) error {
+	return tx.Omit("State").Save(city).Error
+}
+
+func (r *cityRepository) DeleteCity(tx *gorm.DB, city *models.City) error {
+	return tx.Delete(city).Error
+}
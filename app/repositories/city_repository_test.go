@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"regexp"
 	"testing"
@@ -103,6 +104,117 @@ func TestCityRepository_GetCities(t *testing.T) {
 	})
 }
 
+func TestCityRepository_GetNumbersOfCity(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCityRepository(db)
+
+	filter := filters.CityFilter{
+		Filter: &filters.SingleFilter{},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "cities"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := repo.GetNumbersOfCity(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, result)
+	})
+
+	t.Run("error counting cities", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "cities"`)).
+			WillReturnError(errors.New("error counting cities"))
+
+		result, err := repo.GetNumbersOfCity(filter)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, result)
+		assert.Equal(t, "error counting cities", err.Error())
+	})
+}
+
+func TestCityRepository_SearchCities(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCityRepository(db)
+
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT
+			ci.id AS city_id, ci.name AS city_name,
+			s.id AS state_id, s.name AS state_name, s.code AS state_code,
+			co.id AS country_id, co.name AS country_name, co.code AS country_code
+		FROM cities ci
+		JOIN states s ON s.id = ci.state_id
+		JOIN countries co ON co.id = s.country_id
+		WHERE ci.name ILIKE $1 OR s.name ILIKE $2 OR co.name ILIKE $3
+			OR ci.name % $4 OR s.name % $5 OR co.name % $6
+		ORDER BY
+			ci.name ILIKE $7 DESC,
+			GREATEST(similarity(ci.name, $8), similarity(s.name, $9), similarity(co.name, $10)) DESC,
+			ci.name
+		LIMIT $11 OFFSET $12
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		expectedResult := make([]*payloads.SearchCityResult, 3)
+		for i := 0; i < len(expectedResult); i++ {
+			country := utils.GenerateCountry()
+			state := utils.GenerateState()
+			city := utils.GenerateCity()
+			expectedResult[i] = &payloads.SearchCityResult{
+				CityId:      city.ID,
+				CityName:    city.Name,
+				StateId:     state.ID,
+				StateName:   state.Name,
+				StateCode:   state.Code,
+				CountryId:   country.ID,
+				CountryName: country.Name,
+				CountryCode: country.Code,
+			}
+		}
+
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("spr%", "spr%", "spr%", "spr", "spr", "spr", "spr%", "spr", "spr", "spr", 10, 0).
+			WillReturnRows(utils.GenerateSqlMockRows(expectedResult))
+
+		result, err := repo.SearchCities("spr", 10, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResult, result)
+	})
+
+	t.Run("escape like wildcards", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(`a\_b\%%`, `a\_b\%%`, `a\_b\%%`, "a_b%", "a_b%", "a_b%", `a\_b\%%`, "a_b%", "a_b%", "a_b%", 10, 0).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.SearchCities("a_b%", 10, 0)
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("error searching cities", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs("spr%", "spr%", "spr%", "spr", "spr", "spr", "spr%", "spr", "spr", "spr", 10, 0).
+			WillReturnError(errors.New("error searching cities"))
+
+		result, err := repo.SearchCities("spr", 10, 0)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error searching cities", err.Error())
+	})
+}
+
 func TestCityRepository_CreateCity(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -115,8 +227,8 @@ func TestCityRepository_CreateCity(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cities" ("id","name","state_id") VALUES ($1,$2,$3)`)).
-			WithArgs(city.ID, city.Name, city.StateID).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cities" ("id","name","state_id","latitude","longitude","time_zone") VALUES ($1,$2,$3,$4,$5,$6)`)).
+			WithArgs(city.ID, city.Name, city.StateID, city.Latitude, city.Longitude, city.TimeZone).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -129,8 +241,8 @@ func TestCityRepository_CreateCity(t *testing.T) {
 
 	t.Run("error creating city", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cities" ("id","name","state_id") VALUES ($1,$2,$3)`)).
-			WithArgs(city.ID, city.Name, city.StateID).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cities" ("id","name","state_id","latitude","longitude","time_zone") VALUES ($1,$2,$3,$4,$5,$6)`)).
+			WithArgs(city.ID, city.Name, city.StateID, city.Latitude, city.Longitude, city.TimeZone).
 			WillReturnError(errors.New("error creating city"))
 		mock.ExpectRollback()
 
@@ -142,3 +254,125 @@ func TestCityRepository_CreateCity(t *testing.T) {
 		assert.Equal(t, "error creating city", err.Error())
 	})
 }
+
+func TestCityRepository_CreateCities(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCityRepository(db)
+
+	cities := utils.GenerateCities(2)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cities" ("id","name","state_id","latitude","longitude","time_zone") VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12)`)).
+			WithArgs(
+				cities[0].ID, cities[0].Name, cities[0].StateID, cities[0].Latitude, cities[0].Longitude, cities[0].TimeZone,
+				cities[1].ID, cities[1].Name, cities[1].StateID, cities[1].Latitude, cities[1].Longitude, cities[1].TimeZone,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateCities(tx, cities)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error creating cities", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cities" ("id","name","state_id","latitude","longitude","time_zone") VALUES ($1,$2,$3,$4,$5,$6),($7,$8,$9,$10,$11,$12)`)).
+			WillReturnError(errors.New("error creating cities"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateCities(tx, cities)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating cities", err.Error())
+	})
+}
+
+func TestCityRepository_UpdateCity(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCityRepository(db)
+
+	city := utils.GenerateCity()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "cities" SET "name"=$1,"state_id"=$2,"latitude"=$3,"longitude"=$4,"time_zone"=$5 WHERE "id" = $6`)).
+			WithArgs(city.Name, city.StateID, city.Latitude, city.Longitude, city.TimeZone, city.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateCity(tx, city)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error updating city", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "cities" SET "name"=$1,"state_id"=$2,"latitude"=$3,"longitude"=$4,"time_zone"=$5 WHERE "id" = $6`)).
+			WithArgs(city.Name, city.StateID, city.Latitude, city.Longitude, city.TimeZone, city.ID).
+			WillReturnError(errors.New("error updating city"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateCity(tx, city)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error updating city", err.Error())
+	})
+}
+
+func TestCityRepository_DeleteCity(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCityRepository(db)
+
+	city := utils.GenerateCity()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "cities" WHERE "cities"."id" = $1`)).
+			WithArgs(city.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteCity(tx, city)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting city", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "cities" WHERE "cities"."id" = $1`)).
+			WithArgs(city.ID).
+			WillReturnError(errors.New("error deleting city"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteCity(tx, city)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting city", err.Error())
+	})
+}
@@ -10,7 +10,11 @@ import (
 type CountryRepository interface {
 	GetCountry(filter filters.CountryFilter) (*models.Country, error)
 	GetCountries(filter filters.CountryFilter) ([]*models.Country, error)
+	GetNumbersOfCountry(filter filters.CountryFilter) (int, error)
 	CreateCountry(tx *gorm.DB, country *models.Country) error
+	CreateCountries(tx *gorm.DB, countries []*models.Country) error
+	UpdateCountry(tx *gorm.DB, country *models.Country) error
+	DeleteCountry(tx *gorm.DB, country *models.Country) error
 }
 
 func NewCountryRepository(db *gorm.DB) CountryRepository {
@@ -45,6 +49,27 @@ func (r *countryRepository) GetCountries(filter filters.CountryFilter) ([]*model
 	return countries, nil
 }
 
+func (r *countryRepository) GetNumbersOfCountry(filter filters.CountryFilter) (int, error) {
+	var count int64
+	if err := filter.GetFilterQuery(r.db).Model(&models.Country{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
 func (r *countryRepository) CreateCountry(tx *gorm.DB, country *models.Country) error {
 	return tx.Create(country).Error
 }
+
+func (r *countryRepository) CreateCountries(tx *gorm.DB, countries []*models.Country) error {
+	return tx.Create(countries).Error
+}
+
+func (r *countryRepository) UpdateCountry(tx *gorm.DB, country *models.Country) error {
+	return tx.Save(country).Error
+}
+
+func (r *countryRepository) DeleteCountry(tx *gorm.DB, country *models.Country) error {
+	return tx.Delete(country).Error
+}
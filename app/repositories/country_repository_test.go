@@ -110,6 +110,40 @@ func TestCountryRepository_GetCountries(t *testing.T) {
 	})
 }
 
+func TestCountryRepository_GetNumbersOfCountry(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCountryRepository(db)
+
+	filter := filters.CountryFilter{
+		Filter: &filters.SingleFilter{},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "countries"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := repo.GetNumbersOfCountry(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, result)
+	})
+
+	t.Run("error counting countries", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "countries"`)).
+			WillReturnError(errors.New("error counting countries"))
+
+		result, err := repo.GetNumbersOfCountry(filter)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, result)
+		assert.Equal(t, "error counting countries", err.Error())
+	})
+}
+
 func TestCountryRepository_CreateCountry(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -122,8 +156,8 @@ func TestCountryRepository_CreateCountry(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "countries" ("id","name","code") VALUES ($1,$2,$3)`)).
-			WithArgs(country.ID, country.Name, country.Code).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "countries" ("id","name","code","id_check_min_age") VALUES ($1,$2,$3,$4)`)).
+			WithArgs(country.ID, country.Name, country.Code, country.IdCheckMinAge).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -136,8 +170,8 @@ func TestCountryRepository_CreateCountry(t *testing.T) {
 
 	t.Run("error creating country", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "countries" ("id","name","code") VALUES ($1,$2,$3)`)).
-			WithArgs(country.ID, country.Name, country.Code).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "countries" ("id","name","code","id_check_min_age") VALUES ($1,$2,$3,$4)`)).
+			WithArgs(country.ID, country.Name, country.Code, country.IdCheckMinAge).
 			WillReturnError(errors.New("error creating country"))
 		mock.ExpectRollback()
 
@@ -149,3 +183,125 @@ func TestCountryRepository_CreateCountry(t *testing.T) {
 		assert.Equal(t, "error creating country", err.Error())
 	})
 }
+
+func TestCountryRepository_CreateCountries(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCountryRepository(db)
+
+	countries := utils.GenerateCountries(2)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "countries" ("id","name","code","id_check_min_age") VALUES ($1,$2,$3,$4),($5,$6,$7,$8)`)).
+			WithArgs(
+				countries[0].ID, countries[0].Name, countries[0].Code, countries[0].IdCheckMinAge,
+				countries[1].ID, countries[1].Name, countries[1].Code, countries[1].IdCheckMinAge,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateCountries(tx, countries)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error creating countries", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "countries" ("id","name","code","id_check_min_age") VALUES ($1,$2,$3,$4),($5,$6,$7,$8)`)).
+			WillReturnError(errors.New("error creating countries"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateCountries(tx, countries)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating countries", err.Error())
+	})
+}
+
+func TestCountryRepository_UpdateCountry(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCountryRepository(db)
+
+	country := utils.GenerateCountry()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "countries" SET "name"=$1,"code"=$2,"id_check_min_age"=$3 WHERE "id" = $4`)).
+			WithArgs(country.Name, country.Code, country.IdCheckMinAge, country.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateCountry(tx, country)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error updating country", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "countries" SET "name"=$1,"code"=$2,"id_check_min_age"=$3 WHERE "id" = $4`)).
+			WithArgs(country.Name, country.Code, country.IdCheckMinAge, country.ID).
+			WillReturnError(errors.New("error updating country"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateCountry(tx, country)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error updating country", err.Error())
+	})
+}
+
+func TestCountryRepository_DeleteCountry(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewCountryRepository(db)
+
+	country := utils.GenerateCountry()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "countries" WHERE "countries"."id" = $1`)).
+			WithArgs(country.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteCountry(tx, country)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting country", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "countries" WHERE "countries"."id" = $1`)).
+			WithArgs(country.ID).
+			WillReturnError(errors.New("error deleting country"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteCountry(tx, country)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting country", err.Error())
+	})
+}
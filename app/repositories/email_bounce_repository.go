@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type EmailBounceRepository interface {
+	CreateEmailBounce(tx *gorm.DB, bounce *models.EmailBounce) error
+}
+
+func NewEmailBounceRepository(db *gorm.DB) EmailBounceRepository {
+	return &emailBounceRepository{db: db}
+}
+
+type emailBounceRepository struct {
+	db *gorm.DB
+}
+
+func (r *emailBounceRepository) CreateEmailBounce(tx *gorm.DB, bounce *models.EmailBounce) error {
+	return tx.Create(bounce).Error
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestEmailBounceRepository_CreateEmailBounce(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewEmailBounceRepository(db)
+
+	bounce := utils.GenerateEmailBounce()
+	query := regexp.QuoteMeta(`INSERT INTO "email_bounces" ("id","email","bounce_type","reason","bounced_at","created_at") VALUES ($1,$2,$3,$4,$5,$6)`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(bounce.ID, bounce.Email, bounce.BounceType, bounce.Reason, bounce.BouncedAt, bounce.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateEmailBounce(tx, bounce)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(bounce.ID, bounce.Email, bounce.BounceType, bounce.Reason, bounce.BouncedAt, bounce.CreatedAt).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateEmailBounce(tx, bounce)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+)
+
+type LoginChallengeRepository interface {
+	GetAndDeleteLoginChallenge(token string) (*models.UserSession, error)
+	CreateLoginChallenge(token string, expiration time.Duration, session *models.UserSession) error
+}
+
+type loginChallengeRepository struct {
+	ctx context.Context
+	rdb *redis.Client
+}
+
+func NewLoginChallengeRepository(rdb *redis.Client) LoginChallengeRepository {
+	return &loginChallengeRepository{ctx: context.Background(), rdb: rdb}
+}
+
+// GetAndDeleteLoginChallenge atomically consumes the challenge, so concurrent verifications cannot both use it.
+func (r *loginChallengeRepository) GetAndDeleteLoginChallenge(token string) (*models.UserSession, error) {
+	challengeString, err := r.rdb.GetDel(r.ctx, r.getChallengeKey(token)).Result()
+	if err != nil {
+		if errors.IsRedisKeyNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var s models.UserSession
+	if err := json.Unmarshal([]byte(challengeString), &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (r *loginChallengeRepository) CreateLoginChallenge(token string, expiration time.Duration, session *models.UserSession) error {
+	challengeData, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return r.rdb.Set(r.ctx, r.getChallengeKey(token), challengeData, expiration).Err()
+}
+
+func (r *loginChallengeRepository) getChallengeKey(token string) string {
+	return fmt.Sprintf("%s:%s", constants.LoginChallenge, token)
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+)
+
+func TestLoginChallengeRepository_GetAndDeleteLoginChallenge(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	repo := NewLoginChallengeRepository(client)
+
+	session := utils.GenerateUserSession()
+	token := uuid.NewString()
+	key := fmt.Sprintf("%s:%s", constants.LoginChallenge, token)
+
+	t.Run("success", func(t *testing.T) {
+		sessionJSON, _ := json.Marshal(session)
+		mock.ExpectGetDel(key).SetVal(string(sessionJSON))
+
+		result, err := repo.GetAndDeleteLoginChallenge(token)
+
+		assert.Nil(t, err)
+		assert.Equal(t, session, result)
+	})
+
+	t.Run("challenge not found", func(t *testing.T) {
+		mock.ExpectGetDel(key).RedisNil()
+
+		result, err := repo.GetAndDeleteLoginChallenge(token)
+
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectGetDel(key).SetErr(errors.New("db error"))
+
+		result, err := repo.GetAndDeleteLoginChallenge(token)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestLoginChallengeRepository_CreateLoginChallenge(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	repo := NewLoginChallengeRepository(client)
+
+	session := utils.GenerateUserSession()
+	token := uuid.NewString()
+	key := fmt.Sprintf("%s:%s", constants.LoginChallenge, token)
+	expiration := 5 * time.Minute
+
+	t.Run("success", func(t *testing.T) {
+		sessionJSON, _ := json.Marshal(session)
+		mock.ExpectSet(key, sessionJSON, expiration).SetVal("OK")
+
+		err := repo.CreateLoginChallenge(token, expiration, session)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		sessionJSON, _ := json.Marshal(session)
+		mock.ExpectSet(key, sessionJSON, expiration).SetErr(errors.New("db error"))
+
+		err := repo.CreateLoginChallenge(token, expiration, session)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
@@ -22,6 +22,7 @@ func TestLoginTokenRepository_GetLoginToken(t *testing.T) {
 	repo := NewLoginTokenRepository(db)
 
 	token := utils.GenerateLoginToken()
+	token.Token = ""
 	filter := filters.LoginTokenFilter{
 		Filter:     &filters.SingleFilter{Logic: filters.And},
 		UserID:     &filters.Condition{Operator: filters.OpEqual, Value: token.UserID},
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"gorm.io/gorm"
+)
+
+type MovieCertificationRepository interface {
+	GetCertificationOfShow(showID uuid.UUID) (*payloads.ShowCertification, error)
+	UpdateCertificationsOfMovie(tx *gorm.DB, movieID uuid.UUID, certifications []*models.MovieCertification) error
+}
+
+func NewMovieCertificationRepository(db *gorm.DB) MovieCertificationRepository {
+	return &movieCertificationRepository{db}
+}
+
+type movieCertificationRepository struct {
+	db *gorm.DB
+}
+
+// GetCertificationOfShow returns the certification of the show's movie in the country of its theater,
+// together with that country's ID check age, or nil if the show does not exist. The country is nil when
+// the theater has no location, the rating is nil when the movie has no certification in that country.
+func (r *movieCertificationRepository) GetCertificationOfShow(showID uuid.UUID) (*payloads.ShowCertification, error) {
+	var certification payloads.ShowCertification
+	query := `
+		SELECT co.id AS country_id, mc.rating, COALESCE(mc.min_age, 0) AS min_age, co.id_check_min_age
+		FROM shows s
+			LEFT JOIN theater_locations tl ON tl.theater_id = s.theater_id
+			LEFT JOIN cities ci ON ci.id = tl.city_id
+			LEFT JOIN states st ON st.id = ci.state_id
+			LEFT JOIN countries co ON co.id = st.country_id
+			LEFT JOIN movie_certifications mc ON mc.movie_id = s.movie_id AND mc.country_id = co.id
+		WHERE s.id = ?
+	`
+	result := r.db.Raw(query, showID).Scan(&certification)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &certification, nil
+}
+
+func (r *movieCertificationRepository) UpdateCertificationsOfMovie(tx *gorm.DB, movieID uuid.UUID, certifications []*models.MovieCertification) error {
+	if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieCertification{}).Error; err != nil {
+		return err
+	}
+
+	if len(certifications) == 0 {
+		return nil
+	}
+
+	return tx.Create(&certifications).Error
+}
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestMovieCertificationRepository_GetCertificationOfShow(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieCertificationRepository(db)
+
+	show := utils.GenerateShow()
+	certification := utils.GenerateShowCertification()
+	expectedQuery := regexp.QuoteMeta(`
+		SELECT co.id AS country_id, mc.rating, COALESCE(mc.min_age, 0) AS min_age, co.id_check_min_age
+		FROM shows s
+			LEFT JOIN theater_locations tl ON tl.theater_id = s.theater_id
+			LEFT JOIN cities ci ON ci.id = tl.city_id
+			LEFT JOIN states st ON st.id = ci.state_id
+			LEFT JOIN countries co ON co.id = st.country_id
+			LEFT JOIN movie_certifications mc ON mc.movie_id = s.movie_id AND mc.country_id = co.id
+		WHERE s.id = $1
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(show.Id).
+			WillReturnRows(utils.GenerateSqlMockRow(certification))
+
+		result, err := repo.GetCertificationOfShow(show.Id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, certification, result)
+	})
+
+	t.Run("show not found", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(show.Id).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetCertificationOfShow(show.Id)
+
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error getting certification", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).
+			WithArgs(show.Id).
+			WillReturnError(errors.New("error getting certification"))
+
+		result, err := repo.GetCertificationOfShow(show.Id)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting certification", err.Error())
+	})
+}
+
+func TestMovieCertificationRepository_UpdateCertificationsOfMovie(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieCertificationRepository(db)
+
+	movie := utils.GenerateMovie()
+	certifications := []*models.MovieCertification{utils.GenerateMovieCertification(), utils.GenerateMovieCertification()}
+	for _, c := range certifications {
+		c.MovieID = movie.ID
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_certifications" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movie_certifications" ("movie_id","country_id","rating","min_age") VALUES ($1,$2,$3,$4),($5,$6,$7,$8)`)).
+			WithArgs(
+				movie.ID, certifications[0].CountryID, certifications[0].Rating, certifications[0].MinAge,
+				movie.ID, certifications[1].CountryID, certifications[1].Rating, certifications[1].MinAge,
+			).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateCertificationsOfMovie(tx, movie.ID, certifications)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("no certifications", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_certifications" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateCertificationsOfMovie(tx, movie.ID, nil)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting certifications", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_certifications" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnError(errors.New("error deleting certifications"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateCertificationsOfMovie(tx, movie.ID, certifications)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting certifications", err.Error())
+	})
+}
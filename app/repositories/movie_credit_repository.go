@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MovieCreditRepository interface {
+	HasCreditsOfPerson(personID uuid.UUID) (bool, error)
+	UpdateCreditsOfMovie(tx *gorm.DB, movieID uuid.UUID, credits []*models.MovieCredit) error
+}
+
+func NewMovieCreditRepository(db *gorm.DB) MovieCreditRepository {
+	return &movieCreditRepository{db}
+}
+
+type movieCreditRepository struct {
+	db *gorm.DB
+}
+
+func (r *movieCreditRepository) HasCreditsOfPerson(personID uuid.UUID) (bool, error) {
+	var credit models.MovieCredit
+	if err := r.db.Where("person_id = ?", personID).Take(&credit).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *movieCreditRepository) UpdateCreditsOfMovie(tx *gorm.DB, movieID uuid.UUID, credits []*models.MovieCredit) error {
+	if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieCredit{}).Error; err != nil {
+		return err
+	}
+
+	if len(credits) == 0 {
+		return nil
+	}
+
+	return tx.Omit(clause.Associations).Create(&credits).Error
+}
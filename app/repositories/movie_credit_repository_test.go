@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestMovieCreditRepository_HasCreditsOfPerson(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieCreditRepository(db)
+
+	credit := utils.GenerateMovieCredit()
+
+	t.Run("has credits", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_credits" WHERE person_id = $1 LIMIT $2`)).
+			WithArgs(credit.PersonID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"movie_id", "person_id", "role", "character_name", "position"}).
+				AddRow(credit.MovieID, credit.PersonID, credit.Role, credit.CharacterName, credit.Position))
+
+		result, err := repo.HasCreditsOfPerson(credit.PersonID)
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("no credits", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_credits" WHERE person_id = $1 LIMIT $2`)).
+			WithArgs(credit.PersonID, 1).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.HasCreditsOfPerson(credit.PersonID)
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("error getting credits", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_credits" WHERE person_id = $1 LIMIT $2`)).
+			WithArgs(credit.PersonID, 1).
+			WillReturnError(errors.New("error getting credits"))
+
+		result, err := repo.HasCreditsOfPerson(credit.PersonID)
+
+		assert.False(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting credits", err.Error())
+	})
+}
+
+func TestMovieCreditRepository_UpdateCreditsOfMovie(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieCreditRepository(db)
+
+	movie := utils.GenerateMovie()
+	credits := []*models.MovieCredit{utils.GenerateMovieCredit(), utils.GenerateMovieCredit()}
+	for _, c := range credits {
+		c.MovieID = movie.ID
+		c.Person = utils.GeneratePerson()
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_credits" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movie_credits" ("movie_id","person_id","role","character_name","position") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+			WithArgs(
+				movie.ID, credits[0].PersonID, credits[0].Role, credits[0].CharacterName, credits[0].Position,
+				movie.ID, credits[1].PersonID, credits[1].Role, credits[1].CharacterName, credits[1].Position,
+			).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateCreditsOfMovie(tx, movie.ID, credits)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("no credits", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_credits" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateCreditsOfMovie(tx, movie.ID, nil)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting credits", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_credits" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnError(errors.New("error deleting credits"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateCreditsOfMovie(tx, movie.ID, credits)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting credits", err.Error())
+	})
+
+	t.Run("error creating credits", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_credits" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movie_credits"`)).
+			WillReturnError(errors.New("error creating credits"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateCreditsOfMovie(tx, movie.ID, credits)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating credits", err.Error())
+	})
+}
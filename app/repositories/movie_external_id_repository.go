@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type MovieExternalIDRepository interface {
+	GetExternalIDs(filter filters.MovieExternalIDFilter) ([]*models.MovieExternalID, error)
+	UpdateExternalIDsOfMovie(tx *gorm.DB, movieID uuid.UUID, externalIDs []*models.MovieExternalID) error
+}
+
+func NewMovieExternalIDRepository(db *gorm.DB) MovieExternalIDRepository {
+	return &movieExternalIDRepository{db}
+}
+
+type movieExternalIDRepository struct {
+	db *gorm.DB
+}
+
+func (r *movieExternalIDRepository) GetExternalIDs(filter filters.MovieExternalIDFilter) ([]*models.MovieExternalID, error) {
+	var externalIDs []*models.MovieExternalID
+	if err := filter.GetFilterQuery(r.db).Find(&externalIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return externalIDs, nil
+}
+
+func (r *movieExternalIDRepository) UpdateExternalIDsOfMovie(tx *gorm.DB, movieID uuid.UUID, externalIDs []*models.MovieExternalID) error {
+	if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieExternalID{}).Error; err != nil {
+		return err
+	}
+
+	if len(externalIDs) == 0 {
+		return nil
+	}
+
+	return tx.Create(&externalIDs).Error
+}
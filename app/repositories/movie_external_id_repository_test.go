@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestMovieExternalIDRepository_GetExternalIDs(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieExternalIDRepository(db)
+
+	externalID := utils.GenerateMovieExternalID()
+	filter := filters.MovieExternalIDFilter{
+		Filter:     &filters.MultiFilter{Logic: filters.And},
+		MovieID:    &filters.Condition{Operator: filters.OpNotEqual, Value: externalID.MovieID},
+		Source:     &filters.Condition{Operator: filters.OpEqual, Value: externalID.Source},
+		ExternalID: &filters.Condition{Operator: filters.OpEqual, Value: externalID.ExternalID},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_external_ids" WHERE movie_id != $1 AND source = $2 AND external_id = $3`)).
+			WithArgs(externalID.MovieID, externalID.Source, externalID.ExternalID).
+			WillReturnRows(utils.GenerateSqlMockRow(externalID))
+
+		result, err := repo.GetExternalIDs(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*models.MovieExternalID{externalID}, result)
+	})
+
+	t.Run("error getting external ids", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_external_ids" WHERE movie_id != $1 AND source = $2 AND external_id = $3`)).
+			WithArgs(externalID.MovieID, externalID.Source, externalID.ExternalID).
+			WillReturnError(errors.New("error getting external ids"))
+
+		result, err := repo.GetExternalIDs(filter)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting external ids", err.Error())
+	})
+}
+
+func TestMovieExternalIDRepository_UpdateExternalIDsOfMovie(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieExternalIDRepository(db)
+
+	movie := utils.GenerateMovie()
+	externalID := utils.GenerateMovieExternalID()
+	externalID.MovieID = movie.ID
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_external_ids" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movie_external_ids" ("movie_id","source","external_id") VALUES ($1,$2,$3)`)).
+			WithArgs(movie.ID, externalID.Source, externalID.ExternalID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateExternalIDsOfMovie(tx, movie.ID, []*models.MovieExternalID{externalID})
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting external ids", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_external_ids" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnError(errors.New("error deleting external ids"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateExternalIDsOfMovie(tx, movie.ID, []*models.MovieExternalID{externalID})
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting external ids", err.Error())
+	})
+}
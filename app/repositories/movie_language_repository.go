@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type MovieLanguageRepository interface {
+	UpdateLanguagesOfMovie(tx *gorm.DB, movieID uuid.UUID, languages []*models.MovieLanguage) error
+}
+
+func NewMovieLanguageRepository(db *gorm.DB) MovieLanguageRepository {
+	return &movieLanguageRepository{db}
+}
+
+type movieLanguageRepository struct {
+	db *gorm.DB
+}
+
+func (r *movieLanguageRepository) UpdateLanguagesOfMovie(tx *gorm.DB, movieID uuid.UUID, languages []*models.MovieLanguage) error {
+	if err := tx.Where("movie_id = ?", movieID).Delete(&models.MovieLanguage{}).Error; err != nil {
+		return err
+	}
+
+	if len(languages) == 0 {
+		return nil
+	}
+
+	return tx.Create(&languages).Error
+}
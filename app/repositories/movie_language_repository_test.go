@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestMovieLanguageRepository_UpdateLanguagesOfMovie(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewMovieLanguageRepository(db)
+
+	movie := utils.GenerateMovie()
+	languages := []*models.MovieLanguage{
+		{MovieID: movie.ID, Language: "en", Type: constants.Subtitle},
+		{MovieID: movie.ID, Language: "vi", Type: constants.Dub},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_languages" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movie_languages" ("movie_id","language","type") VALUES ($1,$2,$3),($4,$5,$6)`)).
+			WithArgs(movie.ID, "en", constants.Subtitle, movie.ID, "vi", constants.Dub).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateLanguagesOfMovie(tx, movie.ID, languages)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("no languages", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_languages" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateLanguagesOfMovie(tx, movie.ID, nil)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error creating languages", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "movie_languages" WHERE movie_id = $1`)).
+			WithArgs(movie.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movie_languages" ("movie_id","language","type") VALUES ($1,$2,$3),($4,$5,$6)`)).
+			WithArgs(movie.ID, "en", constants.Subtitle, movie.ID, "vi", constants.Dub).
+			WillReturnError(errors.New("error creating languages"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateLanguagesOfMovie(tx, movie.ID, languages)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating languages", err.Error())
+	})
+}
@@ -11,9 +11,9 @@ import (
 )
 
 type MovieRepository interface {
-	GetMovie(filter filters.MovieFilter, includeGenres bool) (*models.Movie, error)
-	GetMovies(filter filters.MovieFilter) ([]*models.Movie, error)
-	GetMoviesWithGenres(filter filters.MovieFilter) ([]*models.Movie, error)
+	GetMovie(filter filters.MovieFilter, includeGenres, includeDetails bool) (*models.Movie, error)
+	GetMovies(filter filters.MovieFilter, includeDetails bool) ([]*models.Movie, error)
+	GetMoviesWithGenres(filter filters.MovieFilter, includeDetails bool) ([]*models.Movie, error)
 	GetNumbersOfMovie(filter filters.MovieFilter) (int, error)
 	CreateMovie(tx *gorm.DB, movie *models.Movie) error
 	UpdateMovie(tx *gorm.DB, movie *models.Movie) error
@@ -28,11 +28,14 @@ func NewMovieRepository(db *gorm.DB) MovieRepository {
 	return &movieRepository{db: db}
 }
 
-func (r *movieRepository) GetMovie(filter filters.MovieFilter, includeGenres bool) (*models.Movie, error) {
+func (r *movieRepository) GetMovie(filter filters.MovieFilter, includeGenres, includeDetails bool) (*models.Movie, error) {
 	query := filter.GetFilterQuery(r.db)
 	if includeGenres {
 		query = query.Preload("Genres")
 	}
+	if includeDetails {
+		query = preloadMovieDetails(query)
+	}
 
 	var m models.Movie
 	if err := query.First(&m).Error; err != nil {
@@ -46,18 +49,28 @@ func (r *movieRepository) GetMovie(filter filters.MovieFilter, includeGenres boo
 	return &m, nil
 }
 
-func (r *movieRepository) GetMovies(filter filters.MovieFilter) ([]*models.Movie, error) {
+func (r *movieRepository) GetMovies(filter filters.MovieFilter, includeDetails bool) ([]*models.Movie, error) {
+	query := filter.GetFilterQuery(r.db)
+	if includeDetails {
+		query = preloadMovieDetails(query)
+	}
+
 	var movies []*models.Movie
-	if err := filter.GetFilterQuery(r.db).Find(&movies).Error; err != nil {
+	if err := query.Find(&movies).Error; err != nil {
 		return nil, err
 	}
 
 	return movies, nil
 }
 
-func (r *movieRepository) GetMoviesWithGenres(filter filters.MovieFilter) ([]*models.Movie, error) {
+func (r *movieRepository) GetMoviesWithGenres(filter filters.MovieFilter, includeDetails bool) ([]*models.Movie, error) {
+	query := filter.GetFilterQuery(r.db)
+	if includeDetails {
+		query = preloadMovieDetails(query)
+	}
+
 	var movies []*models.Movie
-	if err := filter.GetFilterQuery(r.db).Find(&movies).Error; err != nil {
+	if err := query.Find(&movies).Error; err != nil {
 		return nil, err
 	}
 
@@ -121,3 +134,9 @@ func (r *movieRepository) UpdateMovie(tx *gorm.DB, movie *models.Movie) error {
 func (r *movieRepository) DeleteMovie(tx *gorm.DB, movie *models.Movie, deletedBy uuid.UUID) error {
 	return tx.Model(movie).Updates(map[string]any{"is_deleted": true, "updated_at": time.Now().UTC(), "last_updated_by": deletedBy}).Error
 }
+
+func preloadMovieDetails(query *gorm.DB) *gorm.DB {
+	return query.Preload("Credits", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Credits.Person").Preload("Certifications").Preload("Languages").Preload("ExternalIDs")
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"errors"
 	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"regexp"
 	"testing"
@@ -44,7 +45,7 @@ func TestMovieRepository_GetMovie(t *testing.T) {
 			WithArgs(genres[0].ID, genres[1].ID, genres[2].ID).
 			WillReturnRows(utils.GenerateSqlMockRows(genres))
 
-		result, err := repo.GetMovie(filter, true)
+		result, err := repo.GetMovie(filter, true, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -60,7 +61,7 @@ func TestMovieRepository_GetMovie(t *testing.T) {
 			WithArgs(movie.ID, 1).
 			WillReturnRows(utils.GenerateSqlMockRow(movie))
 
-		result, err := repo.GetMovie(filter, false)
+		result, err := repo.GetMovie(filter, false, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -72,7 +73,7 @@ func TestMovieRepository_GetMovie(t *testing.T) {
 			WithArgs(movie.ID, 1).
 			WillReturnRows(sqlmock.NewRows(nil))
 
-		result, err := repo.GetMovie(filter, false)
+		result, err := repo.GetMovie(filter, false, false)
 
 		assert.Nil(t, result)
 		assert.Nil(t, err)
@@ -83,7 +84,7 @@ func TestMovieRepository_GetMovie(t *testing.T) {
 			WithArgs(movie.ID, 1).
 			WillReturnError(errors.New("error getting movie"))
 
-		result, err := repo.GetMovie(filter, false)
+		result, err := repo.GetMovie(filter, false, false)
 
 		assert.Nil(t, result)
 		assert.Error(t, err)
@@ -111,7 +112,7 @@ func TestMovieRepository_GetMovies(t *testing.T) {
 			WithArgs(limit, offset).
 			WillReturnRows(utils.GenerateSqlMockRows(movies))
 
-		result, err := repo.GetMovies(filter)
+		result, err := repo.GetMovies(filter, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -120,12 +121,75 @@ func TestMovieRepository_GetMovies(t *testing.T) {
 		}
 	})
 
+	t.Run("filter by metadata", func(t *testing.T) {
+		personID := uuid.New()
+		countryID := uuid.New()
+		filter := filters.MovieFilter{
+			Filter: &filters.MultiFilter{Logic: filters.And, Limit: &limit, Offset: &offset},
+			Credit: &filters.MovieCreditCondition{
+				PersonID: &filters.Condition{Operator: filters.OpEqual, Value: personID},
+				Role:     &filters.Condition{Operator: filters.OpEqual, Value: constants.Director},
+			},
+			Rating: &filters.MovieCertificationCondition{
+				CountryID: &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+				MinAge:    &filters.Condition{Operator: filters.OpLessEqual, Value: 13},
+			},
+			Subtitle: &filters.Condition{Operator: filters.OpEqual, Value: "vi"},
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movies" WHERE id IN (SELECT movie_id FROM "movie_credits" WHERE person_id = $1 AND role = $2) AND id IN (SELECT movie_id FROM "movie_certifications" WHERE country_id = $3 AND min_age <= $4) AND id IN (SELECT movie_id FROM "movie_languages" WHERE language = $5 AND type = $6) LIMIT $7 OFFSET $8`)).
+			WithArgs(personID, constants.Director, countryID, 13, "vi", constants.Subtitle, limit, offset).
+			WillReturnRows(utils.GenerateSqlMockRows(movies))
+
+		result, err := repo.GetMovies(filter, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, movies, result)
+	})
+
+	t.Run("include details", func(t *testing.T) {
+		movie := movies[0]
+		credit := utils.GenerateMovieCredit()
+		credit.MovieID = movie.ID
+		person := utils.GeneratePerson()
+		person.ID = credit.PersonID
+		certification := utils.GenerateMovieCertification()
+		certification.MovieID = movie.ID
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movies" LIMIT $1 OFFSET $2`)).
+			WithArgs(limit, offset).
+			WillReturnRows(utils.GenerateSqlMockRow(movie))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_certifications" WHERE "movie_certifications"."movie_id" = $1`)).
+			WithArgs(movie.ID).
+			WillReturnRows(utils.GenerateSqlMockRow(certification))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_credits" WHERE "movie_credits"."movie_id" = $1 ORDER BY position`)).
+			WithArgs(movie.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"movie_id", "person_id", "role", "character_name", "position"}).
+				AddRow(credit.MovieID, credit.PersonID, credit.Role, credit.CharacterName, credit.Position))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE "people"."id" = $1`)).
+			WithArgs(person.ID).
+			WillReturnRows(utils.GenerateSqlMockRow(person))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_external_ids" WHERE "movie_external_ids"."movie_id" = $1`)).
+			WithArgs(movie.ID).
+			WillReturnRows(sqlmock.NewRows(nil))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movie_languages" WHERE "movie_languages"."movie_id" = $1`)).
+			WithArgs(movie.ID).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetMovies(filter, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, person, result[0].Credits[0].Person)
+		assert.Equal(t, certification, result[0].Certifications[0])
+	})
+
 	t.Run("error getting movies", func(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "movies" LIMIT $1 OFFSET $2`)).
 			WithArgs(limit, offset).
 			WillReturnError(errors.New("error getting movies"))
 
-		result, err := repo.GetMovies(filter)
+		result, err := repo.GetMovies(filter, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -162,7 +226,7 @@ func TestMovieRepository_GetMoviesWithGenres(t *testing.T) {
 			WithArgs(movies[0].ID, movies[1].ID).
 			WillReturnRows(rows)
 
-		result, err := repo.GetMoviesWithGenres(filter)
+		result, err := repo.GetMoviesWithGenres(filter, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -180,7 +244,7 @@ func TestMovieRepository_GetMoviesWithGenres(t *testing.T) {
 			WithArgs(limit, offset).
 			WillReturnError(errors.New("error getting movies"))
 
-		result, err := repo.GetMoviesWithGenres(filter)
+		result, err := repo.GetMoviesWithGenres(filter, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -192,7 +256,7 @@ func TestMovieRepository_GetMoviesWithGenres(t *testing.T) {
 			WithArgs(limit, offset).
 			WillReturnRows(sqlmock.NewRows(nil))
 
-		result, err := repo.GetMoviesWithGenres(filter)
+		result, err := repo.GetMoviesWithGenres(filter, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -207,7 +271,7 @@ func TestMovieRepository_GetMoviesWithGenres(t *testing.T) {
 			WithArgs(movies[0].ID, movies[1].ID).
 			WillReturnError(errors.New("error getting genres"))
 
-		result, err := repo.GetMoviesWithGenres(filter)
+		result, err := repo.GetMoviesWithGenres(filter, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -262,8 +326,8 @@ func TestMovieRepository_CreateMovie(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movies" ("id","title","description","release_date","duration_minutes","language","rating","is_active","created_at","updated_at","is_deleted","created_by","last_updated_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`)).
-			WithArgs(movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.IsActive, movie.CreatedAt, movie.UpdatedAt, movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movies" ("id","title","description","release_date","duration_minutes","language","rating","trailer_url","is_active","created_at","updated_at","is_deleted","created_by","last_updated_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`)).
+			WithArgs(movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.TrailerUrl, movie.IsActive, movie.CreatedAt, movie.UpdatedAt, movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -276,8 +340,8 @@ func TestMovieRepository_CreateMovie(t *testing.T) {
 
 	t.Run("error creating movie", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movies" ("id","title","description","release_date","duration_minutes","language","rating","is_active","created_at","updated_at","is_deleted","created_by","last_updated_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`)).
-			WithArgs(movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.IsActive, movie.CreatedAt, movie.UpdatedAt, movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "movies" ("id","title","description","release_date","duration_minutes","language","rating","trailer_url","is_active","created_at","updated_at","is_deleted","created_by","last_updated_by") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`)).
+			WithArgs(movie.ID, movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.TrailerUrl, movie.IsActive, movie.CreatedAt, movie.UpdatedAt, movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy).
 			WillReturnError(errors.New("error creating movie"))
 		mock.ExpectRollback()
 
@@ -302,8 +366,8 @@ func TestMovieRepository_UpdateMovie(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "movies" SET "title"=$1,"description"=$2,"release_date"=$3,"duration_minutes"=$4,"language"=$5,"rating"=$6,"is_active"=$7,"created_at"=$8,"updated_at"=$9,"is_deleted"=$10,"created_by"=$11,"last_updated_by"=$12 WHERE "id" = $13`)).
-			WithArgs(movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.IsActive, movie.CreatedAt, sqlmock.AnyArg(), movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy, movie.ID).
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "movies" SET "title"=$1,"description"=$2,"release_date"=$3,"duration_minutes"=$4,"language"=$5,"rating"=$6,"trailer_url"=$7,"is_active"=$8,"created_at"=$9,"updated_at"=$10,"is_deleted"=$11,"created_by"=$12,"last_updated_by"=$13 WHERE "id" = $14`)).
+			WithArgs(movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.TrailerUrl, movie.IsActive, movie.CreatedAt, sqlmock.AnyArg(), movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy, movie.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -316,8 +380,8 @@ func TestMovieRepository_UpdateMovie(t *testing.T) {
 
 	t.Run("error updating movie", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "movies" SET "title"=$1,"description"=$2,"release_date"=$3,"duration_minutes"=$4,"language"=$5,"rating"=$6,"is_active"=$7,"created_at"=$8,"updated_at"=$9,"is_deleted"=$10,"created_by"=$11,"last_updated_by"=$12 WHERE "id" = $13`)).
-			WithArgs(movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.IsActive, movie.CreatedAt, sqlmock.AnyArg(), movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy, movie.ID).
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "movies" SET "title"=$1,"description"=$2,"release_date"=$3,"duration_minutes"=$4,"language"=$5,"rating"=$6,"trailer_url"=$7,"is_active"=$8,"created_at"=$9,"updated_at"=$10,"is_deleted"=$11,"created_by"=$12,"last_updated_by"=$13 WHERE "id" = $14`)).
+			WithArgs(movie.Title, movie.Description, movie.ReleaseDate, movie.DurationMinutes, movie.Language, movie.Rating, movie.TrailerUrl, movie.IsActive, movie.CreatedAt, sqlmock.AnyArg(), movie.IsDeleted, movie.CreatedBy, movie.LastUpdatedBy, movie.ID).
 			WillReturnError(errors.New("error updating movie"))
 		mock.ExpectRollback()
 
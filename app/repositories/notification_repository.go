@@ -2,38 +2,91 @@ package repositories
 
 import (
 	"encoding/json"
-	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/config"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
 )
 
+// NotificationRepository stores events in the outbox within the caller's transaction, the outbox relay publishes them to Kafka.
 type NotificationRepository interface {
-	SendUserRegistrationEvent(event payloads.UserRegistrationEvent) error
+	SendUserRegistrationEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.UserRegistrationEvent) error
+	SendPasswordResetRequestedEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.PasswordResetRequestedEvent) error
+	SendMovieEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.MovieEvent) error
+	SendGenreEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.GenreEvent) error
+	SendTheaterEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.TheaterEvent) error
+	SendShowEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.ShowEvent) error
 }
 
-func NewNotificationRepository(kafkaProducer sarama.SyncProducer) NotificationRepository {
+func NewNotificationRepository(outboxRepo OutboxRepository) NotificationRepository {
 	return &notificationRepository{
-		kafkaProducer: kafkaProducer,
+		outboxRepo: outboxRepo,
 	}
 }
 
 type notificationRepository struct {
-	kafkaProducer sarama.SyncProducer
+	outboxRepo OutboxRepository
 }
 
-func (r *notificationRepository) SendUserRegistrationEvent(event payloads.UserRegistrationEvent) error {
-	messageBytes, err := json.Marshal(event)
+func (r *notificationRepository) SendUserRegistrationEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.UserRegistrationEvent) error {
+	return r.sendEvent(tx, config.AppEnv.KafkaUserRegistrationTopic, requestID, event)
+}
+
+func (r *notificationRepository) SendPasswordResetRequestedEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.PasswordResetRequestedEvent) error {
+	return r.sendEvent(tx, config.AppEnv.KafkaPasswordResetTopic, requestID, event)
+}
+
+func (r *notificationRepository) SendMovieEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.MovieEvent) error {
+	return r.sendEvent(tx, config.AppEnv.KafkaMovieTopic, requestID, event)
+}
+
+func (r *notificationRepository) SendGenreEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.GenreEvent) error {
+	return r.sendEvent(tx, config.AppEnv.KafkaGenreTopic, requestID, event)
+}
+
+func (r *notificationRepository) SendTheaterEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.TheaterEvent) error {
+	return r.sendEvent(tx, config.AppEnv.KafkaTheaterTopic, requestID, event)
+}
+
+func (r *notificationRepository) SendShowEvent(tx *gorm.DB, requestID uuid.UUID, event payloads.ShowEvent) error {
+	return r.sendEvent(tx, config.AppEnv.KafkaShowTopic, requestID, event)
+}
+
+func (r *notificationRepository) sendEvent(tx *gorm.DB, topic string, requestID uuid.UUID, event payloads.Event) error {
+	envelope := payloads.NewEventEnvelope(config.AppEnv.EventProducer, requestID, event)
+	messageBytes, err := json.Marshal(envelope)
 	if err != nil {
 		return err
 	}
 
-	_, _, err = r.kafkaProducer.SendMessage(&sarama.ProducerMessage{
-		Topic: config.AppEnv.KafkaUserRegistrationTopic,
-		Value: sarama.ByteEncoder(messageBytes),
-	})
+	headers := map[string]string{
+		constants.EventIDHeader:            envelope.EventID.String(),
+		constants.EventTypeHeader:          string(envelope.EventType),
+		constants.EventSchemaVersionHeader: strconv.Itoa(envelope.SchemaVersion),
+		constants.EventProducerHeader:      envelope.Producer,
+		constants.ContentType:              constants.ApplicationJson,
+	}
+	if envelope.RequestID != nil {
+		headers[constants.RequestIDHeader] = envelope.RequestID.String()
+	}
+	headerBytes, err := json.Marshal(headers)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	now := time.Now().UTC()
+	return r.outboxRepo.CreateMessage(tx, &models.OutboxMessage{
+		ID:            envelope.EventID,
+		Topic:         topic,
+		Key:           event.GetKey(),
+		Headers:       string(headerBytes),
+		Payload:       string(messageBytes),
+		Status:        constants.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
 }
@@ -0,0 +1,334 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/schemas"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/config"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestNotificationRepository_SendUserRegistrationEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.AppEnv.EventProducer = "reservation-service"
+	config.AppEnv.KafkaUserRegistrationTopic = "users.user_registrations"
+
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	repo := NewNotificationRepository(outboxRepo)
+
+	requestID := uuid.New()
+	event := payloads.UserRegistrationEvent{
+		UserID:            uuid.New(),
+		Email:             "example@example.com",
+		FirstName:         "First",
+		LastName:          "Last",
+		VerificationToken: "token value",
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, message *models.OutboxMessage) error {
+				assert.Equal(t, config.AppEnv.KafkaUserRegistrationTopic, message.Topic)
+				assert.Equal(t, event.UserID.String(), message.Key)
+				assert.Equal(t, constants.OutboxPending, message.Status)
+				assertEventEnvelope(t, message, constants.UserRegistered, &requestID)
+				return nil
+			},
+		).Times(1)
+
+		err := repo.SendUserRegistrationEvent(nil, requestID, event)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("without request id", func(t *testing.T) {
+		outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, message *models.OutboxMessage) error {
+				assertEventEnvelope(t, message, constants.UserRegistered, nil)
+				return nil
+			},
+		).Times(1)
+
+		err := repo.SendUserRegistrationEvent(nil, uuid.Nil, event)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).Return(errors.New("db error")).Times(1)
+
+		err := repo.SendUserRegistrationEvent(nil, requestID, event)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestNotificationRepository_SendPasswordResetRequestedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.AppEnv.EventProducer = "reservation-service"
+	config.AppEnv.KafkaPasswordResetTopic = "users.password_resets"
+
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	repo := NewNotificationRepository(outboxRepo)
+
+	requestID := uuid.New()
+	now := time.Now().UTC()
+	event := payloads.PasswordResetRequestedEvent{
+		UserID:     uuid.New(),
+		Email:      "example@example.com",
+		FirstName:  "First",
+		LastName:   "Last",
+		ResetToken: "token value",
+		ExpiresAt:  now.Add(5 * time.Minute),
+		CreatedAt:  now,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, message *models.OutboxMessage) error {
+				assert.Equal(t, config.AppEnv.KafkaPasswordResetTopic, message.Topic)
+				assert.Equal(t, event.UserID.String(), message.Key)
+				assertEventEnvelope(t, message, constants.PasswordResetRequested, &requestID)
+				return nil
+			},
+		).Times(1)
+
+		err := repo.SendPasswordResetRequestedEvent(nil, requestID, event)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).Return(errors.New("db error")).Times(1)
+
+		err := repo.SendPasswordResetRequestedEvent(nil, requestID, event)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestNotificationRepository_SendMovieEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.AppEnv.EventProducer = "reservation-service"
+	config.AppEnv.KafkaMovieTopic = "catalog.movies"
+
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	repo := NewNotificationRepository(outboxRepo)
+
+	requestID := uuid.New()
+	before := utils.GenerateMovie()
+	after := *before
+	after.Title = "updated title"
+	after.Genres = []models.Genre{*utils.GenerateGenre()}
+
+	tests := []struct {
+		name  string
+		event payloads.MovieEvent
+	}{
+		{"created", payloads.NewCatalogEvent(constants.MovieCreated, before.ID, nil, before)},
+		{"updated", payloads.NewCatalogEvent(constants.MovieUpdated, before.ID, before, &after)},
+		{"deleted", payloads.NewCatalogEvent[models.Movie](constants.MovieDeleted, before.ID, before, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(tx *gorm.DB, message *models.OutboxMessage) error {
+					assert.Equal(t, config.AppEnv.KafkaMovieTopic, message.Topic)
+					assert.Equal(t, before.ID.String(), message.Key)
+					assertEventEnvelope(t, message, tt.event.Type, &requestID)
+					return nil
+				},
+			).Times(1)
+
+			err := repo.SendMovieEvent(nil, requestID, tt.event)
+
+			assert.Nil(t, err)
+		})
+	}
+
+	t.Run("db error", func(t *testing.T) {
+		outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).Return(errors.New("db error")).Times(1)
+
+		err := repo.SendMovieEvent(nil, requestID, tests[0].event)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestNotificationRepository_SendGenreEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.AppEnv.EventProducer = "reservation-service"
+	config.AppEnv.KafkaGenreTopic = "catalog.genres"
+
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	repo := NewNotificationRepository(outboxRepo)
+
+	requestID := uuid.New()
+	before := utils.GenerateGenre()
+	after := *before
+	after.Name = "updated name"
+
+	tests := []struct {
+		name  string
+		event payloads.GenreEvent
+	}{
+		{"created", payloads.NewCatalogEvent(constants.GenreCreated, before.ID, nil, before)},
+		{"updated", payloads.NewCatalogEvent(constants.GenreUpdated, before.ID, before, &after)},
+		{"deleted", payloads.NewCatalogEvent[models.Genre](constants.GenreDeleted, before.ID, before, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(tx *gorm.DB, message *models.OutboxMessage) error {
+					assert.Equal(t, config.AppEnv.KafkaGenreTopic, message.Topic)
+					assert.Equal(t, before.ID.String(), message.Key)
+					assertEventEnvelope(t, message, tt.event.Type, &requestID)
+					return nil
+				},
+			).Times(1)
+
+			err := repo.SendGenreEvent(nil, requestID, tt.event)
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestNotificationRepository_SendTheaterEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.AppEnv.EventProducer = "reservation-service"
+	config.AppEnv.KafkaTheaterTopic = "catalog.theaters"
+
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	repo := NewNotificationRepository(outboxRepo)
+
+	requestID := uuid.New()
+	before := utils.GenerateTheater()
+	after := *before
+	after.Location = utils.GenerateTheaterLocation()
+	after.Location.TheaterID = &before.ID
+
+	tests := []struct {
+		name  string
+		event payloads.TheaterEvent
+	}{
+		{"created", payloads.NewCatalogEvent(constants.TheaterCreated, before.ID, nil, before)},
+		{"updated", payloads.NewCatalogEvent(constants.TheaterUpdated, before.ID, before, &after)},
+		{"deleted", payloads.NewCatalogEvent[models.Theater](constants.TheaterDeleted, before.ID, before, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(tx *gorm.DB, message *models.OutboxMessage) error {
+					assert.Equal(t, config.AppEnv.KafkaTheaterTopic, message.Topic)
+					assert.Equal(t, before.ID.String(), message.Key)
+					assertEventEnvelope(t, message, tt.event.Type, &requestID)
+					return nil
+				},
+			).Times(1)
+
+			err := repo.SendTheaterEvent(nil, requestID, tt.event)
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestNotificationRepository_SendShowEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.AppEnv.EventProducer = "reservation-service"
+	config.AppEnv.KafkaShowTopic = "catalog.shows"
+
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	repo := NewNotificationRepository(outboxRepo)
+
+	requestID := uuid.New()
+	show := utils.GenerateShow()
+	show.TimeZone = "UTC"
+	after := *show
+	after.Status = constants.Completed
+
+	tests := []struct {
+		name      string
+		event     payloads.ShowEvent
+		requestID uuid.UUID
+	}{
+		{"created", payloads.NewCatalogEvent(constants.ShowCreated, show.Id, nil, show), requestID},
+		{"updated", payloads.NewCatalogEvent(constants.ShowUpdated, show.Id, show, &after), uuid.Nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outboxRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(tx *gorm.DB, message *models.OutboxMessage) error {
+					assert.Equal(t, config.AppEnv.KafkaShowTopic, message.Topic)
+					assert.Equal(t, show.Id.String(), message.Key)
+					var expectedRequestID *uuid.UUID
+					if tt.requestID != uuid.Nil {
+						expectedRequestID = &tt.requestID
+					}
+					assertEventEnvelope(t, message, tt.event.Type, expectedRequestID)
+					return nil
+				},
+			).Times(1)
+
+			err := repo.SendShowEvent(nil, tt.requestID, tt.event)
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func assertEventEnvelope(t *testing.T, message *models.OutboxMessage, eventType constants.EventType, requestID *uuid.UUID) {
+	schema, err := schemas.GetEventSchema(eventType, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, schemas.Validate(schema, []byte(message.Payload)))
+
+	var envelope struct {
+		EventID   uuid.UUID  `json:"event_id"`
+		Producer  string     `json:"producer"`
+		RequestID *uuid.UUID `json:"request_id"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(message.Payload), &envelope))
+	assert.Equal(t, message.ID, envelope.EventID)
+	assert.Equal(t, config.AppEnv.EventProducer, envelope.Producer)
+	assert.Equal(t, requestID, envelope.RequestID)
+
+	var headers map[string]string
+	assert.Nil(t, json.Unmarshal([]byte(message.Headers), &headers))
+	assert.Equal(t, envelope.EventID.String(), headers[constants.EventIDHeader])
+	assert.Equal(t, string(eventType), headers[constants.EventTypeHeader])
+	assert.Equal(t, "1", headers[constants.EventSchemaVersionHeader])
+	assert.Equal(t, config.AppEnv.EventProducer, headers[constants.EventProducerHeader])
+	assert.Equal(t, constants.ApplicationJson, headers[constants.ContentType])
+	if requestID != nil {
+		assert.Equal(t, requestID.String(), headers[constants.RequestIDHeader])
+	} else {
+		assert.NotContains(t, headers, constants.RequestIDHeader)
+	}
+}
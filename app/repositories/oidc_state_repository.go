@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+)
+
+type OidcStateRepository interface {
+	CreateState(state string, expiration time.Duration, oidcState *models.OidcState) error
+	ConsumeState(state string) (*models.OidcState, error)
+}
+
+type oidcStateRepository struct {
+	ctx context.Context
+	rdb *redis.Client
+}
+
+func NewOidcStateRepository(rdb *redis.Client) OidcStateRepository {
+	return &oidcStateRepository{ctx: context.Background(), rdb: rdb}
+}
+
+func (r *oidcStateRepository) CreateState(state string, expiration time.Duration, oidcState *models.OidcState) error {
+	stateData, err := json.Marshal(oidcState)
+	if err != nil {
+		return err
+	}
+
+	return r.rdb.Set(r.ctx, r.getStateKey(state), stateData, expiration).Err()
+}
+
+func (r *oidcStateRepository) ConsumeState(state string) (*models.OidcState, error) {
+	stateString, err := r.rdb.GetDel(r.ctx, r.getStateKey(state)).Result()
+	if err != nil {
+		if errors.IsRedisKeyNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var s models.OidcState
+	if err := json.Unmarshal([]byte(stateString), &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (r *oidcStateRepository) getStateKey(state string) string {
+	return fmt.Sprintf("%s:%s", constants.OidcState, state)
+}
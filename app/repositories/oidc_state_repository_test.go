@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+)
+
+func TestOidcStateRepository_CreateState(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	repo := NewOidcStateRepository(client)
+
+	state := "state"
+	oidcState := &models.OidcState{Provider: "google", Nonce: "nonce", CodeVerifier: "verifier"}
+	key := fmt.Sprintf("%s:%s", constants.OidcState, state)
+	expiration := 10 * time.Minute
+
+	t.Run("success", func(t *testing.T) {
+		stateJSON, _ := json.Marshal(oidcState)
+		mock.ExpectSet(key, stateJSON, expiration).SetVal("OK")
+
+		err := repo.CreateState(state, expiration, oidcState)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		stateJSON, _ := json.Marshal(oidcState)
+		mock.ExpectSet(key, stateJSON, expiration).SetErr(errors.New("db error"))
+
+		err := repo.CreateState(state, expiration, oidcState)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestOidcStateRepository_ConsumeState(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	repo := NewOidcStateRepository(client)
+
+	state := "state"
+	oidcState := &models.OidcState{Provider: "google", Nonce: "nonce", CodeVerifier: "verifier"}
+	key := fmt.Sprintf("%s:%s", constants.OidcState, state)
+
+	t.Run("success", func(t *testing.T) {
+		stateJSON, _ := json.Marshal(oidcState)
+		mock.ExpectGetDel(key).SetVal(string(stateJSON))
+
+		result, err := repo.ConsumeState(state)
+
+		assert.Nil(t, err)
+		assert.Equal(t, oidcState, result)
+	})
+
+	t.Run("state not found", func(t *testing.T) {
+		mock.ExpectGetDel(key).RedisNil()
+
+		result, err := repo.ConsumeState(state)
+
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectGetDel(key).SetErr(errors.New("db error"))
+
+		result, err := repo.ConsumeState(state)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
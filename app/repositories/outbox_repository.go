@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type OutboxRepository interface {
+	CreateMessage(tx *gorm.DB, message *models.OutboxMessage) error
+	TryLockRelay(tx *gorm.DB) (bool, error)
+	GetPendingMessages(tx *gorm.DB, limit int) ([]*models.OutboxMessage, error)
+	UpdateMessage(tx *gorm.DB, message *models.OutboxMessage) error
+	DeleteProcessedMessages(tx *gorm.DB, before time.Time) (int64, error)
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func (r *outboxRepository) CreateMessage(tx *gorm.DB, message *models.OutboxMessage) error {
+	return tx.Create(message).Error
+}
+
+// TryLockRelay takes the relay advisory lock for the rest of the transaction and reports false when
+// another relay already holds it.
+func (r *outboxRepository) TryLockRelay(tx *gorm.DB) (bool, error) {
+	var locked bool
+	if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", constants.OutboxRelayLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// GetPendingMessages locks the returned rows so concurrent relays never publish the same message twice.
+// Messages whose key has an earlier message waiting for a retry are held back to keep per-key ordering.
+// SKIP LOCKED would let a relay pass over an earlier message locked by another relay and publish a later
+// one of the same key first, so callers must hold the lock from TryLockRelay.
+func (r *outboxRepository) GetPendingMessages(tx *gorm.DB, limit int) ([]*models.OutboxMessage, error) {
+	var messages []*models.OutboxMessage
+	now := time.Now().UTC()
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", constants.OutboxPending, now).
+		Where(`message_key = '' OR NOT EXISTS (
+			SELECT 1 FROM outbox earlier
+			WHERE earlier.message_key = outbox.message_key AND earlier.status = ? AND earlier.created_at < outbox.created_at AND earlier.next_attempt_at > ?
+		)`, constants.OutboxPending, now).
+		Order("created_at").
+		Limit(limit).
+		Find(&messages).Error; err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *outboxRepository) UpdateMessage(tx *gorm.DB, message *models.OutboxMessage) error {
+	return tx.Model(message).Updates(map[string]any{
+		"status":          message.Status,
+		"attempts":        message.Attempts,
+		"last_error":      message.LastError,
+		"next_attempt_at": message.NextAttemptAt,
+		"sent_at":         message.SentAt,
+		"payload":         message.Payload,
+	}).Error
+}
+
+// DeleteProcessedMessages removes sent and dead-lettered messages created before the given time.
+func (r *outboxRepository) DeleteProcessedMessages(tx *gorm.DB, before time.Time) (int64, error) {
+	result := tx.Where("status IN ? AND created_at < ?", []constants.OutboxMessageStatus{constants.OutboxSent, constants.OutboxDeadLetter}, before).
+		Delete(&models.OutboxMessage{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestOutboxRepository_CreateMessage(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewOutboxRepository(db)
+
+	message := utils.GenerateOutboxMessage()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "outbox" ("id","topic","message_key","headers","payload","status","attempts","last_error","next_attempt_at","created_at","sent_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`)).
+			WithArgs(message.ID, message.Topic, message.Key, message.Headers, message.Payload, message.Status, message.Attempts, message.LastError, message.NextAttemptAt, message.CreatedAt, message.SentAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateMessage(tx, message)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "outbox" ("id","topic","message_key","headers","payload","status","attempts","last_error","next_attempt_at","created_at","sent_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`)).
+			WithArgs(message.ID, message.Topic, message.Key, message.Headers, message.Payload, message.Status, message.Attempts, message.LastError, message.NextAttemptAt, message.CreatedAt, message.SentAt).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateMessage(tx, message)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestOutboxRepository_TryLockRelay(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewOutboxRepository(db)
+
+	t.Run("lock acquired", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+			WithArgs(constants.OutboxRelayLockKey).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		locked, err := repo.TryLockRelay(tx)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.True(t, locked)
+	})
+
+	t.Run("lock held by another relay", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+			WithArgs(constants.OutboxRelayLockKey).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		locked, err := repo.TryLockRelay(tx)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.False(t, locked)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock($1)`)).
+			WithArgs(constants.OutboxRelayLockKey).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		locked, err := repo.TryLockRelay(tx)
+		tx.Rollback()
+
+		assert.False(t, locked)
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestOutboxRepository_GetPendingMessages(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewOutboxRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		messages := utils.GenerateOutboxMessages(3)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "outbox" WHERE (status = $1 AND next_attempt_at <= $2) AND (message_key = '' OR NOT EXISTS (
+			SELECT 1 FROM outbox earlier
+			WHERE earlier.message_key = outbox.message_key AND earlier.status = $3 AND earlier.created_at < outbox.created_at AND earlier.next_attempt_at > $4
+		)) ORDER BY created_at LIMIT $5 FOR UPDATE SKIP LOCKED`)).
+			WithArgs(constants.OutboxPending, sqlmock.AnyArg(), constants.OutboxPending, sqlmock.AnyArg(), 10).
+			WillReturnRows(utils.GenerateSqlMockRows(messages))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.GetPendingMessages(tx, 10)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Equal(t, messages, result)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "outbox" WHERE (status = $1 AND next_attempt_at <= $2) AND (message_key = '' OR NOT EXISTS (
+			SELECT 1 FROM outbox earlier
+			WHERE earlier.message_key = outbox.message_key AND earlier.status = $3 AND earlier.created_at < outbox.created_at AND earlier.next_attempt_at > $4
+		)) ORDER BY created_at LIMIT $5 FOR UPDATE SKIP LOCKED`)).
+			WithArgs(constants.OutboxPending, sqlmock.AnyArg(), constants.OutboxPending, sqlmock.AnyArg(), 10).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		result, err := repo.GetPendingMessages(tx, 10)
+		tx.Rollback()
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestOutboxRepository_UpdateMessage(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewOutboxRepository(db)
+
+	message := utils.GenerateOutboxMessage()
+	message.Status = constants.OutboxSent
+	message.Attempts = 1
+	message.SentAt = utils.GetPointerOf(time.Now().UTC())
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "outbox" SET "attempts"=$1,"last_error"=$2,"next_attempt_at"=$3,"payload"=$4,"sent_at"=$5,"status"=$6 WHERE "id" = $7`)).
+			WithArgs(message.Attempts, message.LastError, message.NextAttemptAt, message.Payload, message.SentAt, message.Status, message.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateMessage(tx, message)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "outbox" SET "attempts"=$1,"last_error"=$2,"next_attempt_at"=$3,"payload"=$4,"sent_at"=$5,"status"=$6 WHERE "id" = $7`)).
+			WithArgs(message.Attempts, message.LastError, message.NextAttemptAt, message.Payload, message.SentAt, message.Status, message.ID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateMessage(tx, message)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestOutboxRepository_DeleteProcessedMessages(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewOutboxRepository(db)
+
+	before := time.Now().UTC().Add(-24 * time.Hour)
+	expectedQuery := regexp.QuoteMeta(`DELETE FROM "outbox" WHERE status IN ($1,$2) AND created_at < $3`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).
+			WithArgs(constants.OutboxSent, constants.OutboxDeadLetter, before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		count, err := repo.DeleteProcessedMessages(tx, before)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).
+			WithArgs(constants.OutboxSent, constants.OutboxDeadLetter, before).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		count, err := repo.DeleteProcessedMessages(tx, before)
+		tx.Rollback()
+
+		assert.Equal(t, int64(0), count)
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
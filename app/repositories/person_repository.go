@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type PersonRepository interface {
+	GetPerson(filter filters.PersonFilter) (*models.Person, error)
+	GetPeople(filter filters.PersonFilter) ([]*models.Person, error)
+	SearchPeople(keyword string, limit, offset int) ([]*models.Person, error)
+	CreatePerson(tx *gorm.DB, person *models.Person) error
+	UpdatePerson(tx *gorm.DB, person *models.Person) error
+	DeletePerson(tx *gorm.DB, person *models.Person) error
+}
+
+func NewPersonRepository(db *gorm.DB) PersonRepository {
+	return &personRepository{db: db}
+}
+
+type personRepository struct {
+	db *gorm.DB
+}
+
+func (r *personRepository) GetPerson(filter filters.PersonFilter) (*models.Person, error) {
+	var person models.Person
+	if err := filter.GetFilterQuery(r.db).First(&person).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (r *personRepository) GetPeople(filter filters.PersonFilter) ([]*models.Person, error) {
+	var people []*models.Person
+	if err := filter.GetFilterQuery(r.db).Find(&people).Error; err != nil {
+		return nil, err
+	}
+
+	return people, nil
+}
+
+func (r *personRepository) SearchPeople(keyword string, limit, offset int) ([]*models.Person, error) {
+	var people []*models.Person
+	if err := r.db.Where("name ILIKE ?", "%"+likePatternEscaper.Replace(keyword)+"%").
+		Order("name").
+		Limit(limit).
+		Offset(offset).
+		Find(&people).Error; err != nil {
+		return nil, err
+	}
+
+	return people, nil
+}
+
+func (r *personRepository) CreatePerson(tx *gorm.DB, person *models.Person) error {
+	return tx.Create(person).Error
+}
+
+func (r *personRepository) UpdatePerson(tx *gorm.DB, person *models.Person) error {
+	return tx.Save(person).Error
+}
+
+func (r *personRepository) DeletePerson(tx *gorm.DB, person *models.Person) error {
+	return tx.Delete(person).Error
+}
@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestPersonRepository_GetPerson(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewPersonRepository(db)
+
+	person := utils.GeneratePerson()
+	filter := filters.PersonFilter{
+		Filter: &filters.SingleFilter{},
+		ID:     &filters.Condition{Operator: filters.OpEqual, Value: person.ID},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE id = $1 ORDER BY "people"."id" LIMIT $2`)).
+			WithArgs(person.ID, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(person))
+
+		result, err := repo.GetPerson(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, person, result)
+	})
+
+	t.Run("person not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE id = $1 ORDER BY "people"."id" LIMIT $2`)).
+			WithArgs(person.ID, 1).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetPerson(filter)
+
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error getting person", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE id = $1 ORDER BY "people"."id" LIMIT $2`)).
+			WithArgs(person.ID, 1).
+			WillReturnError(errors.New("error getting person"))
+
+		result, err := repo.GetPerson(filter)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting person", err.Error())
+	})
+}
+
+func TestPersonRepository_GetPeople(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewPersonRepository(db)
+
+	people := utils.GeneratePeople(3)
+	ids := []uuid.UUID{people[0].ID, people[1].ID, people[2].ID}
+	filter := filters.PersonFilter{
+		Filter: &filters.MultiFilter{Logic: filters.And},
+		ID:     &filters.Condition{Operator: filters.OpIn, Value: ids},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE id IN ($1,$2,$3)`)).
+			WithArgs(ids[0], ids[1], ids[2]).
+			WillReturnRows(utils.GenerateSqlMockRows(people))
+
+		result, err := repo.GetPeople(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, people, result)
+	})
+
+	t.Run("error getting people", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE id IN ($1,$2,$3)`)).
+			WithArgs(ids[0], ids[1], ids[2]).
+			WillReturnError(errors.New("error getting people"))
+
+		result, err := repo.GetPeople(filter)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting people", err.Error())
+	})
+}
+
+func TestPersonRepository_SearchPeople(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewPersonRepository(db)
+
+	t.Run("success", func(t *testing.T) {
+		people := utils.GeneratePeople(3)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE name ILIKE $1 ORDER BY name LIMIT $2 OFFSET $3`)).
+			WithArgs("%50\\%%", 10, 10).
+			WillReturnRows(utils.GenerateSqlMockRows(people))
+
+		result, err := repo.SearchPeople("50%", 10, 10)
+
+		assert.Nil(t, err)
+		assert.Equal(t, people, result)
+	})
+
+	t.Run("error searching people", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "people" WHERE name ILIKE $1 ORDER BY name LIMIT $2`)).
+			WithArgs("%nolan%", 10).
+			WillReturnError(errors.New("error searching people"))
+
+		result, err := repo.SearchPeople("nolan", 10, 0)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error searching people", err.Error())
+	})
+}
+
+func TestPersonRepository_CreatePerson(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewPersonRepository(db)
+
+	person := utils.GeneratePerson()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "people" ("id","name","biography","created_at","updated_at") VALUES ($1,$2,$3,$4,$5)`)).
+			WithArgs(person.ID, person.Name, person.Biography, person.CreatedAt, person.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreatePerson(tx, person)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error creating person", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "people" ("id","name","biography","created_at","updated_at") VALUES ($1,$2,$3,$4,$5)`)).
+			WithArgs(person.ID, person.Name, person.Biography, person.CreatedAt, person.UpdatedAt).
+			WillReturnError(errors.New("error creating person"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreatePerson(tx, person)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating person", err.Error())
+	})
+}
+
+func TestPersonRepository_UpdatePerson(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewPersonRepository(db)
+
+	person := utils.GeneratePerson()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "people" SET "name"=$1,"biography"=$2,"created_at"=$3,"updated_at"=$4 WHERE "id" = $5`)).
+			WithArgs(person.Name, person.Biography, person.CreatedAt, sqlmock.AnyArg(), person.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdatePerson(tx, person)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error updating person", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "people" SET "name"=$1,"biography"=$2,"created_at"=$3,"updated_at"=$4 WHERE "id" = $5`)).
+			WithArgs(person.Name, person.Biography, person.CreatedAt, sqlmock.AnyArg(), person.ID).
+			WillReturnError(errors.New("error updating person"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdatePerson(tx, person)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error updating person", err.Error())
+	})
+}
+
+func TestPersonRepository_DeletePerson(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewPersonRepository(db)
+
+	person := utils.GeneratePerson()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "people" WHERE "people"."id" = $1`)).
+			WithArgs(person.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeletePerson(tx, person)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting person", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "people" WHERE "people"."id" = $1`)).
+			WithArgs(person.ID).
+			WillReturnError(errors.New("error deleting person"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeletePerson(tx, person)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting person", err.Error())
+	})
+}
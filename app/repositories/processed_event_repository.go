@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type ProcessedEventRepository interface {
+	CreateProcessedEvent(tx *gorm.DB, event *models.ProcessedEvent) (bool, error)
+	DeleteProcessedEvents(tx *gorm.DB, before time.Time) (int64, error)
+}
+
+func NewProcessedEventRepository(db *gorm.DB) ProcessedEventRepository {
+	return &processedEventRepository{db: db}
+}
+
+type processedEventRepository struct {
+	db *gorm.DB
+}
+
+// CreateProcessedEvent returns false when the idempotency key was already recorded by the consumer group.
+func (r *processedEventRepository) CreateProcessedEvent(tx *gorm.DB, event *models.ProcessedEvent) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteProcessedEvents removes idempotency keys recorded before the given time.
+func (r *processedEventRepository) DeleteProcessedEvents(tx *gorm.DB, before time.Time) (int64, error) {
+	result := tx.Where("processed_at < ?", before).Delete(&models.ProcessedEvent{})
+
+	return result.RowsAffected, result.Error
+}
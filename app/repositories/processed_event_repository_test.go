@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestProcessedEventRepository_CreateProcessedEvent(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewProcessedEventRepository(db)
+
+	event := utils.GenerateProcessedEvent()
+	query := regexp.QuoteMeta(`INSERT INTO "processed_events" ("consumer_group","idempotency_key","topic","processed_at") VALUES ($1,$2,$3,$4) ON CONFLICT DO NOTHING`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(event.ConsumerGroup, event.IdempotencyKey, event.Topic, event.ProcessedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		created, err := repo.CreateProcessedEvent(tx, event)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.True(t, created)
+	})
+
+	t.Run("already processed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(event.ConsumerGroup, event.IdempotencyKey, event.Topic, event.ProcessedAt).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		created, err := repo.CreateProcessedEvent(tx, event)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(event.ConsumerGroup, event.IdempotencyKey, event.Topic, event.ProcessedAt).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		created, err := repo.CreateProcessedEvent(tx, event)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+		assert.False(t, created)
+	})
+}
+
+func TestProcessedEventRepository_DeleteProcessedEvents(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewProcessedEventRepository(db)
+
+	before := time.Now().UTC().Add(-24 * time.Hour)
+	expectedQuery := regexp.QuoteMeta(`DELETE FROM "processed_events" WHERE processed_at < $1`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		count, err := repo.DeleteProcessedEvents(tx, before)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).
+			WithArgs(before).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		count, err := repo.DeleteProcessedEvents(tx, before)
+		tx.Rollback()
+
+		assert.Equal(t, int64(0), count)
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type SeatBlockRepository interface {
+	GetSeatBlock(seatId uuid.UUID, showId *uuid.UUID) (*models.SeatBlock, error)
+	CreateSeatBlock(tx *gorm.DB, block *models.SeatBlock) error
+	DeleteSeatBlock(tx *gorm.DB, block *models.SeatBlock) error
+}
+
+func NewSeatBlockRepository(db *gorm.DB) SeatBlockRepository {
+	return &seatBlockRepository{db: db}
+}
+
+type seatBlockRepository struct {
+	db *gorm.DB
+}
+
+func (r *seatBlockRepository) GetSeatBlock(seatId uuid.UUID, showId *uuid.UUID) (*models.SeatBlock, error) {
+	query := r.db.Where("seat_id = ?", seatId)
+	if showId == nil {
+		query = query.Where("show_id IS NULL")
+	} else {
+		query = query.Where("show_id = ?", *showId)
+	}
+
+	var block models.SeatBlock
+	if err := query.First(&block).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (r *seatBlockRepository) CreateSeatBlock(tx *gorm.DB, block *models.SeatBlock) error {
+	return tx.Create(block).Error
+}
+
+func (r *seatBlockRepository) DeleteSeatBlock(tx *gorm.DB, block *models.SeatBlock) error {
+	return tx.Delete(block).Error
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestSeatBlockRepository_GetSeatBlock(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatBlockRepository(db)
+
+	block := utils.GenerateSeatBlock()
+
+	t.Run("success permanent block", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seat_blocks" WHERE seat_id = $1 AND show_id IS NULL ORDER BY "seat_blocks"."id" LIMIT $2`)).
+			WithArgs(block.SeatId, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(block))
+
+		result, err := repo.GetSeatBlock(*block.SeatId, nil)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, block.Id, result.Id)
+		assert.Equal(t, block.Reason, result.Reason)
+	})
+
+	t.Run("success show block", func(t *testing.T) {
+		showBlock := utils.GenerateSeatBlock()
+		showBlock.ShowId = utils.GetPointerOf(utils.GenerateShow().Id)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seat_blocks" WHERE seat_id = $1 AND show_id = $2 ORDER BY "seat_blocks"."id" LIMIT $3`)).
+			WithArgs(showBlock.SeatId, showBlock.ShowId, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(showBlock))
+
+		result, err := repo.GetSeatBlock(*showBlock.SeatId, showBlock.ShowId)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, showBlock.ShowId, result.ShowId)
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seat_blocks" WHERE seat_id = $1 AND show_id IS NULL ORDER BY "seat_blocks"."id" LIMIT $2`)).
+			WithArgs(block.SeatId, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(nil))
+
+		result, err := repo.GetSeatBlock(*block.SeatId, nil)
+
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "seat_blocks" WHERE seat_id = $1 AND show_id IS NULL ORDER BY "seat_blocks"."id" LIMIT $2`)).
+			WithArgs(block.SeatId, 1).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetSeatBlock(*block.SeatId, nil)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSeatBlockRepository_CreateSeatBlock(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatBlockRepository(db)
+
+	block := utils.GenerateSeatBlock()
+	query := regexp.QuoteMeta(`INSERT INTO "seat_blocks" ("id","seat_id","show_id","reason","blocked_by","created_at") VALUES ($1,$2,$3,$4,$5,$6)`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(block.Id, block.SeatId, block.ShowId, block.Reason, block.BlockedBy, block.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateSeatBlock(tx, block)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(block.Id, block.SeatId, block.ShowId, block.Reason, block.BlockedBy, block.CreatedAt).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateSeatBlock(tx, block)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSeatBlockRepository_DeleteSeatBlock(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatBlockRepository(db)
+
+	block := utils.GenerateSeatBlock()
+	query := regexp.QuoteMeta(`DELETE FROM "seat_blocks" WHERE "seat_blocks"."id" = $1`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(block.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteSeatBlock(tx, block)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(block.Id).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteSeatBlock(tx, block)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
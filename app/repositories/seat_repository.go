@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
@@ -9,7 +10,13 @@ import (
 
 type SeatRepository interface {
 	GetSeat(filter filters.SeatFilter) (*models.Seat, error)
+	GetSeats(filter filters.SeatFilter) ([]*models.Seat, error)
+	GetAvailableSeats(theaterId, showId uuid.UUID) ([]*models.Seat, error)
 	CreateSeat(tx *gorm.DB, seat *models.Seat) error
+	CreateSeatPair(tx *gorm.DB, seat, pair *models.Seat) error
+	UpdateSeat(tx *gorm.DB, seat *models.Seat) error
+	DeleteSeat(tx *gorm.DB, seat *models.Seat) error
+	DeleteSeatsOfTheater(tx *gorm.DB, theaterId uuid.UUID) error
 }
 
 func NewSeatRepository(db *gorm.DB) SeatRepository {
@@ -33,6 +40,70 @@ func (r *seatRepository) GetSeat(filter filters.SeatFilter) (*models.Seat, error
 	return &seat, nil
 }
 
+func (r *seatRepository) GetSeats(filter filters.SeatFilter) ([]*models.Seat, error) {
+	var seats []*models.Seat
+	if err := filter.GetFilterQuery(r.db).Find(&seats).Error; err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+func (r *seatRepository) GetAvailableSeats(theaterId, showId uuid.UUID) ([]*models.Seat, error) {
+	var seats []*models.Seat
+	query := `
+		SELECT s.*
+		FROM seats s
+		WHERE s.theater_id = ?
+			AND NOT s.is_deleted
+			AND NOT EXISTS (
+				SELECT 1
+				FROM seat_blocks b
+				WHERE b.seat_id = s.id
+					AND (b.show_id IS NULL OR b.show_id = ?)
+			)
+		ORDER BY s.row, s.number
+	`
+	if err := r.db.Raw(query, theaterId, showId).Scan(&seats).Error; err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
 func (r *seatRepository) CreateSeat(tx *gorm.DB, seat *models.Seat) error {
 	return tx.Create(seat).Error
 }
+
+// CreateSeatPair inserts both couple seats unlinked and links them afterwards, the paired_seat_id foreign key is checked per statement.
+func (r *seatRepository) CreateSeatPair(tx *gorm.DB, seat, pair *models.Seat) error {
+	seat.PairedSeatId, pair.PairedSeatId = nil, nil
+	if err := tx.Create(seat).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(pair).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(seat).Update("paired_seat_id", pair.Id).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(pair).Update("paired_seat_id", seat.Id).Error; err != nil {
+		return err
+	}
+
+	seat.PairedSeatId, pair.PairedSeatId = &pair.Id, &seat.Id
+	return nil
+}
+
+func (r *seatRepository) UpdateSeat(tx *gorm.DB, seat *models.Seat) error {
+	return tx.Save(seat).Error
+}
+
+func (r *seatRepository) DeleteSeat(tx *gorm.DB, seat *models.Seat) error {
+	return tx.Model(seat).Update("is_deleted", true).Error
+}
+
+func (r *seatRepository) DeleteSeatsOfTheater(tx *gorm.DB, theaterId uuid.UUID) error {
+	return tx.Model(&models.Seat{}).Where("theater_id = ? AND is_deleted = ?", theaterId, false).Update("is_deleted", true).Error
+}
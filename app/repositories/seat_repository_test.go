@@ -3,9 +3,11 @@ package repositories
 import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"regexp"
 	"testing"
@@ -66,6 +68,100 @@ func TestSeatRepository_GetSeat(t *testing.T) {
 	})
 }
 
+func TestSeatRepository_GetSeats(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatRepository(db)
+
+	theater := utils.GenerateTheater()
+	seats := []*models.Seat{utils.GenerateSeat(), utils.GenerateSeat()}
+	filter := filters.SeatFilter{
+		Filter: &filters.MultiFilter{Sort: []filters.SortOption{
+			{Field: "row", Direction: filters.Asc},
+			{Field: "number", Direction: filters.Asc},
+		}},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	query := regexp.QuoteMeta(`SELECT * FROM "seats" WHERE theater_id = $1 AND is_deleted = $2 ORDER BY row ASC,number ASC`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID, false).
+			WillReturnRows(utils.GenerateSqlMockRows(seats))
+
+		result, err := repo.GetSeats(filter)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, len(seats))
+		assert.Equal(t, seats[0].Id, result[0].Id)
+		assert.Equal(t, seats[1].Id, result[1].Id)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID, false).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetSeats(filter)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSeatRepository_GetAvailableSeats(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatRepository(db)
+
+	show := utils.GenerateShow()
+	seats := []*models.Seat{utils.GenerateSeat(), utils.GenerateSeat()}
+	query := regexp.QuoteMeta(`
+		SELECT s.*
+		FROM seats s
+		WHERE s.theater_id = $1
+			AND NOT s.is_deleted
+			AND NOT EXISTS (
+				SELECT 1
+				FROM seat_blocks b
+				WHERE b.seat_id = s.id
+					AND (b.show_id IS NULL OR b.show_id = $2)
+			)
+		ORDER BY s.row, s.number
+	`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(show.TheaterId, show.Id).
+			WillReturnRows(utils.GenerateSqlMockRows(seats))
+
+		result, err := repo.GetAvailableSeats(*show.TheaterId, show.Id)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, len(seats))
+		assert.Equal(t, seats[0].Id, result[0].Id)
+		assert.Equal(t, seats[1].Id, result[1].Id)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(show.TheaterId, show.Id).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetAvailableSeats(*show.TheaterId, show.Id)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
 func TestSeatRepository_CreateSeat(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -78,8 +174,8 @@ func TestSeatRepository_CreateSeat(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "seats" ("id","theater_id","row","number","type") VALUES ($1,$2,$3,$4,$5)`)).
-			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "seats" ("id","theater_id","row","number","type","paired_seat_id","is_deleted") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type, seat.PairedSeatId, seat.IsDeleted).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -92,8 +188,8 @@ func TestSeatRepository_CreateSeat(t *testing.T) {
 
 	t.Run("db error", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "seats" ("id","theater_id","row","number","type") VALUES ($1,$2,$3,$4,$5)`)).
-			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "seats" ("id","theater_id","row","number","type","paired_seat_id","is_deleted") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type, seat.PairedSeatId, seat.IsDeleted).
 			WillReturnError(errors.New("db error"))
 		mock.ExpectRollback()
 
@@ -104,3 +200,206 @@ func TestSeatRepository_CreateSeat(t *testing.T) {
 		assert.EqualError(t, err, "db error")
 	})
 }
+
+func TestSeatRepository_CreateSeatPair(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatRepository(db)
+
+	insertQuery := regexp.QuoteMeta(`INSERT INTO "seats" ("id","theater_id","row","number","type","paired_seat_id","is_deleted") VALUES ($1,$2,$3,$4,$5,$6,$7)`)
+	updateQuery := regexp.QuoteMeta(`UPDATE "seats" SET "paired_seat_id"=$1 WHERE "id" = $2`)
+
+	t.Run("success", func(t *testing.T) {
+		seat := utils.GenerateSeat()
+		pair := utils.GenerateSeat()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).
+			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type, nil, seat.IsDeleted).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(insertQuery).
+			WithArgs(pair.Id, pair.TheaterId, pair.Row, pair.Number, pair.Type, nil, pair.IsDeleted).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(updateQuery).
+			WithArgs(pair.Id, seat.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(updateQuery).
+			WithArgs(seat.Id, pair.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateSeatPair(tx, seat, pair)
+		tx.Commit()
+
+		assert.NoError(t, err)
+		assert.Equal(t, &pair.Id, seat.PairedSeatId)
+		assert.Equal(t, &seat.Id, pair.PairedSeatId)
+	})
+
+	t.Run("error creating paired seat", func(t *testing.T) {
+		seat := utils.GenerateSeat()
+		pair := utils.GenerateSeat()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).
+			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type, nil, seat.IsDeleted).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(insertQuery).
+			WithArgs(pair.Id, pair.TheaterId, pair.Row, pair.Number, pair.Type, nil, pair.IsDeleted).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateSeatPair(tx, seat, pair)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+
+	t.Run("error linking seats", func(t *testing.T) {
+		seat := utils.GenerateSeat()
+		pair := utils.GenerateSeat()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).
+			WithArgs(seat.Id, seat.TheaterId, seat.Row, seat.Number, seat.Type, nil, seat.IsDeleted).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(insertQuery).
+			WithArgs(pair.Id, pair.TheaterId, pair.Row, pair.Number, pair.Type, nil, pair.IsDeleted).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(updateQuery).
+			WithArgs(pair.Id, seat.Id).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateSeatPair(tx, seat, pair)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSeatRepository_UpdateSeat(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatRepository(db)
+
+	seat := utils.GenerateSeat()
+	query := regexp.QuoteMeta(`UPDATE "seats" SET "theater_id"=$1,"row"=$2,"number"=$3,"type"=$4,"paired_seat_id"=$5,"is_deleted"=$6 WHERE "id" = $7`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(seat.TheaterId, seat.Row, seat.Number, seat.Type, seat.PairedSeatId, seat.IsDeleted, seat.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateSeat(tx, seat)
+		tx.Commit()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(seat.TheaterId, seat.Row, seat.Number, seat.Type, seat.PairedSeatId, seat.IsDeleted, seat.Id).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateSeat(tx, seat)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSeatRepository_DeleteSeat(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatRepository(db)
+
+	seat := utils.GenerateSeat()
+	query := regexp.QuoteMeta(`UPDATE "seats" SET "is_deleted"=$1 WHERE "id" = $2`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(true, seat.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteSeat(tx, seat)
+		tx.Commit()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(true, seat.Id).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteSeat(tx, seat)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestSeatRepository_DeleteSeatsOfTheater(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewSeatRepository(db)
+
+	theaterId := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "seats" SET "is_deleted"=$1 WHERE theater_id = $2 AND is_deleted = $3`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(true, theaterId, false).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteSeatsOfTheater(tx, theaterId)
+		tx.Commit()
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).
+			WithArgs(true, theaterId, false).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteSeatsOfTheater(tx, theaterId)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
@@ -1,23 +1,26 @@
 package repositories
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
 type ShowRepository interface {
 	GetShow(filter filters.ShowFilter) (*models.Show, error)
 	GetShows(filter filters.ShowFilter) ([]*models.Show, error)
-	IsShowInValidTimeRange(theaterId uuid.UUID, startTime time.Time, endTime time.Time) (bool, error)
+	IsShowInValidTimeRange(tx *gorm.DB, theaterId uuid.UUID, startTime time.Time, endTime time.Time) (bool, error)
+	HasUpcomingShows(tx *gorm.DB, theaterId uuid.UUID) (bool, error)
 	CreateShow(tx *gorm.DB, show *models.Show) error
 	UpdateShowStatus(tx *gorm.DB, showId uuid.UUID, status constants.ShowStatus) error
-	ScheduleActivateShows(tx *gorm.DB, beforeStart time.Duration) error
-	ScheduleCompleteShows(tx *gorm.DB) error
+	ScheduleActivateShows(tx *gorm.DB, now time.Time, beforeStart time.Duration) ([]*models.Show, error)
+	ScheduleCompleteShows(tx *gorm.DB, now time.Time) ([]*models.Show, error)
 }
 
 func NewShowRepository(db *gorm.DB) ShowRepository {
@@ -30,7 +33,7 @@ type showRepository struct {
 
 func (r *showRepository) GetShow(filter filters.ShowFilter) (*models.Show, error) {
 	var show models.Show
-	if err := filter.GetFilterQuery(r.db).Preload("Movie").First(&show).Error; err != nil {
+	if err := filter.GetFilterQuery(r.withTimeZone()).Preload("Movie").First(&show).Error; err != nil {
 		if errors.IsRecordNotFoundError(err) {
 			return nil, nil
 		}
@@ -43,14 +46,14 @@ func (r *showRepository) GetShow(filter filters.ShowFilter) (*models.Show, error
 
 func (r *showRepository) GetShows(filter filters.ShowFilter) ([]*models.Show, error) {
 	var shows []*models.Show
-	if err := filter.GetFilterQuery(r.db).Find(&shows).Error; err != nil {
+	if err := filter.GetFilterQuery(r.withTimeZone()).Find(&shows).Error; err != nil {
 		return nil, err
 	}
 
 	return shows, nil
 }
 
-func (r *showRepository) IsShowInValidTimeRange(theaterId uuid.UUID, startTime, endTime time.Time) (bool, error) {
+func (r *showRepository) IsShowInValidTimeRange(tx *gorm.DB, theaterId uuid.UUID, startTime, endTime time.Time) (bool, error) {
 	var show models.Show
 	query := `
 		SELECT id
@@ -64,7 +67,7 @@ func (r *showRepository) IsShowInValidTimeRange(theaterId uuid.UUID, startTime,
 			    OR (start_time <= ? AND end_time >= ?)
 			)
 	`
-	if err := r.db.Raw(query, constants.Active, constants.Scheduled, theaterId, startTime, endTime, startTime, endTime, startTime, endTime).
+	if err := tx.Raw(query, constants.Active, constants.Scheduled, theaterId, startTime, endTime, startTime, endTime, startTime, endTime).
 		First(&show).Error; err != nil {
 		if errors.IsRecordNotFoundError(err) {
 			return true, nil
@@ -76,6 +79,26 @@ func (r *showRepository) IsShowInValidTimeRange(theaterId uuid.UUID, startTime,
 	return false, nil
 }
 
+func (r *showRepository) HasUpcomingShows(tx *gorm.DB, theaterId uuid.UUID) (bool, error) {
+	var show models.Show
+	query := `
+		SELECT id
+		FROM shows
+		WHERE theater_id = ?
+			AND status IN (?, ?)
+			AND end_time > NOW()
+	`
+	if err := tx.Raw(query, theaterId, constants.Active, constants.Scheduled).First(&show).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *showRepository) CreateShow(tx *gorm.DB, show *models.Show) error {
 	return tx.Create(show).Error
 }
@@ -87,19 +110,41 @@ func (r *showRepository) UpdateShowStatus(tx *gorm.DB, showId uuid.UUID, status
 		Error
 }
 
-func (r *showRepository) ScheduleActivateShows(tx *gorm.DB, beforeStart time.Duration) error {
-	currentTime := time.Now().UTC()
-	maxStartTime := currentTime.Add(beforeStart)
-	return tx.Model(&models.Show{}).
-		Where("start_time <= ? AND status = ?", maxStartTime, constants.Scheduled).
-		Updates(map[string]interface{}{"status": constants.Active, "updated_at": currentTime}).
-		Error
+// ScheduleActivateShows activates scheduled shows starting within beforeStart and returns them as they were before the update.
+func (r *showRepository) ScheduleActivateShows(tx *gorm.DB, now time.Time, beforeStart time.Duration) ([]*models.Show, error) {
+	return r.updateShowsStatus(tx, now, constants.Active, "start_time <= ? AND status = ?", now.Add(beforeStart), constants.Scheduled)
 }
 
-func (r *showRepository) ScheduleCompleteShows(tx *gorm.DB) error {
-	currentTime := time.Now().UTC()
-	return tx.Model(&models.Show{}).
-		Where("end_time <= ? AND status = ?", currentTime, constants.Active).
-		Updates(map[string]interface{}{"status": constants.Completed, "updated_at": currentTime}).
-		Error
+// ScheduleCompleteShows completes active shows that have ended and returns them as they were before the update.
+func (r *showRepository) ScheduleCompleteShows(tx *gorm.DB, now time.Time) ([]*models.Show, error) {
+	return r.updateShowsStatus(tx, now, constants.Completed, "end_time <= ? AND status = ?", now, constants.Active)
+}
+
+func (r *showRepository) updateShowsStatus(tx *gorm.DB, now time.Time, status constants.ShowStatus, query string, args ...any) ([]*models.Show, error) {
+	var shows []*models.Show
+	if err := tx.Select(fmt.Sprintf("shows.*, %s AS time_zone", filters.ShowTimeZone)).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "shows"}}).
+		Where(query, args...).
+		Find(&shows).Error; err != nil {
+		return nil, err
+	}
+	if len(shows) == 0 {
+		return shows, nil
+	}
+
+	ids := make([]uuid.UUID, len(shows))
+	for i, show := range shows {
+		ids[i] = show.Id
+	}
+	if err := tx.Model(&models.Show{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"status": status, "updated_at": now}).Error; err != nil {
+		return nil, err
+	}
+
+	return shows, nil
+}
+
+func (r *showRepository) withTimeZone() *gorm.DB {
+	return r.db.Select(fmt.Sprintf("shows.*, %s AS time_zone", filters.ShowTimeZone))
 }
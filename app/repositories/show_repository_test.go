@@ -3,6 +3,7 @@ package repositories
 import (
 	"database/sql/driver"
 	"errors"
+	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
@@ -30,7 +31,7 @@ func TestShowRepository_GetShow(t *testing.T) {
 		Id:     &filters.Condition{Operator: filters.OpEqual, Value: show.Id},
 	}
 
-	showQuery := regexp.QuoteMeta(`SELECT * FROM "shows" WHERE id = $1 ORDER BY "shows"."id" LIMIT $2`)
+	showQuery := regexp.QuoteMeta(fmt.Sprintf(`SELECT shows.*, %s AS time_zone FROM "shows" WHERE id = $1 ORDER BY "shows"."id" LIMIT $2`, filters.ShowTimeZone))
 	showArgs := []driver.Value{show.Id, 1}
 	movieQuery := regexp.QuoteMeta(`SELECT * FROM "movies" WHERE "movies"."id" = $1`)
 	movieArgs := []driver.Value{show.MovieId.String()}
@@ -103,7 +104,7 @@ func TestShowRepository_GetShows(t *testing.T) {
 		Status: &filters.Condition{Operator: filters.OpEqual, Value: constants.Active},
 	}
 
-	query := regexp.QuoteMeta(`SELECT * FROM "shows" WHERE status = $1`)
+	query := regexp.QuoteMeta(fmt.Sprintf(`SELECT shows.*, %s AS time_zone FROM "shows" WHERE status = $1`, filters.ShowTimeZone))
 	args := []driver.Value{constants.Active}
 
 	t.Run("success", func(t *testing.T) {
@@ -129,6 +130,42 @@ func TestShowRepository_GetShows(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "error getting shows")
 	})
+
+	t.Run("filter by local date", func(t *testing.T) {
+		dateFilter := filters.ShowFilter{
+			Filter:    &filters.SingleFilter{Logic: filters.And},
+			Status:    &filters.Condition{Operator: filters.OpEqual, Value: constants.Active},
+			LocalDate: &filters.Condition{Operator: filters.OpEqual, Value: "2026-10-19"},
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(
+			`SELECT shows.*, %s AS time_zone FROM "shows" WHERE status = $1 AND (start_time AT TIME ZONE %s)::date = $2`,
+			filters.ShowTimeZone, filters.ShowTimeZone,
+		))).
+			WithArgs(constants.Active, "2026-10-19").
+			WillReturnRows(utils.GenerateSqlMockRows(shows))
+
+		result, err := repo.GetShows(dateFilter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, shows, result)
+	})
+
+	t.Run("filter by local today", func(t *testing.T) {
+		todayFilter := filters.ShowFilter{
+			Filter:    &filters.SingleFilter{Logic: filters.And},
+			LocalDate: &filters.Condition{Operator: filters.OpEqual, Value: filters.ShowLocalToday},
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(
+			`SELECT shows.*, %s AS time_zone FROM "shows" WHERE (start_time AT TIME ZONE %s)::date = (NOW() AT TIME ZONE %s)::date`,
+			filters.ShowTimeZone, filters.ShowTimeZone, filters.ShowTimeZone,
+		))).
+			WillReturnRows(utils.GenerateSqlMockRows(shows))
+
+		result, err := repo.GetShows(todayFilter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, shows, result)
+	})
 }
 
 func TestShowRepository_IsShowInValidTimeRange(t *testing.T) {
@@ -158,7 +195,7 @@ func TestShowRepository_IsShowInValidTimeRange(t *testing.T) {
 			WithArgs(constants.Active, constants.Scheduled, show.TheaterId, show.StartTime, show.EndTime, show.StartTime, show.EndTime, show.StartTime, show.EndTime).
 			WillReturnRows(utils.GenerateSqlMockRow(nil))
 
-		result, err := repo.IsShowInValidTimeRange(*show.TheaterId, show.StartTime, show.EndTime)
+		result, err := repo.IsShowInValidTimeRange(db, *show.TheaterId, show.StartTime, show.EndTime)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -170,7 +207,7 @@ func TestShowRepository_IsShowInValidTimeRange(t *testing.T) {
 			WithArgs(constants.Active, constants.Scheduled, show.TheaterId, show.StartTime, show.EndTime, show.StartTime, show.EndTime, show.StartTime, show.EndTime).
 			WillReturnRows(utils.GenerateSqlMockRow(show))
 
-		result, err := repo.IsShowInValidTimeRange(*show.TheaterId, show.StartTime, show.EndTime)
+		result, err := repo.IsShowInValidTimeRange(db, *show.TheaterId, show.StartTime, show.EndTime)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -182,7 +219,7 @@ func TestShowRepository_IsShowInValidTimeRange(t *testing.T) {
 			WithArgs(constants.Active, constants.Scheduled, show.TheaterId, show.StartTime, show.EndTime, show.StartTime, show.EndTime, show.StartTime, show.EndTime).
 			WillReturnError(errors.New("error getting show"))
 
-		result, err := repo.IsShowInValidTimeRange(*show.TheaterId, show.StartTime, show.EndTime)
+		result, err := repo.IsShowInValidTimeRange(db, *show.TheaterId, show.StartTime, show.EndTime)
 
 		assert.NotNil(t, result)
 		assert.NotNil(t, err)
@@ -191,6 +228,57 @@ func TestShowRepository_IsShowInValidTimeRange(t *testing.T) {
 	})
 }
 
+func TestShowRepository_HasUpcomingShows(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewShowRepository(db)
+
+	show := utils.GenerateShow()
+	query := regexp.QuoteMeta(`
+		SELECT id
+		FROM shows
+		WHERE theater_id = $1
+			AND status IN ($2, $3)
+			AND end_time > NOW()
+`)
+
+	t.Run("has upcoming shows", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(show.TheaterId, constants.Active, constants.Scheduled).
+			WillReturnRows(utils.GenerateSqlMockRow(show))
+
+		result, err := repo.HasUpcomingShows(db, *show.TheaterId)
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("no upcoming shows", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(show.TheaterId, constants.Active, constants.Scheduled).
+			WillReturnRows(utils.GenerateSqlMockRow(nil))
+
+		result, err := repo.HasUpcomingShows(db, *show.TheaterId)
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("error getting show", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(show.TheaterId, constants.Active, constants.Scheduled).
+			WillReturnError(errors.New("error getting show"))
+
+		result, err := repo.HasUpcomingShows(db, *show.TheaterId)
+
+		assert.False(t, result)
+		assert.EqualError(t, err, "error getting show")
+	})
+}
+
 func TestShowRepository_CreateShow(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -279,31 +367,62 @@ func TestShowRepository_ScheduleActivateShows(t *testing.T) {
 
 	repo := NewShowRepository(db)
 
-	statement := regexp.QuoteMeta(`UPDATE "shows" SET "status"=$1,"updated_at"=$2 WHERE start_time <= $3 AND status = $4`)
-	args := []driver.Value{constants.Active, sqlmock.AnyArg(), sqlmock.AnyArg(), constants.Scheduled}
+	now := time.Now().UTC()
+	shows := utils.GenerateShows(2)
+	query := regexp.QuoteMeta(fmt.Sprintf(`SELECT shows.*, %s AS time_zone FROM "shows" WHERE start_time <= $1 AND status = $2 FOR UPDATE OF "shows"`, filters.ShowTimeZone))
+	statement := regexp.QuoteMeta(`UPDATE "shows" SET "status"=$1,"updated_at"=$2 WHERE id IN ($3,$4)`)
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(statement).WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(query).WithArgs(now.Add(time.Hour*24), constants.Scheduled).WillReturnRows(utils.GenerateSqlMockRows(shows))
+		mock.ExpectExec(statement).WithArgs(constants.Active, now, shows[0].Id, shows[1].Id).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.ScheduleActivateShows(tx, now, time.Hour*24)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Equal(t, shows, result)
+	})
+
+	t.Run("no shows", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(now.Add(time.Hour*24), constants.Scheduled).WillReturnRows(sqlmock.NewRows(nil))
 		mock.ExpectCommit()
 
 		tx := db.Begin()
-		err := repo.ScheduleActivateShows(tx, time.Hour*24)
+		result, err := repo.ScheduleActivateShows(tx, now, time.Hour*24)
 		tx.Commit()
 
 		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("error getting shows", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(now.Add(time.Hour*24), constants.Scheduled).WillReturnError(errors.New("error getting shows"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		result, err := repo.ScheduleActivateShows(tx, now, time.Hour*24)
+		tx.Rollback()
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "error getting shows")
 	})
 
 	t.Run("error updating shows", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(statement).WithArgs(args...).WillReturnError(errors.New("error updating shows"))
+		mock.ExpectQuery(query).WithArgs(now.Add(time.Hour*24), constants.Scheduled).WillReturnRows(utils.GenerateSqlMockRows(shows))
+		mock.ExpectExec(statement).WithArgs(constants.Active, now, shows[0].Id, shows[1].Id).WillReturnError(errors.New("error updating shows"))
 		mock.ExpectRollback()
 
 		tx := db.Begin()
-		err := repo.ScheduleActivateShows(tx, time.Hour*24)
+		result, err := repo.ScheduleActivateShows(tx, now, time.Hour*24)
 		tx.Rollback()
 
-		assert.NotNil(t, err)
+		assert.Nil(t, result)
 		assert.EqualError(t, err, "error updating shows")
 	})
 }
@@ -316,31 +435,62 @@ func TestShowRepository_ScheduleCompleteShows(t *testing.T) {
 
 	repo := NewShowRepository(db)
 
-	statement := regexp.QuoteMeta(`UPDATE "shows" SET "status"=$1,"updated_at"=$2 WHERE end_time <= $3 AND status = $4`)
-	args := []driver.Value{constants.Completed, sqlmock.AnyArg(), sqlmock.AnyArg(), constants.Active}
+	now := time.Now().UTC()
+	shows := utils.GenerateShows(2)
+	query := regexp.QuoteMeta(fmt.Sprintf(`SELECT shows.*, %s AS time_zone FROM "shows" WHERE end_time <= $1 AND status = $2 FOR UPDATE OF "shows"`, filters.ShowTimeZone))
+	statement := regexp.QuoteMeta(`UPDATE "shows" SET "status"=$1,"updated_at"=$2 WHERE id IN ($3,$4)`)
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(statement).WithArgs(args...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(query).WithArgs(now, constants.Active).WillReturnRows(utils.GenerateSqlMockRows(shows))
+		mock.ExpectExec(statement).WithArgs(constants.Completed, now, shows[0].Id, shows[1].Id).WillReturnResult(sqlmock.NewResult(0, 2))
 		mock.ExpectCommit()
 
 		tx := db.Begin()
-		err := repo.ScheduleCompleteShows(tx)
+		result, err := repo.ScheduleCompleteShows(tx, now)
 		tx.Commit()
 
 		assert.Nil(t, err)
+		assert.Equal(t, shows, result)
+	})
+
+	t.Run("no shows", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(now, constants.Active).WillReturnRows(sqlmock.NewRows(nil))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.ScheduleCompleteShows(tx, now)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("error getting shows", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).WithArgs(now, constants.Active).WillReturnError(errors.New("error getting shows"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		result, err := repo.ScheduleCompleteShows(tx, now)
+		tx.Rollback()
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "error getting shows")
 	})
 
 	t.Run("error updating shows", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(statement).WithArgs(args...).WillReturnError(errors.New("error updating shows"))
+		mock.ExpectQuery(query).WithArgs(now, constants.Active).WillReturnRows(utils.GenerateSqlMockRows(shows))
+		mock.ExpectExec(statement).WithArgs(constants.Completed, now, shows[0].Id, shows[1].Id).WillReturnError(errors.New("error updating shows"))
 		mock.ExpectRollback()
 
 		tx := db.Begin()
-		err := repo.ScheduleCompleteShows(tx)
+		result, err := repo.ScheduleCompleteShows(tx, now)
 		tx.Rollback()
 
-		assert.NotNil(t, err)
+		assert.Nil(t, result)
 		assert.EqualError(t, err, "error updating shows")
 	})
 }
@@ -10,7 +10,11 @@ import (
 type StateRepository interface {
 	GetState(filter filters.StateFilter) (*models.State, error)
 	GetStates(filter filters.StateFilter) ([]*models.State, error)
+	GetNumbersOfState(filter filters.StateFilter) (int, error)
 	CreateState(tx *gorm.DB, state *models.State) error
+	CreateStates(tx *gorm.DB, states []*models.State) error
+	UpdateState(tx *gorm.DB, state *models.State) error
+	DeleteState(tx *gorm.DB, state *models.State) error
 }
 
 func NewStateRepository(db *gorm.DB) StateRepository {
@@ -43,6 +47,27 @@ func (r *stateRepository) GetStates(filter filters.StateFilter) ([]*models.State
 	return states, nil
 }
 
+func (r *stateRepository) GetNumbersOfState(filter filters.StateFilter) (int, error) {
+	var count int64
+	if err := filter.GetFilterQuery(r.db).Model(&models.State{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
 func (r *stateRepository) CreateState(tx *gorm.DB, state *models.State) error {
 	return tx.Create(state).Error
 }
+
+func (r *stateRepository) CreateStates(tx *gorm.DB, states []*models.State) error {
+	return tx.Omit("Country").Create(states).Error
+}
+
+func (r *stateRepository) UpdateState(tx *gorm.DB, state *models.State) error {
+	return tx.Omit("Country").Save(state).Error
+}
+
+func (r *stateRepository) DeleteState(tx *gorm.DB, state *models.State) error {
+	return tx.Delete(state).Error
+}
@@ -104,6 +104,40 @@ func TestStateRepository_GetStates(t *testing.T) {
 	})
 }
 
+func TestStateRepository_GetNumbersOfState(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewStateRepository(db)
+
+	filter := filters.StateFilter{
+		Filter: &filters.SingleFilter{},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "states"`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := repo.GetNumbersOfState(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, result)
+	})
+
+	t.Run("error counting states", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "states"`)).
+			WillReturnError(errors.New("error counting states"))
+
+		result, err := repo.GetNumbersOfState(filter)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, result)
+		assert.Equal(t, "error counting states", err.Error())
+	})
+}
+
 func TestStateRepository_CreateState(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -143,3 +177,125 @@ func TestStateRepository_CreateState(t *testing.T) {
 		assert.Equal(t, "error creating state", err.Error())
 	})
 }
+
+func TestStateRepository_CreateStates(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewStateRepository(db)
+
+	states := utils.GenerateStates(2)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "states" ("id","name","code","country_id") VALUES ($1,$2,$3,$4),($5,$6,$7,$8)`)).
+			WithArgs(
+				states[0].ID, states[0].Name, states[0].Code, states[0].CountryID,
+				states[1].ID, states[1].Name, states[1].Code, states[1].CountryID,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateStates(tx, states)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error creating states", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "states" ("id","name","code","country_id") VALUES ($1,$2,$3,$4),($5,$6,$7,$8)`)).
+			WillReturnError(errors.New("error creating states"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateStates(tx, states)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating states", err.Error())
+	})
+}
+
+func TestStateRepository_UpdateState(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewStateRepository(db)
+
+	state := utils.GenerateState()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "states" SET "name"=$1,"code"=$2,"country_id"=$3 WHERE "id" = $4`)).
+			WithArgs(state.Name, state.Code, state.CountryID, state.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateState(tx, state)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error updating state", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "states" SET "name"=$1,"code"=$2,"country_id"=$3 WHERE "id" = $4`)).
+			WithArgs(state.Name, state.Code, state.CountryID, state.ID).
+			WillReturnError(errors.New("error updating state"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateState(tx, state)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error updating state", err.Error())
+	})
+}
+
+func TestStateRepository_DeleteState(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewStateRepository(db)
+
+	state := utils.GenerateState()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "states" WHERE "states"."id" = $1`)).
+			WithArgs(state.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteState(tx, state)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting state", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "states" WHERE "states"."id" = $1`)).
+			WithArgs(state.ID).
+			WillReturnError(errors.New("error deleting state"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteState(tx, state)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting state", err.Error())
+	})
+}
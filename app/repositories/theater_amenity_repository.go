@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type TheaterAmenityRepository interface {
+	UpdateAmenitiesOfTheater(tx *gorm.DB, theaterID uuid.UUID, amenities []constants.TheaterAmenity) error
+}
+
+func NewTheaterAmenityRepository(db *gorm.DB) TheaterAmenityRepository {
+	return &theaterAmenityRepository{db}
+}
+
+type theaterAmenityRepository struct {
+	db *gorm.DB
+}
+
+func (r *theaterAmenityRepository) UpdateAmenitiesOfTheater(tx *gorm.DB, theaterID uuid.UUID, amenities []constants.TheaterAmenity) error {
+	if err := tx.Where("theater_id = ?", theaterID).Delete(&models.TheaterAmenity{}).Error; err != nil {
+		return err
+	}
+
+	if len(amenities) == 0 {
+		return nil
+	}
+
+	newAmenities := make([]models.TheaterAmenity, len(amenities))
+	for i, amenity := range amenities {
+		newAmenities[i] = models.TheaterAmenity{
+			TheaterID: theaterID,
+			Amenity:   amenity,
+		}
+	}
+
+	return tx.Create(&newAmenities).Error
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestTheaterAmenityRepository_UpdateAmenitiesOfTheater(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterAmenityRepository(db)
+
+	theater := utils.GenerateTheater()
+	amenities := []constants.TheaterAmenity{constants.Imax, constants.Parking}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_amenities" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theater_amenities" ("theater_id","amenity") VALUES ($1,$2),($3,$4)`)).
+			WithArgs(theater.ID, amenities[0], theater.ID, amenities[1]).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateAmenitiesOfTheater(tx, theater.ID, amenities)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("no amenities", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_amenities" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateAmenitiesOfTheater(tx, theater.ID, nil)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting amenities", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_amenities" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnError(errors.New("error deleting amenities"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateAmenitiesOfTheater(tx, theater.ID, amenities)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting amenities", err.Error())
+	})
+
+	t.Run("error creating amenities", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_amenities" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theater_amenities" ("theater_id","amenity") VALUES ($1,$2),($3,$4)`)).
+			WithArgs(theater.ID, amenities[0], theater.ID, amenities[1]).
+			WillReturnError(errors.New("error creating amenities"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateAmenitiesOfTheater(tx, theater.ID, amenities)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating amenities", err.Error())
+	})
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
@@ -9,8 +10,12 @@ import (
 
 type TheaterLocationRepository interface {
 	GetLocation(filter filters.TheaterLocationFilter) (*models.TheaterLocation, error)
+	HasLocationsInCity(cityId uuid.UUID) (bool, error)
+	HasLocationsInState(stateId uuid.UUID) (bool, error)
+	HasLocationsInCountry(countryId uuid.UUID) (bool, error)
 	CreateTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error
 	UpdateTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error
+	DeleteTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error
 }
 
 func NewTheaterLocationRepository(db *gorm.DB) TheaterLocationRepository {
@@ -36,6 +41,36 @@ func (r *theaterLocationRepository) GetLocation(filter filters.TheaterLocationFi
 	return &location, nil
 }
 
+func (r *theaterLocationRepository) HasLocationsInCity(cityId uuid.UUID) (bool, error) {
+	query := `
+		SELECT tl.id
+		FROM theater_locations tl
+		WHERE tl.city_id = ?
+	`
+	return r.hasLocations(query, cityId)
+}
+
+func (r *theaterLocationRepository) HasLocationsInState(stateId uuid.UUID) (bool, error) {
+	query := `
+		SELECT tl.id
+		FROM theater_locations tl
+		JOIN cities ci ON ci.id = tl.city_id
+		WHERE ci.state_id = ?
+	`
+	return r.hasLocations(query, stateId)
+}
+
+func (r *theaterLocationRepository) HasLocationsInCountry(countryId uuid.UUID) (bool, error) {
+	query := `
+		SELECT tl.id
+		FROM theater_locations tl
+		JOIN cities ci ON ci.id = tl.city_id
+		JOIN states s ON s.id = ci.state_id
+		WHERE s.country_id = ?
+	`
+	return r.hasLocations(query, countryId)
+}
+
 func (r *theaterLocationRepository) CreateTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error {
 	return tx.Create(location).Error
 }
@@ -43,3 +78,20 @@ func (r *theaterLocationRepository) CreateTheaterLocation(tx *gorm.DB, location
 func (r *theaterLocationRepository) UpdateTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error {
 	return tx.Save(location).Error
 }
+
+func (r *theaterLocationRepository) DeleteTheaterLocation(tx *gorm.DB, location *models.TheaterLocation) error {
+	return tx.Delete(location).Error
+}
+
+func (r *theaterLocationRepository) hasLocations(query string, args ...any) (bool, error) {
+	var location models.TheaterLocation
+	if err := r.db.Raw(query, args...).First(&location).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
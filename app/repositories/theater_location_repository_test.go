@@ -3,6 +3,7 @@ package repositories
 import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
@@ -61,6 +62,156 @@ func TestTheaterLocationRepository_GetLocation(t *testing.T) {
 	})
 }
 
+func TestTheaterLocationRepository_HasLocationsInCity(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterLocationRepository(db)
+
+	cityId := uuid.New()
+	query := regexp.QuoteMeta(`
+		SELECT tl.id
+		FROM theater_locations tl
+		WHERE tl.city_id = $1
+`)
+
+	t.Run("has locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(cityId).
+			WillReturnRows(utils.GenerateSqlMockRow(utils.GenerateTheaterLocation()))
+
+		result, err := repo.HasLocationsInCity(cityId)
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("no locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(cityId).
+			WillReturnRows(utils.GenerateSqlMockRow(nil))
+
+		result, err := repo.HasLocationsInCity(cityId)
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("error getting locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(cityId).
+			WillReturnError(errors.New("error getting locations"))
+
+		result, err := repo.HasLocationsInCity(cityId)
+
+		assert.False(t, result)
+		assert.EqualError(t, err, "error getting locations")
+	})
+}
+
+func TestTheaterLocationRepository_HasLocationsInState(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterLocationRepository(db)
+
+	stateId := uuid.New()
+	query := regexp.QuoteMeta(`
+		SELECT tl.id
+		FROM theater_locations tl
+		JOIN cities ci ON ci.id = tl.city_id
+		WHERE ci.state_id = $1
+`)
+
+	t.Run("has locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(stateId).
+			WillReturnRows(utils.GenerateSqlMockRow(utils.GenerateTheaterLocation()))
+
+		result, err := repo.HasLocationsInState(stateId)
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("no locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(stateId).
+			WillReturnRows(utils.GenerateSqlMockRow(nil))
+
+		result, err := repo.HasLocationsInState(stateId)
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("error getting locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(stateId).
+			WillReturnError(errors.New("error getting locations"))
+
+		result, err := repo.HasLocationsInState(stateId)
+
+		assert.False(t, result)
+		assert.EqualError(t, err, "error getting locations")
+	})
+}
+
+func TestTheaterLocationRepository_HasLocationsInCountry(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterLocationRepository(db)
+
+	countryId := uuid.New()
+	query := regexp.QuoteMeta(`
+		SELECT tl.id
+		FROM theater_locations tl
+		JOIN cities ci ON ci.id = tl.city_id
+		JOIN states s ON s.id = ci.state_id
+		WHERE s.country_id = $1
+`)
+
+	t.Run("has locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(countryId).
+			WillReturnRows(utils.GenerateSqlMockRow(utils.GenerateTheaterLocation()))
+
+		result, err := repo.HasLocationsInCountry(countryId)
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("no locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(countryId).
+			WillReturnRows(utils.GenerateSqlMockRow(nil))
+
+		result, err := repo.HasLocationsInCountry(countryId)
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("error getting locations", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(countryId).
+			WillReturnError(errors.New("error getting locations"))
+
+		result, err := repo.HasLocationsInCountry(countryId)
+
+		assert.False(t, result)
+		assert.EqualError(t, err, "error getting locations")
+	})
+}
+
 func TestTheaterLocationRepository_CreateTheaterLocation(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -142,3 +293,43 @@ func TestTheaterLocationRepository_UpdateTheaterLocation(t *testing.T) {
 		assert.Equal(t, "error updating location", err.Error())
 	})
 }
+
+func TestTheaterLocationRepository_DeleteTheaterLocation(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterLocationRepository(db)
+
+	location := utils.GenerateTheaterLocation()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_locations" WHERE "theater_locations"."id" = $1`)).
+			WithArgs(location.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteTheaterLocation(tx, location)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting location", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_locations" WHERE "theater_locations"."id" = $1`)).
+			WithArgs(location.ID).
+			WillReturnError(errors.New("error deleting location"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteTheaterLocation(tx, location)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting location", err.Error())
+	})
+}
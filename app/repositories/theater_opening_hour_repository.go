@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type TheaterOpeningHourRepository interface {
+	GetOpeningHours(theaterID uuid.UUID) ([]*models.TheaterOpeningHour, error)
+	UpdateOpeningHoursOfTheater(tx *gorm.DB, theaterID uuid.UUID, hours []*models.TheaterOpeningHour) error
+}
+
+func NewTheaterOpeningHourRepository(db *gorm.DB) TheaterOpeningHourRepository {
+	return &theaterOpeningHourRepository{db}
+}
+
+type theaterOpeningHourRepository struct {
+	db *gorm.DB
+}
+
+func (r *theaterOpeningHourRepository) GetOpeningHours(theaterID uuid.UUID) ([]*models.TheaterOpeningHour, error) {
+	var hours []*models.TheaterOpeningHour
+	if err := r.db.Where("theater_id = ?", theaterID).Order("weekday").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+func (r *theaterOpeningHourRepository) UpdateOpeningHoursOfTheater(tx *gorm.DB, theaterID uuid.UUID, hours []*models.TheaterOpeningHour) error {
+	if err := tx.Where("theater_id = ?", theaterID).Delete(&models.TheaterOpeningHour{}).Error; err != nil {
+		return err
+	}
+
+	if len(hours) == 0 {
+		return nil
+	}
+
+	return tx.Create(&hours).Error
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestTheaterOpeningHourRepository_GetOpeningHours(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterOpeningHourRepository(db)
+
+	theater := utils.GenerateTheater()
+	hours := utils.GenerateTheaterOpeningHours(3)
+	for _, h := range hours {
+		h.TheaterID = theater.ID
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_opening_hours" WHERE theater_id = $1 ORDER BY weekday`)).
+			WithArgs(theater.ID).
+			WillReturnRows(utils.GenerateSqlMockRows(hours))
+
+		result, err := repo.GetOpeningHours(theater.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, hours, result)
+	})
+
+	t.Run("error getting opening hours", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_opening_hours" WHERE theater_id = $1 ORDER BY weekday`)).
+			WithArgs(theater.ID).
+			WillReturnError(errors.New("error getting opening hours"))
+
+		result, err := repo.GetOpeningHours(theater.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting opening hours", err.Error())
+	})
+}
+
+func TestTheaterOpeningHourRepository_UpdateOpeningHoursOfTheater(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterOpeningHourRepository(db)
+
+	theater := utils.GenerateTheater()
+	hours := utils.GenerateTheaterOpeningHours(2)
+	for _, h := range hours {
+		h.TheaterID = theater.ID
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_opening_hours" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theater_opening_hours" ("id","theater_id","weekday","open_time","close_time") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+			WithArgs(
+				hours[0].ID, hours[0].TheaterID, hours[0].Weekday, hours[0].OpenTime, hours[0].CloseTime,
+				hours[1].ID, hours[1].TheaterID, hours[1].Weekday, hours[1].OpenTime, hours[1].CloseTime,
+			).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateOpeningHoursOfTheater(tx, theater.ID, hours)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("no opening hours", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_opening_hours" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateOpeningHoursOfTheater(tx, theater.ID, nil)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting opening hours", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_opening_hours" WHERE theater_id = $1`)).
+			WithArgs(theater.ID).
+			WillReturnError(errors.New("error deleting opening hours"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateOpeningHoursOfTheater(tx, theater.ID, hours)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting opening hours", err.Error())
+	})
+}
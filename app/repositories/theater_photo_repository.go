@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type TheaterPhotoRepository interface {
+	GetPhoto(filter filters.TheaterPhotoFilter) (*models.TheaterPhoto, error)
+	CreatePhoto(tx *gorm.DB, photo *models.TheaterPhoto) error
+	DeletePhoto(tx *gorm.DB, photo *models.TheaterPhoto) error
+}
+
+func NewTheaterPhotoRepository(db *gorm.DB) TheaterPhotoRepository {
+	return &theaterPhotoRepository{
+		db: db,
+	}
+}
+
+type theaterPhotoRepository struct {
+	db *gorm.DB
+}
+
+func (r *theaterPhotoRepository) GetPhoto(filter filters.TheaterPhotoFilter) (*models.TheaterPhoto, error) {
+	var photo models.TheaterPhoto
+	if err := filter.GetFilterQuery(r.db).First(&photo).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &photo, nil
+}
+
+func (r *theaterPhotoRepository) CreatePhoto(tx *gorm.DB, photo *models.TheaterPhoto) error {
+	return tx.Create(photo).Error
+}
+
+func (r *theaterPhotoRepository) DeletePhoto(tx *gorm.DB, photo *models.TheaterPhoto) error {
+	return tx.Delete(photo).Error
+}
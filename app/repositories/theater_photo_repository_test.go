@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestTheaterPhotoRepository_GetPhoto(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterPhotoRepository(db)
+
+	photo := utils.GenerateTheaterPhoto()
+	filter := filters.TheaterPhotoFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: photo.ID},
+		TheaterID: &filters.Condition{Operator: filters.OpEqual, Value: photo.TheaterID},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_photos" WHERE id = $1 AND theater_id = $2 ORDER BY "theater_photos"."id" LIMIT $3`)).
+			WithArgs(photo.ID, photo.TheaterID, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(photo))
+
+		result, err := repo.GetPhoto(filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, photo, result)
+	})
+
+	t.Run("photo not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_photos" WHERE id = $1 AND theater_id = $2 ORDER BY "theater_photos"."id" LIMIT $3`)).
+			WithArgs(photo.ID, photo.TheaterID, 1).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetPhoto(filter)
+
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error getting photo", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_photos" WHERE id = $1 AND theater_id = $2 ORDER BY "theater_photos"."id" LIMIT $3`)).
+			WithArgs(photo.ID, photo.TheaterID, 1).
+			WillReturnError(errors.New("error getting photo"))
+
+		result, err := repo.GetPhoto(filter)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "error getting photo", err.Error())
+	})
+}
+
+func TestTheaterPhotoRepository_CreatePhoto(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterPhotoRepository(db)
+
+	photo := utils.GenerateTheaterPhoto()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theater_photos" ("id","theater_id","url","caption","position","created_at") VALUES ($1,$2,$3,$4,$5,$6)`)).
+			WithArgs(photo.ID, photo.TheaterID, photo.Url, photo.Caption, photo.Position, photo.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreatePhoto(tx, photo)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error creating photo", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theater_photos" ("id","theater_id","url","caption","position","created_at") VALUES ($1,$2,$3,$4,$5,$6)`)).
+			WithArgs(photo.ID, photo.TheaterID, photo.Url, photo.Caption, photo.Position, photo.CreatedAt).
+			WillReturnError(errors.New("error creating photo"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreatePhoto(tx, photo)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error creating photo", err.Error())
+	})
+}
+
+func TestTheaterPhotoRepository_DeletePhoto(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterPhotoRepository(db)
+
+	photo := utils.GenerateTheaterPhoto()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_photos" WHERE "theater_photos"."id" = $1`)).
+			WithArgs(photo.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeletePhoto(tx, photo)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting photo", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "theater_photos" WHERE "theater_photos"."id" = $1`)).
+			WithArgs(photo.ID).
+			WillReturnError(errors.New("error deleting photo"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeletePhoto(tx, photo)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting photo", err.Error())
+	})
+}
@@ -1,36 +1,52 @@
 package repositories
 
 import (
+	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TheaterRepository interface {
-	GetTheater(filter filters.TheaterFilter, includeLocation bool) (*models.Theater, error)
-	GetTheaters(filter filters.TheaterFilter, includeLocation bool) ([]*models.Theater, error)
-	GetNearbyTheatersWithLocations(lat, lon, distance float64) ([]*payloads.GetTheaterWithLocationResult, error)
+	GetTheater(filter filters.TheaterFilter, includeLocation, includeDetails bool) (*models.Theater, error)
+	GetTheaters(filter filters.TheaterFilter, includeLocation, includeDetails bool) ([]*models.Theater, error)
+	GetNearbyTheatersWithLocations(lat, lon, distance float64, limit, offset int) ([]*payloads.GetTheaterWithLocationResult, error)
 	GetNumbersOfTheater(filter filters.TheaterFilter) (int, error)
+	GetTimeZone(theaterID uuid.UUID) (string, error)
+	LockTheater(tx *gorm.DB, id uuid.UUID) (*models.Theater, error)
 	CreateTheater(tx *gorm.DB, theater *models.Theater) error
+	UpdateTheater(tx *gorm.DB, theater *models.Theater) error
+	DeleteTheater(tx *gorm.DB, theater *models.Theater) error
 }
 
-func NewTheaterRepository(db *gorm.DB) TheaterRepository {
+// NewTheaterRepository takes whether theater_locations has the geog column, it only exists when PostGIS is installed.
+func NewTheaterRepository(db *gorm.DB, geographyAvailable bool) TheaterRepository {
 	return &theaterRepository{
-		db: db,
+		db:                 db,
+		geographyAvailable: geographyAvailable,
 	}
 }
 
 type theaterRepository struct {
-	db *gorm.DB
+	db                 *gorm.DB
+	geographyAvailable bool
 }
 
-func (r *theaterRepository) GetTheater(filter filters.TheaterFilter, includeLocation bool) (*models.Theater, error) {
+func (r *theaterRepository) GetTheater(filter filters.TheaterFilter, includeLocation, includeDetails bool) (*models.Theater, error) {
 	query := filter.GetFilterQuery(r.db)
 	if includeLocation {
 		query = query.Preload("Location")
 	}
+	if includeDetails {
+		query = query.Preload("Amenities").Preload("OpeningHours", func(db *gorm.DB) *gorm.DB {
+			return db.Order("weekday")
+		}).Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		})
+	}
 
 	var theater models.Theater
 	if err := query.First(&theater).Error; err != nil {
@@ -44,11 +60,18 @@ func (r *theaterRepository) GetTheater(filter filters.TheaterFilter, includeLoca
 	return &theater, nil
 }
 
-func (r *theaterRepository) GetTheaters(filter filters.TheaterFilter, includeLocation bool) ([]*models.Theater, error) {
+func (r *theaterRepository) GetTheaters(filter filters.TheaterFilter, includeLocation, includeDetails bool) ([]*models.Theater, error) {
 	query := filter.GetFilterQuery(r.db)
 	if includeLocation {
 		query = query.Preload("Location")
 	}
+	if includeDetails {
+		query = query.Preload("Amenities").Preload("OpeningHours", func(db *gorm.DB) *gorm.DB {
+			return db.Order("weekday")
+		}).Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		})
+	}
 
 	var theaters []*models.Theater
 	if err := query.Find(&theaters).Error; err != nil {
@@ -58,23 +81,57 @@ func (r *theaterRepository) GetTheaters(filter filters.TheaterFilter, includeLoc
 	return theaters, nil
 }
 
-func (r *theaterRepository) GetNearbyTheatersWithLocations(lat, lon, distance float64) ([]*payloads.GetTheaterWithLocationResult, error) {
+func (r *theaterRepository) GetNearbyTheatersWithLocations(lat, lon, distance float64, limit, offset int) ([]*payloads.GetTheaterWithLocationResult, error) {
+	if r.geographyAvailable {
+		return r.getNearbyTheatersByGeography(lat, lon, distance, limit, offset)
+	}
+
+	return r.getNearbyTheatersByHaversine(lat, lon, distance, limit, offset)
+}
+
+func (r *theaterRepository) getNearbyTheatersByGeography(lat, lon, distance float64, limit, offset int) ([]*payloads.GetTheaterWithLocationResult, error) {
+	var theaterLocations []*payloads.GetTheaterWithLocationResult
+	query := `
+		WITH origin AS (
+			SELECT ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography AS geog
+		)
+		SELECT
+			t.id, t.name,
+			tl.id AS location_id, tl.city_id, tl.address, tl.postal_code, tl.latitude, tl.longitude,
+			ST_Distance(tl.geog, origin.geog) / 1000 AS distance
+		FROM theaters t
+		JOIN theater_locations tl ON t.id = tl.theater_id
+		CROSS JOIN origin
+		WHERE NOT t.is_deleted AND ST_DWithin(tl.geog, origin.geog, ?)
+		ORDER BY distance
+		LIMIT ? OFFSET ?
+	`
+	if err := r.db.Raw(query, lon, lat, distance*1000, limit, offset).Scan(&theaterLocations).Error; err != nil {
+		return nil, err
+	}
+
+	return theaterLocations, nil
+}
+
+func (r *theaterRepository) getNearbyTheatersByHaversine(lat, lon, distance float64, limit, offset int) ([]*payloads.GetTheaterWithLocationResult, error) {
 	var theaterLocations []*payloads.GetTheaterWithLocationResult
 	query := `
 		WITH data AS (
 			SELECT
 				t.id, t.name,
 				tl.id AS location_id, tl.city_id, tl.address, tl.postal_code, tl.latitude, tl.longitude,
-				(6371 * acos(cos(radians(?)) * cos(radians(tl.latitude)) * cos(radians(tl.longitude) - radians(?)) + sin(radians(?)) * sin(radians(tl.latitude)))) AS distance
+				(6371 * acos(LEAST(1, GREATEST(-1, cos(radians(?)) * cos(radians(tl.latitude)) * cos(radians(tl.longitude) - radians(?)) + sin(radians(?)) * sin(radians(tl.latitude)))))) AS distance
 			FROM theaters t
 			JOIN theater_locations tl ON t.id = tl.theater_id
+			WHERE NOT t.is_deleted
 		)
 		SELECT *
 		FROM data
-		WHERE distance < ?
+		WHERE distance <= ?
 		ORDER BY distance
+		LIMIT ? OFFSET ?
 	`
-	if err := r.db.Raw(query, lat, lon, lat, distance).Scan(&theaterLocations).Error; err != nil {
+	if err := r.db.Raw(query, lat, lon, lat, distance, limit, offset).Scan(&theaterLocations).Error; err != nil {
 		return nil, err
 	}
 
@@ -90,6 +147,45 @@ func (r *theaterRepository) GetNumbersOfTheater(filter filters.TheaterFilter) (i
 	return int(count), nil
 }
 
+// GetTimeZone returns the theater's own zone, or the zone of its city when the theater has none, or UTC.
+func (r *theaterRepository) GetTimeZone(theaterID uuid.UUID) (string, error) {
+	var timeZone string
+	query := `
+		SELECT COALESCE(t.time_zone, c.time_zone, 'UTC')
+		FROM theaters t
+			LEFT JOIN theater_locations tl ON tl.theater_id = t.id
+			LEFT JOIN cities c ON c.id = tl.city_id
+		WHERE t.id = ?
+	`
+	if err := r.db.Raw(query, theaterID).Scan(&timeZone).Error; err != nil {
+		return "", err
+	}
+
+	return timeZone, nil
+}
+
 func (r *theaterRepository) CreateTheater(tx *gorm.DB, theater *models.Theater) error {
-	return tx.Create(theater).Error
+	return tx.Omit(clause.Associations).Create(theater).Error
+}
+
+func (r *theaterRepository) UpdateTheater(tx *gorm.DB, theater *models.Theater) error {
+	return tx.Omit(clause.Associations).Save(theater).Error
+}
+
+// LockTheater locks the theater row until the transaction ends, it is used to serialize show creation with deleting the theater or its seats.
+func (r *theaterRepository) LockTheater(tx *gorm.DB, id uuid.UUID) (*models.Theater, error) {
+	var theater models.Theater
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&theater).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &theater, nil
+}
+
+func (r *theaterRepository) DeleteTheater(tx *gorm.DB, theater *models.Theater) error {
+	return tx.Model(theater).Update("is_deleted", true).Error
 }
@@ -3,9 +3,12 @@ package repositories
 import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"regexp"
@@ -18,7 +21,7 @@ func TestTheaterRepository_GetTheater(t *testing.T) {
 		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
 	}()
 
-	repo := NewTheaterRepository(db)
+	repo := NewTheaterRepository(db, true)
 
 	theater := utils.GenerateTheater()
 	location := utils.GenerateTheaterLocation()
@@ -38,7 +41,7 @@ func TestTheaterRepository_GetTheater(t *testing.T) {
 			WithArgs(theater.ID).
 			WillReturnRows(utils.GenerateSqlMockRow(location))
 
-		result, err := repo.GetTheater(filter, true)
+		result, err := repo.GetTheater(filter, true, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -50,7 +53,7 @@ func TestTheaterRepository_GetTheater(t *testing.T) {
 			WithArgs(filter.ID.Value, filter.Name.Value, 1).
 			WillReturnRows(sqlmock.NewRows(nil))
 
-		result, err := repo.GetTheater(filter, true)
+		result, err := repo.GetTheater(filter, true, false)
 
 		assert.Nil(t, result)
 		assert.Nil(t, err)
@@ -61,7 +64,7 @@ func TestTheaterRepository_GetTheater(t *testing.T) {
 			WithArgs(filter.ID.Value, filter.Name.Value, 1).
 			WillReturnError(errors.New("error getting theater"))
 
-		result, err := repo.GetTheater(filter, true)
+		result, err := repo.GetTheater(filter, true, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -75,7 +78,7 @@ func TestTheaterRepository_GetTheaters(t *testing.T) {
 		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
 	}()
 
-	repo := NewTheaterRepository(db)
+	repo := NewTheaterRepository(db, true)
 
 	theaters := utils.GenerateTheaters(3)
 	locations := utils.GenerateTheaterLocations(3)
@@ -97,7 +100,7 @@ func TestTheaterRepository_GetTheaters(t *testing.T) {
 			WithArgs(theaters[0].ID, theaters[1].ID, theaters[2].ID).
 			WillReturnRows(utils.GenerateSqlMockRows(locations))
 
-		result, err := repo.GetTheaters(filter, true)
+		result, err := repo.GetTheaters(filter, true, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -109,7 +112,7 @@ func TestTheaterRepository_GetTheaters(t *testing.T) {
 			WithArgs(limit, offset).
 			WillReturnError(errors.New("error getting theaters"))
 
-		result, err := repo.GetTheaters(filter, true)
+		result, err := repo.GetTheaters(filter, true, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -124,12 +127,48 @@ func TestTheaterRepository_GetTheaters(t *testing.T) {
 			WithArgs(theaters[0].ID, theaters[1].ID, theaters[2].ID).
 			WillReturnError(errors.New("error getting locations"))
 
-		result, err := repo.GetTheaters(filter, true)
+		result, err := repo.GetTheaters(filter, true, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, "error getting locations", err.Error())
 	})
+
+	t.Run("search by city and amenity with details", func(t *testing.T) {
+		cityId := uuid.New()
+		searchFilter := filters.TheaterFilter{
+			Filter:    &filters.MultiFilter{Limit: &limit, Offset: &offset},
+			CityID:    &filters.Condition{Operator: filters.OpEqual, Value: cityId},
+			Amenities: []*filters.Condition{{Operator: filters.OpEqual, Value: constants.Imax}},
+		}
+		theater := utils.GenerateTheater()
+		amenity := &models.TheaterAmenity{TheaterID: theater.ID, Amenity: constants.Imax}
+		hour := utils.GenerateTheaterOpeningHour()
+		hour.TheaterID = theater.ID
+		photo := utils.GenerateTheaterPhoto()
+		photo.TheaterID = theater.ID
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theaters" WHERE id IN (SELECT theater_id FROM "theater_locations" WHERE city_id = $1) AND id IN (SELECT theater_id FROM "theater_amenities" WHERE amenity = $2) LIMIT $3 OFFSET $4`)).
+			WithArgs(cityId, constants.Imax, limit, offset).
+			WillReturnRows(utils.GenerateSqlMockRow(theater))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_amenities" WHERE "theater_amenities"."theater_id" = $1`)).
+			WithArgs(theater.ID).
+			WillReturnRows(utils.GenerateSqlMockRow(amenity))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_opening_hours" WHERE "theater_opening_hours"."theater_id" = $1 ORDER BY weekday`)).
+			WithArgs(theater.ID).
+			WillReturnRows(utils.GenerateSqlMockRow(hour))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "theater_photos" WHERE "theater_photos"."theater_id" = $1 ORDER BY position`)).
+			WithArgs(theater.ID).
+			WillReturnRows(utils.GenerateSqlMockRow(photo))
+
+		result, err := repo.GetTheaters(searchFilter, false, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, []*models.TheaterAmenity{amenity}, result[0].Amenities)
+		assert.Equal(t, []*models.TheaterOpeningHour{hour}, result[0].OpeningHours)
+		assert.Equal(t, []*models.TheaterPhoto{photo}, result[0].Photos)
+	})
 }
 
 func TestTheaterRepository_GetNearbyTheatersWithLocations(t *testing.T) {
@@ -138,60 +177,95 @@ func TestTheaterRepository_GetNearbyTheatersWithLocations(t *testing.T) {
 		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
 	}()
 
-	repo := NewTheaterRepository(db)
-
-	lat := 100.0
+	lat := 10.0
 	lon := 101.0
 	distance := 10.0
-	expectedQuery := regexp.QuoteMeta(`
+	limit := 10
+	offset := 0
+	geographyQuery := regexp.QuoteMeta(`
+		WITH origin AS (
+			SELECT ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography AS geog
+		)
+		SELECT
+			t.id, t.name,
+			tl.id AS location_id, tl.city_id, tl.address, tl.postal_code, tl.latitude, tl.longitude,
+			ST_Distance(tl.geog, origin.geog) / 1000 AS distance
+		FROM theaters t
+		JOIN theater_locations tl ON t.id = tl.theater_id
+		CROSS JOIN origin
+		WHERE NOT t.is_deleted AND ST_DWithin(tl.geog, origin.geog, $3)
+		ORDER BY distance
+		LIMIT $4 OFFSET $5
+	`)
+	haversineQuery := regexp.QuoteMeta(`
 		WITH data AS (
 			SELECT
 				t.id, t.name,
 				tl.id AS location_id, tl.city_id, tl.address, tl.postal_code, tl.latitude, tl.longitude,
-				(6371 * acos(cos(radians($1)) * cos(radians(tl.latitude)) * cos(radians(tl.longitude) - radians($2)) + sin(radians($3)) * sin(radians(tl.latitude)))) AS distance
+				(6371 * acos(LEAST(1, GREATEST(-1, cos(radians($1)) * cos(radians(tl.latitude)) * cos(radians(tl.longitude) - radians($2)) + sin(radians($3)) * sin(radians(tl.latitude)))))) AS distance
 			FROM theaters t
 			JOIN theater_locations tl ON t.id = tl.theater_id
+			WHERE NOT t.is_deleted
 		)
 		SELECT *
 		FROM data
-		WHERE distance < $4
+		WHERE distance <= $4
 		ORDER BY distance
+		LIMIT $5 OFFSET $6
 	`)
 
-	t.Run("success", func(t *testing.T) {
-		expectedResult := make([]*payloads.GetTheaterWithLocationResult, 3)
-		for i := 0; i < len(expectedResult); i++ {
-			theater := utils.GenerateTheater()
-			location := utils.GenerateTheaterLocation()
-			expectedResult[i] = &payloads.GetTheaterWithLocationResult{
-				Id:         theater.ID,
-				Name:       theater.Name,
-				LocationId: location.ID,
-				CityId:     location.CityID,
-				Address:    location.Address,
-				PostalCode: location.PostalCode,
-				Latitude:   location.Latitude,
-				Longitude:  location.Longitude,
-			}
+	expectedResult := make([]*payloads.GetTheaterWithLocationResult, 3)
+	for i := 0; i < len(expectedResult); i++ {
+		theater := utils.GenerateTheater()
+		location := utils.GenerateTheaterLocation()
+		expectedResult[i] = &payloads.GetTheaterWithLocationResult{
+			Id:         theater.ID,
+			Name:       theater.Name,
+			LocationId: location.ID,
+			CityId:     location.CityID,
+			Address:    location.Address,
+			PostalCode: location.PostalCode,
+			Latitude:   location.Latitude,
+			Longitude:  location.Longitude,
+			Distance:   float64(i),
 		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := NewTheaterRepository(db, true)
 
-		mock.ExpectQuery(expectedQuery).
-			WithArgs(lat, lon, lat, distance).
+		mock.ExpectQuery(geographyQuery).
+			WithArgs(lon, lat, distance*1000, limit, offset).
 			WillReturnRows(utils.GenerateSqlMockRows(expectedResult))
 
-		result, err := repo.GetNearbyTheatersWithLocations(lat, lon, distance)
+		result, err := repo.GetNearbyTheatersWithLocations(lat, lon, distance, limit, offset)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, expectedResult, result)
 	})
 
+	t.Run("success without geography", func(t *testing.T) {
+		repo := NewTheaterRepository(db, false)
+
+		mock.ExpectQuery(haversineQuery).
+			WithArgs(lat, lon, lat, distance, limit, offset).
+			WillReturnRows(utils.GenerateSqlMockRows(expectedResult))
+
+		result, err := repo.GetNearbyTheatersWithLocations(lat, lon, distance, limit, offset)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResult, result)
+	})
+
 	t.Run("error getting theaters", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).
-			WithArgs(lat, lon, lat, distance).
+		repo := NewTheaterRepository(db, true)
+
+		mock.ExpectQuery(geographyQuery).
+			WithArgs(lon, lat, distance*1000, limit, offset).
 			WillReturnError(errors.New("error getting theaters"))
 
-		result, err := repo.GetNearbyTheatersWithLocations(lat, lon, distance)
+		result, err := repo.GetNearbyTheatersWithLocations(lat, lon, distance, limit, offset)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -205,7 +279,7 @@ func TestTheaterRepository_GetNumbersOfTheater(t *testing.T) {
 		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
 	}()
 
-	repo := NewTheaterRepository(db)
+	repo := NewTheaterRepository(db, true)
 
 	filter := filters.TheaterFilter{
 		Filter: &filters.SingleFilter{},
@@ -234,20 +308,60 @@ func TestTheaterRepository_GetNumbersOfTheater(t *testing.T) {
 	})
 }
 
+func TestTheaterRepository_GetTimeZone(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterRepository(db, true)
+
+	theater := utils.GenerateTheater()
+	query := regexp.QuoteMeta(`
+		SELECT COALESCE(t.time_zone, c.time_zone, 'UTC')
+		FROM theaters t
+			LEFT JOIN theater_locations tl ON tl.theater_id = t.id
+			LEFT JOIN cities c ON c.id = tl.city_id
+		WHERE t.id = $1
+`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("Europe/Paris"))
+
+		result, err := repo.GetTimeZone(theater.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Europe/Paris", result)
+	})
+
+	t.Run("error getting time zone", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID).
+			WillReturnError(errors.New("error getting time zone"))
+
+		result, err := repo.GetTimeZone(theater.ID)
+
+		assert.Empty(t, result)
+		assert.EqualError(t, err, "error getting time zone")
+	})
+}
+
 func TestTheaterRepository_CreateTheater(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
 		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
 	}()
 
-	repo := NewTheaterRepository(db)
+	repo := NewTheaterRepository(db, true)
 
 	theater := utils.GenerateTheater()
 
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theaters" ("id","name") VALUES ($1,$2)`)).
-			WithArgs(theater.ID, theater.Name).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theaters" ("id","name","phone","website","time_zone","is_deleted") VALUES ($1,$2,$3,$4,$5,$6)`)).
+			WithArgs(theater.ID, theater.Name, theater.Phone, theater.Website, theater.TimeZone, theater.IsDeleted).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -260,8 +374,8 @@ func TestTheaterRepository_CreateTheater(t *testing.T) {
 
 	t.Run("error creating theater", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theaters" ("id","name") VALUES ($1,$2)`)).
-			WithArgs(theater.ID, theater.Name).
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "theaters" ("id","name","phone","website","time_zone","is_deleted") VALUES ($1,$2,$3,$4,$5,$6)`)).
+			WithArgs(theater.ID, theater.Name, theater.Phone, theater.Website, theater.TimeZone, theater.IsDeleted).
 			WillReturnError(errors.New("error creating theater"))
 		mock.ExpectRollback()
 
@@ -273,3 +387,140 @@ func TestTheaterRepository_CreateTheater(t *testing.T) {
 		assert.Equal(t, "error creating theater", err.Error())
 	})
 }
+
+func TestTheaterRepository_UpdateTheater(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterRepository(db, true)
+
+	theater := utils.GenerateTheater()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "theaters" SET "name"=$1,"phone"=$2,"website"=$3,"time_zone"=$4,"is_deleted"=$5 WHERE "id" = $6`)).
+			WithArgs(theater.Name, theater.Phone, theater.Website, theater.TimeZone, theater.IsDeleted, theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.UpdateTheater(tx, theater)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error updating theater", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "theaters" SET "name"=$1,"phone"=$2,"website"=$3,"time_zone"=$4,"is_deleted"=$5 WHERE "id" = $6`)).
+			WithArgs(theater.Name, theater.Phone, theater.Website, theater.TimeZone, theater.IsDeleted, theater.ID).
+			WillReturnError(errors.New("error updating theater"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.UpdateTheater(tx, theater)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error updating theater", err.Error())
+	})
+}
+
+func TestTheaterRepository_LockTheater(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterRepository(db, true)
+
+	theater := utils.GenerateTheater()
+	query := regexp.QuoteMeta(`SELECT * FROM "theaters" WHERE id = $1 LIMIT $2 FOR UPDATE`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(theater))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.LockTheater(tx, theater.ID)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Equal(t, theater.ID, result.ID)
+	})
+
+	t.Run("theater not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(nil))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.LockTheater(tx, theater.ID)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("error locking theater", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(query).
+			WithArgs(theater.ID, 1).
+			WillReturnError(errors.New("error locking theater"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		result, err := repo.LockTheater(tx, theater.ID)
+		tx.Rollback()
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "error locking theater")
+	})
+}
+
+func TestTheaterRepository_DeleteTheater(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewTheaterRepository(db, true)
+
+	theater := utils.GenerateTheater()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "theaters" SET "is_deleted"=$1 WHERE "id" = $2`)).
+			WithArgs(true, theater.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteTheater(tx, theater)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting theater", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "theaters" SET "is_deleted"=$1 WHERE "id" = $2`)).
+			WithArgs(true, theater.ID).
+			WillReturnError(errors.New("error deleting theater"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteTheater(tx, theater)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error deleting theater", err.Error())
+	})
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	GetIdentity(filter filters.UserIdentityFilter) (*models.UserIdentity, error)
+	CreateIdentity(tx *gorm.DB, identity *models.UserIdentity) error
+}
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{db}
+}
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+func (r *userIdentityRepository) GetIdentity(filter filters.UserIdentityFilter) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	if err := filter.GetFilterQuery(r.db).First(&identity).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (r *userIdentityRepository) CreateIdentity(tx *gorm.DB, identity *models.UserIdentity) error {
+	return tx.Create(identity).Error
+}
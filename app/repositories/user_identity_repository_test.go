@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestUserIdentityRepository_GetIdentity(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserIdentityRepository(db)
+
+	identity := utils.GenerateUserIdentity()
+	filter := filters.UserIdentityFilter{
+		Filter:   &filters.SingleFilter{Logic: filters.And},
+		Provider: &filters.Condition{Operator: filters.OpEqual, Value: identity.Provider},
+		Subject:  &filters.Condition{Operator: filters.OpEqual, Value: identity.Subject},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE provider = $1 AND subject = $2 ORDER BY "user_identities"."id" LIMIT $3`)).
+			WithArgs(identity.Provider, identity.Subject, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(identity))
+
+		result, err := repo.GetIdentity(filter)
+
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+		assert.Equal(t, identity, result)
+	})
+
+	t.Run("identity not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE provider = $1 AND subject = $2 ORDER BY "user_identities"."id" LIMIT $3`)).
+			WithArgs(identity.Provider, identity.Subject, 1).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetIdentity(filter)
+
+		assert.Nil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE provider = $1 AND subject = $2 ORDER BY "user_identities"."id" LIMIT $3`)).
+			WithArgs(identity.Provider, identity.Subject, 1).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetIdentity(filter)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserIdentityRepository_CreateIdentity(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserIdentityRepository(db)
+
+	identity := utils.GenerateUserIdentity()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_identities" ("id","user_id","provider","subject","email","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+			WithArgs(identity.ID, identity.UserID, identity.Provider, identity.Subject, identity.Email, identity.CreatedAt, identity.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateIdentity(db, identity)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_identities" ("id","user_id","provider","subject","email","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+			WithArgs(identity.ID, identity.UserID, identity.Provider, identity.Subject, identity.Email, identity.CreatedAt, identity.UpdatedAt).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateIdentity(db, identity)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
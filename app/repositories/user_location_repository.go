@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+)
+
+type UserLocationRepository interface {
+	GetUserLocation(ip string) (*models.UserLocation, error)
+	CreateUserLocation(ip string, expiration time.Duration, location *models.UserLocation) error
+}
+
+type userLocationRepository struct {
+	ctx context.Context
+	rdb *redis.Client
+}
+
+func NewUserLocationRepository(rdb *redis.Client) UserLocationRepository {
+	return &userLocationRepository{ctx: context.Background(), rdb: rdb}
+}
+
+func (r *userLocationRepository) GetUserLocation(ip string) (*models.UserLocation, error) {
+	locationString, err := r.rdb.Get(r.ctx, r.getLocationKey(ip)).Result()
+	if err != nil {
+		if errors.IsRedisKeyNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var l models.UserLocation
+	if err := json.Unmarshal([]byte(locationString), &l); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func (r *userLocationRepository) CreateUserLocation(ip string, expiration time.Duration, location *models.UserLocation) error {
+	locationData, err := json.Marshal(location)
+	if err != nil {
+		return err
+	}
+
+	return r.rdb.Set(r.ctx, r.getLocationKey(ip), locationData, expiration).Err()
+}
+
+func (r *userLocationRepository) getLocationKey(ip string) string {
+	return fmt.Sprintf("%s:%s", constants.UserLocation, ip)
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+)
+
+func TestUserLocationRepository_GetUserLocation(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	repo := NewUserLocationRepository(client)
+
+	ip := "203.0.113.10"
+	key := fmt.Sprintf("%s:%s", constants.UserLocation, ip)
+	location := &models.UserLocation{Latitude: 10.8231, Longitude: 106.6297}
+
+	t.Run("success", func(t *testing.T) {
+		locationJSON, _ := json.Marshal(location)
+		mock.ExpectGet(key).SetVal(string(locationJSON))
+
+		result, err := repo.GetUserLocation(ip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, location, result)
+	})
+
+	t.Run("location not found", func(t *testing.T) {
+		mock.ExpectGet(key).RedisNil()
+
+		result, err := repo.GetUserLocation(ip)
+
+		assert.Nil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectGet(key).SetErr(errors.New("db error"))
+
+		result, err := repo.GetUserLocation(ip)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserLocationRepository_CreateUserLocation(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	repo := NewUserLocationRepository(client)
+
+	ip := "203.0.113.10"
+	key := fmt.Sprintf("%s:%s", constants.UserLocation, ip)
+	location := &models.UserLocation{Latitude: 10.8231, Longitude: 106.6297}
+	expiration := 24 * time.Hour
+
+	t.Run("success", func(t *testing.T) {
+		locationJSON, _ := json.Marshal(location)
+		mock.ExpectSet(key, locationJSON, expiration).SetVal("OK")
+
+		err := repo.CreateUserLocation(ip, expiration, location)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		locationJSON, _ := json.Marshal(location)
+		mock.ExpectSet(key, locationJSON, expiration).SetErr(errors.New("db error"))
+
+		err := repo.CreateUserLocation(ip, expiration, location)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+)
+
+type UserRecoveryCodeRepository interface {
+	GetCode(filter filters.UserRecoveryCodeFilter) (*models.UserRecoveryCode, error)
+	CreateCodes(tx *gorm.DB, codes []*models.UserRecoveryCode) error
+	UseCode(tx *gorm.DB, code *models.UserRecoveryCode) (bool, error)
+	DeleteUserCodes(tx *gorm.DB, userID uuid.UUID) error
+}
+
+func NewUserRecoveryCodeRepository(db *gorm.DB) UserRecoveryCodeRepository {
+	return &userRecoveryCodeRepository{db}
+}
+
+type userRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func (r *userRecoveryCodeRepository) GetCode(filter filters.UserRecoveryCodeFilter) (*models.UserRecoveryCode, error) {
+	var code models.UserRecoveryCode
+	if err := filter.GetFilterQuery(r.db).First(&code).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+func (r *userRecoveryCodeRepository) CreateCodes(tx *gorm.DB, codes []*models.UserRecoveryCode) error {
+	return tx.Create(codes).Error
+}
+
+// UseCode marks the code as used only if it is still unused and reports whether it did.
+func (r *userRecoveryCodeRepository) UseCode(tx *gorm.DB, code *models.UserRecoveryCode) (bool, error) {
+	result := tx.Model(code).Where("is_used = ?", false).Updates(map[string]any{"is_used": true})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *userRecoveryCodeRepository) DeleteUserCodes(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Delete(&models.UserRecoveryCode{}, "user_id = ?", userID).Error
+}
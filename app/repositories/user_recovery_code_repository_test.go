@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestUserRecoveryCodeRepository_GetCode(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserRecoveryCodeRepository(db)
+
+	code := utils.GenerateUserRecoveryCode()
+	filter := filters.UserRecoveryCodeFilter{
+		Filter:   &filters.SingleFilter{Logic: filters.And},
+		UserID:   &filters.Condition{Operator: filters.OpEqual, Value: code.UserID},
+		CodeHash: &filters.Condition{Operator: filters.OpEqual, Value: code.CodeHash},
+		IsUsed:   &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_recovery_codes" WHERE user_id = $1 AND code_hash = $2 AND is_used = $3 ORDER BY "user_recovery_codes"."id" LIMIT $4`)).
+			WithArgs(code.UserID, code.CodeHash, false, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(code))
+
+		result, err := repo.GetCode(filter)
+
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+		assert.Equal(t, code, result)
+	})
+
+	t.Run("code not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_recovery_codes" WHERE user_id = $1 AND code_hash = $2 AND is_used = $3 ORDER BY "user_recovery_codes"."id" LIMIT $4`)).
+			WithArgs(code.UserID, code.CodeHash, false, 1).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetCode(filter)
+
+		assert.Nil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_recovery_codes" WHERE user_id = $1 AND code_hash = $2 AND is_used = $3 ORDER BY "user_recovery_codes"."id" LIMIT $4`)).
+			WithArgs(code.UserID, code.CodeHash, false, 1).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetCode(filter)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserRecoveryCodeRepository_CreateCodes(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserRecoveryCodeRepository(db)
+
+	codes := utils.GenerateUserRecoveryCodes(2)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_recovery_codes" ("id","user_id","code_hash","is_used","created_at") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+			WithArgs(
+				codes[0].ID, codes[0].UserID, codes[0].CodeHash, codes[0].IsUsed, codes[0].CreatedAt,
+				codes[1].ID, codes[1].UserID, codes[1].CodeHash, codes[1].IsUsed, codes[1].CreatedAt,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateCodes(tx, codes)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_recovery_codes" ("id","user_id","code_hash","is_used","created_at") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateCodes(tx, codes)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserRecoveryCodeRepository_UseCode(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserRecoveryCodeRepository(db)
+
+	code := utils.GenerateUserRecoveryCode()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_recovery_codes" SET "is_used"=$1 WHERE is_used = $2 AND "id" = $3`)).
+			WithArgs(true, false, code.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.UseCode(tx, code)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("code already used", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_recovery_codes" SET "is_used"=$1 WHERE is_used = $2 AND "id" = $3`)).
+			WithArgs(true, false, code.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.UseCode(tx, code)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_recovery_codes" SET "is_used"=$1 WHERE is_used = $2 AND "id" = $3`)).
+			WithArgs(true, false, code.ID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		result, err := repo.UseCode(tx, code)
+		tx.Rollback()
+
+		assert.False(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserRecoveryCodeRepository_DeleteUserCodes(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserRecoveryCodeRepository(db)
+
+	code := utils.GenerateUserRecoveryCode()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_recovery_codes" WHERE user_id = $1`)).
+			WithArgs(code.UserID).
+			WillReturnResult(sqlmock.NewResult(1, 10))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteUserCodes(tx, code.UserID)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_recovery_codes" WHERE user_id = $1`)).
+			WithArgs(code.UserID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteUserCodes(tx, code.UserID)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
@@ -1,16 +1,20 @@
 package repositories
 
 import (
+	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"gorm.io/gorm"
+	"time"
 )
 
 type UserRegistrationTokenRepository interface {
 	GetToken(filter filters.UserRegistrationTokenFilter) (*models.UserRegistrationToken, error)
+	GetTokens(filter filters.UserRegistrationTokenFilter) ([]*models.UserRegistrationToken, error)
 	CreateToken(tx *gorm.DB, token *models.UserRegistrationToken) error
 	UseToken(tx *gorm.DB, token *models.UserRegistrationToken) error
+	RevokeTokens(tx *gorm.DB, tokens []*models.UserRegistrationToken) error
 }
 
 func NewUserRegistrationTokenRepository(db *gorm.DB) UserRegistrationTokenRepository {
@@ -34,6 +38,15 @@ func (r *userRegistrationTokenRepository) GetToken(filter filters.UserRegistrati
 	return &token, nil
 }
 
+func (r *userRegistrationTokenRepository) GetTokens(filter filters.UserRegistrationTokenFilter) ([]*models.UserRegistrationToken, error) {
+	var tokens []*models.UserRegistrationToken
+	if err := filter.GetFilterQuery(r.db).Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
 func (r *userRegistrationTokenRepository) CreateToken(tx *gorm.DB, token *models.UserRegistrationToken) error {
 	return tx.Create(token).Error
 }
@@ -41,3 +54,17 @@ func (r *userRegistrationTokenRepository) CreateToken(tx *gorm.DB, token *models
 func (r *userRegistrationTokenRepository) UseToken(tx *gorm.DB, token *models.UserRegistrationToken) error {
 	return tx.Model(token).Updates(map[string]any{"is_used": true}).Error
 }
+
+func (r *userRegistrationTokenRepository) RevokeTokens(tx *gorm.DB, tokens []*models.UserRegistrationToken) error {
+	tokenIDs := make([]uuid.UUID, len(tokens))
+	for i, token := range tokens {
+		tokenIDs[i] = token.ID
+	}
+
+	filter := filters.UserRegistrationTokenFilter{
+		Filter: &filters.MultiFilter{},
+		ID:     &filters.Condition{Operator: filters.OpIn, Value: tokenIDs},
+	}
+
+	return filter.GetFilterQuery(tx).Model(&models.UserRegistrationToken{}).Updates(map[string]any{"expires_at": time.Now().UTC()}).Error
+}
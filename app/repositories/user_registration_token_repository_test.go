@@ -9,6 +9,7 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func TestUserRegistrationTokenRepository_GetToken(t *testing.T) {
@@ -61,6 +62,49 @@ func TestUserRegistrationTokenRepository_GetToken(t *testing.T) {
 	})
 }
 
+func TestUserRegistrationTokenRepository_GetTokens(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserRegistrationTokenRepository(db)
+
+	user := utils.GenerateUser()
+	filter := filters.UserRegistrationTokenFilter{
+		Filter:    &filters.MultiFilter{},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: user.ID},
+		IsUsed:    &filters.Condition{Operator: filters.OpEqual, Value: false},
+		ExpiresAt: &filters.Condition{Operator: filters.OpGreater, Value: time.Now().UTC()},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		tokens := utils.GenerateUserRegistrationTokens(3)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_registration_tokens" WHERE user_id = $1 AND is_used = $2 AND expires_at > $3`)).
+			WithArgs(filter.UserID.Value, filter.IsUsed.Value, filter.ExpiresAt.Value).
+			WillReturnRows(utils.GenerateSqlMockRows(tokens))
+
+		result, err := repo.GetTokens(filter)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, tokens, result)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_registration_tokens" WHERE user_id = $1 AND is_used = $2 AND expires_at > $3`)).
+			WithArgs(filter.UserID.Value, filter.IsUsed.Value, filter.ExpiresAt.Value).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetTokens(filter)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
 func TestUserRegistrationTokenRepository_CreateToken(t *testing.T) {
 	db, mock := mock_db.SetupTestDB(t)
 	defer func() {
@@ -140,3 +184,43 @@ func TestUserRegistrationTokenRepository_UseToken(t *testing.T) {
 		assert.EqualError(t, err, "db error")
 	})
 }
+
+func TestUserRegistrationTokenRepository_RevokeTokens(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserRegistrationTokenRepository(db)
+
+	tokens := utils.GenerateUserRegistrationTokens(3)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_registration_tokens" SET "expires_at"=$1 WHERE id IN ($2,$3,$4)`)).
+			WithArgs(sqlmock.AnyArg(), tokens[0].ID, tokens[1].ID, tokens[2].ID).
+			WillReturnResult(sqlmock.NewResult(3, 3))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.RevokeTokens(tx, tokens)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_registration_tokens" SET "expires_at"=$1 WHERE id IN ($2,$3,$4)`)).
+			WithArgs(sqlmock.AnyArg(), tokens[0].ID, tokens[1].ID, tokens[2].ID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.RevokeTokens(tx, tokens)
+		tx.Rollback()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
@@ -8,13 +8,14 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 )
 
 type UserSessionRepository interface {
 	GetUserSession(sessionID string) (*models.UserSession, error)
-	GetUserSessionID(tokenValue string) string
+	GetUserSessionID(tokenHash string) string
 	CreateUserSession(sessionID string, expiration time.Duration, session *models.UserSession) error
 	DeleteUserSession(sessionID string) error
 	DeleteUserSessions(userID uuid.UUID) error
@@ -47,8 +48,8 @@ func (r *userSessionRepository) GetUserSession(sessionID string) (*models.UserSe
 	return &s, nil
 }
 
-func (r *userSessionRepository) GetUserSessionID(tokenValue string) string {
-	return fmt.Sprintf("session:%s", tokenValue)
+func (r *userSessionRepository) GetUserSessionID(tokenHash string) string {
+	return fmt.Sprintf("%s:%s", constants.UserSession, tokenHash)
 }
 
 func (r *userSessionRepository) CreateUserSession(sessionID string, expiration time.Duration, session *models.UserSession) error {
@@ -78,7 +79,7 @@ func (r *userSessionRepository) DeleteUserSessions(userID uuid.UUID) error {
 	var err error
 
 	for {
-		keys, cursor, err = r.rdb.Scan(r.ctx, cursor, "*", 100).Result()
+		keys, cursor, err = r.rdb.Scan(r.ctx, cursor, r.GetUserSessionID("*"), 100).Result()
 		if err != nil {
 			return fmt.Errorf("error scanning keys: %v", err)
 		}
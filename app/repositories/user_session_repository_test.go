@@ -135,7 +135,7 @@ func TestUserSessionRepository_DeleteUserSessions(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		sessionData, _ := json.Marshal(session)
 
-		mock.ExpectScan(0, "*", 100).SetVal([]string{sessionID}, 0)
+		mock.ExpectScan(0, "session:*", 100).SetVal([]string{sessionID}, 0)
 		mock.ExpectGet(sessionID).SetVal(string(sessionData))
 		mock.ExpectDel(sessionID).SetVal(1)
 
@@ -145,7 +145,7 @@ func TestUserSessionRepository_DeleteUserSessions(t *testing.T) {
 	})
 
 	t.Run("error scanning keys", func(t *testing.T) {
-		mock.ExpectScan(0, "*", 100).SetErr(errors.New("error scanning keys"))
+		mock.ExpectScan(0, "session:*", 100).SetErr(errors.New("error scanning keys"))
 
 		err := repo.DeleteUserSessions(uuid.New())
 
@@ -154,7 +154,7 @@ func TestUserSessionRepository_DeleteUserSessions(t *testing.T) {
 	})
 
 	t.Run("error getting keys", func(t *testing.T) {
-		mock.ExpectScan(0, "*", 100).SetVal([]string{sessionID}, 0)
+		mock.ExpectScan(0, "session:*", 100).SetVal([]string{sessionID}, 0)
 		mock.ExpectGet(sessionID).SetErr(errors.New("error getting keys"))
 
 		err := repo.DeleteUserSessions(session.UserID)
@@ -166,7 +166,7 @@ func TestUserSessionRepository_DeleteUserSessions(t *testing.T) {
 	t.Run("error deleting sessions", func(t *testing.T) {
 		sessionData, _ := json.Marshal(session)
 
-		mock.ExpectScan(0, "*", 100).SetVal([]string{sessionID}, 0)
+		mock.ExpectScan(0, "session:*", 100).SetVal([]string{sessionID}, 0)
 		mock.ExpectGet(sessionID).SetVal(string(sessionData))
 		mock.ExpectDel(sessionID).SetErr(errors.New("error deleting sessions"))
 
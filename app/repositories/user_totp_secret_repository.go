@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type UserTotpSecretRepository interface {
+	GetSecret(filter filters.UserTotpSecretFilter) (*models.UserTotpSecret, error)
+	CreateSecret(tx *gorm.DB, secret *models.UserTotpSecret) error
+	EnableSecret(tx *gorm.DB, secret *models.UserTotpSecret, step int64) error
+	UpdateLastUsedStep(tx *gorm.DB, secret *models.UserTotpSecret, step int64) (bool, error)
+	DeleteUserSecret(tx *gorm.DB, userID uuid.UUID) error
+}
+
+func NewUserTotpSecretRepository(db *gorm.DB) UserTotpSecretRepository {
+	return &userTotpSecretRepository{db}
+}
+
+type userTotpSecretRepository struct {
+	db *gorm.DB
+}
+
+func (r *userTotpSecretRepository) GetSecret(filter filters.UserTotpSecretFilter) (*models.UserTotpSecret, error) {
+	var secret models.UserTotpSecret
+	if err := filter.GetFilterQuery(r.db).First(&secret).Error; err != nil {
+		if errors.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+func (r *userTotpSecretRepository) CreateSecret(tx *gorm.DB, secret *models.UserTotpSecret) error {
+	return tx.Create(secret).Error
+}
+
+func (r *userTotpSecretRepository) EnableSecret(tx *gorm.DB, secret *models.UserTotpSecret, step int64) error {
+	return tx.Model(secret).Updates(map[string]any{
+		"is_enabled":     true,
+		"last_used_step": step,
+		"updated_at":     time.Now().UTC(),
+	}).Error
+}
+
+// UpdateLastUsedStep records the step only if it is newer than the stored one and reports whether it did,
+// so a code accepted by a concurrent request is rejected as a replay.
+func (r *userTotpSecretRepository) UpdateLastUsedStep(tx *gorm.DB, secret *models.UserTotpSecret, step int64) (bool, error) {
+	result := tx.Model(secret).Where("last_used_step < ?", step).Updates(map[string]any{
+		"last_used_step": step,
+		"updated_at":     time.Now().UTC(),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *userTotpSecretRepository) DeleteUserSecret(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Delete(&models.UserTotpSecret{}, "user_id = ?", userID).Error
+}
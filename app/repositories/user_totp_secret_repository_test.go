@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"regexp"
+	"testing"
+)
+
+func TestUserTotpSecretRepository_GetSecret(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserTotpSecretRepository(db)
+
+	secret := utils.GenerateUserTotpSecret()
+	filter := filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: secret.UserID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: secret.IsEnabled},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_totp_secrets" WHERE user_id = $1 AND is_enabled = $2 ORDER BY "user_totp_secrets"."id" LIMIT $3`)).
+			WithArgs(secret.UserID, secret.IsEnabled, 1).
+			WillReturnRows(utils.GenerateSqlMockRow(secret))
+
+		result, err := repo.GetSecret(filter)
+
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, result)
+	})
+
+	t.Run("secret not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_totp_secrets" WHERE user_id = $1 AND is_enabled = $2 ORDER BY "user_totp_secrets"."id" LIMIT $3`)).
+			WithArgs(secret.UserID, secret.IsEnabled, 1).
+			WillReturnRows(sqlmock.NewRows(nil))
+
+		result, err := repo.GetSecret(filter)
+
+		assert.Nil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_totp_secrets" WHERE user_id = $1 AND is_enabled = $2 ORDER BY "user_totp_secrets"."id" LIMIT $3`)).
+			WithArgs(secret.UserID, secret.IsEnabled, 1).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetSecret(filter)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserTotpSecretRepository_CreateSecret(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserTotpSecretRepository(db)
+
+	secret := utils.GenerateUserTotpSecret()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_totp_secrets" ("id","user_id","secret","is_enabled","last_used_step","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+			WithArgs(secret.ID, secret.UserID, secret.Secret, secret.IsEnabled, secret.LastUsedStep, secret.CreatedAt, secret.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.CreateSecret(tx, secret)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_totp_secrets" ("id","user_id","secret","is_enabled","last_used_step","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7)`)).
+			WithArgs(secret.ID, secret.UserID, secret.Secret, secret.IsEnabled, secret.LastUsedStep, secret.CreatedAt, secret.UpdatedAt).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.CreateSecret(tx, secret)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserTotpSecretRepository_EnableSecret(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserTotpSecretRepository(db)
+
+	secret := utils.GenerateUserTotpSecret()
+	step := secret.LastUsedStep + 1
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_totp_secrets" SET "is_enabled"=$1,"last_used_step"=$2,"updated_at"=$3 WHERE "id" = $4`)).
+			WithArgs(true, step, sqlmock.AnyArg(), secret.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.EnableSecret(tx, secret, step)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_totp_secrets" SET "is_enabled"=$1,"last_used_step"=$2,"updated_at"=$3 WHERE "id" = $4`)).
+			WithArgs(true, step, sqlmock.AnyArg(), secret.ID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.EnableSecret(tx, secret, step)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserTotpSecretRepository_UpdateLastUsedStep(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserTotpSecretRepository(db)
+
+	secret := utils.GenerateUserTotpSecret()
+	step := secret.LastUsedStep + 1
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_totp_secrets" SET "last_used_step"=$1,"updated_at"=$2 WHERE last_used_step < $3 AND "id" = $4`)).
+			WithArgs(step, sqlmock.AnyArg(), step, secret.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.UpdateLastUsedStep(tx, secret, step)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("step already used", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_totp_secrets" SET "last_used_step"=$1,"updated_at"=$2 WHERE last_used_step < $3 AND "id" = $4`)).
+			WithArgs(step, sqlmock.AnyArg(), step, secret.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		result, err := repo.UpdateLastUsedStep(tx, secret, step)
+		tx.Commit()
+
+		assert.Nil(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_totp_secrets" SET "last_used_step"=$1,"updated_at"=$2 WHERE last_used_step < $3 AND "id" = $4`)).
+			WithArgs(step, sqlmock.AnyArg(), step, secret.ID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		result, err := repo.UpdateLastUsedStep(tx, secret, step)
+		tx.Rollback()
+
+		assert.False(t, result)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestUserTotpSecretRepository_DeleteUserSecret(t *testing.T) {
+	db, mock := mock_db.SetupTestDB(t)
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestDB(db, mock))
+	}()
+
+	repo := NewUserTotpSecretRepository(db)
+
+	secret := utils.GenerateUserTotpSecret()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_totp_secrets" WHERE user_id = $1`)).
+			WithArgs(secret.UserID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := db.Begin()
+		err := repo.DeleteUserSecret(tx, secret.UserID)
+		tx.Commit()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_totp_secrets" WHERE user_id = $1`)).
+			WithArgs(secret.UserID).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx := db.Begin()
+		err := repo.DeleteUserSecret(tx, secret.UserID)
+		tx.Rollback()
+
+		assert.EqualError(t, err, "db error")
+	})
+}
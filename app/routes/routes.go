@@ -27,13 +27,21 @@ func RegisterRoutes() *gin.Engine {
 			users.POST("/", c.UserController.CreateUser)
 
 			users.POST("/login", c.UserController.LoginUser)
+			users.POST("/login/two-factor", c.UserController.VerifyTwoFactorLogin)
+			users.GET("/login/oidc/:provider", c.UserController.StartOidcLogin)
+			users.POST("/login/oidc/:provider/callback", c.UserController.CompleteOidcLogin)
 			users.POST("/logout", m.AuthMiddleware.RequireAuthMiddleware(), c.UserController.LogoutUser)
 
 			users.POST("/verify", c.UserController.VerifyUser)
+			users.POST("/verification/resend", c.UserController.ResendVerificationEmail)
 
 			users.PUT("/password", m.AuthMiddleware.RequireAuthMiddleware(), c.UserController.UpdateUserPassword)
 			users.POST("/password-reset-token", c.UserController.CreatePasswordResetToken)
 			users.POST("/password-reset", c.UserController.ResetPassword)
+
+			users.POST("/me/two-factor", m.AuthMiddleware.RequireAuthMiddleware(), c.UserController.EnrollTwoFactor)
+			users.POST("/me/two-factor/confirm", m.AuthMiddleware.RequireAuthMiddleware(), c.UserController.ConfirmTwoFactor)
+			users.DELETE("/me/two-factor", m.AuthMiddleware.RequireAuthMiddleware(), c.UserController.DisableTwoFactor)
 		}
 
 		profiles := apiV1.Group("/profiles")
@@ -74,6 +82,30 @@ func RegisterRoutes() *gin.Engine {
 				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
 				c.MovieController.UpdateMovieGenres,
 			)
+			movies.PUT(
+				"/:id/credits",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.MovieController.UpdateMovieCredits,
+			)
+			movies.PUT(
+				"/:id/certifications",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.MovieController.UpdateMovieCertifications,
+			)
+			movies.PUT(
+				"/:id/languages",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.MovieController.UpdateMovieLanguages,
+			)
+			movies.PUT(
+				"/:id/external-ids",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.MovieController.UpdateMovieExternalIDs,
+			)
 			movies.DELETE(
 				"/:id",
 				m.AuthMiddleware.RequireAuthMiddleware(),
@@ -82,6 +114,30 @@ func RegisterRoutes() *gin.Engine {
 			)
 		}
 
+		people := apiV1.Group("/people")
+		{
+			people.GET("/:id", c.PersonController.GetPerson)
+			people.GET("/", c.PersonController.SearchPeople)
+			people.POST(
+				"/",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.PersonController.CreatePerson,
+			)
+			people.PUT(
+				"/:id",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.PersonController.UpdatePerson,
+			)
+			people.DELETE(
+				"/:id",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyMovies),
+				c.PersonController.DeletePerson,
+			)
+		}
+
 		genres := apiV1.Group("/genres")
 		{
 			genres.GET("/:id", c.GenreController.GetGenre)
@@ -115,6 +171,29 @@ func RegisterRoutes() *gin.Engine {
 				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
 				c.LocationController.CreateCountry,
 			)
+			countries.POST(
+				"/import",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+				m.FilesUploadMiddleware.RequireNumberOfUploadedFilesMiddleware(constants.LocationImportFileFormKey, 1),
+				m.FilesUploadMiddleware.NotExceedMaxSizeLimitMiddleware(constants.LocationImportFileFormKey, config.AppEnv.MaxLocationImportFileSize),
+				m.FilesUploadMiddleware.NotExceedMaxSizeLimitMiddleware(constants.CountryInfoFileFormKey, config.AppEnv.MaxLocationImportFileSize),
+				m.FilesUploadMiddleware.NotExceedMaxSizeLimitMiddleware(constants.Admin1CodesFileFormKey, config.AppEnv.MaxLocationImportFileSize),
+				c.LocationController.ImportLocations,
+			)
+			countries.GET("/:countryId", c.LocationController.GetCountry)
+			countries.PUT(
+				"/:countryId",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+				c.LocationController.UpdateCountry,
+			)
+			countries.DELETE(
+				"/:countryId",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+				c.LocationController.DeleteCountry,
+			)
 
 			states := countries.Group("/:countryId/states")
 			{
@@ -125,6 +204,19 @@ func RegisterRoutes() *gin.Engine {
 					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
 					c.LocationController.CreateState,
 				)
+				states.GET("/:stateId", c.LocationController.GetState)
+				states.PUT(
+					"/:stateId",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+					c.LocationController.UpdateState,
+				)
+				states.DELETE(
+					"/:stateId",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+					c.LocationController.DeleteState,
+				)
 
 				cities := states.Group("/:stateId/cities")
 				{
@@ -135,10 +227,28 @@ func RegisterRoutes() *gin.Engine {
 						m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
 						c.LocationController.CreateCity,
 					)
+					cities.GET("/:cityId", c.LocationController.GetCity)
+					cities.PUT(
+						"/:cityId",
+						m.AuthMiddleware.RequireAuthMiddleware(),
+						m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+						c.LocationController.UpdateCity,
+					)
+					cities.DELETE(
+						"/:cityId",
+						m.AuthMiddleware.RequireAuthMiddleware(),
+						m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyLocations),
+						c.LocationController.DeleteCity,
+					)
 				}
 			}
 		}
 
+		cities := apiV1.Group("/cities")
+		{
+			cities.GET("/search", c.LocationController.SearchCities)
+		}
+
 		theaters := apiV1.Group("/theaters")
 		{
 			theaters.GET("/:theaterId", c.TheaterController.GetTheater)
@@ -150,6 +260,18 @@ func RegisterRoutes() *gin.Engine {
 				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
 				c.TheaterController.CreateTheater,
 			)
+			theaters.PUT(
+				"/:theaterId",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+				c.TheaterController.UpdateTheater,
+			)
+			theaters.DELETE(
+				"/:theaterId",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+				c.TheaterController.DeleteTheater,
+			)
 
 			theaterLocations := theaters.Group("/:theaterId/locations")
 			{
@@ -165,16 +287,76 @@ func RegisterRoutes() *gin.Engine {
 					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
 					c.TheaterController.UpdateTheaterLocation,
 				)
+				theaterLocations.DELETE(
+					"/",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.DeleteTheaterLocation,
+				)
+			}
+
+			theaters.PUT(
+				"/:theaterId/amenities",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+				c.TheaterController.UpdateTheaterAmenities,
+			)
+			theaters.PUT(
+				"/:theaterId/opening-hours",
+				m.AuthMiddleware.RequireAuthMiddleware(),
+				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+				c.TheaterController.UpdateTheaterOpeningHours,
+			)
+
+			theaterPhotos := theaters.Group("/:theaterId/photos")
+			{
+				theaterPhotos.POST(
+					"/",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.CreateTheaterPhoto,
+				)
+				theaterPhotos.DELETE(
+					"/:photoId",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.DeleteTheaterPhoto,
+				)
 			}
 
 			seats := theaters.Group("/:theaterId/seats")
 			{
+				seats.GET("/", c.TheaterController.GetSeats)
 				seats.POST(
 					"/",
 					m.AuthMiddleware.RequireAuthMiddleware(),
 					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
 					c.TheaterController.CreateSeat,
 				)
+				seats.PUT(
+					"/:seatId",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.UpdateSeat,
+				)
+				seats.DELETE(
+					"/:seatId",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.DeleteSeat,
+				)
+				seats.POST(
+					"/:seatId/block",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.BlockSeat,
+				)
+				seats.DELETE(
+					"/:seatId/block",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyTheaters),
+					c.TheaterController.UnblockSeat,
+				)
 			}
 		}
 
@@ -183,12 +365,30 @@ func RegisterRoutes() *gin.Engine {
 			shows.GET("/:id", m.AuthMiddleware.OptionalAuthMiddleware(), c.ShowController.GetShow)
 			shows.GET("/active", c.ShowController.GetActiveShows)
 			shows.GET("/scheduled", c.ShowController.GetScheduledShows)
+			shows.GET("/:id/age-check", m.AuthMiddleware.RequireAuthMiddleware(), c.ShowController.CheckAgeRestriction)
 			shows.POST(
 				"/",
 				m.AuthMiddleware.RequireAuthMiddleware(),
 				m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyShows),
 				c.ShowController.CreateShow,
 			)
+
+			showSeats := shows.Group("/:id/seats")
+			{
+				showSeats.GET("/", m.AuthMiddleware.OptionalAuthMiddleware(), c.ShowController.GetAvailableSeats)
+				showSeats.POST(
+					"/:seatId/block",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyShows),
+					c.ShowController.BlockSeat,
+				)
+				showSeats.DELETE(
+					"/:seatId/block",
+					m.AuthMiddleware.RequireAuthMiddleware(),
+					m.AuthMiddleware.RequireFeatureFlagMiddleware(constants.CanModifyShows),
+					c.ShowController.UnblockSeat,
+				)
+			}
 		}
 	}
 
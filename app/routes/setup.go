@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/auth"
@@ -36,25 +37,50 @@ type Repositories struct {
 	GenreRepository                 repositories.GenreRepository
 	PasswordResetTokenRepository    repositories.PasswordResetTokenRepository
 	MovieGenreRepository            repositories.MovieGenreRepository
+	PersonRepository                repositories.PersonRepository
+	MovieCreditRepository           repositories.MovieCreditRepository
+	MovieCertificationRepository    repositories.MovieCertificationRepository
+	MovieLanguageRepository         repositories.MovieLanguageRepository
+	MovieExternalIDRepository       repositories.MovieExternalIDRepository
 	CountryRepository               repositories.CountryRepository
 	StateRepository                 repositories.StateRepository
 	CityRepository                  repositories.CityRepository
 	TheaterRepository               repositories.TheaterRepository
 	TheaterLocationRepository       repositories.TheaterLocationRepository
+	TheaterAmenityRepository        repositories.TheaterAmenityRepository
+	TheaterOpeningHourRepository    repositories.TheaterOpeningHourRepository
+	TheaterPhotoRepository          repositories.TheaterPhotoRepository
 	SeatRepository                  repositories.SeatRepository
+	SeatBlockRepository             repositories.SeatBlockRepository
 	ShowRepository                  repositories.ShowRepository
 	NotificationRepository          repositories.NotificationRepository
+	UserTotpSecretRepository        repositories.UserTotpSecretRepository
+	UserRecoveryCodeRepository      repositories.UserRecoveryCodeRepository
+	LoginChallengeRepository        repositories.LoginChallengeRepository
+	UserIdentityRepository          repositories.UserIdentityRepository
+	OidcStateRepository             repositories.OidcStateRepository
+	OutboxRepository                repositories.OutboxRepository
+	ProcessedEventRepository        repositories.ProcessedEventRepository
+	EmailBounceRepository           repositories.EmailBounceRepository
+	UserLocationRepository          repositories.UserLocationRepository
 }
 
 type Services struct {
-	UserService        services.UserService
-	UserProfileService services.UserProfileService
-	MovieService       services.MovieService
-	GenreService       services.GenreService
-	LocationService    services.LocationService
-	TheaterService     services.TheaterService
-	ShowService        services.ShowService
-	RateLimiterService services.RateLimiterService
+	UserService           services.UserService
+	UserProfileService    services.UserProfileService
+	MovieService          services.MovieService
+	GenreService          services.GenreService
+	PersonService         services.PersonService
+	LocationService       services.LocationService
+	LocationImportService services.LocationImportService
+	TheaterService        services.TheaterService
+	ShowService           services.ShowService
+	AgeGateService        services.AgeGateService
+	RateLimiterService    services.RateLimiterService
+	OutboxRelayService    services.OutboxRelayService
+	EventConsumerService  services.EventConsumerService
+	PaymentResultService  services.PaymentResultService
+	EmailBounceService    services.EmailBounceService
 }
 
 type Controllers struct {
@@ -62,6 +88,7 @@ type Controllers struct {
 	UserProfileController controllers.UserProfileController
 	MovieController       controllers.MovieController
 	GenreController       controllers.GenreController
+	PersonController      controllers.PersonController
 	LocationController    controllers.LocationController
 	TheaterController     controllers.TheaterController
 	ShowController        controllers.ShowController
@@ -75,6 +102,8 @@ type Middlewares struct {
 }
 
 func setupRepositories() {
+	outboxRepository := repositories.NewOutboxRepository(config.DB)
+	geographyAvailable := config.DB.Migrator().HasColumn("theater_locations", "geog")
 	r = &Repositories{
 		UserRepository:                  repositories.NewUserRepository(config.DB),
 		UserRegistrationTokenRepository: repositories.NewUserRegistrationTokenRepository(config.DB),
@@ -87,18 +116,48 @@ func setupRepositories() {
 		GenreRepository:                 repositories.NewGenreRepository(config.DB),
 		PasswordResetTokenRepository:    repositories.NewPasswordResetTokenRepository(config.DB),
 		MovieGenreRepository:            repositories.NewMovieGenreRepository(config.DB),
+		PersonRepository:                repositories.NewPersonRepository(config.DB),
+		MovieCreditRepository:           repositories.NewMovieCreditRepository(config.DB),
+		MovieCertificationRepository:    repositories.NewMovieCertificationRepository(config.DB),
+		MovieLanguageRepository:         repositories.NewMovieLanguageRepository(config.DB),
+		MovieExternalIDRepository:       repositories.NewMovieExternalIDRepository(config.DB),
 		CountryRepository:               repositories.NewCountryRepository(config.DB),
 		StateRepository:                 repositories.NewStateRepository(config.DB),
 		CityRepository:                  repositories.NewCityRepository(config.DB),
-		TheaterRepository:               repositories.NewTheaterRepository(config.DB),
+		TheaterRepository:               repositories.NewTheaterRepository(config.DB, geographyAvailable),
 		TheaterLocationRepository:       repositories.NewTheaterLocationRepository(config.DB),
+		TheaterAmenityRepository:        repositories.NewTheaterAmenityRepository(config.DB),
+		TheaterOpeningHourRepository:    repositories.NewTheaterOpeningHourRepository(config.DB),
+		TheaterPhotoRepository:          repositories.NewTheaterPhotoRepository(config.DB),
 		SeatRepository:                  repositories.NewSeatRepository(config.DB),
+		SeatBlockRepository:             repositories.NewSeatBlockRepository(config.DB),
 		ShowRepository:                  repositories.NewShowRepository(config.DB),
-		NotificationRepository:          repositories.NewNotificationRepository(config.KafkaProducerClient),
+		NotificationRepository:          repositories.NewNotificationRepository(outboxRepository),
+		UserTotpSecretRepository:        repositories.NewUserTotpSecretRepository(config.DB),
+		UserRecoveryCodeRepository:      repositories.NewUserRecoveryCodeRepository(config.DB),
+		LoginChallengeRepository:        repositories.NewLoginChallengeRepository(config.RedisClient),
+		UserIdentityRepository:          repositories.NewUserIdentityRepository(config.DB),
+		OidcStateRepository:             repositories.NewOidcStateRepository(config.RedisClient),
+		OutboxRepository:                outboxRepository,
+		ProcessedEventRepository:        repositories.NewProcessedEventRepository(config.DB),
+		EmailBounceRepository:           repositories.NewEmailBounceRepository(config.DB),
+		UserLocationRepository:          repositories.NewUserLocationRepository(config.RedisClient),
 	}
 }
 
 func setupServices(repositories *Repositories) {
+	showService := services.NewShowService(
+		config.DB,
+		transactionManager,
+		repositories.ShowRepository,
+		repositories.MovieRepository,
+		repositories.TheaterRepository,
+		repositories.TheaterOpeningHourRepository,
+		repositories.SeatRepository,
+		repositories.SeatBlockRepository,
+		repositories.FeatureFlagRepository,
+		repositories.NotificationRepository,
+	)
 	s = &Services{
 		UserService: services.NewUserService(
 			config.DB,
@@ -112,6 +171,26 @@ func setupServices(repositories *Repositories) {
 			repositories.PasswordResetTokenRepository,
 			repositories.UserRegistrationTokenRepository,
 			repositories.NotificationRepository,
+			repositories.UserTotpSecretRepository,
+			repositories.UserRecoveryCodeRepository,
+			repositories.LoginChallengeRepository,
+			repositories.UserIdentityRepository,
+			repositories.OidcStateRepository,
+			services.NewLoginThrottleService(
+				config.RedisClient,
+				config.AppEnv.MaxFailedLoginAttempts,
+				config.AppEnv.MaxFailedLoginAttemptsPerIp,
+				time.Duration(config.AppEnv.FailedLoginAttemptsWindowTime)*time.Minute,
+				time.Duration(config.AppEnv.LoginLockoutTime)*time.Minute,
+				time.Duration(config.AppEnv.LoginBackoffTime)*time.Second,
+			),
+			services.NewRateLimiterService(
+				config.RedisClient,
+				constants.VerificationResendRateLimit,
+				config.AppEnv.MaxVerificationEmailResends,
+				time.Duration(config.AppEnv.VerificationEmailResendWindowTime)*time.Minute,
+			),
+			setupOidcProviders(),
 		),
 		UserProfileService: services.NewUserProfileService(
 			config.DB,
@@ -126,13 +205,27 @@ func setupServices(repositories *Repositories) {
 			repositories.MovieRepository,
 			repositories.GenreRepository,
 			repositories.MovieGenreRepository,
+			repositories.PersonRepository,
+			repositories.MovieCreditRepository,
+			repositories.CountryRepository,
+			repositories.MovieCertificationRepository,
+			repositories.MovieLanguageRepository,
+			repositories.MovieExternalIDRepository,
 			repositories.FeatureFlagRepository,
+			repositories.NotificationRepository,
 		),
 		GenreService: services.NewGenreService(
 			config.DB,
 			transactionManager,
 			repositories.GenreRepository,
 			repositories.MovieGenreRepository,
+			repositories.NotificationRepository,
+		),
+		PersonService: services.NewPersonService(
+			config.DB,
+			transactionManager,
+			repositories.PersonRepository,
+			repositories.MovieCreditRepository,
 		),
 		LocationService: services.NewLocationService(
 			config.DB,
@@ -140,29 +233,72 @@ func setupServices(repositories *Repositories) {
 			repositories.CountryRepository,
 			repositories.StateRepository,
 			repositories.CityRepository,
+			repositories.TheaterLocationRepository,
+		),
+		LocationImportService: services.NewLocationImportService(
+			config.DB,
+			transactionManager,
+			repositories.CountryRepository,
+			repositories.StateRepository,
+			repositories.CityRepository,
+			config.AppEnv.LocationImportBatchSize,
 		),
 		TheaterService: services.NewTheaterService(
 			config.DB,
 			transactionManager,
 			repositories.TheaterRepository,
 			repositories.TheaterLocationRepository,
+			repositories.TheaterAmenityRepository,
+			repositories.TheaterOpeningHourRepository,
+			repositories.TheaterPhotoRepository,
 			repositories.SeatRepository,
+			repositories.SeatBlockRepository,
+			repositories.ShowRepository,
 			repositories.CityRepository,
-			services.NewUserLocationService(config.AppEnv.UserLocationApiUrl, config.AppEnv.UserLocationApiTimeout),
+			services.NewUserLocationService(
+				setupUserLocationProvider(),
+				repositories.UserLocationRepository,
+				config.AppEnv.UserLocationCacheTime,
+			),
+			repositories.NotificationRepository,
 		),
-		ShowService: services.NewShowService(
-			config.DB,
-			transactionManager,
-			repositories.ShowRepository,
-			repositories.MovieRepository,
-			repositories.TheaterRepository,
-			repositories.FeatureFlagRepository,
+		ShowService: showService,
+		AgeGateService: services.NewAgeGateService(
+			showService,
+			repositories.MovieCertificationRepository,
+			repositories.UserProfileRepository,
 		),
 		RateLimiterService: services.NewRateLimiterService(
 			config.RedisClient,
+			constants.ClientRateLimit,
 			config.AppEnv.MaxRequestsPerMinute,
 			time.Minute,
 		),
+		OutboxRelayService: services.NewOutboxRelayService(
+			config.DB,
+			transactionManager,
+			repositories.OutboxRepository,
+			config.KafkaProducerClient,
+			config.AppEnv.OutboxRelayBatchSize,
+			config.AppEnv.OutboxMaxAttempts,
+			time.Duration(config.AppEnv.OutboxRetryBackoffTime)*time.Second,
+			time.Duration(config.AppEnv.OutboxMaxRetryBackoffTime)*time.Second,
+			time.Duration(config.AppEnv.OutboxRetentionTime)*time.Hour,
+		),
+		EventConsumerService: services.NewEventConsumerService(
+			config.DB,
+			transactionManager,
+			repositories.ProcessedEventRepository,
+			config.KafkaConsumerGroupClient,
+			config.KafkaProducerClient,
+			config.AppEnv.KafkaConsumerGroup,
+			config.AppEnv.KafkaDeadLetterTopicSuffix,
+			config.AppEnv.ConsumerMaxAttempts,
+			time.Duration(config.AppEnv.ConsumerRetryBackoffTime)*time.Second,
+			time.Duration(config.AppEnv.ConsumerProcessedEventRetention)*time.Hour,
+		),
+		PaymentResultService: services.NewPaymentResultService(),
+		EmailBounceService:   services.NewEmailBounceService(repositories.EmailBounceRepository),
 	}
 }
 
@@ -172,15 +308,16 @@ func setupControllers(services *Services) {
 		UserProfileController: *controllers.NewUserProfileController(&services.UserProfileService),
 		MovieController:       *controllers.NewMovieController(&services.MovieService),
 		GenreController:       *controllers.NewGenreController(&services.GenreService),
-		LocationController:    *controllers.NewLocationController(&services.LocationService),
+		PersonController:      *controllers.NewPersonController(&services.PersonService),
+		LocationController:    *controllers.NewLocationController(&services.LocationService, &services.LocationImportService),
 		TheaterController:     *controllers.NewTheaterController(&services.TheaterService),
-		ShowController:        *controllers.NewShowController(&services.ShowService),
+		ShowController:        *controllers.NewShowController(&services.ShowService, &services.AgeGateService),
 	}
 }
 
 func setupMiddlewares(repositories *Repositories) {
 	m = &Middlewares{
-		AuthMiddleware:        *middlewares.NewAuthMiddleware(repositories.UserSessionRepository, repositories.FeatureFlagRepository),
+		AuthMiddleware:        *middlewares.NewAuthMiddleware(authenticator, repositories.UserSessionRepository, repositories.FeatureFlagRepository),
 		FilesUploadMiddleware: *middlewares.NewFilesUploadMiddleware(),
 		ContextMiddleware:     *middlewares.NewContextMiddleware(),
 		RateLimitMiddleware:   *middlewares.NewRateLimitMiddleware(),
@@ -194,6 +331,14 @@ func setupRouter() {
 
 	router = gin.Default()
 
+	// Client IPs from forwarding headers are only used for requests coming through the configured proxies.
+	if err := router.SetTrustedProxies(config.AppEnv.TrustedProxies); err != nil {
+		log.Fatal(err)
+	}
+	if len(config.AppEnv.RemoteIPHeaders) > 0 {
+		router.RemoteIPHeaders = config.AppEnv.RemoteIPHeaders
+	}
+
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{config.AppEnv.PlatformUiEndpoint},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
@@ -211,6 +356,38 @@ func registerCronJobs() {
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	_, err = config.CronJobManager.AddFunc(fmt.Sprintf("@every %ds", config.AppEnv.OutboxRelayInterval), func() {
+		if err := s.OutboxRelayService.RelayPendingMessages(); err != nil {
+			log.Println(err)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = config.CronJobManager.AddFunc("0 30 * * * *", func() {
+		if err := s.OutboxRelayService.PurgeProcessedMessages(); err != nil {
+			log.Println(err)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = config.CronJobManager.AddFunc("0 45 * * * *", func() {
+		if err := s.EventConsumerService.PurgeProcessedEvents(); err != nil {
+			log.Println(err)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func registerEventHandlers() {
+	s.EventConsumerService.RegisterHandler(config.AppEnv.KafkaPaymentResultTopic, s.PaymentResultService.HandlePaymentResultEvent)
+	s.EventConsumerService.RegisterHandler(config.AppEnv.KafkaEmailBounceTopic, s.EmailBounceService.HandleEmailBounceEvent)
 }
 
 func setupRoutes() {
@@ -219,5 +396,32 @@ func setupRoutes() {
 	setupControllers(s)
 	setupMiddlewares(r)
 	registerCronJobs()
+	registerEventHandlers()
 	setupRouter()
 }
+
+func GetEventConsumerService() services.EventConsumerService {
+	return s.EventConsumerService
+}
+
+func setupOidcProviders() map[string]services.OidcProviderService {
+	providers := make(map[string]services.OidcProviderService)
+	for _, p := range config.AppEnv.OidcProviders {
+		providers[p.Name] = services.NewOidcProviderService(p.Issuer, p.ClientID, p.ClientSecret, p.RedirectUrl, config.AppEnv.OidcApiTimeout)
+	}
+
+	return providers
+}
+
+func setupUserLocationProvider() services.UserLocationProvider {
+	if config.AppEnv.UserLocationProvider == constants.GeoIpDatabaseLocationProvider {
+		provider, err := services.NewGeoIpDatabaseLocationProvider(config.AppEnv.UserLocationDatabasePaths...)
+		if err != nil {
+			log.Fatalf("Failed to load geoip database: %v", err)
+		}
+
+		return provider
+	}
+
+	return services.NewIpApiLocationProvider(config.AppEnv.UserLocationApiUrl, config.AppEnv.UserLocationApiTimeout)
+}
@@ -0,0 +1,15 @@
+package schemas
+
+import (
+	"embed"
+	"fmt"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+)
+
+//go:embed events
+var eventSchemas embed.FS
+
+// GetEventSchema returns the JSON Schema of the event envelope for the given event type and schema version.
+func GetEventSchema(eventType constants.EventType, schemaVersion int) ([]byte, error) {
+	return eventSchemas.ReadFile(fmt.Sprintf("events/%s/v%d.json", eventType, schemaVersion))
+}
@@ -0,0 +1,184 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+)
+
+var (
+	uuidRegex  = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+)
+
+type schema struct {
+	Type                 any                `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	Const                any                `json:"const"`
+	Format               string             `json:"format"`
+	MinLength            *int               `json:"minLength"`
+	Minimum              *float64           `json:"minimum"`
+}
+
+// Validate checks a JSON document against the subset of JSON Schema used by the event schemas:
+// type, properties, required, additionalProperties, items, enum, const, format, minLength and minimum.
+func Validate(schemaBytes, document []byte) error {
+	var s schema
+	if err := json.Unmarshal(schemaBytes, &s); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	var value any
+	if err := json.Unmarshal(document, &value); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+
+	return s.validate("$", value)
+}
+
+func (s *schema) validate(path string, value any) error {
+	if s.Type != nil && !s.matchesType(value) {
+		return fmt.Errorf("%s: expected type %v", path, s.Type)
+	}
+
+	if s.Const != nil && fmt.Sprint(s.Const) != fmt.Sprint(value) {
+		return fmt.Errorf("%s: expected constant %v", path, s.Const)
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: value %v is not one of %v", path, value, s.Enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return s.validateString(path, v)
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fmt.Errorf("%s: value %v is less than %v", path, v, *s.Minimum)
+		}
+	case map[string]any:
+		return s.validateObject(path, v)
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *schema) validateString(path, value string) error {
+	if s.MinLength != nil && len(value) < *s.MinLength {
+		return fmt.Errorf("%s: length is less than %d", path, *s.MinLength)
+	}
+
+	switch s.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return fmt.Errorf("%s: %s is not a valid date-time", path, value)
+		}
+	case "uuid":
+		if !uuidRegex.MatchString(value) {
+			return fmt.Errorf("%s: %s is not a valid uuid", path, value)
+		}
+	case "email":
+		if !emailRegex.MatchString(value) {
+			return fmt.Errorf("%s: %s is not a valid email", path, value)
+		}
+	}
+
+	return nil
+}
+
+func (s *schema) validateObject(path string, value map[string]any) error {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			return fmt.Errorf("%s: missing required property %s", path, name)
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("%s: unexpected property %s", path, name)
+			}
+			continue
+		}
+
+		if err := property.validate(path+"."+name, value[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *schema) matchesType(value any) bool {
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, v := range t {
+			types = append(types, fmt.Sprint(v))
+		}
+	}
+
+	for _, t := range types {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
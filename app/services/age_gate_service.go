@@ -0,0 +1,102 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"time"
+)
+
+type AgeGateService interface {
+	CheckShowAccess(showID, userID uuid.UUID, userEmail string) (*payloads.AgeGateResult, *errors.ApiError)
+}
+
+func NewAgeGateService(
+	showService ShowService,
+	certificationRepo repositories.MovieCertificationRepository,
+	userProfileRepo repositories.UserProfileRepository,
+) AgeGateService {
+	return &ageGateService{
+		showService:       showService,
+		certificationRepo: certificationRepo,
+		userProfileRepo:   userProfileRepo,
+	}
+}
+
+type ageGateService struct {
+	showService       ShowService
+	certificationRepo repositories.MovieCertificationRepository
+	userProfileRepo   repositories.UserProfileRepository
+}
+
+// CheckShowAccess evaluates the certification of the show's movie in the theater's country against
+// the user's age on the show's local date. Underage users and users without a date of birth are rejected,
+// as is everyone when the theater's country is unknown. Movies without a rating there are treated as
+// adult only and always require an ID check.
+func (s *ageGateService) CheckShowAccess(showID, userID uuid.UUID, userEmail string) (*payloads.AgeGateResult, *errors.ApiError) {
+	show, apiErr := s.showService.GetShow(showID, &userEmail)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	certification, err := s.certificationRepo.GetCertificationOfShow(showID)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if certification == nil || certification.CountryID == nil {
+		return nil, errors.ForbiddenError("age rating of this show can not be determined")
+	}
+
+	result := &payloads.AgeGateResult{
+		Rating: certification.Rating,
+		MinAge: certification.MinAge,
+	}
+	if certification.Rating == nil {
+		result.Unrated = true
+		result.MinAge = constants.UnratedMinAge
+	}
+	if result.MinAge == 0 {
+		return result, nil
+	}
+
+	profile, err := s.userProfileRepo.GetProfile(filters.UserProfileFilter{
+		Filter: &filters.SingleFilter{},
+		UserID: &filters.Condition{Operator: filters.OpEqual, Value: userID},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if profile == nil || profile.DateOfBirth == nil {
+		return nil, errors.ForbiddenError("date of birth is required to book this show, please complete your profile")
+	}
+	dob, err := time.Parse("2006-01-02", *profile.DateOfBirth)
+	if err != nil {
+		return nil, errors.ForbiddenError("date of birth is invalid, please update your profile")
+	}
+
+	if ageOn(dob, show.LocalStartTime) < result.MinAge {
+		return nil, errors.ForbiddenError("user is under the minimum age for this show")
+	}
+
+	idCheckMinAge := constants.DefaultIdCheckMinAge
+	if certification.IdCheckMinAge != nil {
+		idCheckMinAge = *certification.IdCheckMinAge
+	}
+	result.RequiresIdCheck = result.Unrated || result.MinAge >= idCheckMinAge
+
+	return result, nil
+}
+
+// ageOn returns the age in full years on the calendar date of date. People born on Feb 29
+// become a year older on Mar 1 in non-leap years.
+func ageOn(dob, date time.Time) int {
+	age := date.Year() - dob.Year()
+	if date.Month() < dob.Month() || (date.Month() == dob.Month() && date.Day() < dob.Day()) {
+		age--
+	}
+
+	return age
+}
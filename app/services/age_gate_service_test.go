@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAgeGateService_CheckShowAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	featureFlagRepo := mock_repositories.NewMockFeatureFlagRepository(ctrl)
+	certificationRepo := mock_repositories.NewMockMovieCertificationRepository(ctrl)
+	userProfileRepo := mock_repositories.NewMockUserProfileRepository(ctrl)
+	showService := NewShowService(nil, nil, showRepo, nil, nil, nil, nil, nil, featureFlagRepo, nil)
+	service := NewAgeGateService(showService, certificationRepo, userProfileRepo)
+	email := "user@example.com"
+
+	show := utils.GenerateShow()
+	show.Status = constants.Active
+	show.TimeZone = "UTC"
+	profile := utils.GenerateUserProfile()
+	profile.DateOfBirth = utils.GetPointerOf("2000-01-01")
+	showFilter := filters.ShowFilter{
+		Filter: &filters.SingleFilter{},
+		Id:     &filters.Condition{Operator: filters.OpEqual, Value: show.Id.String()},
+	}
+	profileFilter := filters.UserProfileFilter{
+		Filter: &filters.SingleFilter{},
+		UserID: &filters.Condition{Operator: filters.OpEqual, Value: profile.UserID},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 13
+		certification.IdCheckMinAge = nil
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(profile, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, err)
+		assert.Equal(t, certification.Rating, result.Rating)
+		assert.Equal(t, 13, result.MinAge)
+		assert.False(t, result.RequiresIdCheck)
+	})
+
+	t.Run("id check required by default age", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = constants.DefaultIdCheckMinAge
+		certification.IdCheckMinAge = nil
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(profile, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, err)
+		assert.True(t, result.RequiresIdCheck)
+	})
+
+	t.Run("id check required by country age", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 16
+		certification.IdCheckMinAge = utils.GetPointerOf(16)
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(profile, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, err)
+		assert.True(t, result.RequiresIdCheck)
+	})
+
+	t.Run("unrated in theater country", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.Rating = nil
+		certification.MinAge = 0
+		certification.IdCheckMinAge = utils.GetPointerOf(21)
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(profile, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, err)
+		assert.True(t, result.Unrated)
+		assert.Nil(t, result.Rating)
+		assert.Equal(t, constants.UnratedMinAge, result.MinAge)
+		assert.True(t, result.RequiresIdCheck)
+	})
+
+	t.Run("unrated in theater country without date of birth", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.Rating = nil
+		certification.MinAge = 0
+		p := utils.GenerateUserProfile()
+		p.UserID = profile.UserID
+		p.DateOfBirth = nil
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(p, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, "date of birth is required to book this show, please complete your profile", err.Error())
+	})
+
+	t.Run("theater country unknown", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.CountryID = nil
+		certification.Rating = nil
+		certification.MinAge = 0
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, "age rating of this show can not be determined", err.Error())
+	})
+
+	t.Run("certification without age limit", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 0
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, err)
+		assert.Equal(t, certification.Rating, result.Rating)
+		assert.False(t, result.RequiresIdCheck)
+	})
+
+	t.Run("show not found", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(nil, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "show not found", err.Error())
+	})
+
+	t.Run("inactive show for non admin user", func(t *testing.T) {
+		scheduled := *show
+		scheduled.Status = constants.Scheduled
+
+		showRepo.EXPECT().GetShow(showFilter).Return(&scheduled, nil).Times(1)
+		featureFlagRepo.EXPECT().HasFlagEnabled(email, constants.CanModifyShows).Return(false).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "show not found", err.Error())
+	})
+
+	t.Run("error getting show", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(nil, errors.New("error getting show")).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting show", err.Error())
+	})
+
+	t.Run("error getting certification", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(nil, errors.New("error getting certification")).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting certification", err.Error())
+	})
+
+	t.Run("profile not found", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 18
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(nil, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, "date of birth is required to book this show, please complete your profile", err.Error())
+	})
+
+	t.Run("missing date of birth", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 18
+		p := utils.GenerateUserProfile()
+		p.UserID = profile.UserID
+		p.DateOfBirth = nil
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(p, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, "date of birth is required to book this show, please complete your profile", err.Error())
+	})
+
+	t.Run("invalid date of birth", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 18
+		p := utils.GenerateUserProfile()
+		p.UserID = profile.UserID
+		p.DateOfBirth = utils.GetPointerOf("01/01/2000")
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(p, nil).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, "date of birth is invalid, please update your profile", err.Error())
+	})
+
+	t.Run("error getting profile", func(t *testing.T) {
+		certification := utils.GenerateShowCertification()
+		certification.MinAge = 18
+
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+		userProfileRepo.EXPECT().GetProfile(profileFilter).Return(nil, errors.New("error getting profile")).Times(1)
+
+		result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting profile", err.Error())
+	})
+}
+
+func TestAgeGateService_CheckShowAccess_BoundaryBirthdays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	certificationRepo := mock_repositories.NewMockMovieCertificationRepository(ctrl)
+	userProfileRepo := mock_repositories.NewMockUserProfileRepository(ctrl)
+	service := NewAgeGateService(NewShowService(nil, nil, showRepo, nil, nil, nil, nil, nil, nil, nil), certificationRepo, userProfileRepo)
+	email := "user@example.com"
+
+	tests := []struct {
+		name        string
+		dateOfBirth string
+		startTime   time.Time
+		timeZone    string
+		minAge      int
+		unrated     bool
+		allowed     bool
+	}{
+		{
+			name:        "birthday on show date",
+			dateOfBirth: "2008-03-15",
+			startTime:   time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      18,
+			allowed:     true,
+		},
+		{
+			name:        "birthday on day after show date",
+			dateOfBirth: "2008-03-16",
+			startTime:   time.Date(2026, 3, 15, 23, 59, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      18,
+			allowed:     false,
+		},
+		{
+			name:        "birthday on day before show date",
+			dateOfBirth: "2008-03-14",
+			startTime:   time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      18,
+			allowed:     true,
+		},
+		{
+			name:        "birthday later in show month",
+			dateOfBirth: "2008-12-31",
+			startTime:   time.Date(2026, 12, 30, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      18,
+			allowed:     false,
+		},
+		{
+			name:        "leap day birthday on Feb 28 of non leap year",
+			dateOfBirth: "2008-02-29",
+			startTime:   time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      18,
+			allowed:     false,
+		},
+		{
+			name:        "leap day birthday on Mar 1 of non leap year",
+			dateOfBirth: "2008-02-29",
+			startTime:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      18,
+			allowed:     true,
+		},
+		{
+			name:        "leap day birthday on Feb 29 of leap year",
+			dateOfBirth: "2008-02-29",
+			startTime:   time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      16,
+			allowed:     true,
+		},
+		{
+			name:        "leap day birthday on Feb 28 of leap year",
+			dateOfBirth: "2008-02-29",
+			startTime:   time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			minAge:      16,
+			allowed:     false,
+		},
+		{
+			name:        "birthday reached in theater time zone ahead of UTC",
+			dateOfBirth: "2008-03-15",
+			startTime:   time.Date(2026, 3, 14, 17, 30, 0, 0, time.UTC),
+			timeZone:    "Asia/Ho_Chi_Minh",
+			minAge:      18,
+			allowed:     true,
+		},
+		{
+			name:        "birthday not reached in theater time zone behind UTC",
+			dateOfBirth: "2008-03-15",
+			startTime:   time.Date(2026, 3, 15, 5, 0, 0, 0, time.UTC),
+			timeZone:    "America/Los_Angeles",
+			minAge:      18,
+			allowed:     false,
+		},
+		{
+			name:        "movie not rated in theater country for minor",
+			dateOfBirth: "2010-06-01",
+			startTime:   time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			unrated:     true,
+			allowed:     false,
+		},
+		{
+			name:        "movie not rated in theater country on 18th birthday",
+			dateOfBirth: "2008-03-15",
+			startTime:   time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+			timeZone:    "UTC",
+			unrated:     true,
+			allowed:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := utils.GenerateShow()
+			show.StartTime = tt.startTime
+			show.EndTime = tt.startTime.Add(2 * time.Hour)
+			show.Status = constants.Active
+			show.TimeZone = tt.timeZone
+			certification := utils.GenerateShowCertification()
+			certification.MinAge = tt.minAge
+			certification.IdCheckMinAge = nil
+			if tt.unrated {
+				certification.Rating = nil
+			}
+			profile := utils.GenerateUserProfile()
+			profile.DateOfBirth = utils.GetPointerOf(tt.dateOfBirth)
+
+			showRepo.EXPECT().GetShow(gomock.Any()).Return(show, nil).Times(1)
+			certificationRepo.EXPECT().GetCertificationOfShow(show.Id).Return(certification, nil).Times(1)
+			userProfileRepo.EXPECT().GetProfile(gomock.Any()).Return(profile, nil).Times(1)
+
+			result, err := service.CheckShowAccess(show.Id, profile.UserID, email)
+
+			if tt.allowed {
+				assert.Nil(t, err)
+				if tt.unrated {
+					assert.Equal(t, constants.UnratedMinAge, result.MinAge)
+					assert.True(t, result.RequiresIdCheck)
+				} else {
+					assert.Equal(t, tt.minAge, result.MinAge)
+				}
+			} else {
+				assert.Nil(t, result)
+				assert.NotNil(t, err)
+				assert.Equal(t, http.StatusForbidden, err.StatusCode)
+				assert.Equal(t, "user is under the minimum age for this show", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+type EmailBounceService interface {
+	HandleEmailBounceEvent(tx *gorm.DB, message *sarama.ConsumerMessage) error
+}
+
+func NewEmailBounceService(emailBounceRepo repositories.EmailBounceRepository) EmailBounceService {
+	return &emailBounceService{
+		emailBounceRepo: emailBounceRepo,
+	}
+}
+
+type emailBounceService struct {
+	emailBounceRepo repositories.EmailBounceRepository
+}
+
+func (s *emailBounceService) HandleEmailBounceEvent(tx *gorm.DB, message *sarama.ConsumerMessage) error {
+	var event payloads.ConsumedEventEnvelope[payloads.EmailBounceEvent]
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		return errors.MalformedEventError("invalid email bounce event: %s", err.Error())
+	}
+
+	email := strings.ToLower(strings.TrimSpace(event.Data.Email))
+	if email == "" {
+		return errors.MalformedEventError("email bounce event %s has no email", event.EventID)
+	}
+	if event.Data.BounceType != constants.HardBounce && event.Data.BounceType != constants.SoftBounce {
+		return errors.MalformedEventError("email bounce event %s has unknown bounce type %s", event.EventID, event.Data.BounceType)
+	}
+
+	bouncedAt := event.Data.BouncedAt
+	if bouncedAt.IsZero() {
+		bouncedAt = event.OccurredAt
+	}
+
+	return s.emailBounceRepo.CreateEmailBounce(tx, &models.EmailBounce{
+		ID:         uuid.New(),
+		Email:      email,
+		BounceType: event.Data.BounceType,
+		Reason:     event.Data.Reason,
+		BouncedAt:  bouncedAt.UTC(),
+		CreatedAt:  time.Now().UTC(),
+	})
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	apiError "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestEmailBounceService_HandleEmailBounceEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repositories.NewMockEmailBounceRepository(ctrl)
+	service := NewEmailBounceService(repo)
+
+	bouncedAt := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	newMessage := func(email, bounceType string) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Value: []byte(fmt.Sprintf(
+				`{"event_id": "6b1f0c9e-4a51-4d1f-9a57-3c1f1a2b3c4d", "event_type": "email.bounced", "schema_version": 1, "occurred_at": "2026-10-19T08:00:05Z", "producer": "notification-service", "request_id": null, "data": {"email": "%s", "bounce_type": "%s", "reason": "mailbox does not exist", "bounced_at": "%s"}}`,
+				email, bounceType, bouncedAt.Format(time.RFC3339),
+			)),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo.EXPECT().CreateEmailBounce(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, bounce *models.EmailBounce) error {
+				assert.Equal(t, "example@example.com", bounce.Email)
+				assert.Equal(t, constants.HardBounce, bounce.BounceType)
+				assert.Equal(t, "mailbox does not exist", *bounce.Reason)
+				assert.Equal(t, bouncedAt, bounce.BouncedAt)
+				return nil
+			},
+		).Times(1)
+
+		err := service.HandleEmailBounceEvent(nil, newMessage(" Example@Example.com ", "HARD"))
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		err := service.HandleEmailBounceEvent(nil, &sarama.ConsumerMessage{Value: []byte("invalid")})
+
+		assert.NotNil(t, err)
+		assert.True(t, apiError.IsMalformedEventError(err))
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		err := service.HandleEmailBounceEvent(nil, newMessage("", "HARD"))
+
+		assert.NotNil(t, err)
+		assert.True(t, apiError.IsMalformedEventError(err))
+		assert.Equal(t, "email bounce event 6b1f0c9e-4a51-4d1f-9a57-3c1f1a2b3c4d has no email", err.Error())
+	})
+
+	t.Run("unknown bounce type", func(t *testing.T) {
+		err := service.HandleEmailBounceEvent(nil, newMessage("example@example.com", "COMPLAINT"))
+
+		assert.NotNil(t, err)
+		assert.True(t, apiError.IsMalformedEventError(err))
+		assert.Equal(t, "email bounce event 6b1f0c9e-4a51-4d1f-9a57-3c1f1a2b3c4d has unknown bounce type COMPLAINT", err.Error())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo.EXPECT().CreateEmailBounce(gomock.Any(), gomock.Any()).Return(errors.New("db error")).Times(1)
+
+		err := service.HandleEmailBounceEvent(nil, newMessage("example@example.com", "SOFT"))
+
+		assert.NotNil(t, err)
+		assert.False(t, apiError.IsMalformedEventError(err))
+		assert.Equal(t, "db error", err.Error())
+	})
+}
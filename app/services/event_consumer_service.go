@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/IBM/sarama"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
+	"gorm.io/gorm"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// EventHandler processes a consumed message within the transaction that records its idempotency key.
+type EventHandler func(tx *gorm.DB, message *sarama.ConsumerMessage) error
+
+type EventConsumerService interface {
+	RegisterHandler(topic string, handler EventHandler)
+	Start(ctx context.Context) error
+	Close() error
+	PurgeProcessedEvents() error
+}
+
+func NewEventConsumerService(
+	db *gorm.DB,
+	transactionManager transaction.TransactionManager,
+	processedEventRepo repositories.ProcessedEventRepository,
+	consumerGroup sarama.ConsumerGroup,
+	kafkaProducer sarama.SyncProducer,
+	groupID string,
+	deadLetterTopicSuffix string,
+	maxAttempts int,
+	retryBackoffTime time.Duration,
+	retentionTime time.Duration,
+) EventConsumerService {
+	return &eventConsumerService{
+		db:                    db,
+		transactionManager:    transactionManager,
+		processedEventRepo:    processedEventRepo,
+		consumerGroup:         consumerGroup,
+		kafkaProducer:         kafkaProducer,
+		groupID:               groupID,
+		deadLetterTopicSuffix: deadLetterTopicSuffix,
+		maxAttempts:           maxAttempts,
+		retryBackoffTime:      retryBackoffTime,
+		retentionTime:         retentionTime,
+		handlers:              make(map[string]EventHandler),
+	}
+}
+
+type eventConsumerService struct {
+	db                    *gorm.DB
+	transactionManager    transaction.TransactionManager
+	processedEventRepo    repositories.ProcessedEventRepository
+	consumerGroup         sarama.ConsumerGroup
+	kafkaProducer         sarama.SyncProducer
+	groupID               string
+	deadLetterTopicSuffix string
+	maxAttempts           int
+	retryBackoffTime      time.Duration
+	retentionTime         time.Duration
+	handlers              map[string]EventHandler
+}
+
+func (s *eventConsumerService) RegisterHandler(topic string, handler EventHandler) {
+	s.handlers[topic] = handler
+}
+
+// Start blocks until the context is cancelled or the consumer group is closed, it rejoins the group after every rebalance.
+func (s *eventConsumerService) Start(ctx context.Context) error {
+	topics := make([]string, 0, len(s.handlers))
+	for topic := range s.handlers {
+		topics = append(topics, topic)
+	}
+	if len(topics) == 0 {
+		return nil
+	}
+	sort.Strings(topics)
+
+	for {
+		if err := s.consumerGroup.Consume(ctx, topics, s); err != nil {
+			if err == sarama.ErrClosedConsumerGroup {
+				return nil
+			}
+
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *eventConsumerService) Close() error {
+	return s.consumerGroup.Close()
+}
+
+// PurgeProcessedEvents forgets idempotency keys older than the retention time, which should outlive the topic retention.
+func (s *eventConsumerService) PurgeProcessedEvents() error {
+	return s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		count, err := s.processedEventRepo.DeleteProcessedEvents(tx, time.Now().UTC().Add(-s.retentionTime))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			log.Printf("purged %d processed events", count)
+		}
+
+		return nil
+	})
+}
+
+func (s *eventConsumerService) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (s *eventConsumerService) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim marks a message only after it was processed or moved to the dead letter topic, so it is redelivered after a crash.
+func (s *eventConsumerService) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if err := s.consumeMessage(session.Context(), message); err != nil {
+				if session.Context().Err() != nil {
+					return nil
+				}
+
+				return err
+			}
+
+			session.MarkMessage(message, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// consumeMessage retries transient failures, malformed messages are moved to the dead letter topic on the first failure.
+func (s *eventConsumerService) consumeMessage(ctx context.Context, message *sarama.ConsumerMessage) error {
+	var err error
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		if err = s.processMessage(message); err == nil {
+			return nil
+		}
+
+		log.Printf("failed to process message %s/%d/%d on attempt %d: %s", message.Topic, message.Partition, message.Offset, attempt, err.Error())
+		if errors.IsMalformedEventError(err) {
+			return s.sendToDeadLetter(message, err, attempt)
+		}
+		if attempt < s.maxAttempts {
+			select {
+			case <-time.After(s.retryBackoffTime):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return s.sendToDeadLetter(message, err, s.maxAttempts)
+}
+
+func (s *eventConsumerService) processMessage(message *sarama.ConsumerMessage) error {
+	handler, ok := s.handlers[message.Topic]
+	if !ok {
+		return errors.MalformedEventError("no handler registered for topic %s", message.Topic)
+	}
+
+	return s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		created, err := s.processedEventRepo.CreateProcessedEvent(tx, &models.ProcessedEvent{
+			ConsumerGroup:  s.groupID,
+			IdempotencyKey: getIdempotencyKey(message),
+			Topic:          message.Topic,
+			ProcessedAt:    time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		if !created {
+			return nil
+		}
+
+		return handler(tx, message)
+	})
+}
+
+func (s *eventConsumerService) sendToDeadLetter(message *sarama.ConsumerMessage, cause error, attempts int) error {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+5)
+	for _, h := range message.Headers {
+		if h != nil {
+			headers = append(headers, *h)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(constants.DeadLetterOriginalTopicHeader), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(constants.DeadLetterOriginalPartitionHeader), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		sarama.RecordHeader{Key: []byte(constants.DeadLetterOriginalOffsetHeader), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(constants.DeadLetterConsumerGroupHeader), Value: []byte(s.groupID)},
+		sarama.RecordHeader{Key: []byte(constants.DeadLetterErrorHeader), Value: []byte(cause.Error())},
+	)
+
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   message.Topic + s.deadLetterTopicSuffix,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(message.Key)
+	}
+
+	if _, _, err := s.kafkaProducer.SendMessage(producerMessage); err != nil {
+		return err
+	}
+
+	log.Printf("message %s/%d/%d moved to dead letter topic after %d attempts: %s", message.Topic, message.Partition, message.Offset, attempts, cause.Error())
+	return nil
+}
+
+// getIdempotencyKey prefers the event id header and falls back to the message position for producers that do not set it.
+func getIdempotencyKey(message *sarama.ConsumerMessage) string {
+	for _, h := range message.Headers {
+		if h != nil && string(h.Key) == constants.EventIDHeader && len(h.Value) > 0 {
+			return string(h.Value)
+		}
+	}
+
+	return fmt.Sprintf("%s:%d:%d", message.Topic, message.Partition, message.Offset)
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	apiError "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_transaction"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type stubConsumerGroupSession struct {
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *stubConsumerGroupSession) Claims() map[string][]int32               { return nil }
+func (s *stubConsumerGroupSession) MemberID() string                         { return "member" }
+func (s *stubConsumerGroupSession) GenerationID() int32                      { return 1 }
+func (s *stubConsumerGroupSession) MarkOffset(string, int32, int64, string)  {}
+func (s *stubConsumerGroupSession) Commit()                                  {}
+func (s *stubConsumerGroupSession) ResetOffset(string, int32, int64, string) {}
+func (s *stubConsumerGroupSession) Context() context.Context                 { return s.ctx }
+func (s *stubConsumerGroupSession) MarkMessage(m *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, m)
+}
+
+type stubConsumerGroupClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func newStubConsumerGroupClaim(messages ...*sarama.ConsumerMessage) *stubConsumerGroupClaim {
+	c := &stubConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, m := range messages {
+		c.messages <- m
+	}
+	close(c.messages)
+
+	return c
+}
+
+func (c *stubConsumerGroupClaim) Topic() string                            { return "" }
+func (c *stubConsumerGroupClaim) Partition() int32                         { return 0 }
+func (c *stubConsumerGroupClaim) InitialOffset() int64                     { return 0 }
+func (c *stubConsumerGroupClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *stubConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+type stubConsumerGroup struct {
+	sarama.ConsumerGroup
+	topics []string
+	err    error
+	closed bool
+}
+
+func (g *stubConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	g.topics = topics
+	return g.err
+}
+
+func (g *stubConsumerGroup) Close() error {
+	g.closed = true
+	return nil
+}
+
+func TestEventConsumerService_Start(t *testing.T) {
+	t.Run("consumes registered topics until closed", func(t *testing.T) {
+		group := &stubConsumerGroup{err: sarama.ErrClosedConsumerGroup}
+		service := NewEventConsumerService(nil, nil, nil, group, nil, "group", ".dlt", 3, 0, time.Hour)
+		service.RegisterHandler("topic.b", func(tx *gorm.DB, message *sarama.ConsumerMessage) error { return nil })
+		service.RegisterHandler("topic.a", func(tx *gorm.DB, message *sarama.ConsumerMessage) error { return nil })
+
+		err := service.Start(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"topic.a", "topic.b"}, group.topics)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		group := &stubConsumerGroup{}
+		service := NewEventConsumerService(nil, nil, nil, group, nil, "group", ".dlt", 3, 0, time.Hour)
+		service.RegisterHandler("topic", func(tx *gorm.DB, message *sarama.ConsumerMessage) error { return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := service.Start(ctx)
+
+		assert.Nil(t, err)
+		assert.Nil(t, service.Close())
+		assert.True(t, group.closed)
+	})
+
+	t.Run("consumer group error", func(t *testing.T) {
+		group := &stubConsumerGroup{err: errors.New("broker unavailable")}
+		service := NewEventConsumerService(nil, nil, nil, group, nil, "group", ".dlt", 3, 0, time.Hour)
+		service.RegisterHandler("topic", func(tx *gorm.DB, message *sarama.ConsumerMessage) error { return nil })
+
+		err := service.Start(context.Background())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "broker unavailable", err.Error())
+	})
+}
+
+func TestEventConsumerService_ConsumeClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	processedEventRepo := mock_repositories.NewMockProcessedEventRepository(ctrl)
+	producer := mocks.NewSyncProducer(t, nil)
+	defer func() {
+		assert.Nil(t, producer.Close())
+	}()
+
+	groupID := "reservation-service"
+	maxAttempts := 2
+	service := NewEventConsumerService(nil, transaction, processedEventRepo, nil, producer, groupID, ".dlt", maxAttempts, 0, time.Hour).(*eventConsumerService)
+
+	var handled []*sarama.ConsumerMessage
+	var handlerErr error
+	service.RegisterHandler("payments", func(tx *gorm.DB, message *sarama.ConsumerMessage) error {
+		handled = append(handled, message)
+		return handlerErr
+	})
+
+	executeInTransaction := func(times int) {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(times)
+	}
+	newMessage := func(offset int64) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Topic:     "payments",
+			Partition: 1,
+			Offset:    offset,
+			Key:       []byte("key"),
+			Value:     []byte(`{"event_id": "id"}`),
+			Headers:   []*sarama.RecordHeader{{Key: []byte(constants.EventIDHeader), Value: []byte("event-id")}},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		handled, handlerErr = nil, nil
+		message := newMessage(1)
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		executeInTransaction(1)
+		processedEventRepo.EXPECT().CreateProcessedEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, event *models.ProcessedEvent) (bool, error) {
+				assert.Equal(t, groupID, event.ConsumerGroup)
+				assert.Equal(t, "event-id", event.IdempotencyKey)
+				assert.Equal(t, "payments", event.Topic)
+				return true, nil
+			},
+		).Times(1)
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*sarama.ConsumerMessage{message}, handled)
+		assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+	})
+
+	t.Run("duplicate message", func(t *testing.T) {
+		handled, handlerErr = nil, nil
+		message := newMessage(2)
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		executeInTransaction(1)
+		processedEventRepo.EXPECT().CreateProcessedEvent(gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.Nil(t, err)
+		assert.Empty(t, handled)
+		assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+	})
+
+	t.Run("message without event id", func(t *testing.T) {
+		handled, handlerErr = nil, nil
+		message := newMessage(3)
+		message.Headers = nil
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		executeInTransaction(1)
+		processedEventRepo.EXPECT().CreateProcessedEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, event *models.ProcessedEvent) (bool, error) {
+				assert.Equal(t, "payments:1:3", event.IdempotencyKey)
+				return true, nil
+			},
+		).Times(1)
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.Nil(t, err)
+		assert.Len(t, handled, 1)
+	})
+
+	t.Run("retry then dead letter", func(t *testing.T) {
+		handled, handlerErr = nil, errors.New("invalid payment")
+		message := newMessage(4)
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		executeInTransaction(maxAttempts)
+		processedEventRepo.EXPECT().CreateProcessedEvent(gomock.Any(), gomock.Any()).Return(true, nil).Times(maxAttempts)
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+			assert.Equal(t, "payments.dlt", m.Topic)
+			key, _ := m.Key.Encode()
+			assert.Equal(t, "key", string(key))
+			value, _ := m.Value.Encode()
+			assert.Equal(t, message.Value, value)
+
+			headers := make(map[string]string)
+			for _, h := range m.Headers {
+				headers[string(h.Key)] = string(h.Value)
+			}
+			assert.Equal(t, "event-id", headers[constants.EventIDHeader])
+			assert.Equal(t, "payments", headers[constants.DeadLetterOriginalTopicHeader])
+			assert.Equal(t, "1", headers[constants.DeadLetterOriginalPartitionHeader])
+			assert.Equal(t, "4", headers[constants.DeadLetterOriginalOffsetHeader])
+			assert.Equal(t, groupID, headers[constants.DeadLetterConsumerGroupHeader])
+			assert.Equal(t, "invalid payment", headers[constants.DeadLetterErrorHeader])
+			return nil
+		})
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.Nil(t, err)
+		assert.Len(t, handled, maxAttempts)
+		assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+	})
+
+	t.Run("malformed message is dead lettered without retry", func(t *testing.T) {
+		handled, handlerErr = nil, apiError.MalformedEventError("invalid payment")
+		message := newMessage(7)
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		executeInTransaction(1)
+		processedEventRepo.EXPECT().CreateProcessedEvent(gomock.Any(), gomock.Any()).Return(true, nil).Times(1)
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+			assert.Equal(t, "payments.dlt", m.Topic)
+
+			headers := make(map[string]string)
+			for _, h := range m.Headers {
+				headers[string(h.Key)] = string(h.Value)
+			}
+			assert.Equal(t, "invalid payment", headers[constants.DeadLetterErrorHeader])
+			return nil
+		})
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.Nil(t, err)
+		assert.Len(t, handled, 1)
+		assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+	})
+
+	t.Run("error sending to dead letter topic", func(t *testing.T) {
+		handled, handlerErr = nil, nil
+		message := newMessage(5)
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		executeInTransaction(maxAttempts)
+		processedEventRepo.EXPECT().CreateProcessedEvent(gomock.Any(), gomock.Any()).Return(false, errors.New("db error")).Times(maxAttempts)
+		producer.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "broker unavailable", err.Error())
+		assert.Empty(t, handled)
+		assert.Empty(t, session.marked)
+	})
+
+	t.Run("unregistered topic", func(t *testing.T) {
+		message := newMessage(6)
+		message.Topic = "unknown"
+		session := &stubConsumerGroupSession{ctx: context.Background()}
+
+		producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(val []byte) error {
+			assert.Equal(t, message.Value, val)
+			return nil
+		})
+
+		err := service.ConsumeClaim(session, newStubConsumerGroupClaim(message))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*sarama.ConsumerMessage{message}, session.marked)
+	})
+
+	t.Run("session cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		session := &stubConsumerGroupSession{ctx: ctx}
+		claim := &stubConsumerGroupClaim{messages: make(chan *sarama.ConsumerMessage)}
+
+		err := service.ConsumeClaim(session, claim)
+
+		assert.Nil(t, err)
+		assert.Empty(t, session.marked)
+	})
+}
+
+func TestEventConsumerService_PurgeProcessedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	processedEventRepo := mock_repositories.NewMockProcessedEventRepository(ctrl)
+
+	retention := 24 * time.Hour
+	service := NewEventConsumerService(nil, transaction, processedEventRepo, nil, nil, "group", ".dlt", 3, 0, retention)
+
+	executeInTransaction := func() {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		executeInTransaction()
+		processedEventRepo.EXPECT().DeleteProcessedEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().UTC().Add(-retention), before, time.Second)
+			return 5, nil
+		}).Times(1)
+
+		err := service.PurgeProcessedEvents()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting events", func(t *testing.T) {
+		executeInTransaction()
+		processedEventRepo.EXPECT().DeleteProcessedEvents(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error")).Times(1)
+
+		err := service.PurgeProcessedEvents()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
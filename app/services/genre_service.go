@@ -2,6 +2,7 @@ package services
 
 import (
 	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
@@ -14,9 +15,9 @@ import (
 type GenreService interface {
 	GetGenre(id uuid.UUID) (*models.Genre, *errors.ApiError)
 	GetGenres() ([]*models.Genre, *errors.ApiError)
-	CreateGenre(req payloads.CreateGenreRequest) (*models.Genre, *errors.ApiError)
-	UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest) (*models.Genre, *errors.ApiError)
-	DeleteGenre(id uuid.UUID) *errors.ApiError
+	CreateGenre(req payloads.CreateGenreRequest, requestID uuid.UUID) (*models.Genre, *errors.ApiError)
+	UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest, requestID uuid.UUID) (*models.Genre, *errors.ApiError)
+	DeleteGenre(id, requestID uuid.UUID) *errors.ApiError
 }
 
 func NewGenreService(
@@ -24,12 +25,14 @@ func NewGenreService(
 	transactionManager transaction.TransactionManager,
 	genreRepo repositories.GenreRepository,
 	movieGenreRepo repositories.MovieGenreRepository,
+	notificationRepo repositories.NotificationRepository,
 ) GenreService {
 	return &genreService{
 		db:                 db,
 		transactionManager: transactionManager,
 		genreRepo:          genreRepo,
 		movieGenreRepo:     movieGenreRepo,
+		notificationRepo:   notificationRepo,
 	}
 }
 
@@ -38,6 +41,7 @@ type genreService struct {
 	transactionManager transaction.TransactionManager
 	genreRepo          repositories.GenreRepository
 	movieGenreRepo     repositories.MovieGenreRepository
+	notificationRepo   repositories.NotificationRepository
 }
 
 func (s *genreService) GetGenre(id uuid.UUID) (*models.Genre, *errors.ApiError) {
@@ -68,7 +72,7 @@ func (s *genreService) GetGenres() ([]*models.Genre, *errors.ApiError) {
 	return genres, nil
 }
 
-func (s *genreService) CreateGenre(req payloads.CreateGenreRequest) (*models.Genre, *errors.ApiError) {
+func (s *genreService) CreateGenre(req payloads.CreateGenreRequest, requestID uuid.UUID) (*models.Genre, *errors.ApiError) {
 	g, err := s.genreRepo.GetGenre(filters.GenreFilter{
 		Filter: &filters.SingleFilter{Logic: filters.And},
 		Name:   &filters.Condition{Operator: filters.OpEqual, Value: req.Name},
@@ -85,7 +89,11 @@ func (s *genreService) CreateGenre(req payloads.CreateGenreRequest) (*models.Gen
 		Name: req.Name,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.genreRepo.CreateGenre(tx, g)
+		if err := s.genreRepo.CreateGenre(tx, g); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendGenreEvent(tx, requestID, payloads.NewCatalogEvent(constants.GenreCreated, g.ID, nil, g))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -93,8 +101,8 @@ func (s *genreService) CreateGenre(req payloads.CreateGenreRequest) (*models.Gen
 	return g, nil
 }
 
-func (s *genreService) UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest) (*models.Genre, *errors.ApiError) {
-	g, apiErr := s.GetGenre(id)
+func (s *genreService) UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest, requestID uuid.UUID) (*models.Genre, *errors.ApiError) {
+	before, apiErr := s.GetGenre(id)
 	if apiErr != nil {
 		return nil, apiErr
 	}
@@ -115,7 +123,11 @@ func (s *genreService) UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest
 		Name: req.Name,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.genreRepo.UpdateGenre(tx, g)
+		if err := s.genreRepo.UpdateGenre(tx, g); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendGenreEvent(tx, requestID, payloads.NewCatalogEvent(constants.GenreUpdated, g.ID, before, g))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -123,7 +135,7 @@ func (s *genreService) UpdateGenre(id uuid.UUID, req payloads.UpdateGenreRequest
 	return g, nil
 }
 
-func (s *genreService) DeleteGenre(id uuid.UUID) *errors.ApiError {
+func (s *genreService) DeleteGenre(id, requestID uuid.UUID) *errors.ApiError {
 	g, apiErr := s.GetGenre(id)
 	if apiErr != nil {
 		return apiErr
@@ -135,7 +147,11 @@ func (s *genreService) DeleteGenre(id uuid.UUID) *errors.ApiError {
 			return err
 		}
 		
-		return s.genreRepo.DeleteGenre(tx, g)
+		if err := s.genreRepo.DeleteGenre(tx, g); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendGenreEvent(tx, requestID, payloads.NewCatalogEvent[models.Genre](constants.GenreDeleted, g.ID, g, nil))
 	}); err != nil {
 		return errors.InternalServerError(err.Error())
 	}
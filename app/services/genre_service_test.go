@@ -2,11 +2,13 @@ package services
 
 import (
 	"errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_transaction"
@@ -20,7 +22,7 @@ func TestGenreService_GetGenre(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockGenreRepository(ctrl)
-	service := NewGenreService(nil, nil, repo, nil)
+	service := NewGenreService(nil, nil, repo, nil, nil)
 
 	genre := utils.GenerateGenre()
 	filter := filters.GenreFilter{
@@ -66,7 +68,7 @@ func TestGenreService_GetGenres(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockGenreRepository(ctrl)
-	service := NewGenreService(nil, nil, repo, nil)
+	service := NewGenreService(nil, nil, repo, nil, nil)
 
 	filter := filters.GenreFilter{
 		Filter: &filters.MultiFilter{Logic: filters.And},
@@ -102,7 +104,9 @@ func TestGenreService_CreateGenre(t *testing.T) {
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	repo := mock_repositories.NewMockGenreRepository(ctrl)
-	service := NewGenreService(nil, transaction, repo, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewGenreService(nil, transaction, repo, nil, notificationRepo)
+	requestID := uuid.New()
 
 	genre := utils.GenerateGenre()
 	req := payloads.CreateGenreRequest{
@@ -117,8 +121,16 @@ func TestGenreService_CreateGenre(t *testing.T) {
 			},
 		).Times(1)
 		repo.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendGenreEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.GenreEvent) error {
+				assert.Equal(t, constants.GenreCreated, e.Type)
+				assert.Nil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.CreateGenre(req)
+		result, err := service.CreateGenre(req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -128,7 +140,7 @@ func TestGenreService_CreateGenre(t *testing.T) {
 	t.Run("duplicate genre name", func(t *testing.T) {
 		repo.EXPECT().GetGenre(gomock.Any()).Return(genre, nil).Times(1)
 
-		result, err := service.CreateGenre(req)
+		result, err := service.CreateGenre(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -139,7 +151,7 @@ func TestGenreService_CreateGenre(t *testing.T) {
 	t.Run("error getting genre", func(t *testing.T) {
 		repo.EXPECT().GetGenre(gomock.Any()).Return(nil, errors.New("error getting genre")).Times(1)
 
-		result, err := service.CreateGenre(req)
+		result, err := service.CreateGenre(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -156,13 +168,31 @@ func TestGenreService_CreateGenre(t *testing.T) {
 		).Times(1)
 		repo.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(errors.New("error creating genre")).Times(1)
 
-		result, err := service.CreateGenre(req)
+		result, err := service.CreateGenre(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error creating genre", err.Error())
 	})
+
+	t.Run("error sending genre event", func(t *testing.T) {
+		repo.EXPECT().GetGenre(gomock.Any()).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().CreateGenre(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendGenreEvent(gomock.Any(), requestID, gomock.Any()).Return(errors.New("error sending event")).Times(1)
+
+		result, err := service.CreateGenre(req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error sending event", err.Error())
+	})
 }
 
 func TestGenreService_UpdateGenre(t *testing.T) {
@@ -171,7 +201,9 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	repo := mock_repositories.NewMockGenreRepository(ctrl)
-	service := NewGenreService(nil, transaction, repo, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewGenreService(nil, transaction, repo, nil, notificationRepo)
+	requestID := uuid.New()
 
 	genre := utils.GenerateGenre()
 	req := payloads.UpdateGenreRequest{
@@ -195,8 +227,16 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 			},
 		).Times(1)
 		repo.EXPECT().UpdateGenre(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendGenreEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.GenreEvent) error {
+				assert.Equal(t, constants.GenreUpdated, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.UpdateGenre(genre.ID, req)
+		result, err := service.UpdateGenre(genre.ID, req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -207,7 +247,7 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 	t.Run("genre not found", func(t *testing.T) {
 		repo.EXPECT().GetGenre(gomock.Eq(idFilter)).Return(nil, nil).Times(1)
 
-		result, err := service.UpdateGenre(genre.ID, req)
+		result, err := service.UpdateGenre(genre.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -218,7 +258,7 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 	t.Run("error getting genre", func(t *testing.T) {
 		repo.EXPECT().GetGenre(gomock.Eq(idFilter)).Return(nil, errors.New("error getting genre")).Times(1)
 
-		result, err := service.UpdateGenre(genre.ID, req)
+		result, err := service.UpdateGenre(genre.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -230,7 +270,7 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 		repo.EXPECT().GetGenre(gomock.Eq(idFilter)).Return(genre, nil).Times(1)
 		repo.EXPECT().GetGenre(gomock.Eq(nameFilter)).Return(genre, nil).Times(1)
 
-		result, err := service.UpdateGenre(genre.ID, req)
+		result, err := service.UpdateGenre(genre.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -242,7 +282,7 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 		repo.EXPECT().GetGenre(gomock.Eq(idFilter)).Return(genre, nil).Times(1)
 		repo.EXPECT().GetGenre(gomock.Eq(nameFilter)).Return(nil, errors.New("error getting genre")).Times(1)
 
-		result, err := service.UpdateGenre(genre.ID, req)
+		result, err := service.UpdateGenre(genre.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -260,7 +300,7 @@ func TestGenreService_UpdateGenre(t *testing.T) {
 		).Times(1)
 		repo.EXPECT().UpdateGenre(gomock.Any(), gomock.Any()).Return(errors.New("error updating genre")).Times(1)
 
-		result, err := service.UpdateGenre(genre.ID, req)
+		result, err := service.UpdateGenre(genre.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -276,7 +316,9 @@ func TestGenreService_DeleteGenre(t *testing.T) {
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	genreRepo := mock_repositories.NewMockGenreRepository(ctrl)
 	movieGenreRepo := mock_repositories.NewMockMovieGenreRepository(ctrl)
-	service := NewGenreService(nil, transaction, genreRepo, movieGenreRepo)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewGenreService(nil, transaction, genreRepo, movieGenreRepo, notificationRepo)
+	requestID := uuid.New()
 
 	genre := utils.GenerateGenre()
 	filter := filters.GenreFilter{
@@ -293,8 +335,16 @@ func TestGenreService_DeleteGenre(t *testing.T) {
 		).Times(1)
 		movieGenreRepo.EXPECT().DeleteByGenreId(gomock.Any(), genre.ID).Return(nil).Times(1)
 		genreRepo.EXPECT().DeleteGenre(gomock.Any(), genre).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendGenreEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.GenreEvent) error {
+				assert.Equal(t, constants.GenreDeleted, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.Nil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		err := service.DeleteGenre(genre.ID)
+		err := service.DeleteGenre(genre.ID, requestID)
 
 		assert.Nil(t, err)
 	})
@@ -302,7 +352,7 @@ func TestGenreService_DeleteGenre(t *testing.T) {
 	t.Run("genre not found", func(t *testing.T) {
 		genreRepo.EXPECT().GetGenre(filter).Return(nil, nil).Times(1)
 
-		err := service.DeleteGenre(genre.ID)
+		err := service.DeleteGenre(genre.ID, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.StatusCode)
@@ -312,7 +362,7 @@ func TestGenreService_DeleteGenre(t *testing.T) {
 	t.Run("error getting genre", func(t *testing.T) {
 		genreRepo.EXPECT().GetGenre(filter).Return(nil, errors.New("error getting genre")).Times(1)
 
-		err := service.DeleteGenre(genre.ID)
+		err := service.DeleteGenre(genre.ID, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -328,7 +378,7 @@ func TestGenreService_DeleteGenre(t *testing.T) {
 		).Times(1)
 		movieGenreRepo.EXPECT().DeleteByGenreId(gomock.Any(), genre.ID).Return(errors.New("error deleting movie genres")).Times(1)
 
-		err := service.DeleteGenre(genre.ID)
+		err := service.DeleteGenre(genre.ID, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -345,7 +395,7 @@ func TestGenreService_DeleteGenre(t *testing.T) {
 		movieGenreRepo.EXPECT().DeleteByGenreId(gomock.Any(), genre.ID).Return(nil).Times(1)
 		genreRepo.EXPECT().DeleteGenre(gomock.Any(), genre).Return(errors.New("error deleting genre")).Times(1)
 
-		err := service.DeleteGenre(genre.ID)
+		err := service.DeleteGenre(genre.ID, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
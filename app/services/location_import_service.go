@@ -0,0 +1,639 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
+	"gorm.io/gorm"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxLocationImportErrors = 100
+
+type LocationImportService interface {
+	ImportLocations(source payloads.LocationImportSource, onProgress func(report *payloads.LocationImportReport)) (*payloads.LocationImportReport, *errors.ApiError)
+}
+
+func NewLocationImportService(
+	db *gorm.DB,
+	transactionManager transaction.TransactionManager,
+	countryRepo repositories.CountryRepository,
+	stateRepo repositories.StateRepository,
+	cityRepo repositories.CityRepository,
+	batchSize int,
+) LocationImportService {
+	return &locationImportService{
+		db:                 db,
+		transactionManager: transactionManager,
+		countryRepo:        countryRepo,
+		stateRepo:          stateRepo,
+		cityRepo:           cityRepo,
+		batchSize:          batchSize,
+	}
+}
+
+type locationImportService struct {
+	db                 *gorm.DB
+	transactionManager transaction.TransactionManager
+	countryRepo        repositories.CountryRepository
+	stateRepo          repositories.StateRepository
+	cityRepo           repositories.CityRepository
+	batchSize          int
+}
+
+type locationImportRecord struct {
+	line        int
+	countryCode string
+	countryName string
+	stateCode   string
+	stateName   string
+	cityName    string
+	latitude    *float64
+	longitude   *float64
+	problem     string
+}
+
+type locationImportBatchResult struct {
+	failed           map[*locationImportRecord]string
+	states           map[*locationImportRecord]*models.State
+	countriesCreated int
+	countriesUpdated int
+	statesCreated    int
+	statesUpdated    int
+	citiesCreated    int
+	citiesUpdated    int
+}
+
+type locationRecordReader func() (*locationImportRecord, error)
+
+func (s *locationImportService) ImportLocations(source payloads.LocationImportSource, onProgress func(report *payloads.LocationImportReport)) (*payloads.LocationImportReport, *errors.ApiError) {
+	next, err := newLocationRecordReader(source)
+	if err != nil {
+		return nil, errors.BadRequestError(err.Error())
+	}
+
+	report := &payloads.LocationImportReport{Errors: []payloads.LocationImportError{}}
+	batch := make([]*locationImportRecord, 0, s.batchSize)
+	flush := func() {
+		s.importBatch(batch, report)
+		batch = batch[:0]
+		if onProgress != nil {
+			onProgress(report)
+		}
+	}
+
+	for {
+		record, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.BadRequestError(err.Error())
+		}
+
+		report.RowsProcessed++
+		if record.problem == "" {
+			record.problem = validateLocationImportRecord(record)
+		}
+		if record.problem != "" {
+			report.RowsFailed++
+			addLocationImportError(report, record.line, record.problem)
+			continue
+		}
+
+		batch = append(batch, record)
+		if len(batch) == s.batchSize {
+			flush()
+		}
+	}
+
+	if len(batch) > 0 {
+		flush()
+	}
+
+	return report, nil
+}
+
+func (s *locationImportService) importBatch(batch []*locationImportRecord, report *payloads.LocationImportReport) {
+	result := &locationImportBatchResult{
+		failed: make(map[*locationImportRecord]string),
+		states: make(map[*locationImportRecord]*models.State),
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		countries, err := s.upsertCountries(tx, batch, result)
+		if err != nil {
+			return err
+		}
+
+		if err := s.upsertStates(tx, batch, countries, result); err != nil {
+			return err
+		}
+
+		return s.insertCities(tx, batch, result)
+	}); err != nil {
+		first, last := batch[0].line, batch[len(batch)-1].line
+		report.RowsFailed += len(batch)
+		addLocationImportError(report, first, fmt.Sprintf("rows from line %d to %d were not imported: %s", first, last, err.Error()))
+		return
+	}
+
+	report.CountriesCreated += result.countriesCreated
+	report.CountriesUpdated += result.countriesUpdated
+	report.StatesCreated += result.statesCreated
+	report.StatesUpdated += result.statesUpdated
+	report.CitiesCreated += result.citiesCreated
+	report.CitiesUpdated += result.citiesUpdated
+	for _, record := range batch {
+		if problem, ok := result.failed[record]; ok {
+			report.RowsFailed++
+			addLocationImportError(report, record.line, problem)
+			continue
+		}
+
+		report.RowsImported++
+	}
+}
+
+func (s *locationImportService) upsertCountries(tx *gorm.DB, batch []*locationImportRecord, result *locationImportBatchResult) (map[string]*models.Country, error) {
+	var codes []string
+	for _, record := range batch {
+		codes = append(codes, record.countryCode)
+	}
+
+	existing, err := s.countryRepo.GetCountries(filters.CountryFilter{
+		Filter: &filters.MultiFilter{Logic: filters.And},
+		Code:   &filters.Condition{Operator: filters.OpIn, Value: codes},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	countries := make(map[string]*models.Country)
+	for _, country := range existing {
+		countries[country.Code] = country
+	}
+
+	var created, updated []*models.Country
+	isNew := make(map[*models.Country]bool)
+	isUpdated := make(map[*models.Country]bool)
+	for _, record := range batch {
+		country, ok := countries[record.countryCode]
+		if !ok {
+			if record.countryName == "" {
+				result.failed[record] = fmt.Sprintf("unknown country %s", record.countryCode)
+				continue
+			}
+
+			country = &models.Country{
+				ID:   uuid.New(),
+				Name: record.countryName,
+				Code: record.countryCode,
+			}
+			countries[country.Code] = country
+			isNew[country] = true
+			created = append(created, country)
+			continue
+		}
+
+		if record.countryName != "" && record.countryName != country.Name {
+			country.Name = record.countryName
+			if !isNew[country] && !isUpdated[country] {
+				isUpdated[country] = true
+				updated = append(updated, country)
+			}
+		}
+	}
+
+	if len(created) > 0 {
+		if err := s.countryRepo.CreateCountries(tx, created); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, country := range updated {
+		if err := s.countryRepo.UpdateCountry(tx, country); err != nil {
+			return nil, err
+		}
+	}
+
+	result.countriesCreated = len(created)
+	result.countriesUpdated = len(updated)
+	return countries, nil
+}
+
+func (s *locationImportService) upsertStates(tx *gorm.DB, batch []*locationImportRecord, countries map[string]*models.Country, result *locationImportBatchResult) error {
+	var countryIDs []uuid.UUID
+	for _, record := range batch {
+		if _, failed := result.failed[record]; !failed {
+			countryIDs = append(countryIDs, countries[record.countryCode].ID)
+		}
+	}
+	if len(countryIDs) == 0 {
+		return nil
+	}
+
+	existing, err := s.stateRepo.GetStates(filters.StateFilter{
+		Filter:    &filters.MultiFilter{Logic: filters.And},
+		CountryID: &filters.Condition{Operator: filters.OpIn, Value: countryIDs},
+	})
+	if err != nil {
+		return err
+	}
+
+	byCode := make(map[string]*models.State)
+	byName := make(map[string]*models.State)
+	index := func(state *models.State) {
+		if state.Code != nil {
+			byCode[locationImportKey(state.CountryID, *state.Code)] = state
+		}
+		byName[locationImportKey(state.CountryID, state.Name)] = state
+	}
+	for _, state := range existing {
+		index(state)
+	}
+
+	var created, updated []*models.State
+	isNew := make(map[*models.State]bool)
+	isUpdated := make(map[*models.State]bool)
+	for _, record := range batch {
+		if _, failed := result.failed[record]; failed {
+			continue
+		}
+
+		countryID := countries[record.countryCode].ID
+		var state *models.State
+		if record.stateCode != "" {
+			state = byCode[locationImportKey(countryID, record.stateCode)]
+		}
+
+		changed := false
+		if state == nil && record.stateName != "" {
+			state = byName[locationImportKey(countryID, record.stateName)]
+			if state != nil && record.stateCode != "" {
+				if state.Code != nil {
+					result.failed[record] = fmt.Sprintf("state %s already exists with code %s", state.Name, *state.Code)
+					continue
+				}
+
+				state.Code = &record.stateCode
+				index(state)
+				changed = true
+			}
+		}
+
+		if state == nil {
+			if record.stateName == "" {
+				result.failed[record] = fmt.Sprintf("unknown state %s.%s", record.countryCode, record.stateCode)
+				continue
+			}
+
+			state = &models.State{
+				ID:        uuid.New(),
+				Name:      record.stateName,
+				CountryID: countryID,
+			}
+			if record.stateCode != "" {
+				state.Code = &record.stateCode
+			}
+			index(state)
+			isNew[state] = true
+			created = append(created, state)
+		}
+
+		if record.stateName != "" && record.stateName != state.Name {
+			if other := byName[locationImportKey(countryID, record.stateName)]; other != nil && other != state {
+				result.failed[record] = fmt.Sprintf("state %s already exists in country %s", record.stateName, record.countryCode)
+				continue
+			}
+
+			delete(byName, locationImportKey(countryID, state.Name))
+			state.Name = record.stateName
+			index(state)
+			changed = true
+		}
+
+		if changed && !isNew[state] && !isUpdated[state] {
+			isUpdated[state] = true
+			updated = append(updated, state)
+		}
+
+		result.states[record] = state
+	}
+
+	if len(created) > 0 {
+		if err := s.stateRepo.CreateStates(tx, created); err != nil {
+			return err
+		}
+	}
+
+	for _, state := range updated {
+		if err := s.stateRepo.UpdateState(tx, state); err != nil {
+			return err
+		}
+	}
+
+	result.statesCreated = len(created)
+	result.statesUpdated = len(updated)
+	return nil
+}
+
+func (s *locationImportService) insertCities(tx *gorm.DB, batch []*locationImportRecord, result *locationImportBatchResult) error {
+	var stateIDs []uuid.UUID
+	var names []string
+	for _, record := range batch {
+		if state, ok := result.states[record]; ok {
+			stateIDs = append(stateIDs, state.ID)
+			names = append(names, record.cityName)
+		}
+	}
+	if len(stateIDs) == 0 {
+		return nil
+	}
+
+	existing, err := s.cityRepo.GetCities(filters.CityFilter{
+		Filter:  &filters.MultiFilter{Logic: filters.And},
+		StateID: &filters.Condition{Operator: filters.OpIn, Value: stateIDs},
+		Name:    &filters.Condition{Operator: filters.OpIn, Value: names},
+	})
+	if err != nil {
+		return err
+	}
+
+	cities := make(map[string]*models.City)
+	for _, city := range existing {
+		cities[locationImportKey(city.StateID, city.Name)] = city
+	}
+
+	var created, updated []*models.City
+	for _, record := range batch {
+		state, ok := result.states[record]
+		if !ok {
+			continue
+		}
+
+		key := locationImportKey(state.ID, record.cityName)
+		if city, ok := cities[key]; ok {
+			// Existing cities are only backfilled with coordinates, never moved.
+			if city.Latitude == nil && record.latitude != nil {
+				city.Latitude = record.latitude
+				city.Longitude = record.longitude
+				updated = append(updated, city)
+			}
+			continue
+		}
+
+		city := &models.City{
+			ID:        uuid.New(),
+			Name:      record.cityName,
+			StateID:   state.ID,
+			Latitude:  record.latitude,
+			Longitude: record.longitude,
+		}
+		cities[key] = city
+		created = append(created, city)
+	}
+
+	if len(created) > 0 {
+		if err := s.cityRepo.CreateCities(tx, created); err != nil {
+			return err
+		}
+	}
+
+	for _, city := range updated {
+		if err := s.cityRepo.UpdateCity(tx, city); err != nil {
+			return err
+		}
+	}
+
+	result.citiesCreated = len(created)
+	result.citiesUpdated = len(updated)
+	return nil
+}
+
+func newLocationRecordReader(source payloads.LocationImportSource) (locationRecordReader, error) {
+	if source.Data == nil {
+		return nil, fmt.Errorf("missing import file")
+	}
+
+	switch source.Format {
+	case constants.CsvImportFormat:
+		return newCsvLocationReader(source.Data)
+	case constants.GeoNamesImportFormat:
+		return newGeoNamesLocationReader(source)
+	default:
+		return nil, fmt.Errorf("unsupported import format %s", source.Format)
+	}
+}
+
+func newCsvLocationReader(data io.Reader) (locationRecordReader, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("import file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"country_code", "city_name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+	_, hasStateCode := columns["state_code"]
+	_, hasStateName := columns["state_name"]
+	if !hasStateCode && !hasStateName {
+		return nil, fmt.Errorf("missing state_code or state_name column")
+	}
+
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	return func() (*locationImportRecord, error) {
+		row, err := reader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := &locationImportRecord{
+			line:        line,
+			countryCode: strings.ToUpper(field(row, "country_code")),
+			countryName: field(row, "country_name"),
+			stateCode:   field(row, "state_code"),
+			stateName:   field(row, "state_name"),
+			cityName:    field(row, "city_name"),
+		}
+		setLocationImportCoordinates(record, field(row, "latitude"), field(row, "longitude"))
+		return record, nil
+	}, nil
+}
+
+// newGeoNamesLocationReader reads a GeoNames cities dump (e.g. cities500.txt). Country and
+// first-level division names come from the optional countryInfo.txt and admin1CodesASCII.txt files.
+func newGeoNamesLocationReader(source payloads.LocationImportSource) (locationRecordReader, error) {
+	countryNames, err := readGeoNamesNames(source.CountryInfo, 0, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	admin1Names, err := readGeoNamesNames(source.Admin1Codes, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := newGeoNamesScanner(source.Data)
+	line := 0
+	return func() (*locationImportRecord, error) {
+		for scanner.Scan() {
+			line++
+			text := scanner.Text()
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+
+			fields := strings.Split(text, "\t")
+			if len(fields) < 11 {
+				return &locationImportRecord{line: line, problem: fmt.Sprintf("expected at least 11 columns, got %d", len(fields))}, nil
+			}
+
+			// Only populated places (feature class P) are cities.
+			if fields[6] != "P" {
+				continue
+			}
+
+			countryCode := strings.ToUpper(strings.TrimSpace(fields[8]))
+			admin1Code := strings.TrimSpace(fields[10])
+			record := &locationImportRecord{
+				line:        line,
+				countryCode: countryCode,
+				countryName: countryNames[countryCode],
+				stateCode:   admin1Code,
+				stateName:   admin1Names[countryCode+"."+admin1Code],
+				cityName:    strings.TrimSpace(fields[1]),
+			}
+			setLocationImportCoordinates(record, strings.TrimSpace(fields[4]), strings.TrimSpace(fields[5]))
+			if admin1Code == "" || admin1Code == "00" {
+				record.problem = "missing admin1 code"
+			}
+
+			return record, nil
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}, nil
+}
+
+func readGeoNamesNames(data io.Reader, keyColumn, nameColumn int) (map[string]string, error) {
+	names := make(map[string]string)
+	if data == nil {
+		return names, nil
+	}
+
+	scanner := newGeoNamesScanner(data)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) > keyColumn && len(fields) > nameColumn {
+			names[strings.TrimSpace(fields[keyColumn])] = strings.TrimSpace(fields[nameColumn])
+		}
+	}
+
+	return names, scanner.Err()
+}
+
+func newGeoNamesScanner(data io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return scanner
+}
+
+func setLocationImportCoordinates(record *locationImportRecord, latitude, longitude string) {
+	if latitude == "" && longitude == "" {
+		return
+	}
+
+	lat, err := strconv.ParseFloat(latitude, 64)
+	if err != nil {
+		record.problem = fmt.Sprintf("invalid latitude %q", latitude)
+		return
+	}
+
+	lon, err := strconv.ParseFloat(longitude, 64)
+	if err != nil {
+		record.problem = fmt.Sprintf("invalid longitude %q", longitude)
+		return
+	}
+
+	record.latitude = &lat
+	record.longitude = &lon
+}
+
+func validateLocationImportRecord(record *locationImportRecord) string {
+	switch {
+	case len(record.countryCode) != 2:
+		return fmt.Sprintf("invalid country code %q", record.countryCode)
+	case utf8.RuneCountInString(record.countryName) > 100:
+		return "country name is too long"
+	case record.stateCode == "" && record.stateName == "":
+		return "missing state code or name"
+	case utf8.RuneCountInString(record.stateCode) > 10:
+		return "state code is too long"
+	case utf8.RuneCountInString(record.stateName) > 100:
+		return "state name is too long"
+	case record.cityName == "":
+		return "missing city name"
+	case utf8.RuneCountInString(record.cityName) > 100:
+		return "city name is too long"
+	case record.latitude != nil && (*record.latitude < -90 || *record.latitude > 90):
+		return "latitude is out of range"
+	case record.longitude != nil && (*record.longitude < -180 || *record.longitude > 180):
+		return "longitude is out of range"
+	default:
+		return ""
+	}
+}
+
+func addLocationImportError(report *payloads.LocationImportReport, line int, message string) {
+	if len(report.Errors) >= maxLocationImportErrors {
+		report.ErrorsTruncated = true
+		return
+	}
+
+	report.Errors = append(report.Errors, payloads.LocationImportError{Line: line, Message: message})
+}
+
+func locationImportKey(parentID uuid.UUID, value string) string {
+	return parentID.String() + "|" + value
+}
@@ -0,0 +1,344 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_transaction"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLocationImportService_ImportLocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
+	service := NewLocationImportService(nil, transaction, countryRepo, stateRepo, cityRepo, 500)
+
+	executeInTransaction := func(times int) {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(times)
+	}
+
+	csvSource := func(rows ...string) payloads.LocationImportSource {
+		data := "country_code,country_name,state_code,state_name,city_name\n" + strings.Join(rows, "\n")
+		return payloads.LocationImportSource{Format: constants.CsvImportFormat, Data: strings.NewReader(data)}
+	}
+
+	t.Run("success with csv", func(t *testing.T) {
+		us := &models.Country{ID: uuid.New(), Name: "United States", Code: "US"}
+		california := &models.State{ID: uuid.New(), Name: "California", Code: utils.GetPointerOf("CA"), CountryID: us.ID}
+		losAngeles := &models.City{ID: uuid.New(), Name: "Los Angeles", StateID: california.ID}
+
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return([]*models.Country{us}, nil).Times(1)
+		countryRepo.EXPECT().CreateCountries(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, countries []*models.Country) error {
+				assert.Len(t, countries, 1)
+				assert.Equal(t, "VN", countries[0].Code)
+				assert.Equal(t, "Viet Nam", countries[0].Name)
+				return nil
+			},
+		).Times(1)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return([]*models.State{california}, nil).Times(1)
+		stateRepo.EXPECT().CreateStates(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, states []*models.State) error {
+				assert.Len(t, states, 1)
+				assert.Equal(t, "Ho Chi Minh", states[0].Name)
+				assert.Equal(t, "SG", *states[0].Code)
+				return nil
+			},
+		).Times(1)
+		cityRepo.EXPECT().GetCities(gomock.Any()).Return([]*models.City{losAngeles}, nil).Times(1)
+		cityRepo.EXPECT().CreateCities(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, cities []*models.City) error {
+				assert.Len(t, cities, 2)
+				assert.Equal(t, "Thu Duc", cities[0].Name)
+				assert.Equal(t, "San Diego", cities[1].Name)
+				assert.Equal(t, california.ID, cities[1].StateID)
+				return nil
+			},
+		).Times(1)
+
+		report, err := service.ImportLocations(csvSource(
+			"VN,Viet Nam,SG,Ho Chi Minh,Thu Duc",
+			"US,United States,CA,California,Los Angeles",
+			"us,,CA,,San Diego",
+		), nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, report.RowsProcessed)
+		assert.Equal(t, 3, report.RowsImported)
+		assert.Equal(t, 0, report.RowsFailed)
+		assert.Equal(t, 1, report.CountriesCreated)
+		assert.Equal(t, 0, report.CountriesUpdated)
+		assert.Equal(t, 1, report.StatesCreated)
+		assert.Equal(t, 0, report.StatesUpdated)
+		assert.Equal(t, 2, report.CitiesCreated)
+		assert.Empty(t, report.Errors)
+	})
+
+	t.Run("update existing country and state", func(t *testing.T) {
+		us := &models.Country{ID: uuid.New(), Name: "USA", Code: "US"}
+		california := &models.State{ID: uuid.New(), Name: "California", CountryID: us.ID}
+
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return([]*models.Country{us}, nil).Times(1)
+		countryRepo.EXPECT().UpdateCountry(gomock.Any(), us).Return(nil).Times(1)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return([]*models.State{california}, nil).Times(1)
+		stateRepo.EXPECT().UpdateState(gomock.Any(), california).Return(nil).Times(1)
+		cityRepo.EXPECT().GetCities(gomock.Any()).Return(nil, nil).Times(1)
+		cityRepo.EXPECT().CreateCities(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		report, err := service.ImportLocations(csvSource(
+			"US,United States,CA,California,Fresno",
+			"US,United States,CA,California,Oakland",
+		), nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, report.RowsImported)
+		assert.Equal(t, 1, report.CountriesUpdated)
+		assert.Equal(t, 1, report.StatesUpdated)
+		assert.Equal(t, 2, report.CitiesCreated)
+		assert.Equal(t, "United States", us.Name)
+		assert.Equal(t, "CA", *california.Code)
+	})
+
+	t.Run("city coordinates", func(t *testing.T) {
+		us := &models.Country{ID: uuid.New(), Name: "United States", Code: "US"}
+		california := &models.State{ID: uuid.New(), Name: "California", Code: utils.GetPointerOf("CA"), CountryID: us.ID}
+		fresno := &models.City{ID: uuid.New(), Name: "Fresno", StateID: california.ID}
+
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return([]*models.Country{us}, nil).Times(1)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return([]*models.State{california}, nil).Times(1)
+		cityRepo.EXPECT().GetCities(gomock.Any()).Return([]*models.City{fresno}, nil).Times(1)
+		cityRepo.EXPECT().CreateCities(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, cities []*models.City) error {
+				assert.Equal(t, 37.80437, *cities[0].Latitude)
+				assert.Equal(t, -122.2708, *cities[0].Longitude)
+				return nil
+			},
+		).Times(1)
+		cityRepo.EXPECT().UpdateCity(gomock.Any(), fresno).Return(nil).Times(1)
+
+		report, err := service.ImportLocations(payloads.LocationImportSource{
+			Format: constants.CsvImportFormat,
+			Data: strings.NewReader(strings.Join([]string{
+				"country_code,state_code,city_name,latitude,longitude",
+				"US,CA,Oakland,37.80437,-122.2708",
+				"US,CA,Fresno,36.74773,-119.77237",
+				"US,CA,Sacramento,north,-121.4944",
+				"US,CA,San Jose,137.33939,-121.89496",
+			}, "\n")),
+		}, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, report.RowsImported)
+		assert.Equal(t, 1, report.CitiesCreated)
+		assert.Equal(t, 1, report.CitiesUpdated)
+		assert.Equal(t, 36.74773, *fresno.Latitude)
+		assert.Equal(t, []payloads.LocationImportError{
+			{Line: 4, Message: `invalid latitude "north"`},
+			{Line: 5, Message: "latitude is out of range"},
+		}, report.Errors)
+	})
+
+	t.Run("invalid and unknown rows", func(t *testing.T) {
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return(nil, nil).Times(1)
+
+		report, err := service.ImportLocations(csvSource(
+			"VNM,Viet Nam,SG,Ho Chi Minh,Thu Duc",
+			"US,United States,,,Boston",
+			"US,United States,CA,California,",
+			"FR,,IDF,,Paris",
+		), nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 4, report.RowsProcessed)
+		assert.Equal(t, 0, report.RowsImported)
+		assert.Equal(t, 4, report.RowsFailed)
+		assert.Equal(t, []payloads.LocationImportError{
+			{Line: 2, Message: `invalid country code "VNM"`},
+			{Line: 3, Message: "missing state code or name"},
+			{Line: 4, Message: "missing city name"},
+			{Line: 5, Message: "unknown country FR"},
+		}, report.Errors)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		us := &models.Country{ID: uuid.New(), Name: "United States", Code: "US"}
+
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return([]*models.Country{us}, nil).Times(1)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return(nil, nil).Times(1)
+
+		report, err := service.ImportLocations(csvSource("US,,CA,,Fresno"), nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.RowsFailed)
+		assert.Equal(t, "unknown state US.CA", report.Errors[0].Message)
+	})
+
+	t.Run("state name used by another code", func(t *testing.T) {
+		us := &models.Country{ID: uuid.New(), Name: "United States", Code: "US"}
+		california := &models.State{ID: uuid.New(), Name: "California", Code: utils.GetPointerOf("CA"), CountryID: us.ID}
+
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return([]*models.Country{us}, nil).Times(1)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return([]*models.State{california}, nil).Times(1)
+
+		report, err := service.ImportLocations(csvSource("US,,CL,California,Fresno"), nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.RowsFailed)
+		assert.Equal(t, "state California already exists with code CA", report.Errors[0].Message)
+	})
+
+	t.Run("failed batch does not stop import", func(t *testing.T) {
+		service := NewLocationImportService(nil, transaction, countryRepo, stateRepo, cityRepo, 2)
+		us := &models.Country{ID: uuid.New(), Name: "United States", Code: "US"}
+		california := &models.State{ID: uuid.New(), Name: "California", Code: utils.GetPointerOf("CA"), CountryID: us.ID}
+
+		executeInTransaction(2)
+		gomock.InOrder(
+			countryRepo.EXPECT().GetCountries(gomock.Any()).Return(nil, errors.New("error getting countries")),
+			countryRepo.EXPECT().GetCountries(gomock.Any()).Return([]*models.Country{us}, nil),
+		)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return([]*models.State{california}, nil).Times(1)
+		cityRepo.EXPECT().GetCities(gomock.Any()).Return(nil, nil).Times(1)
+		cityRepo.EXPECT().CreateCities(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		var progress []int
+		report, err := service.ImportLocations(csvSource(
+			"US,,CA,,Fresno",
+			"US,,CA,,Oakland",
+			"US,,CA,,San Jose",
+		), func(report *payloads.LocationImportReport) {
+			progress = append(progress, report.RowsProcessed)
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 3}, progress)
+		assert.Equal(t, 1, report.RowsImported)
+		assert.Equal(t, 2, report.RowsFailed)
+		assert.Equal(t, []payloads.LocationImportError{
+			{Line: 2, Message: "rows from line 2 to 3 were not imported: error getting countries"},
+		}, report.Errors)
+	})
+
+	t.Run("success with geonames", func(t *testing.T) {
+		geoNamesRow := func(name, featureClass, countryCode, admin1Code string) string {
+			fields := make([]string, 19)
+			fields[1], fields[4], fields[5], fields[6], fields[8], fields[10] = name, "10.8494", "106.7537", featureClass, countryCode, admin1Code
+			return strings.Join(fields, "\t")
+		}
+		data := strings.Join([]string{
+			"# cities500",
+			geoNamesRow("Thu Duc", "P", "VN", "20"),
+			geoNamesRow("Ho Chi Minh Province", "A", "VN", "20"),
+			geoNamesRow("Spratly", "P", "VN", "00"),
+			"broken row",
+		}, "\n")
+		countryInfo := "#ISO\tISO3\tISO-Numeric\tfips\tCountry\nVN\tVNM\t704\tVM\tVietnam\n"
+		admin1Codes := "VN.20\tHo Chi Minh\tHo Chi Minh\t1566083\n"
+
+		executeInTransaction(1)
+		countryRepo.EXPECT().GetCountries(gomock.Any()).Return(nil, nil).Times(1)
+		countryRepo.EXPECT().CreateCountries(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, countries []*models.Country) error {
+				assert.Equal(t, "Vietnam", countries[0].Name)
+				return nil
+			},
+		).Times(1)
+		stateRepo.EXPECT().GetStates(gomock.Any()).Return(nil, nil).Times(1)
+		stateRepo.EXPECT().CreateStates(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, states []*models.State) error {
+				assert.Equal(t, "Ho Chi Minh", states[0].Name)
+				assert.Equal(t, "20", *states[0].Code)
+				return nil
+			},
+		).Times(1)
+		cityRepo.EXPECT().GetCities(gomock.Any()).Return(nil, nil).Times(1)
+		cityRepo.EXPECT().CreateCities(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, cities []*models.City) error {
+				assert.Equal(t, "Thu Duc", cities[0].Name)
+				assert.Equal(t, 10.8494, *cities[0].Latitude)
+				assert.Equal(t, 106.7537, *cities[0].Longitude)
+				return nil
+			},
+		).Times(1)
+
+		report, err := service.ImportLocations(payloads.LocationImportSource{
+			Format:      constants.GeoNamesImportFormat,
+			Data:        strings.NewReader(data),
+			CountryInfo: strings.NewReader(countryInfo),
+			Admin1Codes: strings.NewReader(admin1Codes),
+		}, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, report.RowsProcessed)
+		assert.Equal(t, 1, report.RowsImported)
+		assert.Equal(t, 2, report.RowsFailed)
+		assert.Equal(t, []payloads.LocationImportError{
+			{Line: 4, Message: "missing admin1 code"},
+			{Line: 5, Message: "expected at least 11 columns, got 1"},
+		}, report.Errors)
+	})
+
+	t.Run("errors are truncated", func(t *testing.T) {
+		rows := make([]string, maxLocationImportErrors+5)
+		for i := range rows {
+			rows[i] = fmt.Sprintf("XYZ%d,,CA,,Fresno", i)
+		}
+
+		report, err := service.ImportLocations(csvSource(rows...), nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, maxLocationImportErrors+5, report.RowsFailed)
+		assert.Len(t, report.Errors, maxLocationImportErrors)
+		assert.True(t, report.ErrorsTruncated)
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			source  payloads.LocationImportSource
+			message string
+		}{
+			{"missing file", payloads.LocationImportSource{Format: constants.CsvImportFormat}, "missing import file"},
+			{"unsupported format", payloads.LocationImportSource{Format: "xlsx", Data: strings.NewReader("")}, "unsupported import format xlsx"},
+			{"empty file", payloads.LocationImportSource{Format: constants.CsvImportFormat, Data: strings.NewReader("")}, "import file is empty"},
+			{"missing city column", payloads.LocationImportSource{Format: constants.CsvImportFormat, Data: strings.NewReader("country_code,state_code\n")}, "missing city_name column"},
+			{"missing state column", payloads.LocationImportSource{Format: constants.CsvImportFormat, Data: strings.NewReader("country_code,city_name\n")}, "missing state_code or state_name column"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				report, err := service.ImportLocations(tt.source, nil)
+
+				assert.Nil(t, report)
+				assert.NotNil(t, err)
+				assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+				assert.Equal(t, tt.message, err.Error())
+			})
+		}
+	})
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
@@ -12,12 +14,22 @@ import (
 )
 
 type LocationService interface {
-	GetCountries() ([]*models.Country, *errors.ApiError)
+	GetCountries(limit, offset int) ([]*models.Country, *models.ResponseMeta, *errors.ApiError)
+	GetCountry(countryID uuid.UUID) (*models.Country, *errors.ApiError)
 	CreateCountry(req payloads.CreateCountryRequest) (*models.Country, *errors.ApiError)
-	GetStatesByCountry(countryID uuid.UUID) ([]*models.State, *errors.ApiError)
+	UpdateCountry(countryID uuid.UUID, req payloads.UpdateCountryRequest) (*models.Country, *errors.ApiError)
+	DeleteCountry(countryID uuid.UUID) *errors.ApiError
+	GetStatesByCountry(countryID uuid.UUID, limit, offset int) ([]*models.State, *models.ResponseMeta, *errors.ApiError)
+	GetState(countryID, stateID uuid.UUID) (*models.State, *errors.ApiError)
 	CreateState(countryID uuid.UUID, req payloads.CreateStateRequest) (*models.State, *errors.ApiError)
-	GetCitiesByState(countryID, stateID uuid.UUID) ([]*models.City, *errors.ApiError)
+	UpdateState(countryID, stateID uuid.UUID, req payloads.UpdateStateRequest) (*models.State, *errors.ApiError)
+	DeleteState(countryID, stateID uuid.UUID) *errors.ApiError
+	GetCitiesByState(countryID, stateID uuid.UUID, limit, offset int) ([]*models.City, *models.ResponseMeta, *errors.ApiError)
+	GetCity(countryID, stateID, cityID uuid.UUID) (*models.City, *errors.ApiError)
 	CreateCity(countryID, stateID uuid.UUID, req payloads.CreateCityRequest) (*models.City, *errors.ApiError)
+	UpdateCity(countryID, stateID, cityID uuid.UUID, req payloads.UpdateCityRequest) (*models.City, *errors.ApiError)
+	DeleteCity(countryID, stateID, cityID uuid.UUID) *errors.ApiError
+	SearchCities(keyword string, limit, offset int) ([]*models.City, *errors.ApiError)
 }
 
 func NewLocationService(
@@ -26,34 +38,53 @@ func NewLocationService(
 	countryRepo repositories.CountryRepository,
 	stateRepo repositories.StateRepository,
 	cityRepo repositories.CityRepository,
+	theaterLocationRepo repositories.TheaterLocationRepository,
 ) LocationService {
 	return &locationService{
-		db:                 db,
-		transactionManager: transactionManager,
-		countryRepo:        countryRepo,
-		stateRepo:          stateRepo,
-		cityRepo:           cityRepo,
+		db:                  db,
+		transactionManager:  transactionManager,
+		countryRepo:         countryRepo,
+		stateRepo:           stateRepo,
+		cityRepo:            cityRepo,
+		theaterLocationRepo: theaterLocationRepo,
 	}
 }
 
 type locationService struct {
-	db                 *gorm.DB
-	transactionManager transaction.TransactionManager
-	countryRepo        repositories.CountryRepository
-	stateRepo          repositories.StateRepository
-	cityRepo           repositories.CityRepository
+	db                  *gorm.DB
+	transactionManager  transaction.TransactionManager
+	countryRepo         repositories.CountryRepository
+	stateRepo           repositories.StateRepository
+	cityRepo            repositories.CityRepository
+	theaterLocationRepo repositories.TheaterLocationRepository
 }
 
-func (s *locationService) GetCountries() ([]*models.Country, *errors.ApiError) {
+func (s *locationService) GetCountries(limit, offset int) ([]*models.Country, *models.ResponseMeta, *errors.ApiError) {
 	filter := filters.CountryFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
+		Filter: &filters.MultiFilter{
+			Logic:  filters.And,
+			Limit:  &limit,
+			Offset: &offset,
+			Sort:   []filters.SortOption{{Field: "name", Direction: filters.Asc}},
+		},
 	}
 	countries, err := s.countryRepo.GetCountries(filter)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return nil, nil, errors.InternalServerError(err.Error())
 	}
 
-	return countries, nil
+	count, err := s.countryRepo.GetNumbersOfCountry(filters.CountryFilter{
+		Filter: &filters.SingleFilter{Logic: filters.And},
+	})
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+
+	return countries, buildLocationsMeta("/countries", limit, offset, count), nil
+}
+
+func (s *locationService) GetCountry(countryID uuid.UUID) (*models.Country, *errors.ApiError) {
+	return s.getCountry(countryID)
 }
 
 func (s *locationService) CreateCountry(req payloads.CreateCountryRequest) (*models.Country, *errors.ApiError) {
@@ -71,9 +102,10 @@ func (s *locationService) CreateCountry(req payloads.CreateCountryRequest) (*mod
 	}
 
 	c = &models.Country{
-		ID:   uuid.New(),
-		Name: req.Name,
-		Code: req.Code,
+		ID:            uuid.New(),
+		Name:          req.Name,
+		Code:          req.Code,
+		IdCheckMinAge: req.IdCheckMinAge,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
 		return s.countryRepo.CreateCountry(tx, c)
@@ -84,42 +116,117 @@ func (s *locationService) CreateCountry(req payloads.CreateCountryRequest) (*mod
 	return c, nil
 }
 
-func (s *locationService) GetStatesByCountry(countryID uuid.UUID) ([]*models.State, *errors.ApiError) {
-	countryFilter := filters.CountryFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+func (s *locationService) UpdateCountry(countryID uuid.UUID, req payloads.UpdateCountryRequest) (*models.Country, *errors.ApiError) {
+	country, apiErr := s.getCountry(countryID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	country, err := s.countryRepo.GetCountry(countryFilter)
-	if err != nil {
+
+	for _, filter := range []filters.CountryFilter{
+		{
+			Filter: &filters.SingleFilter{Logic: filters.And},
+			ID:     &filters.Condition{Operator: filters.OpNotEqual, Value: countryID},
+			Name:   &filters.Condition{Operator: filters.OpEqual, Value: req.Name},
+		},
+		{
+			Filter: &filters.SingleFilter{Logic: filters.And},
+			ID:     &filters.Condition{Operator: filters.OpNotEqual, Value: countryID},
+			Code:   &filters.Condition{Operator: filters.OpEqual, Value: req.Code},
+		},
+	} {
+		c, err := s.countryRepo.GetCountry(filter)
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+		if c != nil {
+			return nil, errors.BadRequestError("duplicate country name or code")
+		}
+	}
+
+	country.Name = req.Name
+	country.Code = req.Code
+	country.IdCheckMinAge = req.IdCheckMinAge
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.countryRepo.UpdateCountry(tx, country)
+	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
-	if country == nil {
-		return nil, errors.NotFoundError("country does not exist")
+
+	return country, nil
+}
+
+func (s *locationService) DeleteCountry(countryID uuid.UUID) *errors.ApiError {
+	country, apiErr := s.getCountry(countryID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	inUse, err := s.theaterLocationRepo.HasLocationsInCountry(countryID)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if inUse {
+		return errors.BadRequestError("country has cities used by theaters")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.countryRepo.DeleteCountry(tx, country)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *locationService) GetStatesByCountry(countryID uuid.UUID, limit, offset int) ([]*models.State, *models.ResponseMeta, *errors.ApiError) {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return nil, nil, apiErr
 	}
 
 	statesFilter := filters.StateFilter{
-		Filter:    &filters.MultiFilter{Logic: filters.And},
+		Filter: &filters.MultiFilter{
+			Logic:  filters.And,
+			Limit:  &limit,
+			Offset: &offset,
+			Sort:   []filters.SortOption{{Field: "name", Direction: filters.Asc}},
+		},
 		CountryID: &filters.Condition{Operator: filters.OpEqual, Value: countryID},
 	}
 	states, err := s.stateRepo.GetStates(statesFilter)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return nil, nil, errors.InternalServerError(err.Error())
 	}
 
-	return states, nil
+	count, err := s.stateRepo.GetNumbersOfState(filters.StateFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		CountryID: &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+	})
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+
+	path := fmt.Sprintf("/countries/%s/states", countryID)
+	return states, buildLocationsMeta(path, limit, offset, count), nil
 }
 
-func (s *locationService) CreateState(countryID uuid.UUID, req payloads.CreateStateRequest) (*models.State, *errors.ApiError) {
-	countryFilter := filters.CountryFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+func (s *locationService) GetState(countryID, stateID uuid.UUID) (*models.State, *errors.ApiError) {
+	country, apiErr := s.getCountry(countryID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	country, err := s.countryRepo.GetCountry(countryFilter)
-	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+
+	state, apiErr := s.getState(countryID, stateID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	if country == nil {
-		return nil, errors.NotFoundError("country does not exist")
+
+	state.Country = country
+	return state, nil
+}
+
+func (s *locationService) CreateState(countryID uuid.UUID, req payloads.CreateStateRequest) (*models.State, *errors.ApiError) {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return nil, apiErr
 	}
 
 	stateFilter := filters.StateFilter{
@@ -150,66 +257,130 @@ func (s *locationService) CreateState(countryID uuid.UUID, req payloads.CreateSt
 	return state, nil
 }
 
-func (s *locationService) GetCitiesByState(countryID, stateID uuid.UUID) ([]*models.City, *errors.ApiError) {
-	countryFilter := filters.CountryFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+func (s *locationService) UpdateState(countryID, stateID uuid.UUID, req payloads.UpdateStateRequest) (*models.State, *errors.ApiError) {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return nil, apiErr
+	}
+
+	state, apiErr := s.getState(countryID, stateID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	country, err := s.countryRepo.GetCountry(countryFilter)
+
+	duplicate, err := s.stateRepo.GetState(filters.StateFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		ID:        &filters.Condition{Operator: filters.OpNotEqual, Value: stateID},
+		CountryID: &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+		Name:      &filters.Condition{Operator: filters.OpEqual, Value: req.Name},
+	})
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
-	if country == nil {
-		return nil, errors.NotFoundError("country does not exist")
+	if duplicate != nil {
+		return nil, errors.BadRequestError("duplicate state name for this country")
 	}
 
-	stateFilter := filters.StateFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: stateID},
+	state.Name = req.Name
+	state.Code = req.Code
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.stateRepo.UpdateState(tx, state)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
 	}
-	state, err := s.stateRepo.GetState(stateFilter)
+
+	return state, nil
+}
+
+func (s *locationService) DeleteState(countryID, stateID uuid.UUID) *errors.ApiError {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return apiErr
+	}
+
+	state, apiErr := s.getState(countryID, stateID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	inUse, err := s.theaterLocationRepo.HasLocationsInState(stateID)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return errors.InternalServerError(err.Error())
 	}
-	if state == nil {
-		return nil, errors.NotFoundError("state does not exist")
+	if inUse {
+		return errors.BadRequestError("state has cities used by theaters")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.stateRepo.DeleteState(tx, state)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *locationService) GetCitiesByState(countryID, stateID uuid.UUID, limit, offset int) ([]*models.City, *models.ResponseMeta, *errors.ApiError) {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return nil, nil, apiErr
+	}
+
+	if _, apiErr := s.getState(countryID, stateID); apiErr != nil {
+		return nil, nil, apiErr
 	}
 
 	citiesFilter := filters.CityFilter{
-		Filter:  &filters.MultiFilter{Logic: filters.And},
+		Filter: &filters.MultiFilter{
+			Logic:  filters.And,
+			Limit:  &limit,
+			Offset: &offset,
+			Sort:   []filters.SortOption{{Field: "name", Direction: filters.Asc}},
+		},
 		StateID: &filters.Condition{Operator: filters.OpEqual, Value: stateID},
 	}
 	cities, err := s.cityRepo.GetCities(citiesFilter)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return nil, nil, errors.InternalServerError(err.Error())
 	}
 
-	return cities, nil
+	count, err := s.cityRepo.GetNumbersOfCity(filters.CityFilter{
+		Filter:  &filters.SingleFilter{Logic: filters.And},
+		StateID: &filters.Condition{Operator: filters.OpEqual, Value: stateID},
+	})
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+
+	path := fmt.Sprintf("/countries/%s/states/%s/cities", countryID, stateID)
+	return cities, buildLocationsMeta(path, limit, offset, count), nil
 }
 
-func (s *locationService) CreateCity(countryID, stateID uuid.UUID, req payloads.CreateCityRequest) (*models.City, *errors.ApiError) {
-	countryFilter := filters.CountryFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+func (s *locationService) GetCity(countryID, stateID, cityID uuid.UUID) (*models.City, *errors.ApiError) {
+	country, apiErr := s.getCountry(countryID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	country, err := s.countryRepo.GetCountry(countryFilter)
-	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
-	}
-	if country == nil {
-		return nil, errors.NotFoundError("country does not exist")
+
+	state, apiErr := s.getState(countryID, stateID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 
-	stateFilter := filters.StateFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: stateID},
+	city, apiErr := s.getCity(stateID, cityID)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	state, err := s.stateRepo.GetState(stateFilter)
-	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+
+	state.Country = country
+	city.State = state
+	return city, nil
+}
+
+func (s *locationService) CreateCity(countryID, stateID uuid.UUID, req payloads.CreateCityRequest) (*models.City, *errors.ApiError) {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return nil, apiErr
 	}
-	if state == nil {
-		return nil, errors.NotFoundError("state does not exist")
+
+	if _, apiErr := s.getState(countryID, stateID); apiErr != nil {
+		return nil, apiErr
 	}
 
 	cityFilter := filters.CityFilter{
@@ -226,9 +397,12 @@ func (s *locationService) CreateCity(countryID, stateID uuid.UUID, req payloads.
 	}
 
 	city = &models.City{
-		ID:      uuid.New(),
-		Name:    req.Name,
-		StateID: stateID,
+		ID:        uuid.New(),
+		Name:      req.Name,
+		StateID:   stateID,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		TimeZone:  req.TimeZone,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
 		return s.cityRepo.CreateCity(tx, city)
@@ -238,3 +412,179 @@ func (s *locationService) CreateCity(countryID, stateID uuid.UUID, req payloads.
 
 	return city, nil
 }
+
+func (s *locationService) UpdateCity(countryID, stateID, cityID uuid.UUID, req payloads.UpdateCityRequest) (*models.City, *errors.ApiError) {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if _, apiErr := s.getState(countryID, stateID); apiErr != nil {
+		return nil, apiErr
+	}
+
+	city, apiErr := s.getCity(stateID, cityID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	duplicate, err := s.cityRepo.GetCity(filters.CityFilter{
+		Filter:  &filters.SingleFilter{Logic: filters.And},
+		ID:      &filters.Condition{Operator: filters.OpNotEqual, Value: cityID},
+		StateID: &filters.Condition{Operator: filters.OpEqual, Value: stateID},
+		Name:    &filters.Condition{Operator: filters.OpEqual, Value: req.Name},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if duplicate != nil {
+		return nil, errors.BadRequestError("duplicate city name for this state")
+	}
+
+	city.Name = req.Name
+	city.Latitude = req.Latitude
+	city.Longitude = req.Longitude
+	city.TimeZone = req.TimeZone
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.cityRepo.UpdateCity(tx, city)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return city, nil
+}
+
+func (s *locationService) DeleteCity(countryID, stateID, cityID uuid.UUID) *errors.ApiError {
+	if _, apiErr := s.getCountry(countryID); apiErr != nil {
+		return apiErr
+	}
+
+	if _, apiErr := s.getState(countryID, stateID); apiErr != nil {
+		return apiErr
+	}
+
+	city, apiErr := s.getCity(stateID, cityID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	inUse, err := s.theaterLocationRepo.HasLocationsInCity(cityID)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if inUse {
+		return errors.BadRequestError("city is used by theaters")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.cityRepo.DeleteCity(tx, city)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *locationService) SearchCities(keyword string, limit, offset int) ([]*models.City, *errors.ApiError) {
+	results, err := s.cityRepo.SearchCities(keyword, limit, offset)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	cities := make([]*models.City, len(results))
+	for i, result := range results {
+		cities[i] = &models.City{
+			ID:      result.CityId,
+			Name:    result.CityName,
+			StateID: result.StateId,
+			State: &models.State{
+				ID:        result.StateId,
+				Name:      result.StateName,
+				Code:      result.StateCode,
+				CountryID: result.CountryId,
+				Country: &models.Country{
+					ID:   result.CountryId,
+					Name: result.CountryName,
+					Code: result.CountryCode,
+				},
+			},
+		}
+	}
+
+	return cities, nil
+}
+
+func (s *locationService) getCountry(countryID uuid.UUID) (*models.Country, *errors.ApiError) {
+	country, err := s.countryRepo.GetCountry(filters.CountryFilter{
+		Filter: &filters.SingleFilter{Logic: filters.And},
+		ID:     &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if country == nil {
+		return nil, errors.NotFoundError("country does not exist")
+	}
+
+	return country, nil
+}
+
+func (s *locationService) getState(countryID, stateID uuid.UUID) (*models.State, *errors.ApiError) {
+	state, err := s.stateRepo.GetState(filters.StateFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: stateID},
+		CountryID: &filters.Condition{Operator: filters.OpEqual, Value: countryID},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if state == nil {
+		return nil, errors.NotFoundError("state does not exist")
+	}
+
+	return state, nil
+}
+
+func (s *locationService) getCity(stateID, cityID uuid.UUID) (*models.City, *errors.ApiError) {
+	city, err := s.cityRepo.GetCity(filters.CityFilter{
+		Filter:  &filters.SingleFilter{Logic: filters.And},
+		ID:      &filters.Condition{Operator: filters.OpEqual, Value: cityID},
+		StateID: &filters.Condition{Operator: filters.OpEqual, Value: stateID},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if city == nil {
+		return nil, errors.NotFoundError("city does not exist")
+	}
+
+	return city, nil
+}
+
+func buildLocationsMeta(path string, limit, offset, count int) *models.ResponseMeta {
+	var prevUrl, nextUrl *string
+
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevUrl = buildLocationsPaginationURL(path, limit, prevOffset)
+	}
+
+	if offset+limit < count {
+		nextUrl = buildLocationsPaginationURL(path, limit, offset+limit)
+	}
+
+	return &models.ResponseMeta{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   count,
+		NextUrl: nextUrl,
+		PrevUrl: prevUrl,
+	}
+}
+
+func buildLocationsPaginationURL(path string, limit, offset int) *string {
+	url := fmt.Sprintf("%s?%s=%d&%s=%d", path, constants.Limit, limit, constants.Offset, offset)
+	return &url
+}
@@ -18,30 +18,89 @@ func TestLocationService_GetCountries(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockCountryRepository(ctrl)
-	service := NewLocationService(nil, nil, repo, nil, nil)
+	service := NewLocationService(nil, nil, repo, nil, nil, nil)
 
 	t.Run("success", func(t *testing.T) {
 		countries := utils.GenerateCountries(3)
 
 		repo.EXPECT().GetCountries(gomock.Any()).Return(countries, nil).Times(1)
+		repo.EXPECT().GetNumbersOfCountry(gomock.Any()).Return(25, nil).Times(1)
 
-		result, err := service.GetCountries()
+		result, meta, err := service.GetCountries(10, 0)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, countries, result)
+		assert.Equal(t, 25, meta.Total)
+		assert.NotNil(t, meta.NextUrl)
+		assert.Nil(t, meta.PrevUrl)
 	})
 
 	t.Run("error getting countries", func(t *testing.T) {
 		repo.EXPECT().GetCountries(gomock.Any()).Return(nil, errors.New("error getting countries")).Times(1)
 
-		result, err := service.GetCountries()
+		result, meta, err := service.GetCountries(10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting countries", err.Error())
 	})
+
+	t.Run("error counting countries", func(t *testing.T) {
+		repo.EXPECT().GetCountries(gomock.Any()).Return(utils.GenerateCountries(3), nil).Times(1)
+		repo.EXPECT().GetNumbersOfCountry(gomock.Any()).Return(0, errors.New("error counting countries")).Times(1)
+
+		result, meta, err := service.GetCountries(10, 0)
+
+		assert.Nil(t, result)
+		assert.Nil(t, meta)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error counting countries", err.Error())
+	})
+}
+
+func TestLocationService_GetCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repositories.NewMockCountryRepository(ctrl)
+	service := NewLocationService(nil, nil, repo, nil, nil, nil)
+
+	country := utils.GenerateCountry()
+
+	t.Run("success", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+
+		result, err := service.GetCountry(country.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, country, result)
+	})
+
+	t.Run("country not found", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.GetCountry(country.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "country does not exist", err.Error())
+	})
+
+	t.Run("error getting country", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, errors.New("error getting country")).Times(1)
+
+		result, err := service.GetCountry(country.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting country", err.Error())
+	})
 }
 
 func TestLocationService_CreateCountry(t *testing.T) {
@@ -50,7 +109,7 @@ func TestLocationService_CreateCountry(t *testing.T) {
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	repo := mock_repositories.NewMockCountryRepository(ctrl)
-	service := NewLocationService(nil, transaction, repo, nil, nil)
+	service := NewLocationService(nil, transaction, repo, nil, nil, nil)
 
 	country := utils.GenerateCountry()
 	req := payloads.CreateCountryRequest{
@@ -121,7 +180,7 @@ func TestLocationService_GetStatesByCountry(t *testing.T) {
 
 	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
 	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
-	service := NewLocationService(nil, nil, countryRepo, stateRepo, nil)
+	service := NewLocationService(nil, nil, countryRepo, stateRepo, nil, nil)
 
 	country := utils.GenerateCountry()
 
@@ -130,20 +189,25 @@ func TestLocationService_GetStatesByCountry(t *testing.T) {
 
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
 		stateRepo.EXPECT().GetStates(gomock.Any()).Return(states, nil).Times(1)
+		stateRepo.EXPECT().GetNumbersOfState(gomock.Any()).Return(25, nil).Times(1)
 
-		result, err := service.GetStatesByCountry(country.ID)
+		result, meta, err := service.GetStatesByCountry(country.ID, 10, 0)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, states, result)
+		assert.Equal(t, 25, meta.Total)
+		assert.NotNil(t, meta.NextUrl)
+		assert.Nil(t, meta.PrevUrl)
 	})
 
 	t.Run("country not found", func(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
 
-		result, err := service.GetStatesByCountry(country.ID)
+		result, meta, err := service.GetStatesByCountry(country.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.StatusCode)
 		assert.Equal(t, "country does not exist", err.Error())
@@ -152,9 +216,10 @@ func TestLocationService_GetStatesByCountry(t *testing.T) {
 	t.Run("error getting country", func(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(nil, errors.New("error getting country")).Times(1)
 
-		result, err := service.GetStatesByCountry(country.ID)
+		result, meta, err := service.GetStatesByCountry(country.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting country", err.Error())
@@ -164,9 +229,10 @@ func TestLocationService_GetStatesByCountry(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
 		stateRepo.EXPECT().GetStates(gomock.Any()).Return(nil, errors.New("error getting states")).Times(1)
 
-		result, err := service.GetStatesByCountry(country.ID)
+		result, meta, err := service.GetStatesByCountry(country.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting states", err.Error())
@@ -180,7 +246,7 @@ func TestLocationService_CreateState(t *testing.T) {
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
 	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
-	service := NewLocationService(nil, transaction, countryRepo, stateRepo, nil)
+	service := NewLocationService(nil, transaction, countryRepo, stateRepo, nil, nil)
 
 	country := utils.GenerateCountry()
 	state := utils.GenerateState()
@@ -280,7 +346,7 @@ func TestLocationService_GetCitiesByState(t *testing.T) {
 	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
 	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
 	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
-	service := NewLocationService(nil, nil, countryRepo, stateRepo, cityRepo)
+	service := NewLocationService(nil, nil, countryRepo, stateRepo, cityRepo, nil)
 
 	country := utils.GenerateCountry()
 	state := utils.GenerateState()
@@ -290,20 +356,25 @@ func TestLocationService_GetCitiesByState(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
 		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
 		cityRepo.EXPECT().GetCities(gomock.Any()).Return(cities, nil).Times(1)
+		cityRepo.EXPECT().GetNumbersOfCity(gomock.Any()).Return(25, nil).Times(1)
 
-		result, err := service.GetCitiesByState(country.ID, state.ID)
+		result, meta, err := service.GetCitiesByState(country.ID, state.ID, 10, 0)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, cities, result)
+		assert.Equal(t, 25, meta.Total)
+		assert.NotNil(t, meta.NextUrl)
+		assert.Nil(t, meta.PrevUrl)
 	})
 
 	t.Run("country not found", func(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
 
-		result, err := service.GetCitiesByState(country.ID, state.ID)
+		result, meta, err := service.GetCitiesByState(country.ID, state.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.StatusCode)
 		assert.Equal(t, "country does not exist", err.Error())
@@ -312,9 +383,10 @@ func TestLocationService_GetCitiesByState(t *testing.T) {
 	t.Run("error getting country", func(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(nil, errors.New("error getting country")).Times(1)
 
-		result, err := service.GetCitiesByState(country.ID, state.ID)
+		result, meta, err := service.GetCitiesByState(country.ID, state.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting country", err.Error())
@@ -324,9 +396,10 @@ func TestLocationService_GetCitiesByState(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
 		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, nil).Times(1)
 
-		result, err := service.GetCitiesByState(state.CountryID, state.ID)
+		result, meta, err := service.GetCitiesByState(state.CountryID, state.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.StatusCode)
 		assert.Equal(t, "state does not exist", err.Error())
@@ -336,9 +409,10 @@ func TestLocationService_GetCitiesByState(t *testing.T) {
 		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
 		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, errors.New("error getting state")).Times(1)
 
-		result, err := service.GetCitiesByState(country.ID, state.ID)
+		result, meta, err := service.GetCitiesByState(country.ID, state.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting state", err.Error())
@@ -349,9 +423,10 @@ func TestLocationService_GetCitiesByState(t *testing.T) {
 		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
 		cityRepo.EXPECT().GetCities(gomock.Any()).Return(nil, errors.New("error getting cities")).Times(1)
 
-		result, err := service.GetCitiesByState(country.ID, state.ID)
+		result, meta, err := service.GetCitiesByState(country.ID, state.ID, 10, 0)
 
 		assert.Nil(t, result)
+		assert.Nil(t, meta)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting cities", err.Error())
@@ -366,13 +441,16 @@ func TestLocationService_CreateCity(t *testing.T) {
 	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
 	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
 	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
-	service := NewLocationService(nil, transaction, countryRepo, stateRepo, cityRepo)
+	service := NewLocationService(nil, transaction, countryRepo, stateRepo, cityRepo, nil)
 
 	country := utils.GenerateCountry()
 	state := utils.GenerateState()
 	city := utils.GenerateCity()
 	req := payloads.CreateCityRequest{
-		Name: city.Name,
+		Name:      city.Name,
+		Latitude:  city.Latitude,
+		Longitude: city.Longitude,
+		TimeZone:  utils.GetPointerOf("Asia/Ho_Chi_Minh"),
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -391,6 +469,9 @@ func TestLocationService_CreateCity(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, req.Latitude, result.Latitude)
+		assert.Equal(t, req.Longitude, result.Longitude)
+		assert.Equal(t, req.TimeZone, result.TimeZone)
 	})
 
 	t.Run("country not found", func(t *testing.T) {
@@ -484,3 +565,652 @@ func TestLocationService_CreateCity(t *testing.T) {
 		assert.Equal(t, "error creating city", err.Error())
 	})
 }
+
+func TestLocationService_UpdateCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	repo := mock_repositories.NewMockCountryRepository(ctrl)
+	service := NewLocationService(nil, transaction, repo, nil, nil, nil)
+
+	country := utils.GenerateCountry()
+	req := payloads.UpdateCountryRequest{
+		Name: utils.GenerateCountry().Name,
+		Code: utils.GenerateCountry().Code,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(2)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().UpdateCountry(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateCountry(country.ID, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, country.ID, result.ID)
+		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, req.Code, result.Code)
+	})
+
+	t.Run("country not found", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateCountry(country.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "country does not exist", err.Error())
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		repo.EXPECT().GetCountry(gomock.Any()).Return(utils.GenerateCountry(), nil).Times(1)
+
+		result, err := service.UpdateCountry(country.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate country name or code", err.Error())
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
+		repo.EXPECT().GetCountry(gomock.Any()).Return(utils.GenerateCountry(), nil).Times(1)
+
+		result, err := service.UpdateCountry(country.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate country name or code", err.Error())
+	})
+
+	t.Run("error updating country", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(2)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().UpdateCountry(gomock.Any(), gomock.Any()).Return(errors.New("error updating country")).Times(1)
+
+		result, err := service.UpdateCountry(country.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating country", err.Error())
+	})
+}
+
+func TestLocationService_DeleteCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	repo := mock_repositories.NewMockCountryRepository(ctrl)
+	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
+	service := NewLocationService(nil, transaction, repo, nil, nil, theaterLocationRepo)
+
+	country := utils.GenerateCountry()
+
+	t.Run("success", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCountry(country.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().DeleteCountry(gomock.Any(), country).Return(nil).Times(1)
+
+		err := service.DeleteCountry(country.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("country not found", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
+
+		err := service.DeleteCountry(country.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "country does not exist", err.Error())
+	})
+
+	t.Run("country used by theaters", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCountry(country.ID).Return(true, nil).Times(1)
+
+		err := service.DeleteCountry(country.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "country has cities used by theaters", err.Error())
+	})
+
+	t.Run("error checking theater locations", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCountry(country.ID).Return(false, errors.New("error getting locations")).Times(1)
+
+		err := service.DeleteCountry(country.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting locations", err.Error())
+	})
+
+	t.Run("error deleting country", func(t *testing.T) {
+		repo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCountry(country.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().DeleteCountry(gomock.Any(), country).Return(errors.New("error deleting country")).Times(1)
+
+		err := service.DeleteCountry(country.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting country", err.Error())
+	})
+}
+
+func TestLocationService_GetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	service := NewLocationService(nil, nil, countryRepo, stateRepo, nil, nil)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.CountryID = country.ID
+
+	t.Run("success", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+
+		result, err := service.GetState(country.ID, state.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, state.ID, result.ID)
+		assert.Equal(t, country, result.Country)
+	})
+
+	t.Run("country not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.GetState(country.ID, state.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "country does not exist", err.Error())
+	})
+
+	t.Run("state not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.GetState(country.ID, state.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "state does not exist", err.Error())
+	})
+
+	t.Run("error getting state", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, errors.New("error getting state")).Times(1)
+
+		result, err := service.GetState(country.ID, state.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting state", err.Error())
+	})
+}
+
+func TestLocationService_UpdateState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	service := NewLocationService(nil, transaction, countryRepo, stateRepo, nil, nil)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.CountryID = country.ID
+	req := payloads.UpdateStateRequest{
+		Name: utils.GenerateState().Name,
+		Code: utils.GenerateState().Code,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		stateRepo.EXPECT().UpdateState(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateState(country.ID, state.ID, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, state.ID, result.ID)
+		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, req.Code, result.Code)
+	})
+
+	t.Run("state not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateState(country.ID, state.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "state does not exist", err.Error())
+	})
+
+	t.Run("duplicate state name", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(utils.GenerateState(), nil).Times(1)
+
+		result, err := service.UpdateState(country.ID, state.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate state name for this country", err.Error())
+	})
+
+	t.Run("error updating state", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		stateRepo.EXPECT().UpdateState(gomock.Any(), gomock.Any()).Return(errors.New("error updating state")).Times(1)
+
+		result, err := service.UpdateState(country.ID, state.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating state", err.Error())
+	})
+}
+
+func TestLocationService_DeleteState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
+	service := NewLocationService(nil, transaction, countryRepo, stateRepo, nil, theaterLocationRepo)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.CountryID = country.ID
+
+	t.Run("success", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInState(state.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		stateRepo.EXPECT().DeleteState(gomock.Any(), state).Return(nil).Times(1)
+
+		err := service.DeleteState(country.ID, state.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("state not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(nil, nil).Times(1)
+
+		err := service.DeleteState(country.ID, state.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "state does not exist", err.Error())
+	})
+
+	t.Run("state used by theaters", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInState(state.ID).Return(true, nil).Times(1)
+
+		err := service.DeleteState(country.ID, state.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "state has cities used by theaters", err.Error())
+	})
+
+	t.Run("error deleting state", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInState(state.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		stateRepo.EXPECT().DeleteState(gomock.Any(), state).Return(errors.New("error deleting state")).Times(1)
+
+		err := service.DeleteState(country.ID, state.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting state", err.Error())
+	})
+}
+
+func TestLocationService_GetCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
+	service := NewLocationService(nil, nil, countryRepo, stateRepo, cityRepo, nil)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.CountryID = country.ID
+	city := utils.GenerateCity()
+	city.StateID = state.ID
+
+	t.Run("success", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+
+		result, err := service.GetCity(country.ID, state.ID, city.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, city.ID, result.ID)
+		assert.Equal(t, state.ID, result.State.ID)
+		assert.Equal(t, country, result.State.Country)
+	})
+
+	t.Run("city not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.GetCity(country.ID, state.ID, city.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "city does not exist", err.Error())
+	})
+
+	t.Run("error getting city", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, errors.New("error getting city")).Times(1)
+
+		result, err := service.GetCity(country.ID, state.ID, city.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting city", err.Error())
+	})
+}
+
+func TestLocationService_UpdateCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
+	service := NewLocationService(nil, transaction, countryRepo, stateRepo, cityRepo, nil)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.CountryID = country.ID
+	city := utils.GenerateCity()
+	city.StateID = state.ID
+	newCity := utils.GenerateCity()
+	req := payloads.UpdateCityRequest{
+		Name:      newCity.Name,
+		Latitude:  newCity.Latitude,
+		Longitude: newCity.Longitude,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		cityRepo.EXPECT().UpdateCity(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateCity(country.ID, state.ID, city.ID, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, city.ID, result.ID)
+		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, req.Latitude, result.Latitude)
+		assert.Equal(t, req.Longitude, result.Longitude)
+	})
+
+	t.Run("city not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateCity(country.ID, state.ID, city.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "city does not exist", err.Error())
+	})
+
+	t.Run("duplicate city name", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(utils.GenerateCity(), nil).Times(1)
+
+		result, err := service.UpdateCity(country.ID, state.ID, city.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate city name for this state", err.Error())
+	})
+
+	t.Run("error updating city", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		cityRepo.EXPECT().UpdateCity(gomock.Any(), gomock.Any()).Return(errors.New("error updating city")).Times(1)
+
+		result, err := service.UpdateCity(country.ID, state.ID, city.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating city", err.Error())
+	})
+}
+
+func TestLocationService_DeleteCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	stateRepo := mock_repositories.NewMockStateRepository(ctrl)
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
+	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
+	service := NewLocationService(nil, transaction, countryRepo, stateRepo, cityRepo, theaterLocationRepo)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	state.CountryID = country.ID
+	city := utils.GenerateCity()
+	city.StateID = state.ID
+
+	t.Run("success", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCity(city.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		cityRepo.EXPECT().DeleteCity(gomock.Any(), city).Return(nil).Times(1)
+
+		err := service.DeleteCity(country.ID, state.ID, city.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("city not found", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, nil).Times(1)
+
+		err := service.DeleteCity(country.ID, state.ID, city.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "city does not exist", err.Error())
+	})
+
+	t.Run("city used by theaters", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCity(city.ID).Return(true, nil).Times(1)
+
+		err := service.DeleteCity(country.ID, state.ID, city.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "city is used by theaters", err.Error())
+	})
+
+	t.Run("error deleting city", func(t *testing.T) {
+		countryRepo.EXPECT().GetCountry(gomock.Any()).Return(country, nil).Times(1)
+		stateRepo.EXPECT().GetState(gomock.Any()).Return(state, nil).Times(1)
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		theaterLocationRepo.EXPECT().HasLocationsInCity(city.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		cityRepo.EXPECT().DeleteCity(gomock.Any(), city).Return(errors.New("error deleting city")).Times(1)
+
+		err := service.DeleteCity(country.ID, state.ID, city.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting city", err.Error())
+	})
+}
+
+func TestLocationService_SearchCities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
+	service := NewLocationService(nil, nil, nil, nil, cityRepo, nil)
+
+	country := utils.GenerateCountry()
+	state := utils.GenerateState()
+	city := utils.GenerateCity()
+	searchResult := &payloads.SearchCityResult{
+		CityId:      city.ID,
+		CityName:    city.Name,
+		StateId:     state.ID,
+		StateName:   state.Name,
+		StateCode:   state.Code,
+		CountryId:   country.ID,
+		CountryName: country.Name,
+		CountryCode: country.Code,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cityRepo.EXPECT().SearchCities("spr", 10, 0).Return([]*payloads.SearchCityResult{searchResult}, nil).Times(1)
+
+		result, err := service.SearchCities("spr", 10, 0)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, city.ID, result[0].ID)
+		assert.Equal(t, city.Name, result[0].Name)
+		assert.Equal(t, state.ID, result[0].StateID)
+		assert.Equal(t, state.Name, result[0].State.Name)
+		assert.Equal(t, state.Code, result[0].State.Code)
+		assert.Equal(t, country.ID, result[0].State.CountryID)
+		assert.Equal(t, country, result[0].State.Country)
+	})
+
+	t.Run("error searching cities", func(t *testing.T) {
+		cityRepo.EXPECT().SearchCities("spr", 10, 0).Return(nil, errors.New("error searching cities")).Times(1)
+
+		result, err := service.SearchCities("spr", 10, 0)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error searching cities", err.Error())
+	})
+}
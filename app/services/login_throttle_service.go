@@ -0,0 +1,167 @@
+package services
+
+import (
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"strings"
+	"time"
+)
+
+type LoginThrottleService interface {
+	GetLockout(email, clientIp string) (time.Duration, error)
+	RegisterFailedAttempt(email, clientIp string) error
+	ResetFailedAttempts(email, clientIp string) error
+	UnlockAccount(email string) error
+}
+
+func NewLoginThrottleService(
+	redisClient *redis.Client,
+	maxAttempts int,
+	maxAttemptsPerIp int,
+	windowTime time.Duration,
+	lockoutTime time.Duration,
+	backoffTime time.Duration,
+) LoginThrottleService {
+	return &loginThrottleService{
+		redisClient:      redisClient,
+		maxAttempts:      maxAttempts,
+		maxAttemptsPerIp: maxAttemptsPerIp,
+		windowTime:       windowTime,
+		lockoutTime:      lockoutTime,
+		backoffTime:      backoffTime,
+	}
+}
+
+type loginThrottleService struct {
+	redisClient      *redis.Client
+	maxAttempts      int
+	maxAttemptsPerIp int
+	windowTime       time.Duration
+	lockoutTime      time.Duration
+	backoffTime      time.Duration
+}
+
+func (s *loginThrottleService) GetLockout(email, clientIp string) (time.Duration, error) {
+	var lockout time.Duration
+	for _, subject := range []string{s.accountSubject(email), s.accountIpSubject(email, clientIp)} {
+		ttl, err := s.redisClient.TTL(ctx, s.lockoutKey(subject)).Result()
+		if err != nil {
+			return 0, err
+		}
+
+		if ttl > lockout {
+			lockout = ttl
+		}
+	}
+
+	return lockout, nil
+}
+
+func (s *loginThrottleService) RegisterFailedAttempt(email, clientIp string) error {
+	account := s.accountSubject(email)
+	accountAttempts, err := s.increaseAttempts(account)
+	if err != nil {
+		return err
+	}
+	if accountAttempts >= s.maxAttempts {
+		if err := s.redisClient.Set(ctx, s.lockoutKey(account), accountAttempts, s.lockoutTime).Err(); err != nil {
+			return err
+		}
+	}
+
+	accountIp := s.accountIpSubject(email, clientIp)
+	ipAttempts, err := s.increaseAttempts(accountIp)
+	if err != nil {
+		return err
+	}
+	if ipAttempts >= s.maxAttemptsPerIp {
+		backoff := s.getBackoffTime(ipAttempts - s.maxAttemptsPerIp)
+		if err := s.redisClient.Set(ctx, s.lockoutKey(accountIp), ipAttempts, backoff).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *loginThrottleService) ResetFailedAttempts(email, clientIp string) error {
+	account := s.accountSubject(email)
+	accountIp := s.accountIpSubject(email, clientIp)
+
+	return s.redisClient.Del(
+		ctx,
+		s.attemptsKey(account),
+		s.lockoutKey(account),
+		s.attemptsKey(accountIp),
+		s.lockoutKey(accountIp),
+	).Err()
+}
+
+func (s *loginThrottleService) UnlockAccount(email string) error {
+	account := s.accountSubject(email)
+	prefixes := []string{s.attemptsKey(account), s.lockoutKey(account)}
+
+	keys := append([]string{}, prefixes...)
+	for _, prefix := range prefixes {
+		iter := s.redisClient.Scan(ctx, 0, fmt.Sprintf("%s:*", escapeRedisPattern(prefix)), 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+
+	return s.redisClient.Del(ctx, keys...).Err()
+}
+
+func (s *loginThrottleService) increaseAttempts(subject string) (int, error) {
+	key := s.attemptsKey(subject)
+	count, err := s.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 {
+		if err := s.redisClient.Expire(ctx, key, s.windowTime).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(count), nil
+}
+
+func (s *loginThrottleService) getBackoffTime(exceededAttempts int) time.Duration {
+	backoff := s.backoffTime
+	for i := 0; i < exceededAttempts && backoff < s.lockoutTime; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.lockoutTime {
+		return s.lockoutTime
+	}
+
+	return backoff
+}
+
+func (s *loginThrottleService) accountSubject(email string) string {
+	return strings.ToLower(email)
+}
+
+func (s *loginThrottleService) accountIpSubject(email, clientIp string) string {
+	return fmt.Sprintf("%s:%s", s.accountSubject(email), clientIp)
+}
+
+func (s *loginThrottleService) attemptsKey(subject string) string {
+	return fmt.Sprintf("%s:%s", constants.LoginAttempts, subject)
+}
+
+func (s *loginThrottleService) lockoutKey(subject string) string {
+	return fmt.Sprintf("%s:%s", constants.LoginLockout, subject)
+}
+
+func escapeRedisPattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+	return replacer.Replace(value)
+}
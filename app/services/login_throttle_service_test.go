@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_db"
+	"testing"
+	"time"
+)
+
+func TestLoginThrottleService_GetLockout(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	service := NewLoginThrottleService(client, 10, 5, 15*time.Minute, 15*time.Minute, time.Second)
+
+	email := "Example@example.com"
+	clientIp := "127.0.0.1"
+	accountLockoutKey := fmt.Sprintf("%s:%s", constants.LoginLockout, "example@example.com")
+	accountIpLockoutKey := fmt.Sprintf("%s:%s:%s", constants.LoginLockout, "example@example.com", clientIp)
+
+	t.Run("not locked", func(t *testing.T) {
+		mock.ExpectTTL(accountLockoutKey).SetVal(-2)
+		mock.ExpectTTL(accountIpLockoutKey).SetVal(-2)
+
+		lockout, err := service.GetLockout(email, clientIp)
+
+		assert.Nil(t, err)
+		assert.Equal(t, time.Duration(0), lockout)
+	})
+
+	t.Run("longest lockout wins", func(t *testing.T) {
+		mock.ExpectTTL(accountLockoutKey).SetVal(30 * time.Second)
+		mock.ExpectTTL(accountIpLockoutKey).SetVal(2 * time.Minute)
+
+		lockout, err := service.GetLockout(email, clientIp)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2*time.Minute, lockout)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectTTL(accountLockoutKey).SetErr(errors.New("db error"))
+
+		lockout, err := service.GetLockout(email, clientIp)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+		assert.Equal(t, time.Duration(0), lockout)
+	})
+}
+
+func TestLoginThrottleService_RegisterFailedAttempt(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	maxAttempts := 10
+	maxAttemptsPerIp := 5
+	window := 15 * time.Minute
+	lockout := 15 * time.Minute
+	backoff := time.Second
+	service := NewLoginThrottleService(client, maxAttempts, maxAttemptsPerIp, window, lockout, backoff)
+
+	email := "example@example.com"
+	clientIp := "127.0.0.1"
+	accountKey := fmt.Sprintf("%s:%s", constants.LoginAttempts, email)
+	accountIpKey := fmt.Sprintf("%s:%s:%s", constants.LoginAttempts, email, clientIp)
+	accountLockoutKey := fmt.Sprintf("%s:%s", constants.LoginLockout, email)
+	accountIpLockoutKey := fmt.Sprintf("%s:%s:%s", constants.LoginLockout, email, clientIp)
+
+	t.Run("first failed attempt", func(t *testing.T) {
+		mock.ExpectIncr(accountKey).SetVal(1)
+		mock.ExpectExpire(accountKey, window).SetVal(true)
+		mock.ExpectIncr(accountIpKey).SetVal(1)
+		mock.ExpectExpire(accountIpKey, window).SetVal(true)
+
+		err := service.RegisterFailedAttempt(email, clientIp)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("progressive backoff per ip", func(t *testing.T) {
+		mock.ExpectIncr(accountKey).SetVal(int64(maxAttemptsPerIp + 2))
+		mock.ExpectIncr(accountIpKey).SetVal(int64(maxAttemptsPerIp + 2))
+		mock.ExpectSet(accountIpLockoutKey, maxAttemptsPerIp+2, 4*backoff).SetVal("OK")
+
+		err := service.RegisterFailedAttempt(email, clientIp)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("backoff is capped by lockout time", func(t *testing.T) {
+		mock.ExpectIncr(accountKey).SetVal(int64(maxAttemptsPerIp + 50))
+		mock.ExpectSet(accountLockoutKey, maxAttemptsPerIp+50, lockout).SetVal("OK")
+		mock.ExpectIncr(accountIpKey).SetVal(int64(maxAttemptsPerIp + 50))
+		mock.ExpectSet(accountIpLockoutKey, maxAttemptsPerIp+50, lockout).SetVal("OK")
+
+		err := service.RegisterFailedAttempt(email, clientIp)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("account lockout", func(t *testing.T) {
+		mock.ExpectIncr(accountKey).SetVal(int64(maxAttempts))
+		mock.ExpectSet(accountLockoutKey, maxAttempts, lockout).SetVal("OK")
+		mock.ExpectIncr(accountIpKey).SetVal(1)
+		mock.ExpectExpire(accountIpKey, window).SetVal(true)
+
+		err := service.RegisterFailedAttempt(email, clientIp)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectIncr(accountKey).SetErr(errors.New("db error"))
+
+		err := service.RegisterFailedAttempt(email, clientIp)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestLoginThrottleService_ResetFailedAttempts(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	service := NewLoginThrottleService(client, 10, 5, 15*time.Minute, 15*time.Minute, time.Second)
+
+	email := "example@example.com"
+	clientIp := "127.0.0.1"
+	keys := []string{
+		fmt.Sprintf("%s:%s", constants.LoginAttempts, email),
+		fmt.Sprintf("%s:%s", constants.LoginLockout, email),
+		fmt.Sprintf("%s:%s:%s", constants.LoginAttempts, email, clientIp),
+		fmt.Sprintf("%s:%s:%s", constants.LoginLockout, email, clientIp),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectDel(keys...).SetVal(4)
+
+		err := service.ResetFailedAttempts(email, clientIp)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectDel(keys...).SetErr(errors.New("db error"))
+
+		err := service.ResetFailedAttempts(email, clientIp)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestLoginThrottleService_UnlockAccount(t *testing.T) {
+	client, mock := mock_db.SetupTestRedis()
+	defer func() {
+		assert.Nil(t, mock_db.TearDownTestRedis(mock))
+	}()
+
+	service := NewLoginThrottleService(client, 10, 5, 15*time.Minute, 15*time.Minute, time.Second)
+
+	email := "ex*ample@example.com"
+	accountKey := fmt.Sprintf("%s:%s", constants.LoginAttempts, email)
+	accountLockoutKey := fmt.Sprintf("%s:%s", constants.LoginLockout, email)
+	accountIpKey := fmt.Sprintf("%s:%s", accountKey, "127.0.0.1")
+	accountIpLockoutKey := fmt.Sprintf("%s:%s", accountLockoutKey, "127.0.0.1")
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectScan(0, `loginAttempts:ex\*ample@example.com:*`, 100).SetVal([]string{accountIpKey}, 0)
+		mock.ExpectScan(0, `loginLockout:ex\*ample@example.com:*`, 100).SetVal([]string{accountIpLockoutKey}, 0)
+		mock.ExpectDel(accountKey, accountLockoutKey, accountIpKey, accountIpLockoutKey).SetVal(4)
+
+		err := service.UnlockAccount(email)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error scanning keys", func(t *testing.T) {
+		mock.ExpectScan(0, `loginAttempts:ex\*ample@example.com:*`, 100).SetErr(errors.New("db error"))
+
+		err := service.UnlockAccount(email)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
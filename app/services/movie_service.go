@@ -5,6 +5,7 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	neturl "net/url"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,12 +17,16 @@ import (
 )
 
 type MovieService interface {
-	GetMovie(id uuid.UUID, userEmail *string, includeGenres bool) (*models.Movie, *errors.ApiError)
-	GetMovies(limit, offset int, userEmail *string, includeGenres bool) ([]*models.Movie, *models.ResponseMeta, *errors.ApiError)
-	CreateMovie(req payloads.CreateMovieRequest, createdBy uuid.UUID) (*models.Movie, *errors.ApiError)
-	UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateMovieRequest) (*models.Movie, *errors.ApiError)
-	AssignGenres(id uuid.UUID, genreIDs []uuid.UUID) *errors.ApiError
-	DeleteMovie(id uuid.UUID, deletedBy uuid.UUID) *errors.ApiError
+	GetMovie(id uuid.UUID, userEmail *string, includeGenres, includeDetails bool) (*models.Movie, *errors.ApiError)
+	GetMovies(search payloads.MovieSearchFilter, limit, offset int, userEmail *string, includeGenres, includeDetails bool) ([]*models.Movie, *models.ResponseMeta, *errors.ApiError)
+	CreateMovie(req payloads.CreateMovieRequest, createdBy, requestID uuid.UUID) (*models.Movie, *errors.ApiError)
+	UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateMovieRequest, requestID uuid.UUID) (*models.Movie, *errors.ApiError)
+	AssignGenres(id uuid.UUID, genreIDs []uuid.UUID, requestID uuid.UUID) *errors.ApiError
+	UpdateMovieCredits(id uuid.UUID, req payloads.UpdateMovieCreditsRequest, requestID uuid.UUID) ([]*models.MovieCredit, *errors.ApiError)
+	UpdateMovieCertifications(id uuid.UUID, req payloads.UpdateMovieCertificationsRequest, requestID uuid.UUID) ([]*models.MovieCertification, *errors.ApiError)
+	UpdateMovieLanguages(id uuid.UUID, req payloads.UpdateMovieLanguagesRequest, requestID uuid.UUID) ([]*models.MovieLanguage, *errors.ApiError)
+	UpdateMovieExternalIDs(id uuid.UUID, req payloads.UpdateMovieExternalIDsRequest, requestID uuid.UUID) ([]*models.MovieExternalID, *errors.ApiError)
+	DeleteMovie(id uuid.UUID, deletedBy, requestID uuid.UUID) *errors.ApiError
 }
 
 type movieService struct {
@@ -30,7 +35,14 @@ type movieService struct {
 	movieRepo          repositories.MovieRepository
 	genreRepo          repositories.GenreRepository
 	movieGenreRepo     repositories.MovieGenreRepository
+	personRepo         repositories.PersonRepository
+	movieCreditRepo    repositories.MovieCreditRepository
+	countryRepo        repositories.CountryRepository
+	certificationRepo  repositories.MovieCertificationRepository
+	movieLanguageRepo  repositories.MovieLanguageRepository
+	externalIDRepo     repositories.MovieExternalIDRepository
 	featureFlagRepo    repositories.FeatureFlagRepository
+	notificationRepo   repositories.NotificationRepository
 }
 
 func NewMovieService(
@@ -39,7 +51,14 @@ func NewMovieService(
 	movieRepo repositories.MovieRepository,
 	genreRepo repositories.GenreRepository,
 	movieGenreRepo repositories.MovieGenreRepository,
+	personRepo repositories.PersonRepository,
+	movieCreditRepo repositories.MovieCreditRepository,
+	countryRepo repositories.CountryRepository,
+	certificationRepo repositories.MovieCertificationRepository,
+	movieLanguageRepo repositories.MovieLanguageRepository,
+	externalIDRepo repositories.MovieExternalIDRepository,
 	featureFlagRepo repositories.FeatureFlagRepository,
+	notificationRepo repositories.NotificationRepository,
 ) MovieService {
 	return &movieService{
 		db:                 db,
@@ -47,12 +66,19 @@ func NewMovieService(
 		movieRepo:          movieRepo,
 		genreRepo:          genreRepo,
 		movieGenreRepo:     movieGenreRepo,
+		personRepo:         personRepo,
+		movieCreditRepo:    movieCreditRepo,
+		countryRepo:        countryRepo,
+		certificationRepo:  certificationRepo,
+		movieLanguageRepo:  movieLanguageRepo,
+		externalIDRepo:     externalIDRepo,
 		featureFlagRepo:    featureFlagRepo,
+		notificationRepo:   notificationRepo,
 	}
 }
 
-func (s *movieService) GetMovie(id uuid.UUID, userEmail *string, includeGenres bool) (*models.Movie, *errors.ApiError) {
-	m, apiErr := s.getMovie(id, includeGenres)
+func (s *movieService) GetMovie(id uuid.UUID, userEmail *string, includeGenres, includeDetails bool) (*models.Movie, *errors.ApiError) {
+	m, apiErr := s.getMovie(id, includeGenres, includeDetails)
 	if apiErr != nil {
 		return nil, apiErr
 	}
@@ -64,7 +90,7 @@ func (s *movieService) GetMovie(id uuid.UUID, userEmail *string, includeGenres b
 	return m, nil
 }
 
-func (s *movieService) GetMovies(limit, offset int, userEmail *string, includeGenres bool) ([]*models.Movie, *models.ResponseMeta, *errors.ApiError) {
+func (s *movieService) GetMovies(search payloads.MovieSearchFilter, limit, offset int, userEmail *string, includeGenres, includeDetails bool) ([]*models.Movie, *models.ResponseMeta, *errors.ApiError) {
 	getFilter := filters.MovieFilter{
 		Filter:    &filters.MultiFilter{Limit: &limit, Offset: &offset},
 		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
@@ -77,13 +103,15 @@ func (s *movieService) GetMovies(limit, offset int, userEmail *string, includeGe
 		getFilter.IsActive = &filters.Condition{Operator: filters.OpEqual, Value: true}
 		countFilter.IsActive = &filters.Condition{Operator: filters.OpEqual, Value: true}
 	}
+	applyMovieSearchFilter(&getFilter, search)
+	applyMovieSearchFilter(&countFilter, search)
 
 	var movies []*models.Movie
 	var err error
 	if includeGenres {
-		movies, err = s.movieRepo.GetMoviesWithGenres(getFilter)
+		movies, err = s.movieRepo.GetMoviesWithGenres(getFilter, includeDetails)
 	} else {
-		movies, err = s.movieRepo.GetMovies(getFilter)
+		movies, err = s.movieRepo.GetMovies(getFilter, includeDetails)
 	}
 	if err != nil {
 		return nil, nil, errors.InternalServerError(err.Error())
@@ -101,12 +129,12 @@ func (s *movieService) GetMovies(limit, offset int, userEmail *string, includeGe
 		if prevOffset < 0 {
 			prevOffset = 0
 		}
-		prevUrl = buildPaginationURL(limit, prevOffset, includeGenres)
+		prevUrl = buildPaginationURL(search, limit, prevOffset, includeGenres, includeDetails)
 	}
 
 	if offset+limit < count {
 		nextUrlOffset := offset + limit
-		nextUrl = buildPaginationURL(limit, nextUrlOffset, includeGenres)
+		nextUrl = buildPaginationURL(search, limit, nextUrlOffset, includeGenres, includeDetails)
 	}
 
 	meta := &models.ResponseMeta{
@@ -120,7 +148,7 @@ func (s *movieService) GetMovies(limit, offset int, userEmail *string, includeGe
 	return movies, meta, nil
 }
 
-func (s *movieService) CreateMovie(req payloads.CreateMovieRequest, createdBy uuid.UUID) (*models.Movie, *errors.ApiError) {
+func (s *movieService) CreateMovie(req payloads.CreateMovieRequest, createdBy, requestID uuid.UUID) (*models.Movie, *errors.ApiError) {
 	m := models.Movie{
 		ID:              uuid.New(),
 		Title:           req.Title,
@@ -129,6 +157,7 @@ func (s *movieService) CreateMovie(req payloads.CreateMovieRequest, createdBy uu
 		DurationMinutes: req.DurationMinutes,
 		Language:        req.Language,
 		Rating:          req.Rating,
+		TrailerUrl:      req.TrailerUrl,
 		IsActive:        *req.IsActive,
 		CreatedAt:       time.Now().UTC(),
 		UpdatedAt:       time.Now().UTC(),
@@ -136,7 +165,11 @@ func (s *movieService) CreateMovie(req payloads.CreateMovieRequest, createdBy uu
 		LastUpdatedBy:   createdBy,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.movieRepo.CreateMovie(tx, &m)
+		if err := s.movieRepo.CreateMovie(tx, &m); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieCreated, m.ID, nil, &m))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -144,11 +177,12 @@ func (s *movieService) CreateMovie(req payloads.CreateMovieRequest, createdBy uu
 	return &m, nil
 }
 
-func (s *movieService) UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateMovieRequest) (*models.Movie, *errors.ApiError) {
-	m, apiErr := s.getMovie(id, false)
+func (s *movieService) UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateMovieRequest, requestID uuid.UUID) (*models.Movie, *errors.ApiError) {
+	m, apiErr := s.getMovie(id, false, false)
 	if apiErr != nil {
 		return nil, apiErr
 	}
+	before := *m
 
 	m.Title = req.Title
 	m.Description = req.Description
@@ -156,11 +190,16 @@ func (s *movieService) UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateM
 	m.DurationMinutes = req.DurationMinutes
 	m.Language = req.Language
 	m.Rating = req.Rating
+	m.TrailerUrl = req.TrailerUrl
 	m.IsActive = *req.IsActive
 	m.UpdatedAt = time.Now().UTC()
 	m.LastUpdatedBy = updatedBy
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.movieRepo.UpdateMovie(tx, m)
+		if err := s.movieRepo.UpdateMovie(tx, m); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieUpdated, m.ID, &before, m))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -168,8 +207,8 @@ func (s *movieService) UpdateMovie(id, updatedBy uuid.UUID, req payloads.UpdateM
 	return m, nil
 }
 
-func (s *movieService) AssignGenres(id uuid.UUID, genreIDs []uuid.UUID) *errors.ApiError {
-	_, apiErr := s.getMovie(id, false)
+func (s *movieService) AssignGenres(id uuid.UUID, genreIDs []uuid.UUID, requestID uuid.UUID) *errors.ApiError {
+	m, apiErr := s.getMovie(id, true, false)
 	if apiErr != nil {
 		return apiErr
 	}
@@ -183,8 +222,26 @@ func (s *movieService) AssignGenres(id uuid.UUID, genreIDs []uuid.UUID) *errors.
 		return errors.BadRequestError("invalid genre ids")
 	}
 
+	genres, err := s.genreRepo.GetGenres(filters.GenreFilter{
+		Filter: &filters.MultiFilter{Logic: filters.And},
+		ID:     &filters.Condition{Operator: filters.OpIn, Value: genreIDs},
+	})
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	after := *m
+	after.Genres = make([]models.Genre, len(genres))
+	for i, g := range genres {
+		after.Genres[i] = *g
+	}
+
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.movieGenreRepo.UpdateGenresOfMovie(tx, id, genreIDs)
+		if err := s.movieGenreRepo.UpdateGenresOfMovie(tx, id, genreIDs); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieUpdated, m.ID, m, &after))
 	}); err != nil {
 		return errors.InternalServerError(err.Error())
 	}
@@ -192,18 +249,203 @@ func (s *movieService) AssignGenres(id uuid.UUID, genreIDs []uuid.UUID) *errors.
 	return nil
 }
 
-func (s *movieService) DeleteMovie(id uuid.UUID, deletedBy uuid.UUID) *errors.ApiError {
-	movie, apiErr := s.getMovie(id, false)
+func (s *movieService) UpdateMovieCredits(id uuid.UUID, req payloads.UpdateMovieCreditsRequest, requestID uuid.UUID) ([]*models.MovieCredit, *errors.ApiError) {
+	m, apiErr := s.getMovie(id, false, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	type creditKey struct {
+		personID uuid.UUID
+		role     constants.CreditRole
+	}
+	keys := make(map[creditKey]bool)
+	var personIDs []uuid.UUID
+	for _, c := range req.Credits {
+		key := creditKey{c.PersonID, c.Role}
+		if keys[key] {
+			return nil, errors.BadRequestError("duplicate person and role in credits")
+		}
+		keys[key] = true
+		personIDs = append(personIDs, c.PersonID)
+	}
+
+	people := make(map[uuid.UUID]*models.Person)
+	if len(personIDs) > 0 {
+		found, err := s.personRepo.GetPeople(filters.PersonFilter{
+			Filter: &filters.MultiFilter{Logic: filters.And},
+			ID:     &filters.Condition{Operator: filters.OpIn, Value: personIDs},
+		})
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+		for _, p := range found {
+			people[p.ID] = p
+		}
+	}
+
+	credits := make([]*models.MovieCredit, len(req.Credits))
+	for i, c := range req.Credits {
+		person, ok := people[c.PersonID]
+		if !ok {
+			return nil, errors.BadRequestError("invalid person ids")
+		}
+		credits[i] = &models.MovieCredit{
+			MovieID:       id,
+			PersonID:      c.PersonID,
+			Role:          c.Role,
+			CharacterName: c.CharacterName,
+			Position:      c.Position,
+			Person:        person,
+		}
+	}
+
+	after := *m
+	after.Credits = credits
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.movieCreditRepo.UpdateCreditsOfMovie(tx, id, credits); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieUpdated, m.ID, m, &after))
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return credits, nil
+}
+
+func (s *movieService) UpdateMovieCertifications(id uuid.UUID, req payloads.UpdateMovieCertificationsRequest, requestID uuid.UUID) ([]*models.MovieCertification, *errors.ApiError) {
+	m, apiErr := s.getMovie(id, false, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	certifications := make([]*models.MovieCertification, len(req.Certifications))
+	countryIDs := make([]uuid.UUID, len(req.Certifications))
+	for i, c := range req.Certifications {
+		countryIDs[i] = c.CountryID
+		certifications[i] = &models.MovieCertification{
+			MovieID:   id,
+			CountryID: c.CountryID,
+			Rating:    c.Rating,
+			MinAge:    *c.MinAge,
+		}
+	}
+
+	if len(countryIDs) > 0 {
+		count, err := s.countryRepo.GetNumbersOfCountry(filters.CountryFilter{
+			Filter: &filters.SingleFilter{},
+			ID:     &filters.Condition{Operator: filters.OpIn, Value: countryIDs},
+		})
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+		if count != len(countryIDs) {
+			return nil, errors.BadRequestError("invalid country ids")
+		}
+	}
+
+	after := *m
+	after.Certifications = certifications
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.certificationRepo.UpdateCertificationsOfMovie(tx, id, certifications); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieUpdated, m.ID, m, &after))
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return certifications, nil
+}
+
+func (s *movieService) UpdateMovieLanguages(id uuid.UUID, req payloads.UpdateMovieLanguagesRequest, requestID uuid.UUID) ([]*models.MovieLanguage, *errors.ApiError) {
+	m, apiErr := s.getMovie(id, false, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	languages := make([]*models.MovieLanguage, 0, len(req.Subtitles)+len(req.Dubs))
+	for _, l := range req.Subtitles {
+		languages = append(languages, &models.MovieLanguage{MovieID: id, Language: l, Type: constants.Subtitle})
+	}
+	for _, l := range req.Dubs {
+		languages = append(languages, &models.MovieLanguage{MovieID: id, Language: l, Type: constants.Dub})
+	}
+
+	after := *m
+	after.Languages = languages
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.movieLanguageRepo.UpdateLanguagesOfMovie(tx, id, languages); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieUpdated, m.ID, m, &after))
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return languages, nil
+}
+
+func (s *movieService) UpdateMovieExternalIDs(id uuid.UUID, req payloads.UpdateMovieExternalIDsRequest, requestID uuid.UUID) ([]*models.MovieExternalID, *errors.ApiError) {
+	m, apiErr := s.getMovie(id, false, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	externalIDs := make([]*models.MovieExternalID, len(req.ExternalIDs))
+	for i, e := range req.ExternalIDs {
+		existing, err := s.externalIDRepo.GetExternalIDs(filters.MovieExternalIDFilter{
+			Filter:     &filters.MultiFilter{Logic: filters.And},
+			MovieID:    &filters.Condition{Operator: filters.OpNotEqual, Value: id},
+			Source:     &filters.Condition{Operator: filters.OpEqual, Value: e.Source},
+			ExternalID: &filters.Condition{Operator: filters.OpEqual, Value: e.ExternalID},
+		})
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+		if len(existing) > 0 {
+			return nil, errors.BadRequestError(fmt.Sprintf("%s id %s is already linked to another movie", e.Source, e.ExternalID))
+		}
+
+		externalIDs[i] = &models.MovieExternalID{MovieID: id, Source: e.Source, ExternalID: e.ExternalID}
+	}
+
+	after := *m
+	after.ExternalIDs = externalIDs
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.externalIDRepo.UpdateExternalIDsOfMovie(tx, id, externalIDs); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent(constants.MovieUpdated, m.ID, m, &after))
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return externalIDs, nil
+}
+
+func (s *movieService) DeleteMovie(id uuid.UUID, deletedBy, requestID uuid.UUID) *errors.ApiError {
+	movie, apiErr := s.getMovie(id, false, false)
 	if apiErr != nil {
 		return apiErr
 	}
+	before := *movie
 
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
 		if err := s.movieGenreRepo.DeleteByMovieId(tx, movie.ID); err != nil {
 			return err
 		}
 
-		return s.movieRepo.DeleteMovie(tx, movie, deletedBy)
+		if err := s.movieRepo.DeleteMovie(tx, movie, deletedBy); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendMovieEvent(tx, requestID, payloads.NewCatalogEvent[models.Movie](constants.MovieDeleted, movie.ID, &before, nil))
 	}); err != nil {
 		return errors.InternalServerError(err.Error())
 	}
@@ -216,12 +458,12 @@ func (s *movieService) isAdminUser(userEmail *string) bool {
 	return isAdmin
 }
 
-func (s *movieService) getMovie(id uuid.UUID, includeGenres bool) (*models.Movie, *errors.ApiError) {
+func (s *movieService) getMovie(id uuid.UUID, includeGenres, includeDetails bool) (*models.Movie, *errors.ApiError) {
 	m, err := s.movieRepo.GetMovie(filters.MovieFilter{
 		Filter:    &filters.SingleFilter{},
 		ID:        &filters.Condition{Operator: filters.OpEqual, Value: id},
 		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
-	}, includeGenres)
+	}, includeGenres, includeDetails)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -247,7 +489,81 @@ func allIdsInSlice(first, second []uuid.UUID) bool {
 	return true
 }
 
-func buildPaginationURL(limit, offset int, includeGenres bool) *string {
+func applyMovieSearchFilter(filter *filters.MovieFilter, search payloads.MovieSearchFilter) {
+	if search.PersonID != nil || search.Role != nil {
+		filter.Credit = &filters.MovieCreditCondition{}
+		if search.PersonID != nil {
+			filter.Credit.PersonID = &filters.Condition{Operator: filters.OpEqual, Value: *search.PersonID}
+		}
+		if search.Role != nil {
+			filter.Credit.Role = &filters.Condition{Operator: filters.OpEqual, Value: *search.Role}
+		}
+	}
+
+	if search.CountryID != nil || search.Certification != nil || search.MaxAge != nil {
+		filter.Rating = &filters.MovieCertificationCondition{}
+		if search.CountryID != nil {
+			filter.Rating.CountryID = &filters.Condition{Operator: filters.OpEqual, Value: *search.CountryID}
+		}
+		if search.Certification != nil {
+			filter.Rating.Rating = &filters.Condition{Operator: filters.OpEqual, Value: *search.Certification}
+		}
+		if search.MaxAge != nil {
+			filter.Rating.MinAge = &filters.Condition{Operator: filters.OpLessEqual, Value: *search.MaxAge}
+		}
+	}
+
+	if search.Subtitle != nil {
+		filter.Subtitle = &filters.Condition{Operator: filters.OpEqual, Value: *search.Subtitle}
+	}
+
+	if search.Dub != nil {
+		filter.Dub = &filters.Condition{Operator: filters.OpEqual, Value: *search.Dub}
+	}
+
+	if search.ExternalSource != nil || search.ExternalID != nil {
+		filter.External = &filters.MovieExternalIDCondition{}
+		if search.ExternalSource != nil {
+			filter.External.Source = &filters.Condition{Operator: filters.OpEqual, Value: *search.ExternalSource}
+		}
+		if search.ExternalID != nil {
+			filter.External.ExternalID = &filters.Condition{Operator: filters.OpEqual, Value: *search.ExternalID}
+		}
+	}
+}
+
+func buildPaginationURL(search payloads.MovieSearchFilter, limit, offset int, includeGenres, includeDetails bool) *string {
 	url := fmt.Sprintf("/movies?%s=%d&%s=%d&%s=%v", constants.Limit, limit, constants.Offset, offset, constants.IncludeGenres, includeGenres)
+	if includeDetails {
+		url += fmt.Sprintf("&%s=%v", constants.IncludeMovieDetails, includeDetails)
+	}
+	if search.PersonID != nil {
+		url += fmt.Sprintf("&%s=%s", constants.PersonID, search.PersonID)
+	}
+	if search.Role != nil {
+		url += fmt.Sprintf("&%s=%s", constants.Role, *search.Role)
+	}
+	if search.CountryID != nil {
+		url += fmt.Sprintf("&%s=%s", constants.CountryID, search.CountryID)
+	}
+	if search.Certification != nil {
+		url += fmt.Sprintf("&%s=%s", constants.Certification, neturl.QueryEscape(*search.Certification))
+	}
+	if search.MaxAge != nil {
+		url += fmt.Sprintf("&%s=%d", constants.MaxAge, *search.MaxAge)
+	}
+	if search.Subtitle != nil {
+		url += fmt.Sprintf("&%s=%s", constants.SubtitleLanguage, *search.Subtitle)
+	}
+	if search.Dub != nil {
+		url += fmt.Sprintf("&%s=%s", constants.DubLanguage, *search.Dub)
+	}
+	if search.ExternalSource != nil {
+		url += fmt.Sprintf("&%s=%s", constants.ExternalSource, *search.ExternalSource)
+	}
+	if search.ExternalID != nil {
+		url += fmt.Sprintf("&%s=%s", constants.ExternalID, neturl.QueryEscape(*search.ExternalID))
+	}
+
 	return &url
 }
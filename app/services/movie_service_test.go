@@ -25,7 +25,7 @@ func TestMovieService_GetMovie(t *testing.T) {
 
 	flagRepo := mock_repositories.NewMockFeatureFlagRepository(ctrl)
 	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
-	service := NewMovieService(nil, nil, movieRepo, nil, nil, flagRepo)
+	service := NewMovieService(nil, nil, movieRepo, nil, nil, nil, nil, nil, nil, nil, nil, flagRepo, nil)
 
 	email := "test@example.com"
 	movie := utils.GenerateMovie()
@@ -37,10 +37,10 @@ func TestMovieService_GetMovie(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false, false).Return(movie, nil).Times(1)
 		flagRepo.EXPECT().HasFlagEnabled(email, constants.CanModifyMovies).Return(true).Times(1)
 
-		result, err := service.GetMovie(movie.ID, &email, false)
+		result, err := service.GetMovie(movie.ID, &email, false, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -48,9 +48,9 @@ func TestMovieService_GetMovie(t *testing.T) {
 	})
 
 	t.Run("movie not found", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false).Return(nil, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false, false).Return(nil, nil).Times(1)
 
-		result, err := service.GetMovie(movie.ID, &email, false)
+		result, err := service.GetMovie(movie.ID, &email, false, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -59,9 +59,9 @@ func TestMovieService_GetMovie(t *testing.T) {
 	})
 
 	t.Run("error getting movie", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false).Return(nil, errors.New("error getting movie")).Times(1)
+		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false, false).Return(nil, errors.New("error getting movie")).Times(1)
 
-		result, err := service.GetMovie(movie.ID, &email, false)
+		result, err := service.GetMovie(movie.ID, &email, false, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -70,9 +70,9 @@ func TestMovieService_GetMovie(t *testing.T) {
 	})
 
 	t.Run("unauthenticated user", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false, false).Return(movie, nil).Times(1)
 
-		result, err := service.GetMovie(movie.ID, nil, false)
+		result, err := service.GetMovie(movie.ID, nil, false, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -81,10 +81,10 @@ func TestMovieService_GetMovie(t *testing.T) {
 	})
 
 	t.Run("unauthorized user", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(gomock.Eq(filter), false, false).Return(movie, nil).Times(1)
 		flagRepo.EXPECT().HasFlagEnabled(email, constants.CanModifyMovies).Return(false).Times(1)
 
-		result, err := service.GetMovie(movie.ID, &email, false)
+		result, err := service.GetMovie(movie.ID, &email, false, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -99,7 +99,7 @@ func TestMovieService_GetMovies(t *testing.T) {
 
 	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
 	flagRepo := mock_repositories.NewMockFeatureFlagRepository(ctrl)
-	service := NewMovieService(nil, nil, movieRepo, nil, nil, flagRepo)
+	service := NewMovieService(nil, nil, movieRepo, nil, nil, nil, nil, nil, nil, nil, nil, flagRepo, nil)
 
 	userEmail := "test@example.com"
 	movies := utils.GenerateMovies(20)
@@ -120,13 +120,13 @@ func TestMovieService_GetMovies(t *testing.T) {
 
 		normalGetFilter := getFilter
 		normalGetFilter.IsActive = &filters.Condition{Operator: filters.OpEqual, Value: true}
-		movieRepo.EXPECT().GetMoviesWithGenres(normalGetFilter).Return(movies, nil).Times(1)
+		movieRepo.EXPECT().GetMoviesWithGenres(normalGetFilter, false).Return(movies, nil).Times(1)
 
 		normalCountFilter := countFilter
 		normalCountFilter.IsActive = &filters.Condition{Operator: filters.OpEqual, Value: true}
 		movieRepo.EXPECT().GetNumbersOfMovie(normalCountFilter).Return(len(movies), nil).Times(1)
 
-		result, meta, err := service.GetMovies(limit, offset, &userEmail, includeGenres)
+		result, meta, err := service.GetMovies(payloads.MovieSearchFilter{}, limit, offset, &userEmail, includeGenres, false)
 
 		assert.NotNil(t, result)
 		assert.NotNil(t, meta)
@@ -149,10 +149,10 @@ func TestMovieService_GetMovies(t *testing.T) {
 
 	t.Run("success for admin user", func(t *testing.T) {
 		flagRepo.EXPECT().HasFlagEnabled(userEmail, constants.CanModifyMovies).Return(true).Times(1)
-		movieRepo.EXPECT().GetMoviesWithGenres(gomock.Eq(getFilter)).Return(movies, nil).Times(1)
+		movieRepo.EXPECT().GetMoviesWithGenres(gomock.Eq(getFilter), false).Return(movies, nil).Times(1)
 		movieRepo.EXPECT().GetNumbersOfMovie(gomock.Eq(countFilter)).Return(len(movies), nil).Times(1)
 
-		result, meta, err := service.GetMovies(limit, offset, &userEmail, includeGenres)
+		result, meta, err := service.GetMovies(payloads.MovieSearchFilter{}, limit, offset, &userEmail, includeGenres, false)
 
 		assert.NotNil(t, result)
 		assert.NotNil(t, meta)
@@ -173,11 +173,46 @@ func TestMovieService_GetMovies(t *testing.T) {
 		assert.Equal(t, &expectedMeta, meta)
 	})
 
+	t.Run("success with search filter", func(t *testing.T) {
+		flagRepo.EXPECT().HasFlagEnabled(userEmail, constants.CanModifyMovies).Return(true).Times(1)
+
+		personID := uuid.New()
+		role := constants.Director
+		maxAge := 13
+		subtitle := "vi"
+		search := payloads.MovieSearchFilter{PersonID: &personID, Role: &role, MaxAge: &maxAge, Subtitle: &subtitle}
+
+		searchGetFilter := getFilter
+		searchGetFilter.Credit = &filters.MovieCreditCondition{
+			PersonID: &filters.Condition{Operator: filters.OpEqual, Value: personID},
+			Role:     &filters.Condition{Operator: filters.OpEqual, Value: role},
+		}
+		searchGetFilter.Rating = &filters.MovieCertificationCondition{
+			MinAge: &filters.Condition{Operator: filters.OpLessEqual, Value: maxAge},
+		}
+		searchGetFilter.Subtitle = &filters.Condition{Operator: filters.OpEqual, Value: subtitle}
+		movieRepo.EXPECT().GetMovies(gomock.Eq(searchGetFilter), true).Return(movies, nil).Times(1)
+
+		searchCountFilter := countFilter
+		searchCountFilter.Credit = searchGetFilter.Credit
+		searchCountFilter.Rating = searchGetFilter.Rating
+		searchCountFilter.Subtitle = searchGetFilter.Subtitle
+		movieRepo.EXPECT().GetNumbersOfMovie(gomock.Eq(searchCountFilter)).Return(len(movies), nil).Times(1)
+
+		result, meta, err := service.GetMovies(search, limit, offset, &userEmail, false, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, movies, result)
+
+		nextUrl := fmt.Sprintf("/movies?limit=10&offset=10&includeGenres=false&includeDetails=true&personId=%s&role=DIRECTOR&maxAge=13&subtitle=vi", personID)
+		assert.Equal(t, &nextUrl, meta.NextUrl)
+	})
+
 	t.Run("error getting movies", func(t *testing.T) {
 		flagRepo.EXPECT().HasFlagEnabled(userEmail, constants.CanModifyMovies).Return(true).Times(1)
-		movieRepo.EXPECT().GetMoviesWithGenres(gomock.Eq(getFilter)).Return(nil, errors.New("error getting movies")).Times(1)
+		movieRepo.EXPECT().GetMoviesWithGenres(gomock.Eq(getFilter), false).Return(nil, errors.New("error getting movies")).Times(1)
 
-		result, meta, err := service.GetMovies(limit, offset, &userEmail, includeGenres)
+		result, meta, err := service.GetMovies(payloads.MovieSearchFilter{}, limit, offset, &userEmail, includeGenres, false)
 
 		assert.Nil(t, result)
 		assert.Nil(t, meta)
@@ -188,10 +223,10 @@ func TestMovieService_GetMovies(t *testing.T) {
 
 	t.Run("error counting movies", func(t *testing.T) {
 		flagRepo.EXPECT().HasFlagEnabled(userEmail, constants.CanModifyMovies).Return(true).Times(1)
-		movieRepo.EXPECT().GetMoviesWithGenres(gomock.Eq(getFilter)).Return(movies, nil).Times(1)
+		movieRepo.EXPECT().GetMoviesWithGenres(gomock.Eq(getFilter), false).Return(movies, nil).Times(1)
 		movieRepo.EXPECT().GetNumbersOfMovie(gomock.Eq(countFilter)).Return(0, errors.New("error counting movies")).Times(1)
 
-		result, meta, err := service.GetMovies(limit, offset, &userEmail, includeGenres)
+		result, meta, err := service.GetMovies(payloads.MovieSearchFilter{}, limit, offset, &userEmail, includeGenres, false)
 
 		assert.Nil(t, result)
 		assert.Nil(t, meta)
@@ -207,7 +242,9 @@ func TestMovieService_CreateMovie(t *testing.T) {
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	repo := mock_repositories.NewMockMovieRepository(ctrl)
-	service := NewMovieService(nil, transaction, repo, nil, nil, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
 
 	movie := utils.GenerateMovie()
 	req := payloads.CreateMovieRequest{
@@ -227,8 +264,16 @@ func TestMovieService_CreateMovie(t *testing.T) {
 			},
 		).Times(1)
 		repo.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.MovieEvent) error {
+				assert.Equal(t, constants.MovieCreated, e.Type)
+				assert.Nil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.CreateMovie(req, movie.CreatedBy)
+		result, err := service.CreateMovie(req, movie.CreatedBy, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -250,7 +295,7 @@ func TestMovieService_CreateMovie(t *testing.T) {
 		).Times(1)
 		repo.EXPECT().CreateMovie(gomock.Any(), gomock.Any()).Return(errors.New("error creating movie")).Times(1)
 
-		result, err := service.CreateMovie(req, movie.CreatedBy)
+		result, err := service.CreateMovie(req, movie.CreatedBy, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -265,7 +310,9 @@ func TestMovieService_UpdateMovie(t *testing.T) {
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	repo := mock_repositories.NewMockMovieRepository(ctrl)
-	service := NewMovieService(nil, transaction, repo, nil, nil, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
 
 	movie := utils.GenerateMovie()
 	req := payloads.UpdateMovieRequest{
@@ -284,14 +331,22 @@ func TestMovieService_UpdateMovie(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		repo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		repo.EXPECT().GetMovie(filter, false, false).Return(movie, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			}).Times(1)
 		repo.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.MovieEvent) error {
+				assert.Equal(t, constants.MovieUpdated, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.UpdateMovie(movie.ID, movie.LastUpdatedBy, req)
+		result, err := service.UpdateMovie(movie.ID, movie.LastUpdatedBy, req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -307,9 +362,9 @@ func TestMovieService_UpdateMovie(t *testing.T) {
 	})
 
 	t.Run("movie not found", func(t *testing.T) {
-		repo.EXPECT().GetMovie(filter, false).Return(nil, nil).Times(1)
+		repo.EXPECT().GetMovie(filter, false, false).Return(nil, nil).Times(1)
 
-		result, err := service.UpdateMovie(movie.ID, movie.LastUpdatedBy, req)
+		result, err := service.UpdateMovie(movie.ID, movie.LastUpdatedBy, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -318,14 +373,14 @@ func TestMovieService_UpdateMovie(t *testing.T) {
 	})
 
 	t.Run("error updating movie", func(t *testing.T) {
-		repo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		repo.EXPECT().GetMovie(filter, false, false).Return(movie, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			}).Times(1)
 		repo.EXPECT().UpdateMovie(gomock.Any(), gomock.Any()).Return(errors.New("error updating movie")).Times(1)
 
-		result, err := service.UpdateMovie(movie.ID, movie.LastUpdatedBy, req)
+		result, err := service.UpdateMovie(movie.ID, movie.LastUpdatedBy, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -341,7 +396,9 @@ func TestMovieService_AssignGenres(t *testing.T) {
 	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
 	genreRepo := mock_repositories.NewMockGenreRepository(ctrl)
 	movieGenreRepo := mock_repositories.NewMockMovieGenreRepository(ctrl)
-	service := NewMovieService(nil, transaction, movieRepo, genreRepo, movieGenreRepo, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, movieRepo, genreRepo, movieGenreRepo, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
 
 	movie := utils.GenerateMovie()
 	allGenreIds := make([]uuid.UUID, 3)
@@ -349,6 +406,11 @@ func TestMovieService_AssignGenres(t *testing.T) {
 		allGenreIds[i] = utils.GenerateGenre().ID
 	}
 	updatedGenreIds := []uuid.UUID{allGenreIds[0], allGenreIds[1]}
+	genres := utils.GenerateGenres(len(updatedGenreIds))
+	genresFilter := filters.GenreFilter{
+		Filter: &filters.MultiFilter{Logic: filters.And},
+		ID:     &filters.Condition{Operator: filters.OpIn, Value: updatedGenreIds},
+	}
 	filter := filters.MovieFilter{
 		Filter:    &filters.SingleFilter{},
 		ID:        &filters.Condition{Operator: filters.OpEqual, Value: movie.ID},
@@ -356,23 +418,32 @@ func TestMovieService_AssignGenres(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(movie, nil).Times(1)
 		genreRepo.EXPECT().GetGenreIDs(gomock.Any()).Return(allGenreIds, nil).Times(1)
+		genreRepo.EXPECT().GetGenres(gomock.Eq(genresFilter)).Return(genres, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			}).Times(1)
 		movieGenreRepo.EXPECT().UpdateGenresOfMovie(gomock.Any(), movie.ID, updatedGenreIds).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.MovieEvent) error {
+				assert.Equal(t, constants.MovieUpdated, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		err := service.AssignGenres(movie.ID, updatedGenreIds)
+		err := service.AssignGenres(movie.ID, updatedGenreIds, requestID)
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("movie not found", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(nil, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(nil, nil).Times(1)
 
-		err := service.AssignGenres(movie.ID, updatedGenreIds)
+		err := service.AssignGenres(movie.ID, updatedGenreIds, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.StatusCode)
@@ -380,9 +451,9 @@ func TestMovieService_AssignGenres(t *testing.T) {
 	})
 
 	t.Run("error getting movie", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(nil, errors.New("error getting movie")).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(nil, errors.New("error getting movie")).Times(1)
 
-		err := service.AssignGenres(movie.ID, updatedGenreIds)
+		err := service.AssignGenres(movie.ID, updatedGenreIds, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -390,10 +461,10 @@ func TestMovieService_AssignGenres(t *testing.T) {
 	})
 
 	t.Run("error getting genres", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(movie, nil).Times(1)
 		genreRepo.EXPECT().GetGenreIDs(gomock.Any()).Return(nil, errors.New("error getting genres")).Times(1)
 
-		err := service.AssignGenres(movie.ID, updatedGenreIds)
+		err := service.AssignGenres(movie.ID, updatedGenreIds, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -402,26 +473,39 @@ func TestMovieService_AssignGenres(t *testing.T) {
 	})
 
 	t.Run("error updated genres not found", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(movie, nil).Times(1)
 		genreRepo.EXPECT().GetGenreIDs(gomock.Any()).Return(allGenreIds, nil).Times(1)
 
-		err := service.AssignGenres(movie.ID, []uuid.UUID{uuid.New(), uuid.New()})
+		err := service.AssignGenres(movie.ID, []uuid.UUID{uuid.New(), uuid.New()}, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
 		assert.Equal(t, "invalid genre ids", err.Error())
 	})
 
+	t.Run("error getting genres by ids", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(movie, nil).Times(1)
+		genreRepo.EXPECT().GetGenreIDs(gomock.Any()).Return(allGenreIds, nil).Times(1)
+		genreRepo.EXPECT().GetGenres(gomock.Eq(genresFilter)).Return(nil, errors.New("error getting genres")).Times(1)
+
+		err := service.AssignGenres(movie.ID, updatedGenreIds, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting genres", err.Error())
+	})
+
 	t.Run("error updating movie", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, true, false).Return(movie, nil).Times(1)
 		genreRepo.EXPECT().GetGenreIDs(gomock.Any()).Return(allGenreIds, nil).Times(1)
+		genreRepo.EXPECT().GetGenres(gomock.Eq(genresFilter)).Return(genres, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			}).Times(1)
 		movieGenreRepo.EXPECT().UpdateGenresOfMovie(gomock.Any(), movie.ID, updatedGenreIds).Return(errors.New("error updating movie")).Times(1)
 
-		err := service.AssignGenres(movie.ID, updatedGenreIds)
+		err := service.AssignGenres(movie.ID, updatedGenreIds, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -437,7 +521,9 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
 	genreRepo := mock_repositories.NewMockGenreRepository(ctrl)
 	movieGenreRepo := mock_repositories.NewMockMovieGenreRepository(ctrl)
-	service := NewMovieService(nil, transaction, movieRepo, genreRepo, movieGenreRepo, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, movieRepo, genreRepo, movieGenreRepo, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
 
 	movie := utils.GenerateMovie()
 	deletedBy := uuid.New()
@@ -448,23 +534,31 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, false, false).Return(movie, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			}).Times(1)
 		movieGenreRepo.EXPECT().DeleteByMovieId(gomock.Any(), movie.ID).Return(nil).Times(1)
 		movieRepo.EXPECT().DeleteMovie(gomock.Any(), movie, deletedBy).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.MovieEvent) error {
+				assert.Equal(t, constants.MovieDeleted, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.Nil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		err := service.DeleteMovie(movie.ID, deletedBy)
+		err := service.DeleteMovie(movie.ID, deletedBy, requestID)
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("movie not found", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(nil, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, false, false).Return(nil, nil).Times(1)
 
-		err := service.DeleteMovie(movie.ID, deletedBy)
+		err := service.DeleteMovie(movie.ID, deletedBy, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusNotFound, err.StatusCode)
@@ -472,9 +566,9 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 	})
 
 	t.Run("error getting movie", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(nil, errors.New("error getting movie")).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, false, false).Return(nil, errors.New("error getting movie")).Times(1)
 
-		err := service.DeleteMovie(movie.ID, deletedBy)
+		err := service.DeleteMovie(movie.ID, deletedBy, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -482,14 +576,14 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 	})
 
 	t.Run("error deleting movie genres", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, false, false).Return(movie, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			}).Times(1)
 		movieGenreRepo.EXPECT().DeleteByMovieId(gomock.Any(), movie.ID).Return(errors.New("error deleting movie genres")).Times(1)
 
-		err := service.DeleteMovie(movie.ID, deletedBy)
+		err := service.DeleteMovie(movie.ID, deletedBy, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -497,7 +591,7 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 	})
 
 	t.Run("error deleting movie", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(filter, false).Return(movie, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(filter, false, false).Return(movie, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -505,10 +599,327 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 		movieGenreRepo.EXPECT().DeleteByMovieId(gomock.Any(), movie.ID).Return(nil).Times(1)
 		movieRepo.EXPECT().DeleteMovie(gomock.Any(), movie, deletedBy).Return(errors.New("error deleting movie")).Times(1)
 
-		err := service.DeleteMovie(movie.ID, deletedBy)
+		err := service.DeleteMovie(movie.ID, deletedBy, requestID)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error deleting movie", err.Error())
 	})
 }
+
+func TestMovieService_UpdateMovieCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
+	personRepo := mock_repositories.NewMockPersonRepository(ctrl)
+	movieCreditRepo := mock_repositories.NewMockMovieCreditRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, movieRepo, nil, nil, personRepo, movieCreditRepo, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	movie := utils.GenerateMovie()
+	people := utils.GeneratePeople(2)
+	filter := filters.MovieFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: movie.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	peopleFilter := filters.PersonFilter{
+		Filter: &filters.MultiFilter{Logic: filters.And},
+		ID:     &filters.Condition{Operator: filters.OpIn, Value: []uuid.UUID{people[0].ID, people[1].ID}},
+	}
+	req := payloads.UpdateMovieCreditsRequest{
+		Credits: []payloads.MovieCreditRequest{
+			{PersonID: people[0].ID, Role: constants.Director},
+			{PersonID: people[1].ID, Role: constants.Actor, CharacterName: utils.GetPointerOf("Cobb"), Position: 1},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		personRepo.EXPECT().GetPeople(peopleFilter).Return(people, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		movieCreditRepo.EXPECT().UpdateCreditsOfMovie(gomock.Any(), movie.ID, gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.MovieEvent) error {
+				assert.Equal(t, constants.MovieUpdated, e.Type)
+				assert.Equal(t, 2, len(e.After.Credits))
+				return nil
+			},
+		).Times(1)
+
+		result, err := service.UpdateMovieCredits(movie.ID, req, requestID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, people[1], result[1].Person)
+		assert.Equal(t, "Cobb", *result[1].CharacterName)
+	})
+
+	t.Run("movie not found", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateMovieCredits(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "movie not found", err.Error())
+	})
+
+	t.Run("duplicate person and role", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+
+		duplicated := payloads.UpdateMovieCreditsRequest{
+			Credits: []payloads.MovieCreditRequest{req.Credits[0], req.Credits[0]},
+		}
+		result, err := service.UpdateMovieCredits(movie.ID, duplicated, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate person and role in credits", err.Error())
+	})
+
+	t.Run("invalid person ids", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		personRepo.EXPECT().GetPeople(peopleFilter).Return(people[:1], nil).Times(1)
+
+		result, err := service.UpdateMovieCredits(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "invalid person ids", err.Error())
+	})
+
+	t.Run("error getting people", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		personRepo.EXPECT().GetPeople(peopleFilter).Return(nil, errors.New("error getting people")).Times(1)
+
+		result, err := service.UpdateMovieCredits(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting people", err.Error())
+	})
+
+	t.Run("error updating credits", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		personRepo.EXPECT().GetPeople(peopleFilter).Return(people, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		movieCreditRepo.EXPECT().UpdateCreditsOfMovie(gomock.Any(), movie.ID, gomock.Any()).Return(errors.New("error updating credits")).Times(1)
+
+		result, err := service.UpdateMovieCredits(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating credits", err.Error())
+	})
+}
+
+func TestMovieService_UpdateMovieCertifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
+	countryRepo := mock_repositories.NewMockCountryRepository(ctrl)
+	certificationRepo := mock_repositories.NewMockMovieCertificationRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, movieRepo, nil, nil, nil, nil, countryRepo, certificationRepo, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	movie := utils.GenerateMovie()
+	countryID := uuid.New()
+	filter := filters.MovieFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: movie.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	countryFilter := filters.CountryFilter{
+		Filter: &filters.SingleFilter{},
+		ID:     &filters.Condition{Operator: filters.OpIn, Value: []uuid.UUID{countryID}},
+	}
+	req := payloads.UpdateMovieCertificationsRequest{
+		Certifications: []payloads.MovieCertificationRequest{
+			{CountryID: countryID, Rating: "PG-13", MinAge: utils.GetPointerOf(13)},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		countryRepo.EXPECT().GetNumbersOfCountry(countryFilter).Return(1, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		expected := []*models.MovieCertification{{MovieID: movie.ID, CountryID: countryID, Rating: "PG-13", MinAge: 13}}
+		certificationRepo.EXPECT().UpdateCertificationsOfMovie(gomock.Any(), movie.ID, expected).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateMovieCertifications(movie.ID, req, requestID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("invalid country ids", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		countryRepo.EXPECT().GetNumbersOfCountry(countryFilter).Return(0, nil).Times(1)
+
+		result, err := service.UpdateMovieCertifications(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "invalid country ids", err.Error())
+	})
+
+	t.Run("error counting countries", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		countryRepo.EXPECT().GetNumbersOfCountry(countryFilter).Return(0, errors.New("error counting countries")).Times(1)
+
+		result, err := service.UpdateMovieCertifications(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error counting countries", err.Error())
+	})
+}
+
+func TestMovieService_UpdateMovieLanguages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
+	movieLanguageRepo := mock_repositories.NewMockMovieLanguageRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, movieRepo, nil, nil, nil, nil, nil, nil, movieLanguageRepo, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	movie := utils.GenerateMovie()
+	filter := filters.MovieFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: movie.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	req := payloads.UpdateMovieLanguagesRequest{Subtitles: []string{"en", "vi"}, Dubs: []string{"vi"}}
+	expected := []*models.MovieLanguage{
+		{MovieID: movie.ID, Language: "en", Type: constants.Subtitle},
+		{MovieID: movie.ID, Language: "vi", Type: constants.Subtitle},
+		{MovieID: movie.ID, Language: "vi", Type: constants.Dub},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		movieLanguageRepo.EXPECT().UpdateLanguagesOfMovie(gomock.Any(), movie.ID, expected).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateMovieLanguages(movie.ID, req, requestID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("error updating languages", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		movieLanguageRepo.EXPECT().UpdateLanguagesOfMovie(gomock.Any(), movie.ID, expected).Return(errors.New("error updating languages")).Times(1)
+
+		result, err := service.UpdateMovieLanguages(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating languages", err.Error())
+	})
+}
+
+func TestMovieService_UpdateMovieExternalIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
+	externalIDRepo := mock_repositories.NewMockMovieExternalIDRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewMovieService(nil, transaction, movieRepo, nil, nil, nil, nil, nil, nil, nil, externalIDRepo, nil, notificationRepo)
+	requestID := uuid.New()
+
+	movie := utils.GenerateMovie()
+	filter := filters.MovieFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: movie.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	req := payloads.UpdateMovieExternalIDsRequest{
+		ExternalIDs: []payloads.MovieExternalIDRequest{{Source: constants.Imdb, ExternalID: "tt1375666"}},
+	}
+	conflictFilter := filters.MovieExternalIDFilter{
+		Filter:     &filters.MultiFilter{Logic: filters.And},
+		MovieID:    &filters.Condition{Operator: filters.OpNotEqual, Value: movie.ID},
+		Source:     &filters.Condition{Operator: filters.OpEqual, Value: constants.Imdb},
+		ExternalID: &filters.Condition{Operator: filters.OpEqual, Value: "tt1375666"},
+	}
+	expected := []*models.MovieExternalID{{MovieID: movie.ID, Source: constants.Imdb, ExternalID: "tt1375666"}}
+
+	t.Run("success", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		externalIDRepo.EXPECT().GetExternalIDs(conflictFilter).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		externalIDRepo.EXPECT().UpdateExternalIDsOfMovie(gomock.Any(), movie.ID, expected).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendMovieEvent(gomock.Any(), requestID, gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateMovieExternalIDs(movie.ID, req, requestID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("linked to another movie", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		externalIDRepo.EXPECT().GetExternalIDs(conflictFilter).Return([]*models.MovieExternalID{utils.GenerateMovieExternalID()}, nil).Times(1)
+
+		result, err := service.UpdateMovieExternalIDs(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "IMDB id tt1375666 is already linked to another movie", err.Error())
+	})
+
+	t.Run("error getting external ids", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(filter, false, true).Return(movie, nil).Times(1)
+		externalIDRepo.EXPECT().GetExternalIDs(conflictFilter).Return(nil, errors.New("error getting external ids")).Times(1)
+
+		result, err := service.UpdateMovieExternalIDs(movie.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting external ids", err.Error())
+	})
+}
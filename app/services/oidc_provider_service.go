@@ -0,0 +1,314 @@
+package services
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcScopes        = "openid email profile"
+	oidcClockSkew     = time.Minute
+)
+
+type OidcProviderService interface {
+	GetAuthorizationUrl(state, nonce, codeChallenge string) (string, *errors.ApiError)
+	ExchangeCode(code, codeVerifier string) (*models.OidcClaims, *errors.ApiError)
+}
+
+func NewOidcProviderService(issuer, clientID, clientSecret, redirectUrl string, timeout int) OidcProviderService {
+	httpClient := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	return &oidcProviderService{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectUrl:  redirectUrl,
+		httpClient:   httpClient,
+		keys:         make(map[string]*rsa.PublicKey),
+	}
+}
+
+type oidcProviderService struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectUrl  string
+	httpClient   *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]*rsa.PublicKey
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type oidcTokenResponse struct {
+	IdToken string `json:"id_token"`
+}
+
+type oidcJwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+type oidcIdTokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type oidcIdTokenClaims struct {
+	models.OidcClaims
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+func (s *oidcProviderService) GetAuthorizationUrl(state, nonce, codeChallenge string) (string, *errors.ApiError) {
+	d, err := s.getDiscovery()
+	if err != nil {
+		return "", errors.InternalServerError(err.Error())
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", s.clientID)
+	params.Set("redirect_uri", s.redirectUrl)
+	params.Set("scope", oidcScopes)
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return d.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+func (s *oidcProviderService) ExchangeCode(code, codeVerifier string) (*models.OidcClaims, *errors.ApiError) {
+	d, err := s.getDiscovery()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.redirectUrl)
+	form.Set("client_id", s.clientID)
+	form.Set("client_secret", s.clientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequest(http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.UnauthorizedError("failed to exchange authorization code")
+	}
+
+	var token oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if token.IdToken == "" {
+		return nil, errors.UnauthorizedError("identity provider did not return an id token")
+	}
+
+	claims, err := s.verifyIdToken(d, token.IdToken)
+	if err != nil {
+		return nil, errors.UnauthorizedError("invalid id token: %s", err.Error())
+	}
+
+	return claims, nil
+}
+
+func (s *oidcProviderService) getDiscovery() (*oidcDiscovery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.discovery != nil {
+		return s.discovery, nil
+	}
+
+	var d oidcDiscovery
+	if err := s.getJson(s.issuer+oidcDiscoveryPath, &d); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != s.issuer {
+		return nil, fmt.Errorf("unexpected issuer %s in provider metadata", d.Issuer)
+	}
+
+	s.discovery = &d
+	return s.discovery, nil
+}
+
+func (s *oidcProviderService) getSigningKey(d *oidcDiscovery, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	var jwks oidcJwks
+	if err := s.getJson(d.JwksUri, &jwks); err != nil {
+		return nil, err
+	}
+
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		s.keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s not found", kid)
+	}
+
+	return key, nil
+}
+
+func (s *oidcProviderService) verifyIdToken(d *oidcDiscovery, rawToken string) (*models.OidcClaims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header oidcIdTokenHeader
+	if err := decodeJwtSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("unsupported signing algorithm %s", header.Alg)
+	}
+
+	key, err := s.getSigningKey(d, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("signature verification failed")
+	}
+
+	var claims oidcIdTokenClaims
+	if err := decodeJwtSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(claims.Issuer, "/") != s.issuer {
+		return nil, fmt.Errorf("unexpected issuer %s", claims.Issuer)
+	}
+	if !s.hasAudience(claims.Audience) {
+		return nil, fmt.Errorf("token is not issued for this client")
+	}
+	if time.Now().UTC().Add(-oidcClockSkew).Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("token is expired")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("missing subject")
+	}
+
+	return &claims.OidcClaims, nil
+}
+
+func (s *oidcProviderService) hasAudience(audience json.RawMessage) bool {
+	var single string
+	if err := json.Unmarshal(audience, &single); err == nil {
+		return single == s.clientID
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(audience, &multiple); err != nil {
+		return false
+	}
+	for _, aud := range multiple {
+		if aud == s.clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *oidcProviderService) getJson(endpoint string, target any) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from identity provider")
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+func decodeJwtSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
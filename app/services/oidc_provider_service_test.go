@@ -0,0 +1,254 @@
+package services
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubIdentityProvider struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	kid           string
+	code          string
+	codeChallenge string
+	claims        map[string]any
+}
+
+func newStubIdentityProvider(t *testing.T, clientID string) *stubIdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	p := &stubIdentityProvider{key: key, kid: "stub-key", code: "authorization-code"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": p.kid,
+				"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil ||
+			r.PostForm.Get("grant_type") != "authorization_code" ||
+			r.PostForm.Get("client_id") != clientID ||
+			r.PostForm.Get("code") != p.code ||
+			pkceChallenge(r.PostForm.Get("code_verifier")) != p.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     p.signIdToken(t, p.claims),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+func (p *stubIdentityProvider) defaultClaims(clientID, nonce string) map[string]any {
+	return map[string]any{
+		"iss":            p.server.URL,
+		"aud":            clientID,
+		"sub":            "external-subject",
+		"email":          "example@example.com",
+		"email_verified": true,
+		"given_name":     "John",
+		"family_name":    "Doe",
+		"nonce":          nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (p *stubIdentityProvider) signIdToken(t *testing.T, claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": p.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	assert.Nil(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func pkceChallenge(codeVerifier string) string {
+	digest := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func TestOidcProviderService_GetAuthorizationUrl(t *testing.T) {
+	clientID := "client-id"
+	idp := newStubIdentityProvider(t, clientID)
+	defer idp.server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		service := NewOidcProviderService(idp.server.URL, clientID, "client-secret", "http://localhost/callback", 10)
+
+		result, err := service.GetAuthorizationUrl("state", "nonce", "challenge")
+
+		assert.Nil(t, err)
+		u, parseErr := url.Parse(result)
+		assert.Nil(t, parseErr)
+		assert.Equal(t, idp.server.URL+"/authorize", strings.Split(result, "?")[0])
+		assert.Equal(t, "code", u.Query().Get("response_type"))
+		assert.Equal(t, clientID, u.Query().Get("client_id"))
+		assert.Equal(t, "http://localhost/callback", u.Query().Get("redirect_uri"))
+		assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+		assert.Equal(t, "state", u.Query().Get("state"))
+		assert.Equal(t, "nonce", u.Query().Get("nonce"))
+		assert.Equal(t, "challenge", u.Query().Get("code_challenge"))
+		assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	})
+
+	t.Run("discovery error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		service := NewOidcProviderService(server.URL, clientID, "client-secret", "http://localhost/callback", 10)
+
+		result, err := service.GetAuthorizationUrl("state", "nonce", "challenge")
+
+		assert.Empty(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "unexpected response from identity provider", err.Error())
+	})
+}
+
+func TestOidcProviderService_ExchangeCode(t *testing.T) {
+	clientID := "client-id"
+	codeVerifier := "code-verifier"
+	idp := newStubIdentityProvider(t, clientID)
+	defer idp.server.Close()
+	idp.codeChallenge = pkceChallenge(codeVerifier)
+
+	service := NewOidcProviderService(idp.server.URL, clientID, "client-secret", "http://localhost/callback", 10)
+
+	t.Run("success", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, "external-subject", result.Subject)
+		assert.Equal(t, "example@example.com", result.Email)
+		assert.True(t, result.EmailVerified)
+		assert.Equal(t, "John", result.GivenName)
+		assert.Equal(t, "Doe", result.FamilyName)
+		assert.Equal(t, "nonce", result.Nonce)
+	})
+
+	t.Run("audience list", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		idp.claims["aud"] = []string{"another-client", clientID}
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("invalid code verifier", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+
+		result, err := service.ExchangeCode(idp.code, "another-verifier")
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "failed to exchange authorization code", err.Error())
+	})
+
+	t.Run("invalid audience", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		idp.claims["aud"] = "another-client"
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid id token: token is not issued for this client", err.Error())
+	})
+
+	t.Run("invalid issuer", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		idp.claims["iss"] = "https://attacker.example.com"
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid id token: unexpected issuer https://attacker.example.com", err.Error())
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		idp.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid id token: token is expired", err.Error())
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		originalKey := idp.key
+		idp.key = otherKey
+		defer func() { idp.key = originalKey }()
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid id token: signature verification failed", err.Error())
+	})
+
+	t.Run("rotated signing key", func(t *testing.T) {
+		originalKid := idp.kid
+		idp.kid = "rotated-key"
+		defer func() { idp.kid = originalKid }()
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+
+		result, err := service.ExchangeCode(idp.code, codeVerifier)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+	})
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
+	"gorm.io/gorm"
+	"log"
+	"sort"
+	"time"
+)
+
+// Sent payloads are redacted because events such as password resets carry raw tokens.
+const redactedOutboxPayload = "{}"
+
+type OutboxRelayService interface {
+	RelayPendingMessages() error
+	PurgeProcessedMessages() error
+}
+
+func NewOutboxRelayService(
+	db *gorm.DB,
+	transactionManager transaction.TransactionManager,
+	outboxRepo repositories.OutboxRepository,
+	kafkaProducer sarama.SyncProducer,
+	batchSize int,
+	maxAttempts int,
+	retryBackoffTime time.Duration,
+	maxRetryBackoffTime time.Duration,
+	retentionTime time.Duration,
+) OutboxRelayService {
+	return &outboxRelayService{
+		db:                  db,
+		transactionManager:  transactionManager,
+		outboxRepo:          outboxRepo,
+		kafkaProducer:       kafkaProducer,
+		batchSize:           batchSize,
+		maxAttempts:         maxAttempts,
+		retryBackoffTime:    retryBackoffTime,
+		maxRetryBackoffTime: maxRetryBackoffTime,
+		retentionTime:       retentionTime,
+	}
+}
+
+type outboxRelayService struct {
+	db                  *gorm.DB
+	transactionManager  transaction.TransactionManager
+	outboxRepo          repositories.OutboxRepository
+	kafkaProducer       sarama.SyncProducer
+	batchSize           int
+	maxAttempts         int
+	retryBackoffTime    time.Duration
+	maxRetryBackoffTime time.Duration
+	retentionTime       time.Duration
+}
+
+func (s *outboxRelayService) RelayPendingMessages() error {
+	return s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		// Only one relay publishes at a time, a second one could publish messages of a key out of order.
+		locked, err := s.outboxRepo.TryLockRelay(tx)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		messages, err := s.outboxRepo.GetPendingMessages(tx, s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		// A failed message holds back later messages with the same key in this batch,
+		// and GetPendingMessages keeps holding them back until it is sent or dead-lettered.
+		heldKeys := make(map[string]bool)
+		for _, message := range messages {
+			if message.Key != "" && heldKeys[message.Key] {
+				continue
+			}
+
+			s.publishMessage(message)
+			if message.Status == constants.OutboxPending && message.Key != "" {
+				heldKeys[message.Key] = true
+			}
+			if err := s.outboxRepo.UpdateMessage(tx, message); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *outboxRelayService) PurgeProcessedMessages() error {
+	return s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		count, err := s.outboxRepo.DeleteProcessedMessages(tx, time.Now().UTC().Add(-s.retentionTime))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			log.Printf("purged %d processed outbox messages", count)
+		}
+
+		return nil
+	})
+}
+
+func (s *outboxRelayService) publishMessage(message *models.OutboxMessage) {
+	now := time.Now().UTC()
+	message.Attempts++
+
+	producerMessage, err := s.getProducerMessage(message)
+	if err == nil {
+		_, _, err = s.kafkaProducer.SendMessage(producerMessage)
+	}
+	if err == nil {
+		message.Status = constants.OutboxSent
+		message.SentAt = &now
+		message.LastError = nil
+		message.Payload = redactedOutboxPayload
+		return
+	}
+
+	lastError := err.Error()
+	message.LastError = &lastError
+	if message.Attempts >= s.maxAttempts {
+		message.Status = constants.OutboxDeadLetter
+		log.Printf("outbox message %s moved to dead letter after %d attempts: %s", message.ID, message.Attempts, lastError)
+		return
+	}
+
+	message.NextAttemptAt = now.Add(s.getRetryBackoffTime(message.Attempts))
+}
+
+func (s *outboxRelayService) getProducerMessage(message *models.OutboxMessage) (*sarama.ProducerMessage, error) {
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(message.Headers), &headers); err != nil {
+		return nil, err
+	}
+
+	headerKeys := make([]string, 0, len(headers))
+	for k := range headers {
+		headerKeys = append(headerKeys, k)
+	}
+	sort.Strings(headerKeys)
+
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   message.Topic,
+		Value:   sarama.StringEncoder(message.Payload),
+		Headers: make([]sarama.RecordHeader, len(headerKeys)),
+	}
+	for i, k := range headerKeys {
+		producerMessage.Headers[i] = sarama.RecordHeader{Key: []byte(k), Value: []byte(headers[k])}
+	}
+	if message.Key != "" {
+		producerMessage.Key = sarama.StringEncoder(message.Key)
+	}
+
+	return producerMessage, nil
+}
+
+func (s *outboxRelayService) getRetryBackoffTime(attempts int) time.Duration {
+	backoff := s.retryBackoffTime
+	for i := 1; i < attempts && backoff < s.maxRetryBackoffTime; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.maxRetryBackoffTime {
+		return s.maxRetryBackoffTime
+	}
+
+	return backoff
+}
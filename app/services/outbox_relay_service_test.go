@@ -0,0 +1,276 @@
+package services
+
+import (
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_transaction"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestOutboxRelayService_RelayPendingMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+	producer := mocks.NewSyncProducer(t, nil)
+	defer func() {
+		assert.Nil(t, producer.Close())
+	}()
+
+	batchSize := 10
+	maxAttempts := 3
+	retryBackoff := 5 * time.Second
+	maxRetryBackoff := 15 * time.Second
+	retention := 24 * time.Hour
+	service := NewOutboxRelayService(nil, transaction, outboxRepo, producer, batchSize, maxAttempts, retryBackoff, maxRetryBackoff, retention)
+
+	executeInTransaction := func() {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		outboxRepo.EXPECT().TryLockRelay(gomock.Any()).Return(true, nil).Times(1)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		message := utils.GenerateOutboxMessage()
+		payload := message.Payload
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return([]*models.OutboxMessage{message}, nil).Times(1)
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+			assert.Equal(t, message.Topic, m.Topic)
+			key, _ := m.Key.Encode()
+			assert.Equal(t, message.Key, string(key))
+			value, _ := m.Value.Encode()
+			assert.Equal(t, payload, string(value))
+			assert.Len(t, m.Headers, 2)
+			assert.Equal(t, constants.ContentType, string(m.Headers[0].Key))
+			assert.Equal(t, constants.ApplicationJson, string(m.Headers[0].Value))
+			assert.Equal(t, constants.EventIDHeader, string(m.Headers[1].Key))
+			return nil
+		})
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), message).Return(nil).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxSent, message.Status)
+		assert.Equal(t, 1, message.Attempts)
+		assert.NotNil(t, message.SentAt)
+		assert.Nil(t, message.LastError)
+		assert.Equal(t, "{}", message.Payload)
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		message := utils.GenerateOutboxMessage()
+		message.Attempts = 1
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return([]*models.OutboxMessage{message}, nil).Times(1)
+		producer.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), message).Return(nil).Times(1)
+
+		before := time.Now().UTC()
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxPending, message.Status)
+		assert.Equal(t, 2, message.Attempts)
+		assert.Equal(t, "broker unavailable", *message.LastError)
+		assert.Nil(t, message.SentAt)
+		assert.WithinDuration(t, before.Add(2*retryBackoff), message.NextAttemptAt, time.Second)
+	})
+
+	t.Run("backoff is capped", func(t *testing.T) {
+		s := NewOutboxRelayService(nil, transaction, outboxRepo, producer, batchSize, 10, retryBackoff, maxRetryBackoff, retention)
+		message := utils.GenerateOutboxMessage()
+		message.Attempts = 5
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return([]*models.OutboxMessage{message}, nil).Times(1)
+		producer.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), message).Return(nil).Times(1)
+
+		before := time.Now().UTC()
+		err := s.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxPending, message.Status)
+		assert.WithinDuration(t, before.Add(maxRetryBackoff), message.NextAttemptAt, time.Second)
+	})
+
+	t.Run("dead letter after max attempts", func(t *testing.T) {
+		message := utils.GenerateOutboxMessage()
+		message.Attempts = maxAttempts - 1
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return([]*models.OutboxMessage{message}, nil).Times(1)
+		producer.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), message).Return(nil).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxDeadLetter, message.Status)
+		assert.Equal(t, maxAttempts, message.Attempts)
+		assert.Equal(t, "broker unavailable", *message.LastError)
+	})
+
+	t.Run("failed message does not block the batch", func(t *testing.T) {
+		messages := utils.GenerateOutboxMessages(2)
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return(messages, nil).Times(1)
+		producer.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+		producer.ExpectSendMessageAndSucceed()
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxPending, messages[0].Status)
+		assert.Equal(t, constants.OutboxSent, messages[1].Status)
+	})
+
+	t.Run("failed message holds back later messages with the same key", func(t *testing.T) {
+		messages := utils.GenerateOutboxMessages(3)
+		messages[1].Key = messages[0].Key
+		payload := messages[1].Payload
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return(messages, nil).Times(1)
+		producer.ExpectSendMessageAndFail(errors.New("broker unavailable"))
+		producer.ExpectSendMessageAndSucceed()
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), messages[0]).Return(nil).Times(1)
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), messages[2]).Return(nil).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxPending, messages[0].Status)
+		assert.Equal(t, constants.OutboxPending, messages[1].Status)
+		assert.Equal(t, 0, messages[1].Attempts)
+		assert.Equal(t, payload, messages[1].Payload)
+		assert.Equal(t, constants.OutboxSent, messages[2].Status)
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		message := utils.GenerateOutboxMessage()
+		message.Headers = "invalid"
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return([]*models.OutboxMessage{message}, nil).Times(1)
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), message).Return(nil).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OutboxPending, message.Status)
+		assert.Equal(t, 1, message.Attempts)
+		assert.NotNil(t, message.LastError)
+	})
+
+	t.Run("another relay is running", func(t *testing.T) {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		outboxRepo.EXPECT().TryLockRelay(gomock.Any()).Return(false, nil).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error locking relay", func(t *testing.T) {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		outboxRepo.EXPECT().TryLockRelay(gomock.Any()).Return(false, errors.New("db error")).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+
+	t.Run("error getting pending messages", func(t *testing.T) {
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return(nil, errors.New("db error")).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+
+	t.Run("error updating message", func(t *testing.T) {
+		message := utils.GenerateOutboxMessage()
+
+		executeInTransaction()
+		outboxRepo.EXPECT().GetPendingMessages(gomock.Any(), batchSize).Return([]*models.OutboxMessage{message}, nil).Times(1)
+		producer.ExpectSendMessageAndSucceed()
+		outboxRepo.EXPECT().UpdateMessage(gomock.Any(), message).Return(errors.New("db error")).Times(1)
+
+		err := service.RelayPendingMessages()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
+
+func TestOutboxRelayService_PurgeProcessedMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	outboxRepo := mock_repositories.NewMockOutboxRepository(ctrl)
+
+	retention := 24 * time.Hour
+	service := NewOutboxRelayService(nil, transaction, outboxRepo, nil, 10, 3, time.Second, time.Minute, retention)
+
+	executeInTransaction := func() {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		executeInTransaction()
+		outboxRepo.EXPECT().DeleteProcessedMessages(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().UTC().Add(-retention), before, time.Second)
+			return 5, nil
+		}).Times(1)
+
+		err := service.PurgeProcessedMessages()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error deleting messages", func(t *testing.T) {
+		executeInTransaction()
+		outboxRepo.EXPECT().DeleteProcessedMessages(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error")).Times(1)
+
+		err := service.PurgeProcessedMessages()
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "db error", err.Error())
+	})
+}
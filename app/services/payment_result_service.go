@@ -0,0 +1,40 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"gorm.io/gorm"
+	"log"
+)
+
+type PaymentResultService interface {
+	HandlePaymentResultEvent(tx *gorm.DB, message *sarama.ConsumerMessage) error
+}
+
+func NewPaymentResultService() PaymentResultService {
+	return &paymentResultService{}
+}
+
+type paymentResultService struct{}
+
+// HandlePaymentResultEvent validates the payment result, reservations are not stored by this service yet so the result is only logged.
+func (s *paymentResultService) HandlePaymentResultEvent(tx *gorm.DB, message *sarama.ConsumerMessage) error {
+	var event payloads.ConsumedEventEnvelope[payloads.PaymentResultEvent]
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		return errors.MalformedEventError("invalid payment result event: %s", err.Error())
+	}
+
+	if event.Data.PaymentID == uuid.Nil || event.Data.ReservationID == uuid.Nil {
+		return errors.MalformedEventError("payment result event %s has no payment or reservation id", event.EventID)
+	}
+	if event.Data.Status != constants.PaymentSucceeded && event.Data.Status != constants.PaymentFailed {
+		return errors.MalformedEventError("payment result event %s has unknown status %s", event.EventID, event.Data.Status)
+	}
+
+	log.Printf("payment %s of reservation %s is %s", event.Data.PaymentID, event.Data.ReservationID, event.Data.Status)
+	return nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	apiError "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"testing"
+)
+
+func TestPaymentResultService_HandlePaymentResultEvent(t *testing.T) {
+	service := NewPaymentResultService()
+
+	eventID := uuid.New()
+	newMessage := func(paymentID, reservationID uuid.UUID, status string) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Value: []byte(fmt.Sprintf(
+				`{"event_id": "%s", "event_type": "payment.completed", "schema_version": 1, "occurred_at": "2026-10-19T08:00:00Z", "producer": "payment-service", "request_id": null, "data": {"payment_id": "%s", "reservation_id": "%s", "status": "%s", "amount": 12.5, "currency": "USD", "failure_reason": null}}`,
+				eventID, paymentID, reservationID, status,
+			)),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		err := service.HandlePaymentResultEvent(nil, newMessage(uuid.New(), uuid.New(), "SUCCEEDED"))
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		err := service.HandlePaymentResultEvent(nil, &sarama.ConsumerMessage{Value: []byte("invalid")})
+
+		assert.NotNil(t, err)
+		assert.True(t, apiError.IsMalformedEventError(err))
+	})
+
+	t.Run("missing reservation id", func(t *testing.T) {
+		err := service.HandlePaymentResultEvent(nil, newMessage(uuid.New(), uuid.Nil, "FAILED"))
+
+		assert.NotNil(t, err)
+		assert.True(t, apiError.IsMalformedEventError(err))
+		assert.Equal(t, fmt.Sprintf("payment result event %s has no payment or reservation id", eventID), err.Error())
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		err := service.HandlePaymentResultEvent(nil, newMessage(uuid.New(), uuid.New(), "PENDING"))
+
+		assert.NotNil(t, err)
+		assert.True(t, apiError.IsMalformedEventError(err))
+		assert.Equal(t, fmt.Sprintf("payment result event %s has unknown status PENDING", eventID), err.Error())
+	})
+}
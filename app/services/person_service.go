@@ -0,0 +1,124 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PersonService interface {
+	GetPerson(id uuid.UUID) (*models.Person, *errors.ApiError)
+	SearchPeople(keyword string, limit, offset int) ([]*models.Person, *errors.ApiError)
+	CreatePerson(req payloads.CreatePersonRequest) (*models.Person, *errors.ApiError)
+	UpdatePerson(id uuid.UUID, req payloads.UpdatePersonRequest) (*models.Person, *errors.ApiError)
+	DeletePerson(id uuid.UUID) *errors.ApiError
+}
+
+func NewPersonService(
+	db *gorm.DB,
+	transactionManager transaction.TransactionManager,
+	personRepo repositories.PersonRepository,
+	movieCreditRepo repositories.MovieCreditRepository,
+) PersonService {
+	return &personService{
+		db:                 db,
+		transactionManager: transactionManager,
+		personRepo:         personRepo,
+		movieCreditRepo:    movieCreditRepo,
+	}
+}
+
+type personService struct {
+	db                 *gorm.DB
+	transactionManager transaction.TransactionManager
+	personRepo         repositories.PersonRepository
+	movieCreditRepo    repositories.MovieCreditRepository
+}
+
+func (s *personService) GetPerson(id uuid.UUID) (*models.Person, *errors.ApiError) {
+	p, err := s.personRepo.GetPerson(filters.PersonFilter{
+		Filter: &filters.SingleFilter{},
+		ID:     &filters.Condition{Operator: filters.OpEqual, Value: id},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if p == nil {
+		return nil, errors.NotFoundError("person not found")
+	}
+
+	return p, nil
+}
+
+func (s *personService) SearchPeople(keyword string, limit, offset int) ([]*models.Person, *errors.ApiError) {
+	people, err := s.personRepo.SearchPeople(keyword, limit, offset)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return people, nil
+}
+
+func (s *personService) CreatePerson(req payloads.CreatePersonRequest) (*models.Person, *errors.ApiError) {
+	p := &models.Person{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Biography: req.Biography,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.personRepo.CreatePerson(tx, p)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return p, nil
+}
+
+func (s *personService) UpdatePerson(id uuid.UUID, req payloads.UpdatePersonRequest) (*models.Person, *errors.ApiError) {
+	p, apiErr := s.GetPerson(id)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	p.Name = req.Name
+	p.Biography = req.Biography
+	p.UpdatedAt = time.Now().UTC()
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.personRepo.UpdatePerson(tx, p)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return p, nil
+}
+
+func (s *personService) DeletePerson(id uuid.UUID) *errors.ApiError {
+	p, apiErr := s.GetPerson(id)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	hasCredits, err := s.movieCreditRepo.HasCreditsOfPerson(id)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if hasCredits {
+		return errors.BadRequestError("person has movie credits")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.personRepo.DeletePerson(tx, p)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
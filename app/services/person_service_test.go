@@ -0,0 +1,248 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_transaction"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
+	"testing"
+)
+
+func TestPersonService_GetPerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	personRepo := mock_repositories.NewMockPersonRepository(ctrl)
+	service := NewPersonService(nil, nil, personRepo, nil)
+
+	person := utils.GeneratePerson()
+	filter := filters.PersonFilter{
+		Filter: &filters.SingleFilter{},
+		ID:     &filters.Condition{Operator: filters.OpEqual, Value: person.ID},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(filter).Return(person, nil).Times(1)
+
+		result, err := service.GetPerson(person.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, person, result)
+	})
+
+	t.Run("person not found", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(filter).Return(nil, nil).Times(1)
+
+		result, err := service.GetPerson(person.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "person not found", err.Error())
+	})
+
+	t.Run("error getting person", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(filter).Return(nil, errors.New("error getting person")).Times(1)
+
+		result, err := service.GetPerson(person.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting person", err.Error())
+	})
+}
+
+func TestPersonService_SearchPeople(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	personRepo := mock_repositories.NewMockPersonRepository(ctrl)
+	service := NewPersonService(nil, nil, personRepo, nil)
+
+	t.Run("success", func(t *testing.T) {
+		people := utils.GeneratePeople(3)
+		personRepo.EXPECT().SearchPeople("nolan", 10, 0).Return(people, nil).Times(1)
+
+		result, err := service.SearchPeople("nolan", 10, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, people, result)
+	})
+
+	t.Run("error searching people", func(t *testing.T) {
+		personRepo.EXPECT().SearchPeople("nolan", 10, 0).Return(nil, errors.New("error searching people")).Times(1)
+
+		result, err := service.SearchPeople("nolan", 10, 0)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error searching people", err.Error())
+	})
+}
+
+func TestPersonService_CreatePerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	personRepo := mock_repositories.NewMockPersonRepository(ctrl)
+	service := NewPersonService(nil, transaction, personRepo, nil)
+
+	req := payloads.CreatePersonRequest{Name: "Christopher Nolan", Biography: utils.GetPointerOf("British-American filmmaker")}
+
+	t.Run("success", func(t *testing.T) {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		personRepo.EXPECT().CreatePerson(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.CreatePerson(req)
+
+		assert.Nil(t, err)
+		assert.NotEqual(t, uuid.Nil, result.ID)
+		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, req.Biography, result.Biography)
+	})
+
+	t.Run("error creating person", func(t *testing.T) {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		personRepo.EXPECT().CreatePerson(gomock.Any(), gomock.Any()).Return(errors.New("error creating person")).Times(1)
+
+		result, err := service.CreatePerson(req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error creating person", err.Error())
+	})
+}
+
+func TestPersonService_UpdatePerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	personRepo := mock_repositories.NewMockPersonRepository(ctrl)
+	service := NewPersonService(nil, transaction, personRepo, nil)
+
+	person := utils.GeneratePerson()
+	req := payloads.UpdatePersonRequest{Name: "Christopher Nolan"}
+
+	t.Run("success", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(person, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		personRepo.EXPECT().UpdatePerson(gomock.Any(), person).Return(nil).Times(1)
+
+		result, err := service.UpdatePerson(person.ID, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, req.Name, result.Name)
+		assert.Nil(t, result.Biography)
+	})
+
+	t.Run("person not found", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.UpdatePerson(person.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("error updating person", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(person, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		personRepo.EXPECT().UpdatePerson(gomock.Any(), person).Return(errors.New("error updating person")).Times(1)
+
+		result, err := service.UpdatePerson(person.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating person", err.Error())
+	})
+}
+
+func TestPersonService_DeletePerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	personRepo := mock_repositories.NewMockPersonRepository(ctrl)
+	movieCreditRepo := mock_repositories.NewMockMovieCreditRepository(ctrl)
+	service := NewPersonService(nil, transaction, personRepo, movieCreditRepo)
+
+	person := utils.GeneratePerson()
+
+	t.Run("success", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(person, nil).Times(1)
+		movieCreditRepo.EXPECT().HasCreditsOfPerson(person.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		personRepo.EXPECT().DeletePerson(gomock.Any(), person).Return(nil).Times(1)
+
+		err := service.DeletePerson(person.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("person has credits", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(person, nil).Times(1)
+		movieCreditRepo.EXPECT().HasCreditsOfPerson(person.ID).Return(true, nil).Times(1)
+
+		err := service.DeletePerson(person.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "person has movie credits", err.Error())
+	})
+
+	t.Run("error checking credits", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(person, nil).Times(1)
+		movieCreditRepo.EXPECT().HasCreditsOfPerson(person.ID).Return(false, errors.New("error checking credits")).Times(1)
+
+		err := service.DeletePerson(person.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error checking credits", err.Error())
+	})
+
+	t.Run("error deleting person", func(t *testing.T) {
+		personRepo.EXPECT().GetPerson(gomock.Any()).Return(person, nil).Times(1)
+		movieCreditRepo.EXPECT().HasCreditsOfPerson(person.ID).Return(false, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			}).Times(1)
+		personRepo.EXPECT().DeletePerson(gomock.Any(), person).Return(errors.New("error deleting person")).Times(1)
+
+		err := service.DeletePerson(person.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting person", err.Error())
+	})
+}
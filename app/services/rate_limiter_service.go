@@ -4,23 +4,24 @@ import (
 	"context"
 	"fmt"
 	"github.com/redis/go-redis/v9"
-	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"time"
 )
 
 var ctx = context.Background()
 
 type RateLimiterService interface {
-	Allow(clientIp string) (bool, time.Duration)
+	Allow(key string) (bool, time.Duration)
 }
 
 func NewRateLimiterService(
 	redisClient *redis.Client,
+	keyPrefix string,
 	maxRequests int,
 	windowTime time.Duration,
 ) RateLimiterService {
 	return &rateLimiterService{
 		redisClient: redisClient,
+		keyPrefix:   keyPrefix,
 		maxRequests: maxRequests,
 		windowTime:  windowTime,
 	}
@@ -28,12 +29,13 @@ func NewRateLimiterService(
 
 type rateLimiterService struct {
 	redisClient *redis.Client
+	keyPrefix   string
 	maxRequests int
 	windowTime  time.Duration
 }
 
-func (s *rateLimiterService) Allow(clientIp string) (bool, time.Duration) {
-	redisKey := fmt.Sprintf("%s:%s", constants.ClientRateLimit, clientIp)
+func (s *rateLimiterService) Allow(key string) (bool, time.Duration) {
+	redisKey := fmt.Sprintf("%s:%s", s.keyPrefix, key)
 
 	count, err := s.redisClient.Incr(ctx, redisKey).Result()
 	if err != nil {
@@ -17,7 +17,7 @@ func TestRateLimiterService_Allow(t *testing.T) {
 	}()
 
 	maxRequests := 5
-	service := NewRateLimiterService(client, maxRequests, time.Minute)
+	service := NewRateLimiterService(client, constants.ClientRateLimit, maxRequests, time.Minute)
 
 	clientIp := "127.0.0.1"
 
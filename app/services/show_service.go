@@ -10,13 +10,17 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
 	"gorm.io/gorm"
+	"log"
 	"time"
 )
 
 type ShowService interface {
 	GetShow(id uuid.UUID, userEmail *string) (*models.Show, *errors.ApiError)
-	GetShows(status constants.ShowStatus, limit, offset int) ([]*models.Show, *errors.ApiError)
-	CreateShow(req payloads.CreateShowRequest) (*models.Show, *errors.ApiError)
+	GetShows(status constants.ShowStatus, search payloads.ShowSearchFilter, limit, offset int) ([]*models.Show, *errors.ApiError)
+	CreateShow(req payloads.CreateShowRequest, requestID uuid.UUID) (*models.Show, *errors.ApiError)
+	GetAvailableSeats(id uuid.UUID, userEmail *string) ([]*models.Seat, *errors.ApiError)
+	BlockSeat(id, seatId, blockedBy uuid.UUID, req payloads.BlockSeatRequest) (*models.SeatBlock, *errors.ApiError)
+	UnblockSeat(id, seatId uuid.UUID) *errors.ApiError
 	ScheduleUpdateShowStatus() error
 }
 
@@ -26,25 +30,37 @@ func NewShowService(
 	showRepo repositories.ShowRepository,
 	movieRepo repositories.MovieRepository,
 	theaterRepo repositories.TheaterRepository,
+	theaterOpeningHourRepo repositories.TheaterOpeningHourRepository,
+	seatRepo repositories.SeatRepository,
+	seatBlockRepo repositories.SeatBlockRepository,
 	featureFlagRepo repositories.FeatureFlagRepository,
+	notificationRepo repositories.NotificationRepository,
 ) ShowService {
 	return &showService{
-		db:                 db,
-		transactionManager: transactionManager,
-		showRepo:           showRepo,
-		movieRepo:          movieRepo,
-		theaterRepo:        theaterRepo,
-		featureFlagRepo:    featureFlagRepo,
+		db:                     db,
+		transactionManager:     transactionManager,
+		showRepo:               showRepo,
+		movieRepo:              movieRepo,
+		theaterRepo:            theaterRepo,
+		theaterOpeningHourRepo: theaterOpeningHourRepo,
+		seatRepo:               seatRepo,
+		seatBlockRepo:          seatBlockRepo,
+		featureFlagRepo:        featureFlagRepo,
+		notificationRepo:       notificationRepo,
 	}
 }
 
 type showService struct {
-	db                 *gorm.DB
-	transactionManager transaction.TransactionManager
-	showRepo           repositories.ShowRepository
-	movieRepo          repositories.MovieRepository
-	theaterRepo        repositories.TheaterRepository
-	featureFlagRepo    repositories.FeatureFlagRepository
+	db                     *gorm.DB
+	transactionManager     transaction.TransactionManager
+	showRepo               repositories.ShowRepository
+	movieRepo              repositories.MovieRepository
+	theaterRepo            repositories.TheaterRepository
+	theaterOpeningHourRepo repositories.TheaterOpeningHourRepository
+	seatRepo               repositories.SeatRepository
+	seatBlockRepo          repositories.SeatBlockRepository
+	featureFlagRepo        repositories.FeatureFlagRepository
+	notificationRepo       repositories.NotificationRepository
 }
 
 func (s *showService) GetShow(id uuid.UUID, userEmail *string) (*models.Show, *errors.ApiError) {
@@ -58,27 +74,50 @@ func (s *showService) GetShow(id uuid.UUID, userEmail *string) (*models.Show, *e
 	if show == nil || !(show.Status == constants.Active || s.adminUser(userEmail)) {
 		return nil, errors.NotFoundError("show not found")
 	}
+	if err := setLocalTimes(show); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
 
 	return show, nil
 }
 
-func (s *showService) GetShows(status constants.ShowStatus, limit, offset int) ([]*models.Show, *errors.ApiError) {
-	shows, err := s.showRepo.GetShows(filters.ShowFilter{
-		Filter: &filters.MultiFilter{Limit: &limit, Offset: &offset},
+func (s *showService) GetShows(status constants.ShowStatus, search payloads.ShowSearchFilter, limit, offset int) ([]*models.Show, *errors.ApiError) {
+	filter := filters.ShowFilter{
+		Filter: &filters.MultiFilter{
+			Logic:  filters.And,
+			Limit:  &limit,
+			Offset: &offset,
+			Sort:   []filters.SortOption{{Field: "start_time", Direction: filters.Asc}},
+		},
 		Status: &filters.Condition{Operator: filters.OpEqual, Value: status},
-	})
+	}
+	if search.TheaterID != nil {
+		filter.TheaterId = &filters.Condition{Operator: filters.OpEqual, Value: *search.TheaterID}
+	}
+	if search.Today {
+		filter.LocalDate = &filters.Condition{Operator: filters.OpEqual, Value: filters.ShowLocalToday}
+	} else if search.Date != nil {
+		filter.LocalDate = &filters.Condition{Operator: filters.OpEqual, Value: search.Date.Format(time.DateOnly)}
+	}
+
+	shows, err := s.showRepo.GetShows(filter)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
+	for _, show := range shows {
+		if err := setLocalTimes(show); err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+	}
 
 	return shows, nil
 }
 
-func (s *showService) CreateShow(req payloads.CreateShowRequest) (*models.Show, *errors.ApiError) {
+func (s *showService) CreateShow(req payloads.CreateShowRequest, requestID uuid.UUID) (*models.Show, *errors.ApiError) {
 	movie, err := s.movieRepo.GetMovie(filters.MovieFilter{
 		Filter: &filters.SingleFilter{},
 		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.MovieId},
-	}, false)
+	}, false, false)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -87,9 +126,10 @@ func (s *showService) CreateShow(req payloads.CreateShowRequest) (*models.Show,
 	}
 
 	theater, err := s.theaterRepo.GetTheater(filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.TheaterId},
-	}, false)
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: req.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}, false, false)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -97,41 +137,160 @@ func (s *showService) CreateShow(req payloads.CreateShowRequest) (*models.Show,
 		return nil, errors.BadRequestError("theater not found")
 	}
 
-	valid, err := s.showRepo.IsShowInValidTimeRange(req.TheaterId, req.StartTime, req.EndTime)
+	timeZone, err := s.theaterRepo.GetTimeZone(theater.ID)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
-	if !valid {
-		return nil, errors.BadRequestError("invalid time range for this show")
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	if apiErr := s.checkOpeningHours(theater.ID, loc, req); apiErr != nil {
+		return nil, apiErr
 	}
 
 	currentTime := time.Now().UTC()
 	show := &models.Show{
-		Id:        uuid.New(),
-		MovieId:   &req.MovieId,
-		TheaterId: &req.TheaterId,
-		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
-		Status:    req.Status,
-		CreatedAt: currentTime,
-		UpdatedAt: currentTime,
+		Id:             uuid.New(),
+		MovieId:        &req.MovieId,
+		TheaterId:      &req.TheaterId,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		Status:         req.Status,
+		CreatedAt:      currentTime,
+		UpdatedAt:      currentTime,
+		TimeZone:       loc.String(),
+		LocalStartTime: req.StartTime.In(loc),
+		LocalEndTime:   req.EndTime.In(loc),
 	}
+	var theaterDeleted, invalidTimeRange bool
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.showRepo.CreateShow(tx, show)
+		// The theater lock also serializes concurrent creates, so two overlapping shows can not both pass the check.
+		locked, err := s.theaterRepo.LockTheater(tx, theater.ID)
+		if err != nil {
+			return err
+		}
+		if locked == nil || locked.IsDeleted {
+			theaterDeleted = true
+			return nil
+		}
+
+		valid, err := s.showRepo.IsShowInValidTimeRange(tx, req.TheaterId, req.StartTime, req.EndTime)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			invalidTimeRange = true
+			return nil
+		}
+
+		if err := s.showRepo.CreateShow(tx, show); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendShowEvent(tx, requestID, payloads.NewCatalogEvent(constants.ShowCreated, show.Id, nil, show))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
+	if theaterDeleted {
+		return nil, errors.BadRequestError("theater not found")
+	}
+	if invalidTimeRange {
+		return nil, errors.BadRequestError("invalid time range for this show")
+	}
 
 	return show, nil
 }
 
+func (s *showService) GetAvailableSeats(id uuid.UUID, userEmail *string) ([]*models.Seat, *errors.ApiError) {
+	show, apiErr := s.GetShow(id, userEmail)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	seats, err := s.seatRepo.GetAvailableSeats(*show.TheaterId, show.Id)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return seats, nil
+}
+
+func (s *showService) BlockSeat(id, seatId, blockedBy uuid.UUID, req payloads.BlockSeatRequest) (*models.SeatBlock, *errors.ApiError) {
+	show, apiErr := s.getOpenShow(id)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.validateShowSeat(show, seatId); apiErr != nil {
+		return nil, apiErr
+	}
+
+	block, err := s.seatBlockRepo.GetSeatBlock(seatId, &show.Id)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if block != nil {
+		return nil, errors.BadRequestError("seat is already blocked for this show")
+	}
+
+	block = &models.SeatBlock{
+		Id:        uuid.New(),
+		SeatId:    &seatId,
+		ShowId:    &show.Id,
+		Reason:    req.Reason,
+		BlockedBy: blockedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.seatBlockRepo.CreateSeatBlock(tx, block)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return block, nil
+}
+
+func (s *showService) UnblockSeat(id, seatId uuid.UUID) *errors.ApiError {
+	show, apiErr := s.getOpenShow(id)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	block, err := s.seatBlockRepo.GetSeatBlock(seatId, &show.Id)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if block == nil {
+		return errors.NotFoundError("seat block not found")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.seatBlockRepo.DeleteSeatBlock(tx, block)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
 func (s *showService) ScheduleUpdateShowStatus() error {
+	now := time.Now().UTC()
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		if err := s.showRepo.ScheduleActivateShows(tx, time.Hour*72); err != nil {
+		activated, err := s.showRepo.ScheduleActivateShows(tx, now, time.Hour*72)
+		if err != nil {
+			return err
+		}
+		if err := s.sendShowStatusEvents(tx, activated, constants.Active, now); err != nil {
 			return err
 		}
 
-		return s.showRepo.ScheduleCompleteShows(tx)
+		completed, err := s.showRepo.ScheduleCompleteShows(tx, now)
+		if err != nil {
+			return err
+		}
+
+		return s.sendShowStatusEvents(tx, completed, constants.Completed, now)
 	}); err != nil {
 		return err
 	}
@@ -139,6 +298,130 @@ func (s *showService) ScheduleUpdateShowStatus() error {
 	return nil
 }
 
+func (s *showService) sendShowStatusEvents(tx *gorm.DB, shows []*models.Show, status constants.ShowStatus, updatedAt time.Time) error {
+	for _, before := range shows {
+		if err := setLocalTimes(before); err != nil {
+			return err
+		}
+
+		after := *before
+		after.Status = status
+		after.UpdatedAt = updatedAt
+		if err := s.notificationRepo.SendShowEvent(tx, uuid.Nil, payloads.NewCatalogEvent(constants.ShowUpdated, before.Id, before, &after)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *showService) getOpenShow(id uuid.UUID) (*models.Show, *errors.ApiError) {
+	show, err := s.showRepo.GetShow(filters.ShowFilter{
+		Filter: &filters.SingleFilter{},
+		Id:     &filters.Condition{Operator: filters.OpEqual, Value: id.String()},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if show == nil {
+		return nil, errors.NotFoundError("show not found")
+	}
+	if show.Status != constants.Active && show.Status != constants.Scheduled {
+		return nil, errors.BadRequestError("show is no longer open")
+	}
+
+	return show, nil
+}
+
+func (s *showService) validateShowSeat(show *models.Show, seatId uuid.UUID) *errors.ApiError {
+	seat, err := s.seatRepo.GetSeat(filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seatId},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: show.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if seat == nil {
+		return errors.NotFoundError("seat not found")
+	}
+
+	return nil
+}
+
+func (s *showService) checkOpeningHours(theaterID uuid.UUID, loc *time.Location, req payloads.CreateShowRequest) *errors.ApiError {
+	hours, err := s.theaterOpeningHourRepo.GetOpeningHours(theaterID)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+
+	if isWithinOpeningHours(hours, req.StartTime.In(loc), req.EndTime.In(loc)) {
+		return nil
+	}
+	if !req.AllowOutsideOpeningHours {
+		return errors.BadRequestError("show is outside the theater opening hours")
+	}
+
+	log.Printf("show of theater %s from %s to %s is outside the theater opening hours", theaterID, req.StartTime, req.EndTime)
+	return nil
+}
+
 func (s *showService) adminUser(email *string) bool {
 	return email != nil && s.featureFlagRepo.HasFlagEnabled(*email, constants.CanModifyShows)
 }
+
+// isWithinOpeningHours expects start and end in the theater time zone. A close time at or before
+// the open time means the theater closes after midnight, so a show may belong to the previous day.
+func isWithinOpeningHours(hours []*models.TheaterOpeningHour, start, end time.Time) bool {
+	for _, day := range []time.Time{start.AddDate(0, 0, -1), start} {
+		for _, h := range hours {
+			if time.Weekday(h.Weekday) != day.Weekday() {
+				continue
+			}
+
+			openAt, err := atClockTime(day, h.OpenTime)
+			if err != nil {
+				continue
+			}
+			closeAt, err := atClockTime(day, h.CloseTime)
+			if err != nil {
+				continue
+			}
+			if !closeAt.After(openAt) {
+				closeAt, _ = atClockTime(day.AddDate(0, 0, 1), h.CloseTime)
+			}
+
+			if !start.Before(openAt) && !end.After(closeAt) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func atClockTime(day time.Time, clock string) (time.Time, error) {
+	c, err := time.Parse(time.TimeOnly, clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	y, m, d := day.Date()
+	return time.Date(y, m, d, c.Hour(), c.Minute(), c.Second(), 0, day.Location()), nil
+}
+
+func setLocalTimes(show *models.Show) error {
+	loc, err := time.LoadLocation(show.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	show.TimeZone = loc.String()
+	show.LocalStartTime = show.StartTime.In(loc)
+	show.LocalEndTime = show.EndTime.In(loc)
+	return nil
+}
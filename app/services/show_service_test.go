@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
@@ -23,7 +24,7 @@ func TestShowService_GetShow(t *testing.T) {
 
 	flagRepo := mock_repositories.NewMockFeatureFlagRepository(ctrl)
 	showRepo := mock_repositories.NewMockShowRepository(ctrl)
-	service := NewShowService(nil, nil, showRepo, nil, nil, flagRepo)
+	service := NewShowService(nil, nil, showRepo, nil, nil, nil, nil, nil, flagRepo, nil)
 
 	show := utils.GenerateShow()
 	show.Status = constants.Completed
@@ -73,30 +74,76 @@ func TestShowService_GetShows(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockShowRepository(ctrl)
-	service := NewShowService(nil, nil, repo, nil, nil, nil)
+	service := NewShowService(nil, nil, repo, nil, nil, nil, nil, nil, nil, nil)
 
 	shows := utils.GenerateShows(3)
 	limit := 3
 	offset := 0
 	filter := filters.ShowFilter{
-		Filter: &filters.MultiFilter{Limit: &limit, Offset: &offset},
+		Filter: &filters.MultiFilter{
+			Logic:  filters.And,
+			Limit:  &limit,
+			Offset: &offset,
+			Sort:   []filters.SortOption{{Field: "start_time", Direction: filters.Asc}},
+		},
 		Status: &filters.Condition{Operator: filters.OpEqual, Value: constants.Active},
 	}
 
 	t.Run("success", func(t *testing.T) {
 		repo.EXPECT().GetShows(filter).Return(shows, nil).Times(1)
 
-		result, err := service.GetShows(constants.Active, limit, offset)
+		result, err := service.GetShows(constants.Active, payloads.ShowSearchFilter{}, limit, offset)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, shows, result)
 	})
 
+	t.Run("shows in local time of theater", func(t *testing.T) {
+		show := utils.GenerateShow()
+		show.StartTime = time.Date(2026, 3, 29, 0, 30, 0, 0, time.UTC)
+		show.EndTime = show.StartTime.Add(2 * time.Hour)
+		show.TimeZone = "Europe/Berlin"
+		repo.EXPECT().GetShows(filter).Return([]*models.Show{show}, nil).Times(1)
+
+		result, err := service.GetShows(constants.Active, payloads.ShowSearchFilter{}, limit, offset)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		// Berlin switches to summer time at 01:00 UTC, in the middle of this show.
+		assert.Equal(t, "2026-03-29T01:30:00+01:00", result[0].LocalStartTime.Format(time.RFC3339))
+		assert.Equal(t, "2026-03-29T04:30:00+02:00", result[0].LocalEndTime.Format(time.RFC3339))
+	})
+
+	t.Run("search by theater and date", func(t *testing.T) {
+		theaterId := uuid.New()
+		date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+		dateFilter := filter
+		dateFilter.TheaterId = &filters.Condition{Operator: filters.OpEqual, Value: theaterId}
+		dateFilter.LocalDate = &filters.Condition{Operator: filters.OpEqual, Value: "2026-10-19"}
+		repo.EXPECT().GetShows(dateFilter).Return(shows, nil).Times(1)
+
+		result, err := service.GetShows(constants.Active, payloads.ShowSearchFilter{TheaterID: &theaterId, Date: &date}, limit, offset)
+
+		assert.Nil(t, err)
+		assert.Equal(t, shows, result)
+	})
+
+	t.Run("search shows of today", func(t *testing.T) {
+		todayFilter := filter
+		todayFilter.LocalDate = &filters.Condition{Operator: filters.OpEqual, Value: filters.ShowLocalToday}
+		repo.EXPECT().GetShows(todayFilter).Return(shows, nil).Times(1)
+
+		result, err := service.GetShows(constants.Active, payloads.ShowSearchFilter{Today: true}, limit, offset)
+
+		assert.Nil(t, err)
+		assert.Equal(t, shows, result)
+	})
+
 	t.Run("error getting shows", func(t *testing.T) {
 		repo.EXPECT().GetShows(filter).Return(nil, errors.New("error getting shows")).Times(1)
 
-		result, err := service.GetShows(constants.Active, limit, offset)
+		result, err := service.GetShows(constants.Active, payloads.ShowSearchFilter{}, limit, offset)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -113,7 +160,10 @@ func TestShowService_CreateShow(t *testing.T) {
 	showRepo := mock_repositories.NewMockShowRepository(ctrl)
 	movieRepo := mock_repositories.NewMockMovieRepository(ctrl)
 	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
-	service := NewShowService(nil, transaction, showRepo, movieRepo, theaterRepo, nil)
+	openingHourRepo := mock_repositories.NewMockTheaterOpeningHourRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewShowService(nil, transaction, showRepo, movieRepo, theaterRepo, openingHourRepo, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
 
 	show := utils.GenerateShow()
 	req := payloads.CreateShowRequest{
@@ -128,22 +178,35 @@ func TestShowService_CreateShow(t *testing.T) {
 		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.MovieId},
 	}
 	theaterFilter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.TheaterId},
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: req.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
+	theater := &models.Theater{ID: req.TheaterId}
 
 	t.Run("success", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(&models.Movie{}, nil).Times(1)
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(&models.Theater{}, nil).Times(1)
-		showRepo.EXPECT().IsShowInValidTimeRange(req.TheaterId, req.StartTime, req.EndTime).Return(true, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("UTC", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), req.TheaterId).Return(theater, nil).Times(1)
+		showRepo.EXPECT().IsShowInValidTimeRange(gomock.Any(), req.TheaterId, req.StartTime, req.EndTime).Return(true, nil).Times(1)
 		showRepo.EXPECT().CreateShow(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendShowEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.ShowEvent) error {
+				assert.Equal(t, constants.ShowCreated, e.Type)
+				assert.Nil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -152,12 +215,14 @@ func TestShowService_CreateShow(t *testing.T) {
 		assert.Equal(t, show.StartTime, result.StartTime)
 		assert.Equal(t, show.EndTime, result.EndTime)
 		assert.Equal(t, show.Status, result.Status)
+		assert.Equal(t, "UTC", result.TimeZone)
+		assert.Equal(t, show.StartTime, result.LocalStartTime)
 	})
 
 	t.Run("movie not found", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(nil, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(nil, nil).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -166,9 +231,9 @@ func TestShowService_CreateShow(t *testing.T) {
 	})
 
 	t.Run("error getting movie", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(nil, errors.New("error getting movie")).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(nil, errors.New("error getting movie")).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -177,10 +242,10 @@ func TestShowService_CreateShow(t *testing.T) {
 	})
 
 	t.Run("theater not found", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(&models.Movie{}, nil).Times(1)
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(nil, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(nil, nil).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -189,10 +254,10 @@ func TestShowService_CreateShow(t *testing.T) {
 	})
 
 	t.Run("error getting theater", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(&models.Movie{}, nil).Times(1)
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(nil, errors.New("error getting theater")).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(nil, errors.New("error getting theater")).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -200,12 +265,33 @@ func TestShowService_CreateShow(t *testing.T) {
 		assert.EqualError(t, err, "error getting theater")
 	})
 
+	t.Run("error getting time zone", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("", errors.New("error getting time zone")).Times(1)
+
+		result, err := service.CreateShow(req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting time zone")
+	})
+
 	t.Run("not valid time", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(&models.Movie{}, nil).Times(1)
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(&models.Theater{}, nil).Times(1)
-		showRepo.EXPECT().IsShowInValidTimeRange(req.TheaterId, req.StartTime, req.EndTime).Return(false, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("UTC", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), req.TheaterId).Return(theater, nil).Times(1)
+		showRepo.EXPECT().IsShowInValidTimeRange(gomock.Any(), req.TheaterId, req.StartTime, req.EndTime).Return(false, nil).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -214,11 +300,19 @@ func TestShowService_CreateShow(t *testing.T) {
 	})
 
 	t.Run("error checking time range", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(&models.Movie{}, nil).Times(1)
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(&models.Theater{}, nil).Times(1)
-		showRepo.EXPECT().IsShowInValidTimeRange(req.TheaterId, req.StartTime, req.EndTime).Return(false, errors.New("error checking time range")).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("UTC", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), req.TheaterId).Return(theater, nil).Times(1)
+		showRepo.EXPECT().IsShowInValidTimeRange(gomock.Any(), req.TheaterId, req.StartTime, req.EndTime).Return(false, errors.New("error checking time range")).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -226,74 +320,467 @@ func TestShowService_CreateShow(t *testing.T) {
 		assert.EqualError(t, err, "error checking time range")
 	})
 
+	t.Run("theater deleted concurrently", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("UTC", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), req.TheaterId).Return(&models.Theater{ID: req.TheaterId, IsDeleted: true}, nil).Times(1)
+
+		result, err := service.CreateShow(req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "theater not found")
+	})
+
 	t.Run("error creating show", func(t *testing.T) {
-		movieRepo.EXPECT().GetMovie(movieFilter, false).Return(&models.Movie{}, nil).Times(1)
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(&models.Theater{}, nil).Times(1)
-		showRepo.EXPECT().IsShowInValidTimeRange(req.TheaterId, req.StartTime, req.EndTime).Return(true, nil).Times(1)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("UTC", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), req.TheaterId).Return(theater, nil).Times(1)
+		showRepo.EXPECT().IsShowInValidTimeRange(gomock.Any(), req.TheaterId, req.StartTime, req.EndTime).Return(true, nil).Times(1)
 		showRepo.EXPECT().CreateShow(gomock.Any(), gomock.Any()).Return(errors.New("error creating show")).Times(1)
 
-		result, err := service.CreateShow(req)
+		result, err := service.CreateShow(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.EqualError(t, err, "error creating show")
 	})
+
+	// 2026-10-19 is a Monday, 03:00 UTC is 10:00 in Ho Chi Minh City.
+	localReq := req
+	localReq.StartTime = time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	localReq.EndTime = localReq.StartTime.Add(2 * time.Hour)
+
+	t.Run("show within opening hours", func(t *testing.T) {
+		hours := []*models.TheaterOpeningHour{{Weekday: int(time.Monday), OpenTime: "09:00:00", CloseTime: "23:00:00"}}
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("Asia/Ho_Chi_Minh", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(hours, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.CreateShow(localReq, requestID)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, "Asia/Ho_Chi_Minh", result.TimeZone)
+		assert.Equal(t, 10, result.LocalStartTime.Hour())
+		assert.Equal(t, localReq.StartTime, result.StartTime)
+	})
+
+	t.Run("show within overnight opening hours of previous day", func(t *testing.T) {
+		hours := []*models.TheaterOpeningHour{{Weekday: int(time.Sunday), OpenTime: "18:00:00", CloseTime: "02:00:00"}}
+		overnightReq := localReq
+		overnightReq.StartTime = time.Date(2026, 10, 18, 17, 30, 0, 0, time.UTC)
+		overnightReq.EndTime = overnightReq.StartTime.Add(90 * time.Minute)
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("Asia/Ho_Chi_Minh", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(hours, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.CreateShow(overnightReq, requestID)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("show outside opening hours", func(t *testing.T) {
+		hours := []*models.TheaterOpeningHour{{Weekday: int(time.Monday), OpenTime: "11:00:00", CloseTime: "23:00:00"}}
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("Asia/Ho_Chi_Minh", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(hours, nil).Times(1)
+
+		result, err := service.CreateShow(localReq, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "show is outside the theater opening hours")
+	})
+
+	t.Run("show outside opening hours allowed", func(t *testing.T) {
+		hours := []*models.TheaterOpeningHour{{Weekday: int(time.Tuesday), OpenTime: "09:00:00", CloseTime: "23:00:00"}}
+		allowedReq := localReq
+		allowedReq.AllowOutsideOpeningHours = true
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("Asia/Ho_Chi_Minh", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(hours, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.CreateShow(allowedReq, requestID)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error getting opening hours", func(t *testing.T) {
+		movieRepo.EXPECT().GetMovie(movieFilter, false, false).Return(&models.Movie{}, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTimeZone(req.TheaterId).Return("UTC", nil).Times(1)
+		openingHourRepo.EXPECT().GetOpeningHours(req.TheaterId).Return(nil, errors.New("error getting opening hours")).Times(1)
+
+		result, err := service.CreateShow(req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting opening hours")
+	})
 }
 
-func TestShowService_ScheduleUpdateShowStatus(t *testing.T) {
+func TestShowService_GetAvailableSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	service := NewShowService(nil, nil, showRepo, nil, nil, nil, seatRepo, nil, nil, nil)
+
+	show := utils.GenerateShow()
+	show.Status = constants.Active
+	seats := []*models.Seat{utils.GenerateSeat(), utils.GenerateSeat()}
+	filter := filters.ShowFilter{
+		Filter: &filters.SingleFilter{},
+		Id:     &filters.Condition{Operator: filters.OpEqual, Value: show.Id.String()},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(filter).Return(show, nil).Times(1)
+		seatRepo.EXPECT().GetAvailableSeats(*show.TheaterId, show.Id).Return(seats, nil).Times(1)
+
+		result, err := service.GetAvailableSeats(show.Id, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, seats, result)
+	})
+
+	t.Run("show not found", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(filter).Return(nil, nil).Times(1)
+
+		result, err := service.GetAvailableSeats(show.Id, nil)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "show not found")
+	})
+
+	t.Run("error getting seats", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(filter).Return(show, nil).Times(1)
+		seatRepo.EXPECT().GetAvailableSeats(*show.TheaterId, show.Id).Return(nil, errors.New("error getting seats")).Times(1)
+
+		result, err := service.GetAvailableSeats(show.Id, nil)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting seats")
+	})
+}
+
+func TestShowService_BlockSeat(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
-	repo := mock_repositories.NewMockShowRepository(ctrl)
-	service := NewShowService(nil, transaction, repo, nil, nil, nil)
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	seatBlockRepo := mock_repositories.NewMockSeatBlockRepository(ctrl)
+	service := NewShowService(nil, transaction, showRepo, nil, nil, nil, seatRepo, seatBlockRepo, nil, nil)
+
+	show := utils.GenerateShow()
+	show.Status = constants.Scheduled
+	seat := utils.GenerateSeat()
+	seat.TheaterId = show.TheaterId
+	userID := uuid.New()
+	req := payloads.BlockSeatRequest{Reason: "press seat"}
+	showFilter := filters.ShowFilter{
+		Filter: &filters.SingleFilter{},
+		Id:     &filters.Condition{Operator: filters.OpEqual, Value: show.Id.String()},
+	}
+	seatFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seat.Id},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: show.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
 
 	t.Run("success", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, &show.Id).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
-		repo.EXPECT().ScheduleActivateShows(gomock.Any(), time.Hour*72).Return(nil).Times(1)
-		repo.EXPECT().ScheduleCompleteShows(gomock.Any()).Return(nil).Times(1)
+		seatBlockRepo.EXPECT().CreateSeatBlock(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-		err := service.ScheduleUpdateShowStatus()
+		result, err := service.BlockSeat(show.Id, seat.Id, userID, req)
 
+		assert.NotNil(t, result)
 		assert.Nil(t, err)
+		assert.Equal(t, &seat.Id, result.SeatId)
+		assert.Equal(t, &show.Id, result.ShowId)
+		assert.Equal(t, req.Reason, result.Reason)
+		assert.Equal(t, userID, result.BlockedBy)
 	})
 
-	t.Run("error activating shows", func(t *testing.T) {
+	t.Run("show not found", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(nil, nil).Times(1)
+
+		result, err := service.BlockSeat(show.Id, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "show not found")
+	})
+
+	t.Run("show no longer open", func(t *testing.T) {
+		completed := *show
+		completed.Status = constants.Completed
+		showRepo.EXPECT().GetShow(showFilter).Return(&completed, nil).Times(1)
+
+		result, err := service.BlockSeat(show.Id, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "show is no longer open")
+	})
+
+	t.Run("seat not in show theater", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		result, err := service.BlockSeat(show.Id, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat not found")
+	})
+
+	t.Run("seat already blocked", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, &show.Id).Return(utils.GenerateSeatBlock(), nil).Times(1)
+
+		result, err := service.BlockSeat(show.Id, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "seat is already blocked for this show")
+	})
+
+	t.Run("error creating seat block", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, &show.Id).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
-		repo.EXPECT().ScheduleActivateShows(gomock.Any(), time.Hour*72).Return(errors.New("error activating shows")).Times(1)
+		seatBlockRepo.EXPECT().CreateSeatBlock(gomock.Any(), gomock.Any()).Return(errors.New("error creating seat block")).Times(1)
 
-		err := service.ScheduleUpdateShowStatus()
+		result, err := service.BlockSeat(show.Id, seat.Id, userID, req)
 
+		assert.Nil(t, result)
 		assert.NotNil(t, err)
-		assert.EqualError(t, err, "error activating shows")
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error creating seat block")
 	})
+}
 
-	t.Run("error completing shows", func(t *testing.T) {
+func TestShowService_UnblockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	seatBlockRepo := mock_repositories.NewMockSeatBlockRepository(ctrl)
+	service := NewShowService(nil, transaction, showRepo, nil, nil, nil, nil, seatBlockRepo, nil, nil)
+
+	show := utils.GenerateShow()
+	show.Status = constants.Active
+	block := utils.GenerateSeatBlock()
+	block.ShowId = &show.Id
+	showFilter := filters.ShowFilter{
+		Filter: &filters.SingleFilter{},
+		Id:     &filters.Condition{Operator: filters.OpEqual, Value: show.Id.String()},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(*block.SeatId, &show.Id).Return(block, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatBlockRepo.EXPECT().DeleteSeatBlock(gomock.Any(), block).Return(nil).Times(1)
+
+		err := service.UnblockSeat(show.Id, *block.SeatId)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("seat block not found", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(*block.SeatId, &show.Id).Return(nil, nil).Times(1)
+
+		err := service.UnblockSeat(show.Id, *block.SeatId)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat block not found")
+	})
+
+	t.Run("error deleting seat block", func(t *testing.T) {
+		showRepo.EXPECT().GetShow(showFilter).Return(show, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(*block.SeatId, &show.Id).Return(block, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
-		repo.EXPECT().ScheduleActivateShows(gomock.Any(), time.Hour*72).Return(nil).Times(1)
-		repo.EXPECT().ScheduleCompleteShows(gomock.Any()).Return(errors.New("error completing shows")).Times(1)
+		seatBlockRepo.EXPECT().DeleteSeatBlock(gomock.Any(), block).Return(errors.New("error deleting seat block")).Times(1)
+
+		err := service.UnblockSeat(show.Id, *block.SeatId)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error deleting seat block")
+	})
+}
+
+func TestShowService_ScheduleUpdateShowStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	repo := mock_repositories.NewMockShowRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewShowService(nil, transaction, repo, nil, nil, nil, nil, nil, nil, notificationRepo)
+
+	executeInTransaction := func() {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		activated := utils.GenerateShows(2)
+		completed := utils.GenerateShows(1)
+		for _, show := range append(activated, completed...) {
+			show.TimeZone = "Asia/Ho_Chi_Minh"
+		}
+		var now time.Time
+		var events []payloads.ShowEvent
+
+		executeInTransaction()
+		repo.EXPECT().ScheduleActivateShows(gomock.Any(), gomock.Any(), time.Hour*72).DoAndReturn(
+			func(tx *gorm.DB, n time.Time, beforeStart time.Duration) ([]*models.Show, error) {
+				now = n
+				return activated, nil
+			},
+		).Times(1)
+		repo.EXPECT().ScheduleCompleteShows(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, n time.Time) ([]*models.Show, error) {
+				assert.Equal(t, now, n)
+				return completed, nil
+			},
+		).Times(1)
+		notificationRepo.EXPECT().SendShowEvent(gomock.Any(), uuid.Nil, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, requestID uuid.UUID, e payloads.ShowEvent) error {
+				events = append(events, e)
+				return nil
+			},
+		).Times(3)
+
+		err := service.ScheduleUpdateShowStatus()
+
+		assert.Nil(t, err)
+		assert.Len(t, events, 3)
+		for i, e := range events {
+			before := append(activated, completed...)[i]
+			expectedStatus := constants.Active
+			if i == 2 {
+				expectedStatus = constants.Completed
+			}
+			assert.Equal(t, constants.ShowUpdated, e.Type)
+			assert.Equal(t, before.Id, e.ID)
+			assert.Equal(t, before, e.Before)
+			assert.Equal(t, expectedStatus, e.After.Status)
+			assert.Equal(t, now, e.After.UpdatedAt)
+			assert.Equal(t, "Asia/Ho_Chi_Minh", e.After.TimeZone)
+			assert.Equal(t, before.StartTime.In(e.After.LocalStartTime.Location()), e.After.LocalStartTime)
+		}
+	})
+
+	t.Run("no shows to update", func(t *testing.T) {
+		executeInTransaction()
+		repo.EXPECT().ScheduleActivateShows(gomock.Any(), gomock.Any(), time.Hour*72).Return(nil, nil).Times(1)
+		repo.EXPECT().ScheduleCompleteShows(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+		err := service.ScheduleUpdateShowStatus()
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error activating shows", func(t *testing.T) {
+		executeInTransaction()
+		repo.EXPECT().ScheduleActivateShows(gomock.Any(), gomock.Any(), time.Hour*72).Return(nil, errors.New("error activating shows")).Times(1)
+
+		err := service.ScheduleUpdateShowStatus()
+
+		assert.NotNil(t, err)
+		assert.EqualError(t, err, "error activating shows")
+	})
+
+	t.Run("error completing shows", func(t *testing.T) {
+		executeInTransaction()
+		repo.EXPECT().ScheduleActivateShows(gomock.Any(), gomock.Any(), time.Hour*72).Return(nil, nil).Times(1)
+		repo.EXPECT().ScheduleCompleteShows(gomock.Any(), gomock.Any()).Return(nil, errors.New("error completing shows")).Times(1)
 
 		err := service.ScheduleUpdateShowStatus()
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "error completing shows")
 	})
+
+	t.Run("error sending event", func(t *testing.T) {
+		executeInTransaction()
+		repo.EXPECT().ScheduleActivateShows(gomock.Any(), gomock.Any(), time.Hour*72).Return(utils.GenerateShows(1), nil).Times(1)
+		notificationRepo.EXPECT().SendShowEvent(gomock.Any(), uuid.Nil, gomock.Any()).Return(errors.New("error sending event")).Times(1)
+
+		err := service.ScheduleUpdateShowStatus()
+
+		assert.NotNil(t, err)
+		assert.EqualError(t, err, "error sending event")
+	})
 }
@@ -11,16 +11,29 @@ import (
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
 	"gorm.io/gorm"
+	"time"
 )
 
 type TheaterService interface {
-	GetTheater(id uuid.UUID, includeLocation bool) (*models.Theater, *errors.ApiError)
-	GetTheaters(limit, offset int, includeLocation bool) ([]*models.Theater, *models.ResponseMeta, *errors.ApiError)
-	GetNearbyTheaters(distance float64) ([]*models.Theater, *errors.ApiError)
-	CreateTheater(req payloads.CreateTheaterRequest) (*models.Theater, *errors.ApiError)
-	CreateTheaterLocation(theaterID uuid.UUID, req payloads.CreateTheaterLocationRequest) (*models.TheaterLocation, *errors.ApiError)
+	GetTheater(id uuid.UUID, includeLocation, includeDetails bool) (*models.Theater, *errors.ApiError)
+	GetTheaters(search payloads.TheaterSearchFilter, limit, offset int, includeLocation, includeDetails bool) ([]*models.Theater, *models.ResponseMeta, *errors.ApiError)
+	GetNearbyTheaters(origin payloads.NearbyTheatersOrigin, distance float64, limit, offset int) ([]*models.Theater, *errors.ApiError)
+	CreateTheater(req payloads.CreateTheaterRequest, requestID uuid.UUID) (*models.Theater, *errors.ApiError)
+	UpdateTheater(id uuid.UUID, req payloads.UpdateTheaterRequest, requestID uuid.UUID) (*models.Theater, *errors.ApiError)
+	DeleteTheater(id uuid.UUID, requestID uuid.UUID) *errors.ApiError
+	CreateTheaterLocation(theaterID uuid.UUID, req payloads.CreateTheaterLocationRequest, requestID uuid.UUID) (*models.TheaterLocation, *errors.ApiError)
+	GetSeats(theaterId uuid.UUID) ([]*models.Seat, *errors.ApiError)
 	CreateSeat(theaterId uuid.UUID, req payloads.CreateSeatPayload) (*models.Seat, *errors.ApiError)
-	UpdateTheaterLocation(theaterId uuid.UUID, req payloads.UpdateTheaterLocationRequest) (*models.TheaterLocation, *errors.ApiError)
+	UpdateSeat(theaterId, seatId uuid.UUID, req payloads.UpdateSeatRequest) (*models.Seat, *errors.ApiError)
+	DeleteSeat(theaterId, seatId uuid.UUID) *errors.ApiError
+	BlockSeat(theaterId, seatId, blockedBy uuid.UUID, req payloads.BlockSeatRequest) (*models.SeatBlock, *errors.ApiError)
+	UnblockSeat(theaterId, seatId uuid.UUID) *errors.ApiError
+	UpdateTheaterLocation(theaterId uuid.UUID, req payloads.UpdateTheaterLocationRequest, requestID uuid.UUID) (*models.TheaterLocation, *errors.ApiError)
+	DeleteTheaterLocation(theaterId uuid.UUID, requestID uuid.UUID) *errors.ApiError
+	UpdateTheaterAmenities(theaterId uuid.UUID, req payloads.UpdateTheaterAmenitiesRequest, requestID uuid.UUID) ([]*models.TheaterAmenity, *errors.ApiError)
+	UpdateTheaterOpeningHours(theaterId uuid.UUID, req payloads.UpdateTheaterOpeningHoursRequest, requestID uuid.UUID) ([]*models.TheaterOpeningHour, *errors.ApiError)
+	CreateTheaterPhoto(theaterId uuid.UUID, req payloads.CreateTheaterPhotoRequest) (*models.TheaterPhoto, *errors.ApiError)
+	DeleteTheaterPhoto(theaterId, photoId uuid.UUID) *errors.ApiError
 }
 
 func NewTheaterService(
@@ -28,37 +41,56 @@ func NewTheaterService(
 	transactionManager transaction.TransactionManager,
 	theaterRepo repositories.TheaterRepository,
 	theaterLocationRepo repositories.TheaterLocationRepository,
+	theaterAmenityRepo repositories.TheaterAmenityRepository,
+	theaterOpeningHourRepo repositories.TheaterOpeningHourRepository,
+	theaterPhotoRepo repositories.TheaterPhotoRepository,
 	seatRepo repositories.SeatRepository,
+	seatBlockRepo repositories.SeatBlockRepository,
+	showRepo repositories.ShowRepository,
 	cityRepo repositories.CityRepository,
 	userLocationService UserLocationService,
+	notificationRepo repositories.NotificationRepository,
 ) TheaterService {
 	return &theaterService{
-		db:                  db,
-		transactionManager:  transactionManager,
-		theaterRepo:         theaterRepo,
-		theaterLocationRepo: theaterLocationRepo,
-		seatRepo:            seatRepo,
-		cityRepo:            cityRepo,
-		userLocationService: userLocationService,
+		db:                     db,
+		transactionManager:     transactionManager,
+		theaterRepo:            theaterRepo,
+		theaterLocationRepo:    theaterLocationRepo,
+		theaterAmenityRepo:     theaterAmenityRepo,
+		theaterOpeningHourRepo: theaterOpeningHourRepo,
+		theaterPhotoRepo:       theaterPhotoRepo,
+		seatRepo:               seatRepo,
+		seatBlockRepo:          seatBlockRepo,
+		showRepo:               showRepo,
+		cityRepo:               cityRepo,
+		userLocationService:    userLocationService,
+		notificationRepo:       notificationRepo,
 	}
 }
 
 type theaterService struct {
-	db                  *gorm.DB
-	transactionManager  transaction.TransactionManager
-	theaterRepo         repositories.TheaterRepository
-	theaterLocationRepo repositories.TheaterLocationRepository
-	seatRepo            repositories.SeatRepository
-	cityRepo            repositories.CityRepository
-	userLocationService UserLocationService
+	db                     *gorm.DB
+	transactionManager     transaction.TransactionManager
+	theaterRepo            repositories.TheaterRepository
+	theaterLocationRepo    repositories.TheaterLocationRepository
+	theaterAmenityRepo     repositories.TheaterAmenityRepository
+	theaterOpeningHourRepo repositories.TheaterOpeningHourRepository
+	theaterPhotoRepo       repositories.TheaterPhotoRepository
+	seatRepo               repositories.SeatRepository
+	seatBlockRepo          repositories.SeatBlockRepository
+	showRepo               repositories.ShowRepository
+	cityRepo               repositories.CityRepository
+	userLocationService    UserLocationService
+	notificationRepo       repositories.NotificationRepository
 }
 
-func (s *theaterService) GetTheater(id uuid.UUID, includeLocation bool) (*models.Theater, *errors.ApiError) {
+func (s *theaterService) GetTheater(id uuid.UUID, includeLocation, includeDetails bool) (*models.Theater, *errors.ApiError) {
 	filter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: id},
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: id},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
-	t, err := s.theaterRepo.GetTheater(filter, includeLocation)
+	t, err := s.theaterRepo.GetTheater(filter, includeLocation, includeDetails)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -69,31 +101,31 @@ func (s *theaterService) GetTheater(id uuid.UUID, includeLocation bool) (*models
 	return t, nil
 }
 
-func (s *theaterService) GetTheaters(limit, offset int, includeLocation bool) ([]*models.Theater, *models.ResponseMeta, *errors.ApiError) {
-	theaters, err := s.theaterRepo.GetTheaters(filters.TheaterFilter{
-		Filter: &filters.MultiFilter{Limit: &limit, Offset: &offset},
-	}, includeLocation)
+func (s *theaterService) GetTheaters(search payloads.TheaterSearchFilter, limit, offset int, includeLocation, includeDetails bool) ([]*models.Theater, *models.ResponseMeta, *errors.ApiError) {
+	getFilter := s.buildTheaterSearchFilter(search)
+	getFilter.Filter = &filters.MultiFilter{Limit: &limit, Offset: &offset}
+	theaters, err := s.theaterRepo.GetTheaters(getFilter, includeLocation, includeDetails)
 	if err != nil {
 		return nil, nil, errors.InternalServerError(err.Error())
 	}
 
-	count, err := s.theaterRepo.GetNumbersOfTheater(filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-	})
+	countFilter := s.buildTheaterSearchFilter(search)
+	countFilter.Filter = &filters.SingleFilter{}
+	count, err := s.theaterRepo.GetNumbersOfTheater(countFilter)
 	if err != nil {
 		return nil, nil, errors.InternalServerError(err.Error())
 	}
 
-	return theaters, s.buildGetTheatersMeta(limit, offset, count, includeLocation), nil
+	return theaters, s.buildGetTheatersMeta(search, limit, offset, count, includeLocation, includeDetails), nil
 }
 
-func (s *theaterService) GetNearbyTheaters(distance float64) ([]*models.Theater, *errors.ApiError) {
-	userLoc, apiErr := s.userLocationService.GetCurrentUserLocation()
+func (s *theaterService) GetNearbyTheaters(origin payloads.NearbyTheatersOrigin, distance float64, limit, offset int) ([]*models.Theater, *errors.ApiError) {
+	userLoc, apiErr := s.getNearbyOrigin(origin)
 	if apiErr != nil {
 		return nil, apiErr
 	}
 
-	theaterLocations, err := s.theaterRepo.GetNearbyTheatersWithLocations(userLoc.Latitude, userLoc.Longitude, distance)
+	theaterLocations, err := s.theaterRepo.GetNearbyTheatersWithLocations(userLoc.Latitude, userLoc.Longitude, distance, limit, offset)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -111,31 +143,35 @@ func (s *theaterService) GetNearbyTheaters(distance float64) ([]*models.Theater,
 				Latitude:   theaterLocation.Latitude,
 				Longitude:  theaterLocation.Longitude,
 			},
+			Distance: &theaterLocation.Distance,
 		})
 	}
 
 	return theaters, nil
 }
 
-func (s *theaterService) CreateTheater(req payloads.CreateTheaterRequest) (*models.Theater, *errors.ApiError) {
-	filter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		Name:   &filters.Condition{Operator: filters.OpEqual, Value: &req.Name},
-	}
-	t, err := s.theaterRepo.GetTheater(filter, false)
-	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+func (s *theaterService) CreateTheater(req payloads.CreateTheaterRequest, requestID uuid.UUID) (*models.Theater, *errors.ApiError) {
+	t, apiErr := s.getTheaterByName(req.Name)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 	if t != nil {
 		return nil, errors.BadRequestError("duplicate theater name")
 	}
 
 	t = &models.Theater{
-		ID:   uuid.New(),
-		Name: req.Name,
+		ID:       uuid.New(),
+		Name:     req.Name,
+		Phone:    req.Phone,
+		Website:  req.Website,
+		TimeZone: req.TimeZone,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.theaterRepo.CreateTheater(tx, t)
+		if err := s.theaterRepo.CreateTheater(tx, t); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterCreated, t.ID, nil, t))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -143,16 +179,73 @@ func (s *theaterService) CreateTheater(req payloads.CreateTheaterRequest) (*mode
 	return t, nil
 }
 
-func (s *theaterService) CreateTheaterLocation(theaterID uuid.UUID, req payloads.CreateTheaterLocationRequest) (*models.TheaterLocation, *errors.ApiError) {
-	t, err := s.theaterRepo.GetTheater(filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: theaterID},
-	}, true)
-	if err != nil {
+func (s *theaterService) UpdateTheater(id uuid.UUID, req payloads.UpdateTheaterRequest, requestID uuid.UUID) (*models.Theater, *errors.ApiError) {
+	t, apiErr := s.GetTheater(id, true, false)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	existing, apiErr := s.getTheaterByName(req.Name)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if existing != nil && existing.ID != id {
+		return nil, errors.BadRequestError("duplicate theater name")
+	}
+
+	after := *t
+	after.Name = req.Name
+	after.Phone = req.Phone
+	after.Website = req.Website
+	after.TimeZone = req.TimeZone
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.theaterRepo.UpdateTheater(tx, &after); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterUpdated, t.ID, t, &after))
+	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
-	if t == nil {
-		return nil, errors.NotFoundError("theater not found")
+
+	return &after, nil
+}
+
+func (s *theaterService) DeleteTheater(id uuid.UUID, requestID uuid.UUID) *errors.ApiError {
+	t, apiErr := s.GetTheater(id, true, false)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	var hasShows bool
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		var err error
+		if hasShows, err = s.hasUpcomingShows(tx, id); err != nil || hasShows {
+			return err
+		}
+
+		if err := s.theaterRepo.DeleteTheater(tx, t); err != nil {
+			return err
+		}
+		if err := s.seatRepo.DeleteSeatsOfTheater(tx, id); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent[models.Theater](constants.TheaterDeleted, t.ID, t, nil))
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if hasShows {
+		return errors.BadRequestError("theater has upcoming shows")
+	}
+
+	return nil
+}
+
+func (s *theaterService) CreateTheaterLocation(theaterID uuid.UUID, req payloads.CreateTheaterLocationRequest, requestID uuid.UUID) (*models.TheaterLocation, *errors.ApiError) {
+	t, apiErr := s.GetTheater(theaterID, true, false)
+	if apiErr != nil {
+		return nil, apiErr
 	}
 	if t.Location != nil {
 		return nil, errors.BadRequestError("duplicate location for this theater")
@@ -172,7 +265,13 @@ func (s *theaterService) CreateTheaterLocation(theaterID uuid.UUID, req payloads
 		Longitude:  req.Longitude,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.theaterLocationRepo.CreateTheaterLocation(tx, l)
+		if err := s.theaterLocationRepo.CreateTheaterLocation(tx, l); err != nil {
+			return err
+		}
+
+		after := *t
+		after.Location = l
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterUpdated, t.ID, t, &after))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -180,8 +279,28 @@ func (s *theaterService) CreateTheaterLocation(theaterID uuid.UUID, req payloads
 	return l, nil
 }
 
+func (s *theaterService) GetSeats(theaterId uuid.UUID) ([]*models.Seat, *errors.ApiError) {
+	if _, apiErr := s.GetTheater(theaterId, false, false); apiErr != nil {
+		return nil, apiErr
+	}
+
+	seats, err := s.seatRepo.GetSeats(filters.SeatFilter{
+		Filter: &filters.MultiFilter{Sort: []filters.SortOption{
+			{Field: "row", Direction: filters.Asc},
+			{Field: "number", Direction: filters.Asc},
+		}},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return seats, nil
+}
+
 func (s *theaterService) CreateSeat(theaterId uuid.UUID, req payloads.CreateSeatPayload) (*models.Seat, *errors.ApiError) {
-	_, apiErr := s.GetTheater(theaterId, false)
+	_, apiErr := s.GetTheater(theaterId, false, false)
 	if apiErr != nil {
 		return nil, apiErr
 	}
@@ -191,6 +310,7 @@ func (s *theaterService) CreateSeat(theaterId uuid.UUID, req payloads.CreateSeat
 		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theaterId},
 		Row:       &filters.Condition{Operator: filters.OpEqual, Value: req.Row},
 		Number:    &filters.Condition{Operator: filters.OpEqual, Value: req.Number},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	})
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
@@ -199,6 +319,10 @@ func (s *theaterService) CreateSeat(theaterId uuid.UUID, req payloads.CreateSeat
 		return nil, errors.BadRequestError("duplicate seat for this theater")
 	}
 
+	if req.Type != constants.Couple && req.PairedNumber != nil {
+		return nil, errors.BadRequestError("only couple seats can be paired")
+	}
+
 	se := &models.Seat{
 		Id:        uuid.New(),
 		TheaterId: &theaterId,
@@ -206,7 +330,27 @@ func (s *theaterService) CreateSeat(theaterId uuid.UUID, req payloads.CreateSeat
 		Number:    req.Number,
 		Type:      req.Type,
 	}
+
+	var pair *models.Seat
+	switch req.Type {
+	case constants.Companion:
+		if apiErr := s.validateCompanionSeat(theaterId, req); apiErr != nil {
+			return nil, apiErr
+		}
+	case constants.Couple:
+		p, apiErr := s.buildPairedSeat(theaterId, req)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		pair = p
+	}
+
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if pair != nil {
+			return s.seatRepo.CreateSeatPair(tx, se, pair)
+		}
+
 		return s.seatRepo.CreateSeat(tx, se)
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
@@ -215,8 +359,206 @@ func (s *theaterService) CreateSeat(theaterId uuid.UUID, req payloads.CreateSeat
 	return se, nil
 }
 
-func (s *theaterService) UpdateTheaterLocation(theaterId uuid.UUID, req payloads.UpdateTheaterLocationRequest) (*models.TheaterLocation, *errors.ApiError) {
-	t, apiErr := s.GetTheater(theaterId, true)
+func (s *theaterService) UpdateSeat(theaterId, seatId uuid.UUID, req payloads.UpdateSeatRequest) (*models.Seat, *errors.ApiError) {
+	seat, apiErr := s.getTheaterSeat(theaterId, seatId)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if seat.Type == constants.Couple {
+		return nil, errors.BadRequestError("couple seats can not change type")
+	}
+	if seat.Type == constants.Wheelchair && req.Type != constants.Wheelchair {
+		if apiErr := s.checkNoAdjacentCompanion(seat); apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	seat.Type = req.Type
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.seatRepo.UpdateSeat(tx, seat)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return seat, nil
+}
+
+func (s *theaterService) DeleteSeat(theaterId, seatId uuid.UUID) *errors.ApiError {
+	seat, apiErr := s.getTheaterSeat(theaterId, seatId)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if seat.Type == constants.Wheelchair {
+		if apiErr := s.checkNoAdjacentCompanion(seat); apiErr != nil {
+			return apiErr
+		}
+	}
+
+	var hasShows bool
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		var err error
+		if hasShows, err = s.hasUpcomingShows(tx, theaterId); err != nil || hasShows {
+			return err
+		}
+
+		if err := s.seatRepo.DeleteSeat(tx, seat); err != nil {
+			return err
+		}
+		if seat.PairedSeatId != nil {
+			return s.seatRepo.DeleteSeat(tx, &models.Seat{Id: *seat.PairedSeatId})
+		}
+
+		return nil
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if hasShows {
+		return errors.BadRequestError("theater has upcoming shows")
+	}
+
+	return nil
+}
+
+func (s *theaterService) BlockSeat(theaterId, seatId, blockedBy uuid.UUID, req payloads.BlockSeatRequest) (*models.SeatBlock, *errors.ApiError) {
+	if _, apiErr := s.getTheaterSeat(theaterId, seatId); apiErr != nil {
+		return nil, apiErr
+	}
+
+	block, err := s.seatBlockRepo.GetSeatBlock(seatId, nil)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if block != nil {
+		return nil, errors.BadRequestError("seat is already blocked")
+	}
+
+	block = &models.SeatBlock{
+		Id:        uuid.New(),
+		SeatId:    &seatId,
+		Reason:    req.Reason,
+		BlockedBy: blockedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.seatBlockRepo.CreateSeatBlock(tx, block)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return block, nil
+}
+
+func (s *theaterService) UnblockSeat(theaterId, seatId uuid.UUID) *errors.ApiError {
+	if _, apiErr := s.getTheaterSeat(theaterId, seatId); apiErr != nil {
+		return apiErr
+	}
+
+	block, err := s.seatBlockRepo.GetSeatBlock(seatId, nil)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if block == nil {
+		return errors.NotFoundError("seat block not found")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.seatBlockRepo.DeleteSeatBlock(tx, block)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *theaterService) getTheaterSeat(theaterId, seatId uuid.UUID) (*models.Seat, *errors.ApiError) {
+	seat, err := s.seatRepo.GetSeat(filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seatId},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if seat == nil {
+		return nil, errors.NotFoundError("seat not found")
+	}
+
+	return seat, nil
+}
+
+func (s *theaterService) validateCompanionSeat(theaterId uuid.UUID, req payloads.CreateSeatPayload) *errors.ApiError {
+	wheelchair, err := s.seatRepo.GetSeat(filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theaterId},
+		Row:       &filters.Condition{Operator: filters.OpEqual, Value: req.Row},
+		Number:    &filters.Condition{Operator: filters.OpIn, Value: []int{req.Number - 1, req.Number + 1}},
+		Type:      &filters.Condition{Operator: filters.OpEqual, Value: constants.Wheelchair},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if wheelchair == nil {
+		return errors.BadRequestError("companion seat must be next to a wheelchair space")
+	}
+
+	return nil
+}
+
+func (s *theaterService) checkNoAdjacentCompanion(seat *models.Seat) *errors.ApiError {
+	companion, err := s.seatRepo.GetSeat(filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: seat.TheaterId},
+		Row:       &filters.Condition{Operator: filters.OpEqual, Value: seat.Row},
+		Number:    &filters.Condition{Operator: filters.OpIn, Value: []int{seat.Number - 1, seat.Number + 1}},
+		Type:      &filters.Condition{Operator: filters.OpEqual, Value: constants.Companion},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if companion != nil {
+		return errors.BadRequestError("wheelchair space has an adjacent companion seat")
+	}
+
+	return nil
+}
+
+func (s *theaterService) buildPairedSeat(theaterId uuid.UUID, req payloads.CreateSeatPayload) (*models.Seat, *errors.ApiError) {
+	if req.PairedNumber == nil {
+		return nil, errors.BadRequestError("couple seat requires a paired number")
+	}
+	if *req.PairedNumber != req.Number-1 && *req.PairedNumber != req.Number+1 {
+		return nil, errors.BadRequestError("paired seat must be next to the couple seat")
+	}
+
+	seat, err := s.seatRepo.GetSeat(filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theaterId},
+		Row:       &filters.Condition{Operator: filters.OpEqual, Value: req.Row},
+		Number:    &filters.Condition{Operator: filters.OpEqual, Value: *req.PairedNumber},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if seat != nil {
+		return nil, errors.BadRequestError("duplicate paired seat for this theater")
+	}
+
+	return &models.Seat{
+		Id:        uuid.New(),
+		TheaterId: &theaterId,
+		Row:       req.Row,
+		Number:    *req.PairedNumber,
+		Type:      constants.Couple,
+	}, nil
+}
+
+func (s *theaterService) UpdateTheaterLocation(theaterId uuid.UUID, req payloads.UpdateTheaterLocationRequest, requestID uuid.UUID) (*models.TheaterLocation, *errors.ApiError) {
+	t, apiErr := s.GetTheater(theaterId, true, false)
 	if apiErr != nil {
 		return nil, apiErr
 	}
@@ -238,7 +580,13 @@ func (s *theaterService) UpdateTheaterLocation(theaterId uuid.UUID, req payloads
 		Longitude:  req.Longitude,
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.theaterLocationRepo.UpdateTheaterLocation(tx, loc)
+		if err := s.theaterLocationRepo.UpdateTheaterLocation(tx, loc); err != nil {
+			return err
+		}
+
+		after := *t
+		after.Location = loc
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterUpdated, t.ID, t, &after))
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
@@ -246,6 +594,188 @@ func (s *theaterService) UpdateTheaterLocation(theaterId uuid.UUID, req payloads
 	return loc, nil
 }
 
+func (s *theaterService) DeleteTheaterLocation(theaterId uuid.UUID, requestID uuid.UUID) *errors.ApiError {
+	t, apiErr := s.GetTheater(theaterId, true, false)
+	if apiErr != nil {
+		return apiErr
+	}
+	if t.Location == nil {
+		return errors.BadRequestError("location not found")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.theaterLocationRepo.DeleteTheaterLocation(tx, t.Location); err != nil {
+			return err
+		}
+
+		after := *t
+		after.Location = nil
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterUpdated, t.ID, t, &after))
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *theaterService) UpdateTheaterAmenities(theaterId uuid.UUID, req payloads.UpdateTheaterAmenitiesRequest, requestID uuid.UUID) ([]*models.TheaterAmenity, *errors.ApiError) {
+	t, apiErr := s.GetTheater(theaterId, true, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	amenities := make([]*models.TheaterAmenity, len(req.Amenities))
+	for i, amenity := range req.Amenities {
+		amenities[i] = &models.TheaterAmenity{TheaterID: theaterId, Amenity: amenity}
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.theaterAmenityRepo.UpdateAmenitiesOfTheater(tx, theaterId, req.Amenities); err != nil {
+			return err
+		}
+
+		after := *t
+		after.Amenities = amenities
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterUpdated, t.ID, t, &after))
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return amenities, nil
+}
+
+func (s *theaterService) UpdateTheaterOpeningHours(theaterId uuid.UUID, req payloads.UpdateTheaterOpeningHoursRequest, requestID uuid.UUID) ([]*models.TheaterOpeningHour, *errors.ApiError) {
+	t, apiErr := s.GetTheater(theaterId, true, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	hours := make([]*models.TheaterOpeningHour, len(req.OpeningHours))
+	weekdays := make(map[int]bool)
+	for i, h := range req.OpeningHours {
+		if weekdays[*h.Weekday] {
+			return nil, errors.BadRequestError(fmt.Sprintf("duplicate opening hours for weekday %d", *h.Weekday))
+		}
+		weekdays[*h.Weekday] = true
+
+		if h.OpenTime == h.CloseTime {
+			return nil, errors.BadRequestError("open time and close time must be different")
+		}
+
+		hours[i] = &models.TheaterOpeningHour{
+			ID:        uuid.New(),
+			TheaterID: theaterId,
+			Weekday:   *h.Weekday,
+			OpenTime:  h.OpenTime + ":00",
+			CloseTime: h.CloseTime + ":00",
+		}
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.theaterOpeningHourRepo.UpdateOpeningHoursOfTheater(tx, theaterId, hours); err != nil {
+			return err
+		}
+
+		after := *t
+		after.OpeningHours = hours
+		return s.notificationRepo.SendTheaterEvent(tx, requestID, payloads.NewCatalogEvent(constants.TheaterUpdated, t.ID, t, &after))
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return hours, nil
+}
+
+func (s *theaterService) CreateTheaterPhoto(theaterId uuid.UUID, req payloads.CreateTheaterPhotoRequest) (*models.TheaterPhoto, *errors.ApiError) {
+	if _, apiErr := s.GetTheater(theaterId, false, false); apiErr != nil {
+		return nil, apiErr
+	}
+
+	photo := &models.TheaterPhoto{
+		ID:        uuid.New(),
+		TheaterID: theaterId,
+		Url:       req.Url,
+		Caption:   req.Caption,
+		Position:  req.Position,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.theaterPhotoRepo.CreatePhoto(tx, photo)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return photo, nil
+}
+
+func (s *theaterService) DeleteTheaterPhoto(theaterId, photoId uuid.UUID) *errors.ApiError {
+	if _, apiErr := s.GetTheater(theaterId, false, false); apiErr != nil {
+		return apiErr
+	}
+
+	photo, err := s.theaterPhotoRepo.GetPhoto(filters.TheaterPhotoFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: photoId},
+		TheaterID: &filters.Condition{Operator: filters.OpEqual, Value: theaterId},
+	})
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if photo == nil {
+		return errors.NotFoundError("photo not found")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.theaterPhotoRepo.DeletePhoto(tx, photo)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *theaterService) getTheaterByName(name string) (*models.Theater, *errors.ApiError) {
+	t, err := s.theaterRepo.GetTheater(filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		Name:      &filters.Condition{Operator: filters.OpEqual, Value: name},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}, false, false)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return t, nil
+}
+
+// hasUpcomingShows locks the theater row first, show creation locks it too so no show is added until the transaction ends.
+func (s *theaterService) hasUpcomingShows(tx *gorm.DB, theaterId uuid.UUID) (bool, error) {
+	if _, err := s.theaterRepo.LockTheater(tx, theaterId); err != nil {
+		return false, err
+	}
+
+	return s.showRepo.HasUpcomingShows(tx, theaterId)
+}
+
+func (s *theaterService) getNearbyOrigin(origin payloads.NearbyTheatersOrigin) (*models.UserLocation, *errors.ApiError) {
+	if origin.Latitude != nil && origin.Longitude != nil {
+		return &models.UserLocation{Latitude: *origin.Latitude, Longitude: *origin.Longitude}, nil
+	}
+
+	if origin.CityID != nil {
+		c, apiErr := s.getCityById(*origin.CityID)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if c.Latitude == nil || c.Longitude == nil {
+			return nil, errors.BadRequestError("city has no coordinates")
+		}
+
+		return &models.UserLocation{Latitude: *c.Latitude, Longitude: *c.Longitude}, nil
+	}
+
+	return s.userLocationService.GetUserLocation(origin.ClientIP)
+}
+
 func (s *theaterService) getCityById(cityId uuid.UUID) (*models.City, *errors.ApiError) {
 	cityFilter := filters.CityFilter{
 		Filter: &filters.SingleFilter{Logic: filters.And},
@@ -262,7 +792,21 @@ func (s *theaterService) getCityById(cityId uuid.UUID) (*models.City, *errors.Ap
 	return c, nil
 }
 
-func (s *theaterService) buildGetTheatersMeta(limit, offset, count int, includeLocation bool) *models.ResponseMeta {
+func (s *theaterService) buildTheaterSearchFilter(search payloads.TheaterSearchFilter) filters.TheaterFilter {
+	filter := filters.TheaterFilter{
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	if search.CityID != nil {
+		filter.CityID = &filters.Condition{Operator: filters.OpEqual, Value: *search.CityID}
+	}
+	for _, amenity := range search.Amenities {
+		filter.Amenities = append(filter.Amenities, &filters.Condition{Operator: filters.OpEqual, Value: amenity})
+	}
+
+	return filter
+}
+
+func (s *theaterService) buildGetTheatersMeta(search payloads.TheaterSearchFilter, limit, offset, count int, includeLocation, includeDetails bool) *models.ResponseMeta {
 	var prevUrl, nextUrl *string
 
 	if offset > 0 {
@@ -270,12 +814,12 @@ func (s *theaterService) buildGetTheatersMeta(limit, offset, count int, includeL
 		if prevOffset < 0 {
 			prevOffset = 0
 		}
-		prevUrl = s.buildPaginationURL(limit, prevOffset, includeLocation)
+		prevUrl = s.buildPaginationURL(search, limit, prevOffset, includeLocation, includeDetails)
 	}
 
 	if offset+limit < count {
 		nextUrlOffset := offset + limit
-		nextUrl = s.buildPaginationURL(limit, nextUrlOffset, includeLocation)
+		nextUrl = s.buildPaginationURL(search, limit, nextUrlOffset, includeLocation, includeDetails)
 	}
 
 	return &models.ResponseMeta{
@@ -287,7 +831,17 @@ func (s *theaterService) buildGetTheatersMeta(limit, offset, count int, includeL
 	}
 }
 
-func (s *theaterService) buildPaginationURL(limit, offset int, includeLocation bool) *string {
+func (s *theaterService) buildPaginationURL(search payloads.TheaterSearchFilter, limit, offset int, includeLocation, includeDetails bool) *string {
 	url := fmt.Sprintf("/theaters?%s=%d&%s=%d&%s=%v", constants.Limit, limit, constants.Offset, offset, constants.IncludeTheaterLocation, includeLocation)
+	if includeDetails {
+		url += fmt.Sprintf("&%s=%v", constants.IncludeTheaterDetails, includeDetails)
+	}
+	if search.CityID != nil {
+		url += fmt.Sprintf("&%s=%s", constants.CityID, search.CityID)
+	}
+	for _, amenity := range search.Amenities {
+		url += fmt.Sprintf("&%s=%s", constants.Amenity, amenity)
+	}
+
 	return &url
 }
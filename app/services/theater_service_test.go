@@ -25,18 +25,19 @@ func TestTheaterService_GetTheater(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockTheaterRepository(ctrl)
-	service := NewTheaterService(nil, nil, repo, nil, nil, nil, nil)
+	service := NewTheaterService(nil, nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	theater := utils.GenerateTheater()
 	filter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(theater, nil).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(theater, nil).Times(1)
 
-		result, err := service.GetTheater(theater.ID, false)
+		result, err := service.GetTheater(theater.ID, false, false)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -44,9 +45,9 @@ func TestTheaterService_GetTheater(t *testing.T) {
 	})
 
 	t.Run("theater not found", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(nil, nil).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(nil, nil).Times(1)
 
-		result, err := service.GetTheater(theater.ID, false)
+		result, err := service.GetTheater(theater.ID, false, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -55,9 +56,9 @@ func TestTheaterService_GetTheater(t *testing.T) {
 	})
 
 	t.Run("error getting theater", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(nil, errors.New("error getting theater")).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(nil, errors.New("error getting theater")).Times(1)
 
-		result, err := service.GetTheater(theater.ID, false)
+		result, err := service.GetTheater(theater.ID, false, false)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -71,7 +72,7 @@ func TestTheaterService_GetTheaters(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockTheaterRepository(ctrl)
-	service := NewTheaterService(nil, nil, repo, nil, nil, nil, nil)
+	service := NewTheaterService(nil, nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	theaters := utils.GenerateTheaters(3)
 
@@ -83,16 +84,18 @@ func TestTheaterService_GetTheaters(t *testing.T) {
 			Limit:  &limit,
 			Offset: &offset,
 		},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 	countFilter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
+		Filter:    &filters.SingleFilter{},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		repo.EXPECT().GetTheaters(getFilter, includeLocation).Return(theaters, nil).Times(1)
+		repo.EXPECT().GetTheaters(getFilter, includeLocation, false).Return(theaters, nil).Times(1)
 		repo.EXPECT().GetNumbersOfTheater(countFilter).Return(len(theaters), nil).Times(1)
 
-		result, meta, err := service.GetTheaters(limit, offset, includeLocation)
+		result, meta, err := service.GetTheaters(payloads.TheaterSearchFilter{}, limit, offset, includeLocation, false)
 
 		assert.NotNil(t, result)
 		assert.NotNil(t, meta)
@@ -110,9 +113,9 @@ func TestTheaterService_GetTheaters(t *testing.T) {
 	})
 
 	t.Run("error getting theaters", func(t *testing.T) {
-		repo.EXPECT().GetTheaters(getFilter, includeLocation).Return(nil, errors.New("error getting theaters")).Times(1)
+		repo.EXPECT().GetTheaters(getFilter, includeLocation, false).Return(nil, errors.New("error getting theaters")).Times(1)
 
-		result, meta, err := service.GetTheaters(limit, offset, includeLocation)
+		result, meta, err := service.GetTheaters(payloads.TheaterSearchFilter{}, limit, offset, includeLocation, false)
 
 		assert.Nil(t, result)
 		assert.Nil(t, meta)
@@ -122,10 +125,10 @@ func TestTheaterService_GetTheaters(t *testing.T) {
 	})
 
 	t.Run("error counting theaters", func(t *testing.T) {
-		repo.EXPECT().GetTheaters(getFilter, includeLocation).Return(theaters, nil).Times(1)
+		repo.EXPECT().GetTheaters(getFilter, includeLocation, false).Return(theaters, nil).Times(1)
 		repo.EXPECT().GetNumbersOfTheater(countFilter).Return(0, errors.New("error counting theaters")).Times(1)
 
-		result, meta, err := service.GetTheaters(limit, offset, includeLocation)
+		result, meta, err := service.GetTheaters(payloads.TheaterSearchFilter{}, limit, offset, includeLocation, false)
 
 		assert.Nil(t, result)
 		assert.Nil(t, meta)
@@ -133,6 +136,37 @@ func TestTheaterService_GetTheaters(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error counting theaters", err.Error())
 	})
+
+	t.Run("search by city and amenities", func(t *testing.T) {
+		cityId := uuid.New()
+		search := payloads.TheaterSearchFilter{
+			CityID:    &cityId,
+			Amenities: []constants.TheaterAmenity{constants.Imax, constants.Parking},
+		}
+		searchGetFilter := getFilter
+		searchGetFilter.CityID = &filters.Condition{Operator: filters.OpEqual, Value: cityId}
+		searchGetFilter.Amenities = []*filters.Condition{
+			{Operator: filters.OpEqual, Value: constants.Imax},
+			{Operator: filters.OpEqual, Value: constants.Parking},
+		}
+		searchCountFilter := searchGetFilter
+		searchCountFilter.Filter = &filters.SingleFilter{}
+
+		repo.EXPECT().GetTheaters(searchGetFilter, includeLocation, true).Return(theaters, nil).Times(1)
+		repo.EXPECT().GetNumbersOfTheater(searchCountFilter).Return(len(theaters), nil).Times(1)
+
+		result, meta, err := service.GetTheaters(search, limit, offset, includeLocation, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, theaters, result)
+
+		nextUrl := fmt.Sprintf(
+			"/theaters?%s=%d&%s=%d&%s=%v&%s=true&%s=%s&%s=%s&%s=%s",
+			constants.Limit, limit, constants.Offset, offset+limit, constants.IncludeTheaterLocation, includeLocation,
+			constants.IncludeTheaterDetails, constants.CityID, cityId, constants.Amenity, constants.Imax, constants.Amenity, constants.Parking,
+		)
+		assert.Equal(t, &nextUrl, meta.NextUrl)
+	})
 }
 
 func TestTheaterService_GetNearbyTheaters(t *testing.T) {
@@ -140,49 +174,100 @@ func TestTheaterService_GetNearbyTheaters(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockTheaterRepository(ctrl)
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
 	userLocService := mock_services.NewMockUserLocationService(ctrl)
-	service := NewTheaterService(nil, nil, repo, nil, nil, nil, userLocService)
+	service := NewTheaterService(nil, nil, repo, nil, nil, nil, nil, nil, nil, nil, cityRepo, userLocService, nil)
 
 	userLoc := &models.UserLocation{
 		Latitude:  20.0,
 		Longitude: 30.0,
 	}
+	clientIp := "203.0.113.10"
+	origin := payloads.NearbyTheatersOrigin{ClientIP: clientIp}
 	distance := 10.0
 
-	t.Run("success", func(t *testing.T) {
-		getTheaterResults := make([]*payloads.GetTheaterWithLocationResult, 3)
-		for i := 0; i < len(getTheaterResults); i++ {
-			theater := utils.GenerateTheater()
-			location := utils.GenerateTheaterLocation()
-			getTheaterResults[i] = &payloads.GetTheaterWithLocationResult{
-				Id:         theater.ID,
-				Name:       theater.Name,
-				LocationId: location.ID,
-				CityId:     location.CityID,
-				Address:    location.Address,
-				PostalCode: location.PostalCode,
-				Latitude:   location.Latitude,
-				Longitude:  location.Longitude,
-			}
+	getTheaterResults := make([]*payloads.GetTheaterWithLocationResult, 3)
+	for i := 0; i < len(getTheaterResults); i++ {
+		theater := utils.GenerateTheater()
+		location := utils.GenerateTheaterLocation()
+		getTheaterResults[i] = &payloads.GetTheaterWithLocationResult{
+			Id:         theater.ID,
+			Name:       theater.Name,
+			LocationId: location.ID,
+			CityId:     location.CityID,
+			Address:    location.Address,
+			PostalCode: location.PostalCode,
+			Latitude:   location.Latitude,
+			Longitude:  location.Longitude,
+			Distance:   float64(i),
 		}
+	}
 
-		userLocService.EXPECT().GetCurrentUserLocation().Return(userLoc, nil).Times(1)
-		repo.EXPECT().GetNearbyTheatersWithLocations(userLoc.Latitude, userLoc.Longitude, distance).Return(getTheaterResults, nil).Times(1)
+	t.Run("success", func(t *testing.T) {
+		userLocService.EXPECT().GetUserLocation(clientIp).Return(userLoc, nil).Times(1)
+		repo.EXPECT().GetNearbyTheatersWithLocations(userLoc.Latitude, userLoc.Longitude, distance, 10, 0).Return(getTheaterResults, nil).Times(1)
 
-		theaters, err := service.GetNearbyTheaters(distance)
+		theaters, err := service.GetNearbyTheaters(origin, distance, 10, 0)
 
 		assert.NotNil(t, theaters)
 		assert.Nil(t, err)
 		for i, theater := range theaters {
 			assert.Equal(t, getTheaterResults[i].Id, theater.ID)
 			assert.Equal(t, getTheaterResults[i].LocationId, theater.Location.ID)
+			assert.Equal(t, getTheaterResults[i].Distance, *theater.Distance)
 		}
 	})
 
+	t.Run("with coordinates", func(t *testing.T) {
+		lat, lon := 10.8231, 106.6297
+		repo.EXPECT().GetNearbyTheatersWithLocations(lat, lon, distance, 10, 0).Return(getTheaterResults, nil).Times(1)
+
+		theaters, err := service.GetNearbyTheaters(payloads.NearbyTheatersOrigin{Latitude: &lat, Longitude: &lon, ClientIP: clientIp}, distance, 10, 0)
+
+		assert.Nil(t, err)
+		assert.Len(t, theaters, len(getTheaterResults))
+	})
+
+	t.Run("with city", func(t *testing.T) {
+		city := utils.GenerateCity()
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+		repo.EXPECT().GetNearbyTheatersWithLocations(*city.Latitude, *city.Longitude, distance, 10, 0).Return(getTheaterResults, nil).Times(1)
+
+		theaters, err := service.GetNearbyTheaters(payloads.NearbyTheatersOrigin{CityID: &city.ID, ClientIP: clientIp}, distance, 10, 0)
+
+		assert.Nil(t, err)
+		assert.Len(t, theaters, len(getTheaterResults))
+	})
+
+	t.Run("city not found", func(t *testing.T) {
+		cityID := uuid.New()
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(nil, nil).Times(1)
+
+		theaters, err := service.GetNearbyTheaters(payloads.NearbyTheatersOrigin{CityID: &cityID}, distance, 10, 0)
+
+		assert.Nil(t, theaters)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "invalid city id", err.Error())
+	})
+
+	t.Run("city without coordinates", func(t *testing.T) {
+		city := utils.GenerateCity()
+		city.Latitude, city.Longitude = nil, nil
+		cityRepo.EXPECT().GetCity(gomock.Any()).Return(city, nil).Times(1)
+
+		theaters, err := service.GetNearbyTheaters(payloads.NearbyTheatersOrigin{CityID: &city.ID}, distance, 10, 0)
+
+		assert.Nil(t, theaters)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "city has no coordinates", err.Error())
+	})
+
 	t.Run("error getting current user location", func(t *testing.T) {
-		userLocService.EXPECT().GetCurrentUserLocation().Return(nil, apiError.InternalServerError("error getting current user location")).Times(1)
+		userLocService.EXPECT().GetUserLocation(clientIp).Return(nil, apiError.InternalServerError("error getting current user location")).Times(1)
 
-		theaters, err := service.GetNearbyTheaters(distance)
+		theaters, err := service.GetNearbyTheaters(origin, distance, 10, 0)
 
 		assert.Nil(t, theaters)
 		assert.NotNil(t, err)
@@ -191,10 +276,10 @@ func TestTheaterService_GetNearbyTheaters(t *testing.T) {
 	})
 
 	t.Run("error getting theaters", func(t *testing.T) {
-		userLocService.EXPECT().GetCurrentUserLocation().Return(userLoc, nil).Times(1)
-		repo.EXPECT().GetNearbyTheatersWithLocations(userLoc.Latitude, userLoc.Longitude, distance).Return(nil, errors.New("error getting theaters")).Times(1)
+		userLocService.EXPECT().GetUserLocation(clientIp).Return(userLoc, nil).Times(1)
+		repo.EXPECT().GetNearbyTheatersWithLocations(userLoc.Latitude, userLoc.Longitude, distance, 10, 0).Return(nil, errors.New("error getting theaters")).Times(1)
 
-		theaters, err := service.GetNearbyTheaters(distance)
+		theaters, err := service.GetNearbyTheaters(origin, distance, 10, 0)
 
 		assert.Nil(t, theaters)
 		assert.NotNil(t, err)
@@ -209,37 +294,49 @@ func TestTheaterService_CreateTheater(t *testing.T) {
 
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	repo := mock_repositories.NewMockTheaterRepository(ctrl)
-	service := NewTheaterService(nil, transaction, repo, nil, nil, nil, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
 
 	theater := utils.GenerateTheater()
 	req := payloads.CreateTheaterRequest{
 		Name: theater.Name,
 	}
 	filter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		Name:   &filters.Condition{Operator: filters.OpEqual, Value: &req.Name},
+		Filter:    &filters.SingleFilter{},
+		Name:      &filters.Condition{Operator: filters.OpEqual, Value: req.Name},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(nil, nil).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
 		repo.EXPECT().CreateTheater(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterCreated, e.Type)
+				assert.Nil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.CreateTheater(req)
+		result, err := service.CreateTheater(req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
 		assert.Equal(t, req.Name, result.Name)
+		assert.Nil(t, result.TimeZone)
 	})
 
 	t.Run("duplicate theater name", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(theater, nil).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(theater, nil).Times(1)
 
-		result, err := service.CreateTheater(req)
+		result, err := service.CreateTheater(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -248,9 +345,9 @@ func TestTheaterService_CreateTheater(t *testing.T) {
 	})
 
 	t.Run("error getting theater", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(nil, errors.New("error getting theater")).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(nil, errors.New("error getting theater")).Times(1)
 
-		result, err := service.CreateTheater(req)
+		result, err := service.CreateTheater(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -259,7 +356,7 @@ func TestTheaterService_CreateTheater(t *testing.T) {
 	})
 
 	t.Run("error creating theater", func(t *testing.T) {
-		repo.EXPECT().GetTheater(filter, false).Return(nil, nil).Times(1)
+		repo.EXPECT().GetTheater(filter, false, false).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -267,7 +364,7 @@ func TestTheaterService_CreateTheater(t *testing.T) {
 		).Times(1)
 		repo.EXPECT().CreateTheater(gomock.Any(), gomock.Any()).Return(errors.New("error creating theater")).Times(1)
 
-		result, err := service.CreateTheater(req)
+		result, err := service.CreateTheater(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -276,6 +373,224 @@ func TestTheaterService_CreateTheater(t *testing.T) {
 	})
 }
 
+func TestTheaterService_UpdateTheater(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	repo := mock_repositories.NewMockTheaterRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	theater := utils.GenerateTheater()
+	req := payloads.UpdateTheaterRequest{
+		Name: utils.GenerateTheater().Name,
+	}
+	idFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	nameFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		Name:      &filters.Condition{Operator: filters.OpEqual, Value: req.Name},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo.EXPECT().GetTheater(idFilter, true, false).Return(theater, nil).Times(1)
+		repo.EXPECT().GetTheater(nameFilter, false, false).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().UpdateTheater(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterUpdated, e.Type)
+				assert.Equal(t, theater.Name, e.Before.Name)
+				assert.Equal(t, req.Name, e.After.Name)
+				return nil
+			},
+		).Times(1)
+
+		result, err := service.UpdateTheater(theater.ID, req, requestID)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, theater.ID, result.ID)
+		assert.Equal(t, req.Name, result.Name)
+	})
+
+	t.Run("theater not found", func(t *testing.T) {
+		repo.EXPECT().GetTheater(idFilter, true, false).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateTheater(theater.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "theater not found", err.Error())
+	})
+
+	t.Run("duplicate theater name", func(t *testing.T) {
+		repo.EXPECT().GetTheater(idFilter, true, false).Return(theater, nil).Times(1)
+		repo.EXPECT().GetTheater(nameFilter, false, false).Return(utils.GenerateTheater(), nil).Times(1)
+
+		result, err := service.UpdateTheater(theater.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate theater name", err.Error())
+	})
+
+	t.Run("error updating theater", func(t *testing.T) {
+		repo.EXPECT().GetTheater(idFilter, true, false).Return(theater, nil).Times(1)
+		repo.EXPECT().GetTheater(nameFilter, false, false).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		repo.EXPECT().UpdateTheater(gomock.Any(), gomock.Any()).Return(errors.New("error updating theater")).Times(1)
+
+		result, err := service.UpdateTheater(theater.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating theater", err.Error())
+	})
+}
+
+func TestTheaterService_DeleteTheater(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	repo := mock_repositories.NewMockTheaterRepository(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, repo, nil, nil, nil, nil, seatRepo, nil, showRepo, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	theater := utils.GenerateTheater()
+	filter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	executeInTransaction := func() {
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		executeInTransaction()
+		repo.EXPECT().LockTheater(gomock.Any(), theater.ID).Return(theater, nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), theater.ID).Return(false, nil).Times(1)
+		repo.EXPECT().DeleteTheater(gomock.Any(), theater).Return(nil).Times(1)
+		seatRepo.EXPECT().DeleteSeatsOfTheater(gomock.Any(), theater.ID).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterDeleted, e.Type)
+				assert.Equal(t, theater, e.Before)
+				assert.Nil(t, e.After)
+				return nil
+			},
+		).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("theater not found", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(nil, nil).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "theater not found", err.Error())
+	})
+
+	t.Run("theater has upcoming shows", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		executeInTransaction()
+		repo.EXPECT().LockTheater(gomock.Any(), theater.ID).Return(theater, nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), theater.ID).Return(true, nil).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "theater has upcoming shows", err.Error())
+	})
+
+	t.Run("error locking theater", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		executeInTransaction()
+		repo.EXPECT().LockTheater(gomock.Any(), theater.ID).Return(nil, errors.New("error locking theater")).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error locking theater", err.Error())
+	})
+
+	t.Run("error checking upcoming shows", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		executeInTransaction()
+		repo.EXPECT().LockTheater(gomock.Any(), theater.ID).Return(theater, nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), theater.ID).Return(false, errors.New("error getting shows")).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting shows", err.Error())
+	})
+
+	t.Run("error deleting theater", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		executeInTransaction()
+		repo.EXPECT().LockTheater(gomock.Any(), theater.ID).Return(theater, nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), theater.ID).Return(false, nil).Times(1)
+		repo.EXPECT().DeleteTheater(gomock.Any(), theater).Return(errors.New("error deleting theater")).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting theater", err.Error())
+	})
+
+	t.Run("error deleting seats", func(t *testing.T) {
+		repo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		executeInTransaction()
+		repo.EXPECT().LockTheater(gomock.Any(), theater.ID).Return(theater, nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), theater.ID).Return(false, nil).Times(1)
+		repo.EXPECT().DeleteTheater(gomock.Any(), theater).Return(nil).Times(1)
+		seatRepo.EXPECT().DeleteSeatsOfTheater(gomock.Any(), theater.ID).Return(errors.New("error deleting seats")).Times(1)
+
+		err := service.DeleteTheater(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting seats", err.Error())
+	})
+}
+
 func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -284,7 +599,9 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
 	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
 	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
-	service := NewTheaterService(nil, transaction, theaterRepo, theaterLocationRepo, nil, cityRepo, nil)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, theaterLocationRepo, nil, nil, nil, nil, nil, nil, cityRepo, nil, notificationRepo)
+	requestID := uuid.New()
 
 	theater := utils.GenerateTheater()
 	city := utils.GenerateCity()
@@ -300,12 +617,13 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.CityID},
 	}
 	theaterFilter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(theater, nil).Times(1)
 		cityRepo.EXPECT().GetCity(cityFilter).Return(city, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -313,8 +631,16 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 			},
 		).Times(1)
 		theaterLocationRepo.EXPECT().CreateTheaterLocation(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterUpdated, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -326,9 +652,9 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("theater not found", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(nil, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(nil, nil).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -337,9 +663,9 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("error getting theater", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(nil, errors.New("error getting theater")).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(nil, errors.New("error getting theater")).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -350,9 +676,9 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	t.Run("duplicate theater location", func(t *testing.T) {
 		th := utils.GenerateTheater()
 		th.Location = utils.GenerateTheaterLocation()
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(th, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(th, nil).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -361,10 +687,10 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("city not found", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(theater, nil).Times(1)
 		cityRepo.EXPECT().GetCity(cityFilter).Return(nil, nil).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -373,10 +699,10 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("error getting city", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(theater, nil).Times(1)
 		cityRepo.EXPECT().GetCity(cityFilter).Return(nil, errors.New("error getting city")).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -385,7 +711,7 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("error creating location", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(theater, nil).Times(1)
 		cityRepo.EXPECT().GetCity(cityFilter).Return(city, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -394,7 +720,7 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 		).Times(1)
 		theaterLocationRepo.EXPECT().CreateTheaterLocation(gomock.Any(), gomock.Any()).Return(errors.New("error creating location")).Times(1)
 
-		result, err := service.CreateTheaterLocation(theater.ID, req)
+		result, err := service.CreateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -403,6 +729,64 @@ func TestTheaterService_CreateTheaterLocation(t *testing.T) {
 	})
 }
 
+func TestTheaterService_GetSeats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	service := NewTheaterService(nil, nil, theaterRepo, nil, nil, nil, nil, seatRepo, nil, nil, nil, nil, nil)
+
+	theater := utils.GenerateTheater()
+	seats := []*models.Seat{utils.GenerateSeat(), utils.GenerateSeat()}
+	theaterFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	seatFilter := filters.SeatFilter{
+		Filter: &filters.MultiFilter{Sort: []filters.SortOption{
+			{Field: "row", Direction: filters.Asc},
+			{Field: "number", Direction: filters.Asc},
+		}},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeats(seatFilter).Return(seats, nil).Times(1)
+
+		result, err := service.GetSeats(theater.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, seats, result)
+	})
+
+	t.Run("theater not found", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(nil, nil).Times(1)
+
+		result, err := service.GetSeats(theater.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "theater not found")
+	})
+
+	t.Run("error getting seats", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeats(seatFilter).Return(nil, errors.New("error getting seats")).Times(1)
+
+		result, err := service.GetSeats(theater.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting seats")
+	})
+}
+
 func TestTheaterService_CreateSeat(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -411,7 +795,7 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
 	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
 
-	service := NewTheaterService(nil, transaction, theaterRepo, nil, seatRepo, nil, nil)
+	service := NewTheaterService(nil, transaction, theaterRepo, nil, nil, nil, nil, seatRepo, nil, nil, nil, nil, nil)
 
 	theater := utils.GenerateTheater()
 	seat := utils.GenerateSeat()
@@ -422,18 +806,20 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 		Type:   seat.Type,
 	}
 	theaterFilter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 	seatFilter := filters.SeatFilter{
 		Filter:    &filters.SingleFilter{},
 		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
 		Row:       &filters.Condition{Operator: filters.OpEqual, Value: req.Row},
 		Number:    &filters.Condition{Operator: filters.OpEqual, Value: req.Number},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
 		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -453,7 +839,7 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 	})
 
 	t.Run("theater not found", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(nil, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(nil, nil).Times(1)
 
 		result, err := service.CreateSeat(theater.ID, req)
 
@@ -464,7 +850,7 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 	})
 
 	t.Run("error getting theater", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(nil, errors.New("error getting theater")).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(nil, errors.New("error getting theater")).Times(1)
 
 		result, err := service.CreateSeat(theater.ID, req)
 
@@ -475,7 +861,7 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 	})
 
 	t.Run("duplicate seat", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
 		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
 
 		result, err := service.CreateSeat(theater.ID, req)
@@ -487,7 +873,7 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 	})
 
 	t.Run("error getting seat", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
 		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, errors.New("error getting seat")).Times(1)
 
 		result, err := service.CreateSeat(theater.ID, req)
@@ -499,7 +885,7 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 	})
 
 	t.Run("error creating seat", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, false).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
 		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -515,64 +901,636 @@ func TestTheaterService_CreateSeat(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.EqualError(t, err, "error creating seat")
 	})
-}
 
-func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	t.Run("paired number on non couple seat", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
 
-	transaction := mock_transaction.NewMockTransactionManager(ctrl)
-	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
-	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
-	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
-	service := NewTheaterService(nil, transaction, theaterRepo, theaterLocationRepo, nil, cityRepo, nil)
+		pairedReq := req
+		pairedReq.PairedNumber = utils.GetPointerOf(req.Number + 1)
+		result, err := service.CreateSeat(theater.ID, pairedReq)
 
-	theater := utils.GenerateTheater()
-	location := utils.GenerateTheaterLocation()
-	location.TheaterID = &theater.ID
-	theater.Location = location
-	city := utils.GenerateCity()
-	req := payloads.UpdateTheaterLocationRequest{
-		CityID:     uuid.New(),
-		Address:    "address",
-		PostalCode: "700000",
-		Latitude:   50.0,
-		Longitude:  100.0,
-	}
-	cityFilter := filters.CityFilter{
-		Filter: &filters.SingleFilter{Logic: filters.And},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.CityID},
-	}
-	theaterFilter := filters.TheaterFilter{
-		Filter: &filters.SingleFilter{},
-		ID:     &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "only couple seats can be paired")
+	})
+
+	companionReq := req
+	companionReq.Type = constants.Companion
+	wheelchairFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		Row:       &filters.Condition{Operator: filters.OpEqual, Value: req.Row},
+		Number:    &filters.Condition{Operator: filters.OpIn, Value: []int{req.Number - 1, req.Number + 1}},
+		Type:      &filters.Condition{Operator: filters.OpEqual, Value: constants.Wheelchair},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	}
 
-	t.Run("success", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(theater, nil).Times(1)
-		cityRepo.EXPECT().GetCity(cityFilter).Return(city, nil).Times(1)
+	t.Run("success companion seat", func(t *testing.T) {
+		wheelchair := utils.GenerateSeat()
+		wheelchair.Type = constants.Wheelchair
+
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(wheelchairFilter).Return(wheelchair, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
-		theaterLocationRepo.EXPECT().UpdateTheaterLocation(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		seatRepo.EXPECT().CreateSeat(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-		l, err := service.UpdateTheaterLocation(theater.ID, req)
+		result, err := service.CreateSeat(theater.ID, companionReq)
 
-		assert.NotNil(t, l)
+		assert.NotNil(t, result)
 		assert.Nil(t, err)
-		assert.Equal(t, req.CityID, l.CityID)
-		assert.Equal(t, req.Address, l.Address)
-		assert.Equal(t, req.PostalCode, l.PostalCode)
-		assert.Equal(t, req.Latitude, l.Latitude)
+		assert.Equal(t, constants.Companion, result.Type)
+		assert.Nil(t, result.PairedSeatId)
+	})
+
+	t.Run("companion seat without wheelchair space", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(wheelchairFilter).Return(nil, nil).Times(1)
+
+		result, err := service.CreateSeat(theater.ID, companionReq)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "companion seat must be next to a wheelchair space")
+	})
+
+	t.Run("error getting wheelchair space", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(wheelchairFilter).Return(nil, errors.New("error getting seat")).Times(1)
+
+		result, err := service.CreateSeat(theater.ID, companionReq)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting seat")
+	})
+
+	coupleReq := req
+	coupleReq.Type = constants.Couple
+	coupleReq.PairedNumber = utils.GetPointerOf(req.Number + 1)
+	pairedSeatFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		Row:       &filters.Condition{Operator: filters.OpEqual, Value: req.Row},
+		Number:    &filters.Condition{Operator: filters.OpEqual, Value: req.Number + 1},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success couple seat", func(t *testing.T) {
+		var created []*models.Seat
+
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(pairedSeatFilter).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatRepo.EXPECT().CreateSeatPair(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, seat, pair *models.Seat) error {
+				created = append(created, seat, pair)
+				return nil
+			},
+		).Times(1)
+
+		result, err := service.CreateSeat(theater.ID, coupleReq)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Len(t, created, 2)
+		assert.Equal(t, result, created[0])
+		assert.Equal(t, constants.Couple, created[1].Type)
+		assert.Equal(t, req.Number+1, created[1].Number)
+		assert.Equal(t, req.Row, created[1].Row)
+	})
+
+	t.Run("couple seat without paired number", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		unpairedReq := coupleReq
+		unpairedReq.PairedNumber = nil
+		result, err := service.CreateSeat(theater.ID, unpairedReq)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "couple seat requires a paired number")
+	})
+
+	t.Run("couple seat not adjacent", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		farReq := coupleReq
+		farReq.PairedNumber = utils.GetPointerOf(req.Number + 2)
+		result, err := service.CreateSeat(theater.ID, farReq)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "paired seat must be next to the couple seat")
+	})
+
+	t.Run("duplicate paired seat", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(pairedSeatFilter).Return(utils.GenerateSeat(), nil).Times(1)
+
+		result, err := service.CreateSeat(theater.ID, coupleReq)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "duplicate paired seat for this theater")
+	})
+
+	t.Run("error creating paired seat", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(pairedSeatFilter).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatRepo.EXPECT().CreateSeatPair(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error creating seat")).Times(1)
+
+		result, err := service.CreateSeat(theater.ID, coupleReq)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error creating seat")
+	})
+}
+
+func TestTheaterService_UpdateSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	service := NewTheaterService(nil, transaction, nil, nil, nil, nil, nil, seatRepo, nil, nil, nil, nil, nil)
+
+	seat := utils.GenerateSeat()
+	req := payloads.UpdateSeatRequest{Type: constants.Vip}
+	seatFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seat.Id},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: *seat.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	companionFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: seat.TheaterId},
+		Row:       &filters.Condition{Operator: filters.OpEqual, Value: seat.Row},
+		Number:    &filters.Condition{Operator: filters.OpIn, Value: []int{seat.Number - 1, seat.Number + 1}},
+		Type:      &filters.Condition{Operator: filters.OpEqual, Value: constants.Companion},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		regular := *seat
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(&regular, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatRepo.EXPECT().UpdateSeat(gomock.Any(), &regular).Return(nil).Times(1)
+
+		result, err := service.UpdateSeat(*seat.TheaterId, seat.Id, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, req.Type, result.Type)
+	})
+
+	t.Run("seat not found", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateSeat(*seat.TheaterId, seat.Id, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat not found")
+	})
+
+	t.Run("couple seat", func(t *testing.T) {
+		couple := *seat
+		couple.Type = constants.Couple
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(&couple, nil).Times(1)
+
+		result, err := service.UpdateSeat(*seat.TheaterId, seat.Id, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "couple seats can not change type")
+	})
+
+	t.Run("wheelchair space with companion", func(t *testing.T) {
+		wheelchair := *seat
+		wheelchair.Type = constants.Wheelchair
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(&wheelchair, nil).Times(1)
+		seatRepo.EXPECT().GetSeat(companionFilter).Return(utils.GenerateSeat(), nil).Times(1)
+
+		result, err := service.UpdateSeat(*seat.TheaterId, seat.Id, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "wheelchair space has an adjacent companion seat")
+	})
+
+	t.Run("error updating seat", func(t *testing.T) {
+		regular := *seat
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(&regular, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatRepo.EXPECT().UpdateSeat(gomock.Any(), &regular).Return(errors.New("error updating seat")).Times(1)
+
+		result, err := service.UpdateSeat(*seat.TheaterId, seat.Id, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error updating seat")
+	})
+}
+
+func TestTheaterService_DeleteSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	showRepo := mock_repositories.NewMockShowRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, nil, nil, nil, nil, seatRepo, nil, showRepo, nil, nil, nil)
+
+	seat := utils.GenerateSeat()
+	seatFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seat.Id},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: *seat.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), *seat.TheaterId).Return(utils.GenerateTheater(), nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), *seat.TheaterId).Return(false, nil).Times(1)
+		seatRepo.EXPECT().DeleteSeat(gomock.Any(), seat).Return(nil).Times(1)
+
+		err := service.DeleteSeat(*seat.TheaterId, seat.Id)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("success couple seat", func(t *testing.T) {
+		couple := *seat
+		couple.Type = constants.Couple
+		couple.PairedSeatId = utils.GetPointerOf(uuid.New())
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(&couple, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), *seat.TheaterId).Return(utils.GenerateTheater(), nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), *seat.TheaterId).Return(false, nil).Times(1)
+		seatRepo.EXPECT().DeleteSeat(gomock.Any(), &couple).Return(nil).Times(1)
+		seatRepo.EXPECT().DeleteSeat(gomock.Any(), &models.Seat{Id: *couple.PairedSeatId}).Return(nil).Times(1)
+
+		err := service.DeleteSeat(*seat.TheaterId, seat.Id)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("seat not found", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		err := service.DeleteSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat not found")
+	})
+
+	t.Run("theater has upcoming shows", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), *seat.TheaterId).Return(utils.GenerateTheater(), nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), *seat.TheaterId).Return(true, nil).Times(1)
+
+		err := service.DeleteSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "theater has upcoming shows")
+	})
+
+	t.Run("error deleting seat", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterRepo.EXPECT().LockTheater(gomock.Any(), *seat.TheaterId).Return(utils.GenerateTheater(), nil).Times(1)
+		showRepo.EXPECT().HasUpcomingShows(gomock.Any(), *seat.TheaterId).Return(false, nil).Times(1)
+		seatRepo.EXPECT().DeleteSeat(gomock.Any(), seat).Return(errors.New("error deleting seat")).Times(1)
+
+		err := service.DeleteSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error deleting seat")
+	})
+}
+
+func TestTheaterService_BlockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	seatBlockRepo := mock_repositories.NewMockSeatBlockRepository(ctrl)
+
+	service := NewTheaterService(nil, transaction, nil, nil, nil, nil, nil, seatRepo, seatBlockRepo, nil, nil, nil, nil)
+
+	seat := utils.GenerateSeat()
+	userID := uuid.New()
+	req := payloads.BlockSeatRequest{Reason: "broken armrest"}
+	seatFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seat.Id},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: *seat.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatBlockRepo.EXPECT().CreateSeatBlock(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.BlockSeat(*seat.TheaterId, seat.Id, userID, req)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, &seat.Id, result.SeatId)
+		assert.Nil(t, result.ShowId)
+		assert.Equal(t, req.Reason, result.Reason)
+		assert.Equal(t, userID, result.BlockedBy)
+	})
+
+	t.Run("seat not found", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		result, err := service.BlockSeat(*seat.TheaterId, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat not found")
+	})
+
+	t.Run("error getting seat", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, errors.New("error getting seat")).Times(1)
+
+		result, err := service.BlockSeat(*seat.TheaterId, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting seat")
+	})
+
+	t.Run("seat already blocked", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(utils.GenerateSeatBlock(), nil).Times(1)
+
+		result, err := service.BlockSeat(*seat.TheaterId, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "seat is already blocked")
+	})
+
+	t.Run("error getting seat block", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(nil, errors.New("error getting seat block")).Times(1)
+
+		result, err := service.BlockSeat(*seat.TheaterId, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting seat block")
+	})
+
+	t.Run("error creating seat block", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatBlockRepo.EXPECT().CreateSeatBlock(gomock.Any(), gomock.Any()).Return(errors.New("error creating seat block")).Times(1)
+
+		result, err := service.BlockSeat(*seat.TheaterId, seat.Id, userID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error creating seat block")
+	})
+}
+
+func TestTheaterService_UnblockSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	seatRepo := mock_repositories.NewMockSeatRepository(ctrl)
+	seatBlockRepo := mock_repositories.NewMockSeatBlockRepository(ctrl)
+
+	service := NewTheaterService(nil, transaction, nil, nil, nil, nil, nil, seatRepo, seatBlockRepo, nil, nil, nil, nil)
+
+	seat := utils.GenerateSeat()
+	block := utils.GenerateSeatBlock()
+	block.SeatId = &seat.Id
+	seatFilter := filters.SeatFilter{
+		Filter:    &filters.SingleFilter{},
+		Id:        &filters.Condition{Operator: filters.OpEqual, Value: seat.Id},
+		TheaterId: &filters.Condition{Operator: filters.OpEqual, Value: *seat.TheaterId},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(block, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatBlockRepo.EXPECT().DeleteSeatBlock(gomock.Any(), block).Return(nil).Times(1)
+
+		err := service.UnblockSeat(*seat.TheaterId, seat.Id)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("seat not found", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(nil, nil).Times(1)
+
+		err := service.UnblockSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat not found")
+	})
+
+	t.Run("seat block not found", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(nil, nil).Times(1)
+
+		err := service.UnblockSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.EqualError(t, err, "seat block not found")
+	})
+
+	t.Run("error getting seat block", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(nil, errors.New("error getting seat block")).Times(1)
+
+		err := service.UnblockSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error getting seat block")
+	})
+
+	t.Run("error deleting seat block", func(t *testing.T) {
+		seatRepo.EXPECT().GetSeat(seatFilter).Return(seat, nil).Times(1)
+		seatBlockRepo.EXPECT().GetSeatBlock(seat.Id, nil).Return(block, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		seatBlockRepo.EXPECT().DeleteSeatBlock(gomock.Any(), block).Return(errors.New("error deleting seat block")).Times(1)
+
+		err := service.UnblockSeat(*seat.TheaterId, seat.Id)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error deleting seat block")
+	})
+}
+
+func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
+	cityRepo := mock_repositories.NewMockCityRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, theaterLocationRepo, nil, nil, nil, nil, nil, nil, cityRepo, nil, notificationRepo)
+	requestID := uuid.New()
+
+	theater := utils.GenerateTheater()
+	location := utils.GenerateTheaterLocation()
+	location.TheaterID = &theater.ID
+	theater.Location = location
+	city := utils.GenerateCity()
+	req := payloads.UpdateTheaterLocationRequest{
+		CityID:     uuid.New(),
+		Address:    "address",
+		PostalCode: "700000",
+		Latitude:   50.0,
+		Longitude:  100.0,
+	}
+	cityFilter := filters.CityFilter{
+		Filter: &filters.SingleFilter{Logic: filters.And},
+		ID:     &filters.Condition{Operator: filters.OpEqual, Value: req.CityID},
+	}
+	theaterFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(theater, nil).Times(1)
+		cityRepo.EXPECT().GetCity(cityFilter).Return(city, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterLocationRepo.EXPECT().UpdateTheaterLocation(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterUpdated, e.Type)
+				assert.NotNil(t, e.Before)
+				assert.NotNil(t, e.After)
+				return nil
+			},
+		).Times(1)
+
+		l, err := service.UpdateTheaterLocation(theater.ID, req, requestID)
+
+		assert.NotNil(t, l)
+		assert.Nil(t, err)
+		assert.Equal(t, req.CityID, l.CityID)
+		assert.Equal(t, req.Address, l.Address)
+		assert.Equal(t, req.PostalCode, l.PostalCode)
+		assert.Equal(t, req.Latitude, l.Latitude)
 		assert.Equal(t, req.Longitude, l.Longitude)
 	})
 
 	t.Run("theater not found", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(nil, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(nil, nil).Times(1)
 
-		l, err := service.UpdateTheaterLocation(theater.ID, req)
+		l, err := service.UpdateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, l)
 		assert.NotNil(t, err)
@@ -581,9 +1539,9 @@ func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("error getting theater", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(nil, errors.New("error getting theater")).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(nil, errors.New("error getting theater")).Times(1)
 
-		l, err := service.UpdateTheaterLocation(theater.ID, req)
+		l, err := service.UpdateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, l)
 		assert.NotNil(t, err)
@@ -593,9 +1551,9 @@ func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
 
 	t.Run("theater location not found", func(t *testing.T) {
 		th := utils.GenerateTheater()
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(th, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(th, nil).Times(1)
 
-		l, err := service.UpdateTheaterLocation(theater.ID, req)
+		l, err := service.UpdateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, l)
 		assert.NotNil(t, err)
@@ -604,7 +1562,7 @@ func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
 	})
 
 	t.Run("error updating location", func(t *testing.T) {
-		theaterRepo.EXPECT().GetTheater(theaterFilter, true).Return(theater, nil).Times(1)
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, false).Return(theater, nil).Times(1)
 		cityRepo.EXPECT().GetCity(cityFilter).Return(city, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -613,7 +1571,7 @@ func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
 		).Times(1)
 		theaterLocationRepo.EXPECT().UpdateTheaterLocation(gomock.Any(), gomock.Any()).Return(errors.New("error updating location")).Times(1)
 
-		l, err := service.UpdateTheaterLocation(theater.ID, req)
+		l, err := service.UpdateTheaterLocation(theater.ID, req, requestID)
 
 		assert.Nil(t, l)
 		assert.NotNil(t, err)
@@ -621,3 +1579,384 @@ func TestTheaterService_UpdateTheaterLocation(t *testing.T) {
 		assert.Equal(t, "error updating location", err.Error())
 	})
 }
+
+func TestTheaterService_DeleteTheaterLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	theaterLocationRepo := mock_repositories.NewMockTheaterLocationRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, theaterLocationRepo, nil, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	theater := utils.GenerateTheater()
+	theater.Location = utils.GenerateTheaterLocation()
+	theater.Location.TheaterID = &theater.ID
+	filter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterLocationRepo.EXPECT().DeleteTheaterLocation(gomock.Any(), theater.Location).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterUpdated, e.Type)
+				assert.NotNil(t, e.Before.Location)
+				assert.Nil(t, e.After.Location)
+				return nil
+			},
+		).Times(1)
+
+		err := service.DeleteTheaterLocation(theater.ID, requestID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("location not found", func(t *testing.T) {
+		noLocation := *theater
+		noLocation.Location = nil
+		theaterRepo.EXPECT().GetTheater(filter, true, false).Return(&noLocation, nil).Times(1)
+
+		err := service.DeleteTheaterLocation(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.EqualError(t, err, "location not found")
+	})
+
+	t.Run("error deleting location", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(filter, true, false).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		theaterLocationRepo.EXPECT().DeleteTheaterLocation(gomock.Any(), theater.Location).Return(errors.New("error deleting location")).Times(1)
+
+		err := service.DeleteTheaterLocation(theater.ID, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.EqualError(t, err, "error deleting location")
+	})
+}
+
+func TestTheaterService_UpdateTheaterAmenities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	amenityRepo := mock_repositories.NewMockTheaterAmenityRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, nil, amenityRepo, nil, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	theater := utils.GenerateTheater()
+	theaterFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	req := payloads.UpdateTheaterAmenitiesRequest{
+		Amenities: []constants.TheaterAmenity{constants.Imax, constants.WheelchairAccess},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		amenityRepo.EXPECT().UpdateAmenitiesOfTheater(gomock.Any(), theater.ID, req.Amenities).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.TheaterEvent) error {
+				assert.Equal(t, constants.TheaterUpdated, e.Type)
+				assert.Equal(t, 2, len(e.After.Amenities))
+				return nil
+			},
+		).Times(1)
+
+		result, err := service.UpdateTheaterAmenities(theater.ID, req, requestID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*models.TheaterAmenity{
+			{TheaterID: theater.ID, Amenity: constants.Imax},
+			{TheaterID: theater.ID, Amenity: constants.WheelchairAccess},
+		}, result)
+	})
+
+	t.Run("theater not found", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(nil, nil).Times(1)
+
+		result, err := service.UpdateTheaterAmenities(theater.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "theater not found", err.Error())
+	})
+
+	t.Run("error updating amenities", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		amenityRepo.EXPECT().UpdateAmenitiesOfTheater(gomock.Any(), theater.ID, req.Amenities).Return(errors.New("error updating amenities")).Times(1)
+
+		result, err := service.UpdateTheaterAmenities(theater.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating amenities", err.Error())
+	})
+}
+
+func TestTheaterService_UpdateTheaterOpeningHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	openingHourRepo := mock_repositories.NewMockTheaterOpeningHourRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, nil, nil, openingHourRepo, nil, nil, nil, nil, nil, nil, notificationRepo)
+	requestID := uuid.New()
+
+	theater := utils.GenerateTheater()
+	theaterFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	req := payloads.UpdateTheaterOpeningHoursRequest{
+		OpeningHours: []payloads.TheaterOpeningHourRequest{
+			{Weekday: utils.GetPointerOf(1), OpenTime: "09:00", CloseTime: "23:00"},
+			{Weekday: utils.GetPointerOf(6), OpenTime: "10:00", CloseTime: "02:00"},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		openingHourRepo.EXPECT().UpdateOpeningHoursOfTheater(gomock.Any(), theater.ID, gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendTheaterEvent(gomock.Any(), requestID, gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.UpdateTheaterOpeningHours(theater.ID, req, requestID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(result))
+		assert.Equal(t, 6, result[1].Weekday)
+		assert.Equal(t, "10:00:00", result[1].OpenTime)
+		assert.Equal(t, "02:00:00", result[1].CloseTime)
+	})
+
+	t.Run("duplicate weekday", func(t *testing.T) {
+		duplicateReq := payloads.UpdateTheaterOpeningHoursRequest{
+			OpeningHours: []payloads.TheaterOpeningHourRequest{req.OpeningHours[0], req.OpeningHours[0]},
+		}
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(theater, nil).Times(1)
+
+		result, err := service.UpdateTheaterOpeningHours(theater.ID, duplicateReq, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "duplicate opening hours for weekday 1", err.Error())
+	})
+
+	t.Run("same open and close time", func(t *testing.T) {
+		sameTimeReq := payloads.UpdateTheaterOpeningHoursRequest{
+			OpeningHours: []payloads.TheaterOpeningHourRequest{{Weekday: utils.GetPointerOf(0), OpenTime: "10:00", CloseTime: "10:00"}},
+		}
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(theater, nil).Times(1)
+
+		result, err := service.UpdateTheaterOpeningHours(theater.ID, sameTimeReq, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "open time and close time must be different", err.Error())
+	})
+
+	t.Run("error updating opening hours", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, true, true).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		openingHourRepo.EXPECT().UpdateOpeningHoursOfTheater(gomock.Any(), theater.ID, gomock.Any()).Return(errors.New("error updating opening hours")).Times(1)
+
+		result, err := service.UpdateTheaterOpeningHours(theater.ID, req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error updating opening hours", err.Error())
+	})
+}
+
+func TestTheaterService_CreateTheaterPhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	photoRepo := mock_repositories.NewMockTheaterPhotoRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, nil, nil, nil, photoRepo, nil, nil, nil, nil, nil, nil)
+
+	theater := utils.GenerateTheater()
+	theaterFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	req := payloads.CreateTheaterPhotoRequest{
+		Url:      "https://example.com/lobby.jpg",
+		Caption:  utils.GetPointerOf("Lobby"),
+		Position: 1,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		photoRepo.EXPECT().CreatePhoto(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.CreateTheaterPhoto(theater.ID, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, theater.ID, result.TheaterID)
+		assert.Equal(t, req.Url, result.Url)
+		assert.Equal(t, req.Caption, result.Caption)
+		assert.Equal(t, req.Position, result.Position)
+	})
+
+	t.Run("theater not found", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(nil, nil).Times(1)
+
+		result, err := service.CreateTheaterPhoto(theater.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "theater not found", err.Error())
+	})
+
+	t.Run("error creating photo", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		photoRepo.EXPECT().CreatePhoto(gomock.Any(), gomock.Any()).Return(errors.New("error creating photo")).Times(1)
+
+		result, err := service.CreateTheaterPhoto(theater.ID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error creating photo", err.Error())
+	})
+}
+
+func TestTheaterService_DeleteTheaterPhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	theaterRepo := mock_repositories.NewMockTheaterRepository(ctrl)
+	photoRepo := mock_repositories.NewMockTheaterPhotoRepository(ctrl)
+	service := NewTheaterService(nil, transaction, theaterRepo, nil, nil, nil, photoRepo, nil, nil, nil, nil, nil, nil)
+
+	theater := utils.GenerateTheater()
+	photo := utils.GenerateTheaterPhoto()
+	photo.TheaterID = theater.ID
+	theaterFilter := filters.TheaterFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+		IsDeleted: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	photoFilter := filters.TheaterPhotoFilter{
+		Filter:    &filters.SingleFilter{},
+		ID:        &filters.Condition{Operator: filters.OpEqual, Value: photo.ID},
+		TheaterID: &filters.Condition{Operator: filters.OpEqual, Value: theater.ID},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		photoRepo.EXPECT().GetPhoto(photoFilter).Return(photo, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		photoRepo.EXPECT().DeletePhoto(gomock.Any(), photo).Return(nil).Times(1)
+
+		err := service.DeleteTheaterPhoto(theater.ID, photo.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("photo not found", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		photoRepo.EXPECT().GetPhoto(photoFilter).Return(nil, nil).Times(1)
+
+		err := service.DeleteTheaterPhoto(theater.ID, photo.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "photo not found", err.Error())
+	})
+
+	t.Run("error getting photo", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		photoRepo.EXPECT().GetPhoto(photoFilter).Return(nil, errors.New("error getting photo")).Times(1)
+
+		err := service.DeleteTheaterPhoto(theater.ID, photo.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting photo", err.Error())
+	})
+
+	t.Run("error deleting photo", func(t *testing.T) {
+		theaterRepo.EXPECT().GetTheater(theaterFilter, false, false).Return(theater, nil).Times(1)
+		photoRepo.EXPECT().GetPhoto(photoFilter).Return(photo, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		photoRepo.EXPECT().DeletePhoto(gomock.Any(), photo).Return(errors.New("error deleting photo")).Times(1)
+
+		err := service.DeleteTheaterPhoto(theater.ID, photo.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting photo", err.Error())
+	})
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"io"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type UserLocationProvider interface {
+	GetLocation(ip netip.Addr) (*models.UserLocation, error)
+}
+
+func NewIpApiLocationProvider(url string, timeout int) UserLocationProvider {
+	httpClient := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+	return &ipApiLocationProvider{
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+type ipApiLocationProvider struct {
+	url        string
+	httpClient *http.Client
+}
+
+type ipApiLocationResponse struct {
+	Status    string  `json:"status"`
+	Message   string  `json:"message"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+}
+
+func (p *ipApiLocationProvider) GetLocation(ip netip.Addr) (*models.UserLocation, error) {
+	req, err := http.NewRequest(http.MethodGet, p.url+ip.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from location API")
+	}
+
+	var location ipApiLocationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&location); err != nil {
+		return nil, err
+	}
+	if location.Status != "success" {
+		return nil, nil
+	}
+
+	return &models.UserLocation{Latitude: location.Latitude, Longitude: location.Longitude}, nil
+}
+
+// NewGeoIpDatabaseLocationProvider loads MaxMind GeoLite2/GeoIP2 City "Blocks" CSV files
+// (IPv4 and/or IPv6) into memory so lookups never leave the process.
+func NewGeoIpDatabaseLocationProvider(paths ...string) (UserLocationProvider, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("missing geoip database file")
+	}
+
+	var readers []io.Reader
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		readers = append(readers, file)
+	}
+
+	return newGeoIpDatabaseLocationProvider(readers...)
+}
+
+type geoIpNetwork struct {
+	prefix   netip.Prefix
+	location models.UserLocation
+}
+
+type geoIpDatabaseLocationProvider struct {
+	networks []geoIpNetwork
+}
+
+func newGeoIpDatabaseLocationProvider(readers ...io.Reader) (UserLocationProvider, error) {
+	var networks []geoIpNetwork
+	for _, reader := range readers {
+		n, err := readGeoIpNetworks(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, n...)
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].prefix.Addr().Less(networks[j].prefix.Addr())
+	})
+
+	return &geoIpDatabaseLocationProvider{networks: networks}, nil
+}
+
+func (p *geoIpDatabaseLocationProvider) GetLocation(ip netip.Addr) (*models.UserLocation, error) {
+	ip = ip.Unmap()
+
+	// Networks do not overlap, so the only candidate is the last one starting at or before the ip.
+	i := sort.Search(len(p.networks), func(i int) bool {
+		return ip.Less(p.networks[i].prefix.Addr())
+	})
+	if i == 0 || !p.networks[i-1].prefix.Contains(ip) {
+		return nil, nil
+	}
+
+	location := p.networks[i-1].location
+	return &location, nil
+}
+
+func readGeoIpNetworks(data io.Reader) ([]geoIpNetwork, error) {
+	reader := csv.NewReader(data)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"network", "latitude", "longitude"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("geoip database is missing %s column", required)
+		}
+	}
+
+	var networks []geoIpNetwork
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return networks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Networks without coordinates only carry country data and are useless here.
+		latitude, latErr := strconv.ParseFloat(row[columns["latitude"]], 64)
+		longitude, lonErr := strconv.ParseFloat(row[columns["longitude"]], 64)
+		if latErr != nil || lonErr != nil {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(row[columns["network"]])
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, geoIpNetwork{
+			prefix:   prefix.Masked(),
+			location: models.UserLocation{Latitude: latitude, Longitude: longitude},
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestIpApiLocationProvider_GetLocation(t *testing.T) {
+	ip := netip.MustParseAddr("203.0.113.10")
+
+	t.Run("success", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/203.0.113.10", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"status": "success", "lat": 10.8231, "lon": 106.6297}`))
+		}))
+		defer mockServer.Close()
+
+		provider := NewIpApiLocationProvider(mockServer.URL+"/", 10)
+
+		location, err := provider.GetLocation(ip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &models.UserLocation{Latitude: 10.8231, Longitude: 106.6297}, location)
+	})
+
+	t.Run("location not found", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"status": "fail", "message": "reserved range"}`))
+		}))
+		defer mockServer.Close()
+
+		provider := NewIpApiLocationProvider(mockServer.URL+"/", 10)
+
+		location, err := provider.GetLocation(ip)
+
+		assert.Nil(t, location)
+		assert.Nil(t, err)
+	})
+
+	t.Run("API returns non-200 status code", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer mockServer.Close()
+
+		provider := NewIpApiLocationProvider(mockServer.URL+"/", 10)
+
+		location, err := provider.GetLocation(ip)
+
+		assert.Nil(t, location)
+		assert.EqualError(t, err, "unexpected response from location API")
+	})
+
+	t.Run("API response body is invalid JSON", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("invalid JSON"))
+		}))
+		defer mockServer.Close()
+
+		provider := NewIpApiLocationProvider(mockServer.URL+"/", 10)
+
+		location, err := provider.GetLocation(ip)
+
+		assert.Nil(t, location)
+		assert.Contains(t, err.Error(), "invalid character")
+	})
+}
+
+func TestGeoIpDatabaseLocationProvider_GetLocation(t *testing.T) {
+	header := "network,geoname_id,registered_country_geoname_id,represented_country_geoname_id,is_anonymous_proxy,is_satellite_provider,postal_code,latitude,longitude,accuracy_radius"
+	ipv4 := strings.Join([]string{
+		header,
+		"203.0.113.0/25,1566083,1562822,,0,0,700000,10.8231,106.6297,20",
+		"198.51.100.0/24,6252001,6252001,,0,0,,,,1000",
+		"192.0.2.0/24,5391959,6252001,,0,0,94103,37.7749,-122.4194,10",
+	}, "\n")
+	ipv6 := strings.Join([]string{
+		header,
+		"2001:db8::/32,2988507,3017382,,0,0,75001,48.8566,2.3522,50",
+	}, "\n")
+
+	provider, err := newGeoIpDatabaseLocationProvider(strings.NewReader(ipv4), strings.NewReader(ipv6))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		ip       string
+		expected *models.UserLocation
+	}{
+		{"first address of network", "203.0.113.0", &models.UserLocation{Latitude: 10.8231, Longitude: 106.6297}},
+		{"last address of network", "203.0.113.127", &models.UserLocation{Latitude: 10.8231, Longitude: 106.6297}},
+		{"address after network", "203.0.113.128", nil},
+		{"network without coordinates", "198.51.100.7", nil},
+		{"other network", "192.0.2.55", &models.UserLocation{Latitude: 37.7749, Longitude: -122.4194}},
+		{"address before first network", "8.8.8.8", nil},
+		{"ipv4 mapped ipv6 address", "::ffff:192.0.2.55", &models.UserLocation{Latitude: 37.7749, Longitude: -122.4194}},
+		{"ipv6 address", "2001:db8::1", &models.UserLocation{Latitude: 48.8566, Longitude: 2.3522}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := provider.GetLocation(netip.MustParseAddr(tt.ip))
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, location)
+		})
+	}
+
+	t.Run("missing column", func(t *testing.T) {
+		provider, err := newGeoIpDatabaseLocationProvider(strings.NewReader("network,geoname_id\n"))
+
+		assert.Nil(t, provider)
+		assert.EqualError(t, err, "geoip database is missing latitude column")
+	})
+
+	t.Run("missing database file", func(t *testing.T) {
+		provider, err := NewGeoIpDatabaseLocationProvider()
+
+		assert.Nil(t, provider)
+		assert.EqualError(t, err, "missing geoip database file")
+	})
+}
@@ -1,50 +1,66 @@
 package services
 
 import (
-	"encoding/json"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
-	"net/http"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"log"
+	"net/netip"
 	"time"
 )
 
 type UserLocationService interface {
-	GetCurrentUserLocation() (*models.UserLocation, *errors.ApiError)
+	GetUserLocation(ip string) (*models.UserLocation, *errors.ApiError)
 }
 
-func NewUserLocationService(url string, timeout int) UserLocationService {
-	httpClient := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+func NewUserLocationService(
+	provider UserLocationProvider,
+	userLocationRepo repositories.UserLocationRepository,
+	cacheTime int,
+) UserLocationService {
 	return &userLocationService{
-		url:        url,
-		httpClient: httpClient,
+		provider:         provider,
+		userLocationRepo: userLocationRepo,
+		cacheTime:        time.Duration(cacheTime) * time.Minute,
 	}
 }
 
 type userLocationService struct {
-	url        string
-	httpClient *http.Client
+	provider         UserLocationProvider
+	userLocationRepo repositories.UserLocationRepository
+	cacheTime        time.Duration
 }
 
-func (s *userLocationService) GetCurrentUserLocation() (*models.UserLocation, *errors.ApiError) {
-	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+func (s *userLocationService) GetUserLocation(ip string) (*models.UserLocation, *errors.ApiError) {
+	addr, err := netip.ParseAddr(ip)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return nil, errors.BadRequestError("invalid client ip")
 	}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return nil, errors.BadRequestError("can not determine location of client ip, provide lat and lon or cityId")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.InternalServerError("unexpected response from location API")
+	location, err := s.userLocationRepo.GetUserLocation(addr.String())
+	if err != nil {
+		log.Printf("failed to read cached location of %s: %s", addr, err.Error())
+	}
+	if location != nil {
+		return location, nil
 	}
 
-	var location models.UserLocation
-	if err := json.NewDecoder(resp.Body).Decode(&location); err != nil {
+	location, err = s.provider.GetLocation(addr)
+	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
+	if location == nil {
+		return nil, errors.BadRequestError("can not determine location of client ip, provide lat and lon or cityId")
+	}
+
+	if err := s.userLocationRepo.CreateUserLocation(addr.String(), s.cacheTime, location); err != nil {
+		log.Printf("failed to cache location of %s: %s", addr, err.Error())
+	}
 
-	return &location, nil
+	return location, nil
 }
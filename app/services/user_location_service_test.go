@@ -1,65 +1,109 @@
 package services
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"go.uber.org/mock/gomock"
 	"net/http"
-	"net/http/httptest"
+	"net/netip"
 	"testing"
+	"time"
 )
 
-func TestUserLocationService_GetCurrentUserLocation(t *testing.T) {
+func TestUserLocationService_GetUserLocation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	provider := mock_services.NewMockUserLocationProvider(ctrl)
+	repo := mock_repositories.NewMockUserLocationRepository(ctrl)
+	service := NewUserLocationService(provider, repo, 60)
+
+	ip := "203.0.113.10"
+	location := &models.UserLocation{Latitude: 10.8231, Longitude: 106.6297}
+
 	t.Run("success", func(t *testing.T) {
-		mockResponse := `{"lat": 10.8231, "lon": 106.6297}`
-		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(mockResponse))
-		}))
-		defer mockServer.Close()
+		repo.EXPECT().GetUserLocation(ip).Return(nil, nil).Times(1)
+		provider.EXPECT().GetLocation(netip.MustParseAddr(ip)).Return(location, nil).Times(1)
+		repo.EXPECT().CreateUserLocation(ip, time.Hour, location).Return(nil).Times(1)
+
+		result, err := service.GetUserLocation(ip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, location, result)
+	})
 
-		service := NewUserLocationService(mockServer.URL, 10)
+	t.Run("cached location", func(t *testing.T) {
+		repo.EXPECT().GetUserLocation(ip).Return(location, nil).Times(1)
 
-		location, apiErr := service.GetCurrentUserLocation()
+		result, err := service.GetUserLocation(ip)
 
-		assert.NotNil(t, location)
-		assert.Nil(t, apiErr)
-		assert.Equal(t, 10.8231, location.Latitude)
-		assert.Equal(t, 106.6297, location.Longitude)
+		assert.Nil(t, err)
+		assert.Equal(t, location, result)
 	})
 
-	t.Run("API returns non-200 status code", func(t *testing.T) {
-		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusInternalServerError)
-		}))
-		defer mockServer.Close()
+	t.Run("cache errors are ignored", func(t *testing.T) {
+		repo.EXPECT().GetUserLocation(ip).Return(nil, errors.New("error getting location")).Times(1)
+		provider.EXPECT().GetLocation(gomock.Any()).Return(location, nil).Times(1)
+		repo.EXPECT().CreateUserLocation(ip, time.Hour, location).Return(errors.New("error caching location")).Times(1)
 
-		service := NewUserLocationService(mockServer.URL, 10)
+		result, err := service.GetUserLocation(ip)
 
-		location, apiErr := service.GetCurrentUserLocation()
+		assert.Nil(t, err)
+		assert.Equal(t, location, result)
+	})
+
+	t.Run("ipv4 mapped ipv6 address", func(t *testing.T) {
+		repo.EXPECT().GetUserLocation(ip).Return(location, nil).Times(1)
 
-		assert.Nil(t, location)
-		assert.NotNil(t, apiErr)
-		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
-		assert.Equal(t, "unexpected response from location API", apiErr.Error())
+		result, err := service.GetUserLocation("::ffff:" + ip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, location, result)
 	})
 
-	t.Run("API response body is invalid JSON", func(t *testing.T) {
-		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("invalid JSON"))
-		}))
-		defer mockServer.Close()
+	t.Run("invalid ip", func(t *testing.T) {
+		result, err := service.GetUserLocation("not-an-ip")
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "invalid client ip", err.Error())
+	})
+
+	t.Run("private ip", func(t *testing.T) {
+		for _, privateIp := range []string{"127.0.0.1", "10.0.0.8", "192.168.1.20", "::1"} {
+			result, err := service.GetUserLocation(privateIp)
+
+			assert.Nil(t, result)
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, "can not determine location of client ip, provide lat and lon or cityId", err.Error())
+		}
+	})
+
+	t.Run("location not found", func(t *testing.T) {
+		repo.EXPECT().GetUserLocation(ip).Return(nil, nil).Times(1)
+		provider.EXPECT().GetLocation(gomock.Any()).Return(nil, nil).Times(1)
+
+		result, err := service.GetUserLocation(ip)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
 
-		service := NewUserLocationService(mockServer.URL, 10)
+	t.Run("provider error", func(t *testing.T) {
+		repo.EXPECT().GetUserLocation(ip).Return(nil, nil).Times(1)
+		provider.EXPECT().GetLocation(gomock.Any()).Return(nil, errors.New("unexpected response from location API")).Times(1)
 
-		location, apiErr := service.GetCurrentUserLocation()
+		result, err := service.GetUserLocation(ip)
 
-		assert.Nil(t, location)
-		assert.NotNil(t, apiErr)
-		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
-		assert.Contains(t, apiErr.Error(), "invalid character")
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "unexpected response from location API", err.Error())
 	})
 }
@@ -1,9 +1,13 @@
 package services
 
 import (
+	"crypto/hmac"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/config"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,12 +23,19 @@ import (
 type UserService interface {
 	GetUser(id uuid.UUID, includeProfile bool) (*models.User, *errors.ApiError)
 	UserExistsByEmail(email string) (bool, *errors.ApiError)
-	CreateUser(req payloads.CreateUserRequest) (*models.User, *errors.ApiError)
-	LoginUser(req payloads.LoginUserRequest) (*models.LoginToken, *errors.ApiError)
+	CreateUser(req payloads.CreateUserRequest, requestID uuid.UUID) (*models.User, *errors.ApiError)
+	LoginUser(req payloads.LoginUserRequest, clientIp string) (*models.LoginToken, *models.LoginChallenge, *errors.ApiError)
+	VerifyTwoFactorLogin(req payloads.VerifyTwoFactorLoginRequest, clientIp string) (*models.LoginToken, *errors.ApiError)
+	EnrollTwoFactor(userID uuid.UUID) (*models.TwoFactorEnrollment, *errors.ApiError)
+	ConfirmTwoFactor(userID uuid.UUID, req payloads.TwoFactorCodeRequest) ([]string, *errors.ApiError)
+	DisableTwoFactor(userID uuid.UUID, req payloads.TwoFactorCodeRequest) *errors.ApiError
+	StartOidcLogin(provider string) (*models.OidcAuthorization, *errors.ApiError)
+	CompleteOidcLogin(provider string, req payloads.OidcCallbackRequest) (*models.LoginToken, *models.LoginChallenge, *errors.ApiError)
 	LogoutUser(tokenValue string) *errors.ApiError
 	VerifyUser(token string) *errors.ApiError
+	ResendVerificationEmail(req payloads.ResendVerificationEmailRequest, requestID uuid.UUID) *errors.ApiError
 	UpdateUserPassword(userID uuid.UUID, req payloads.UpdatePasswordRequest) *errors.ApiError
-	CreatePasswordResetToken(req payloads.CreatePasswordResetTokenRequest) (*models.PasswordResetToken, *errors.ApiError)
+	CreatePasswordResetToken(req payloads.CreatePasswordResetTokenRequest, requestID uuid.UUID) *errors.ApiError
 	ResetUserPassword(resetToken string, request payloads.ResetPasswordRequest) *errors.ApiError
 }
 
@@ -40,6 +51,14 @@ type userService struct {
 	passwordResetTokenRepo    repositories.PasswordResetTokenRepository
 	userRegistrationTokenRepo repositories.UserRegistrationTokenRepository
 	notificationRepo          repositories.NotificationRepository
+	userTotpSecretRepo        repositories.UserTotpSecretRepository
+	userRecoveryCodeRepo      repositories.UserRecoveryCodeRepository
+	loginChallengeRepo        repositories.LoginChallengeRepository
+	userIdentityRepo          repositories.UserIdentityRepository
+	oidcStateRepo             repositories.OidcStateRepository
+	loginThrottleService      LoginThrottleService
+	verificationResendLimiter RateLimiterService
+	oidcProviders             map[string]OidcProviderService
 }
 
 func NewUserService(
@@ -54,6 +73,14 @@ func NewUserService(
 	passwordResetTokenRepo repositories.PasswordResetTokenRepository,
 	userRegistrationTokenRepo repositories.UserRegistrationTokenRepository,
 	notificationRepo repositories.NotificationRepository,
+	userTotpSecretRepo repositories.UserTotpSecretRepository,
+	userRecoveryCodeRepo repositories.UserRecoveryCodeRepository,
+	loginChallengeRepo repositories.LoginChallengeRepository,
+	userIdentityRepo repositories.UserIdentityRepository,
+	oidcStateRepo repositories.OidcStateRepository,
+	loginThrottleService LoginThrottleService,
+	verificationResendLimiter RateLimiterService,
+	oidcProviders map[string]OidcProviderService,
 ) UserService {
 	return &userService{
 		db:                        db,
@@ -67,6 +94,14 @@ func NewUserService(
 		passwordResetTokenRepo:    passwordResetTokenRepo,
 		userRegistrationTokenRepo: userRegistrationTokenRepo,
 		notificationRepo:          notificationRepo,
+		userTotpSecretRepo:        userTotpSecretRepo,
+		userRecoveryCodeRepo:      userRecoveryCodeRepo,
+		loginChallengeRepo:        loginChallengeRepo,
+		userIdentityRepo:          userIdentityRepo,
+		oidcStateRepo:             oidcStateRepo,
+		loginThrottleService:      loginThrottleService,
+		verificationResendLimiter: verificationResendLimiter,
+		oidcProviders:             oidcProviders,
 	}
 }
 
@@ -94,7 +129,7 @@ func (s *userService) UserExistsByEmail(email string) (bool, *errors.ApiError) {
 	return exist, nil
 }
 
-func (s *userService) CreateUser(req payloads.CreateUserRequest) (*models.User, *errors.ApiError) {
+func (s *userService) CreateUser(req payloads.CreateUserRequest, requestID uuid.UUID) (*models.User, *errors.ApiError) {
 	exists, apiErr := s.UserExistsByEmail(req.Email)
 	if apiErr != nil {
 		return nil, apiErr
@@ -128,12 +163,9 @@ func (s *userService) CreateUser(req payloads.CreateUserRequest) (*models.User,
 		CreatedAt:   currentTime,
 		UpdatedAt:   currentTime,
 	}
-	t := &models.UserRegistrationToken{
-		ID:         uuid.New(),
-		TokenValue: s.authenticator.GenerateRegistrationToken(),
-		IsUsed:     false,
-		CreatedAt:  currentTime,
-		ExpiresAt:  currentTime.Add(time.Duration(config.AppEnv.UserRegistrationTokenExpireTime) * time.Minute),
+	t, token, err := s.newUserRegistrationToken(currentTime)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
 		dbUser, err := s.userRepo.CreateOrUpdateUser(tx, u)
@@ -146,90 +178,333 @@ func (s *userService) CreateUser(req payloads.CreateUserRequest) (*models.User,
 			return err
 		}
 
+		if err := s.revokeActiveUserRegistrationTokens(tx, dbUser.ID); err != nil {
+			return err
+		}
+
 		t.UserID = dbUser.ID
-		return s.userRegistrationTokenRepo.CreateToken(tx, t)
+		if err := s.userRegistrationTokenRepo.CreateToken(tx, t); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendUserRegistrationEvent(tx, requestID, payloads.UserRegistrationEvent{
+			UserID:            dbUser.ID,
+			Email:             req.Email,
+			FirstName:         req.Profile.FirstName,
+			LastName:          req.Profile.LastName,
+			VerificationToken: token,
+			CreatedAt:         currentTime,
+		})
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
 
-	e := payloads.UserRegistrationEvent{
-		Email:             req.Email,
-		FirstName:         req.Profile.FirstName,
-		LastName:          req.Profile.LastName,
-		VerificationToken: t.TokenValue,
-		CreatedAt:         currentTime,
+	return u, nil
+}
+
+func (s *userService) LoginUser(req payloads.LoginUserRequest, clientIp string) (*models.LoginToken, *models.LoginChallenge, *errors.ApiError) {
+	lockout, err := s.loginThrottleService.GetLockout(req.Email, clientIp)
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+	if lockout > 0 {
+		return nil, nil, errors.TooManyRequestsError("too many failed login attempts, try again in %.0f seconds", math.Ceil(lockout.Seconds()))
+	}
+
+	u, err := s.getUserByEmail(req.Email, false)
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
 	}
-	if err := s.notificationRepo.SendUserRegistrationEvent(e); err != nil {
+	passwordHash := auth.DummyPasswordHash
+	if u != nil {
+		passwordHash = u.PasswordHash
+	}
+	if !s.authenticator.DoPasswordsMatch(passwordHash, req.Password) || u == nil {
+		if err := s.loginThrottleService.RegisterFailedAttempt(req.Email, clientIp); err != nil {
+			return nil, nil, errors.InternalServerError(err.Error())
+		}
+
+		return nil, nil, errors.UnauthorizedError("invalid email or password")
+	}
+
+	secret, err := s.getEnabledTotpSecret(u.ID)
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+	if secret != nil {
+		// Failed attempts are only reset once the second factor is verified, otherwise
+		// a correct password would clear the failures recorded for wrong codes.
+		c, apiErr := s.createLoginChallenge(u)
+		return nil, c, apiErr
+	}
+
+	if err := s.loginThrottleService.ResetFailedAttempts(req.Email, clientIp); err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+
+	t, apiErr := s.createLoginToken(u.ID, u.Email)
+	return t, nil, apiErr
+}
+
+func (s *userService) VerifyTwoFactorLogin(req payloads.VerifyTwoFactorLoginRequest, clientIp string) (*models.LoginToken, *errors.ApiError) {
+	challengeHash := s.authenticator.HashToken(req.ChallengeToken)
+	session, err := s.loginChallengeRepo.GetAndDeleteLoginChallenge(challengeHash)
+	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
+	if session == nil {
+		return nil, errors.UnauthorizedError("invalid or expired challenge")
+	}
 
-	return u, nil
+	lockout, err := s.loginThrottleService.GetLockout(session.Email, clientIp)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if lockout > 0 {
+		return nil, errors.TooManyRequestsError("too many failed login attempts, try again in %.0f seconds", math.Ceil(lockout.Seconds()))
+	}
+
+	secret, err := s.getEnabledTotpSecret(session.UserID)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if secret == nil {
+		return nil, errors.UnauthorizedError("invalid or expired challenge")
+	}
+
+	verified, err := s.verifySecondFactor(secret, req.Code)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if !verified {
+		if err := s.loginThrottleService.RegisterFailedAttempt(session.Email, clientIp); err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+
+		return nil, errors.UnauthorizedError("invalid two-factor code")
+	}
+
+	if err := s.loginThrottleService.ResetFailedAttempts(session.Email, clientIp); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return s.createLoginToken(session.UserID, session.Email)
 }
 
-func (s *userService) LoginUser(req payloads.LoginUserRequest) (*models.LoginToken, *errors.ApiError) {
-	u, err := s.getUserByEmail(req.Email, false)
+func (s *userService) EnrollTwoFactor(userID uuid.UUID) (*models.TwoFactorEnrollment, *errors.ApiError) {
+	u, err := s.getUserById(userID, false)
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
 	if u == nil {
-		return nil, errors.UnauthorizedError("invalid email %s", req.Email)
+		return nil, errors.NotFoundError("user does not exist")
+	}
+
+	enabledSecret, err := s.getEnabledTotpSecret(userID)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if enabledSecret != nil {
+		return nil, errors.BadRequestError("two-factor authentication is already enabled")
 	}
 
-	if !s.authenticator.DoPasswordsMatch(u.PasswordHash, req.Password) {
-		return nil, errors.UnauthorizedError("invalid password")
+	secretValue, err := s.authenticator.GenerateTotpSecret()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
 	}
 
-	token := s.authenticator.GenerateLoginToken()
+	now := time.Now().UTC()
+	secret := &models.UserTotpSecret{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Secret:    secretValue,
+		IsEnabled: false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.userTotpSecretRepo.DeleteUserSecret(tx, userID); err != nil {
+			return err
+		}
 
-	t, err := s.loginTokenRepo.GetLoginToken(filters.LoginTokenFilter{
-		Filter:     &filters.SingleFilter{Logic: filters.And},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token},
+		return s.userTotpSecretRepo.CreateSecret(tx, secret)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:          secretValue,
+		ProvisioningUri: s.authenticator.GetTotpProvisioningUri(secretValue, config.AppEnv.TotpIssuer, u.Email),
+	}, nil
+}
+
+func (s *userService) ConfirmTwoFactor(userID uuid.UUID, req payloads.TwoFactorCodeRequest) ([]string, *errors.ApiError) {
+	secret, err := s.userTotpSecretRepo.GetSecret(filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: userID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: false},
 	})
 	if err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
-	if t != nil {
-		return nil, errors.InternalServerError("token value already exists")
+	if secret == nil {
+		return nil, errors.BadRequestError("two-factor enrollment not found")
+	}
+
+	step, ok := s.authenticator.ValidateTotpCode(secret.Secret, req.Code, time.Now().UTC())
+	if !ok {
+		return nil, errors.BadRequestError("invalid two-factor code")
+	}
+
+	codes, err := s.authenticator.GenerateRecoveryCodes(constants.TwoFactorRecoveryCodeCount)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
 	}
 
 	now := time.Now().UTC()
-	validDuration := time.Duration(config.AppEnv.LoginTokenExpireTime) * time.Minute
-	t = &models.LoginToken{
-		ID:         uuid.New(),
-		UserID:     u.ID,
-		TokenValue: token,
-		CreatedAt:  now,
-		ExpiresAt:  now.Add(validDuration),
+	recoveryCodes := make([]*models.UserRecoveryCode, len(codes))
+	for i, code := range codes {
+		recoveryCodes[i] = &models.UserRecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  s.authenticator.HashToken(code),
+			IsUsed:    false,
+			CreatedAt: now,
+		}
 	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.loginTokenRepo.CreateLoginToken(tx, t)
+		if err := s.userTotpSecretRepo.EnableSecret(tx, secret, step); err != nil {
+			return err
+		}
+
+		if err := s.userRecoveryCodeRepo.DeleteUserCodes(tx, userID); err != nil {
+			return err
+		}
+
+		return s.userRecoveryCodeRepo.CreateCodes(tx, recoveryCodes)
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
 
+	return codes, nil
+}
+
+func (s *userService) DisableTwoFactor(userID uuid.UUID, req payloads.TwoFactorCodeRequest) *errors.ApiError {
+	secret, err := s.getEnabledTotpSecret(userID)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if secret == nil {
+		return errors.BadRequestError("two-factor authentication is not enabled")
+	}
+
+	verified, err := s.verifySecondFactor(secret, req.Code)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	if !verified {
+		return errors.BadRequestError("invalid two-factor code")
+	}
+
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.userTotpSecretRepo.DeleteUserSecret(tx, userID); err != nil {
+			return err
+		}
+
+		return s.userRecoveryCodeRepo.DeleteUserCodes(tx, userID)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
+func (s *userService) StartOidcLogin(provider string) (*models.OidcAuthorization, *errors.ApiError) {
+	p, ok := s.oidcProviders[provider]
+	if !ok {
+		return nil, errors.NotFoundError("identity provider %s is not supported", provider)
+	}
+
+	state, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	nonce, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	codeVerifier, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	authorizationUrl, apiErr := p.GetAuthorizationUrl(state, nonce, s.authenticator.GetPkceCodeChallenge(codeVerifier))
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	if err := s.transactionManager.ExecuteInRedisTransaction(s.rdb, func(tx *redis.Tx) error {
-		return s.userSessionRepo.CreateUserSession(
-			s.userSessionRepo.GetUserSessionID(token),
-			validDuration,
-			&models.UserSession{UserID: u.ID, Email: u.Email},
+		return s.oidcStateRepo.CreateState(
+			state,
+			time.Duration(config.AppEnv.OidcStateExpireTime)*time.Minute,
+			&models.OidcState{Provider: provider, Nonce: nonce, CodeVerifier: codeVerifier},
 		)
 	}); err != nil {
 		return nil, errors.InternalServerError(err.Error())
 	}
 
-	return t, nil
+	return &models.OidcAuthorization{AuthorizationUrl: authorizationUrl}, nil
+}
+
+func (s *userService) CompleteOidcLogin(provider string, req payloads.OidcCallbackRequest) (*models.LoginToken, *models.LoginChallenge, *errors.ApiError) {
+	p, ok := s.oidcProviders[provider]
+	if !ok {
+		return nil, nil, errors.NotFoundError("identity provider %s is not supported", provider)
+	}
+
+	state, err := s.oidcStateRepo.ConsumeState(req.State)
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+	if state == nil || state.Provider != provider {
+		return nil, nil, errors.UnauthorizedError("invalid or expired state")
+	}
+
+	claims, apiErr := p.ExchangeCode(req.Code, state.CodeVerifier)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+	if !hmac.Equal([]byte(claims.Nonce), []byte(state.Nonce)) {
+		return nil, nil, errors.UnauthorizedError("invalid nonce")
+	}
+
+	u, apiErr := s.getOrCreateOidcUser(provider, claims)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+	secret, err := s.getEnabledTotpSecret(u.ID)
+	if err != nil {
+		return nil, nil, errors.InternalServerError(err.Error())
+	}
+	if secret != nil {
+		c, apiErr := s.createLoginChallenge(u)
+		return nil, c, apiErr
+	}
+
+	t, apiErr := s.createLoginToken(u.ID, u.Email)
+	return t, nil, apiErr
 }
 
 func (s *userService) LogoutUser(tokenValue string) *errors.ApiError {
 	token, err := s.loginTokenRepo.GetLoginToken(filters.LoginTokenFilter{
 		Filter:     &filters.SingleFilter{Logic: filters.And},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: tokenValue},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: s.authenticator.HashToken(tokenValue)},
 	})
 	if err != nil {
 		return errors.InternalServerError(err.Error())
 
 	}
-	if token == nil {
+	if token == nil || !s.authenticator.DoTokensMatch(token.TokenValue, tokenValue) {
 		return errors.BadRequestError("token not found")
 	}
 
@@ -251,14 +526,14 @@ func (s *userService) LogoutUser(tokenValue string) *errors.ApiError {
 func (s *userService) VerifyUser(token string) *errors.ApiError {
 	t, err := s.userRegistrationTokenRepo.GetToken(filters.UserRegistrationTokenFilter{
 		Filter:     &filters.SingleFilter{},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: s.authenticator.HashToken(token)},
 		IsUsed:     &filters.Condition{Operator: filters.OpEqual, Value: false},
 		ExpiresAt:  &filters.Condition{Operator: filters.OpGreater, Value: time.Now().UTC()},
 	})
 	if err != nil {
 		return errors.InternalServerError(err.Error())
 	}
-	if t == nil {
+	if t == nil || !s.authenticator.DoTokensMatch(t.TokenValue, token) {
 		return errors.BadRequestError("invalid or expired token")
 	}
 
@@ -289,6 +564,54 @@ func (s *userService) VerifyUser(token string) *errors.ApiError {
 	return nil
 }
 
+func (s *userService) ResendVerificationEmail(req payloads.ResendVerificationEmailRequest, requestID uuid.UUID) *errors.ApiError {
+	allowed, retryAfter := s.verificationResendLimiter.Allow(strings.ToLower(req.Email))
+	if !allowed {
+		return errors.TooManyRequestsError("too many verification emails requested, try again in %.0f seconds", math.Ceil(retryAfter.Seconds()))
+	}
+
+	u, err := s.getUserByEmail(req.Email, true)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	// Unknown and already verified emails are ignored so the endpoint does not reveal which accounts exist.
+	if u == nil || u.IsVerified {
+		return nil
+	}
+
+	currentTime := time.Now().UTC()
+	t, token, err := s.newUserRegistrationToken(currentTime)
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	t.UserID = u.ID
+	e := payloads.UserRegistrationEvent{
+		UserID:            u.ID,
+		Email:             u.Email,
+		VerificationToken: token,
+		CreatedAt:         currentTime,
+	}
+	if u.Profile != nil {
+		e.FirstName = u.Profile.FirstName
+		e.LastName = u.Profile.LastName
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if err := s.revokeActiveUserRegistrationTokens(tx, u.ID); err != nil {
+			return err
+		}
+
+		if err := s.userRegistrationTokenRepo.CreateToken(tx, t); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendUserRegistrationEvent(tx, requestID, e)
+	}); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
+	return nil
+}
+
 func (s *userService) UpdateUserPassword(userID uuid.UUID, req payloads.UpdatePasswordRequest) *errors.ApiError {
 	u, err := s.getUserById(userID, false)
 	if err != nil {
@@ -322,55 +645,77 @@ func (s *userService) UpdateUserPassword(userID uuid.UUID, req payloads.UpdatePa
 	return nil
 }
 
-func (s *userService) CreatePasswordResetToken(req payloads.CreatePasswordResetTokenRequest) (*models.PasswordResetToken, *errors.ApiError) {
-	u, err := s.getUserByEmail(req.Email, false)
+func (s *userService) CreatePasswordResetToken(req payloads.CreatePasswordResetTokenRequest, requestID uuid.UUID) *errors.ApiError {
+	u, err := s.getUserByEmail(req.Email, true)
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return errors.InternalServerError(err.Error())
 	}
+	// Unknown emails are ignored so the endpoint does not reveal which accounts exist.
 	if u == nil {
-		return nil, errors.NotFoundError("email %s does not exist", req.Email)
+		return nil
 	}
 
-	token := s.authenticator.GeneratePasswordResetToken()
+	token, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+	tokenHash := s.authenticator.HashToken(token)
 
 	t, err := s.passwordResetTokenRepo.GetToken(filters.PasswordResetTokenFilter{
 		Filter:     &filters.SingleFilter{},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: tokenHash},
 	})
 	if err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return errors.InternalServerError(err.Error())
 	}
 	if t != nil {
-		return nil, errors.InternalServerError("token value already exists")
+		return errors.InternalServerError("token value already exists")
 	}
 
 	now := time.Now().UTC()
 	t = &models.PasswordResetToken{
 		ID:         uuid.New(),
 		UserID:     u.ID,
-		TokenValue: token,
+		TokenValue: tokenHash,
 		IsUsed:     false,
 		CreatedAt:  now,
 		ExpiresAt:  now.Add(time.Duration(config.AppEnv.PassResetTokenExpireTime) * time.Minute),
 	}
+	e := payloads.PasswordResetRequestedEvent{
+		UserID:     u.ID,
+		Email:      u.Email,
+		ResetToken: token,
+		ExpiresAt:  t.ExpiresAt,
+		CreatedAt:  now,
+	}
+	if u.Profile != nil {
+		e.FirstName = u.Profile.FirstName
+		e.LastName = u.Profile.LastName
+	}
 	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
-		return s.passwordResetTokenRepo.CreateToken(tx, t)
+		if err := s.passwordResetTokenRepo.CreateToken(tx, t); err != nil {
+			return err
+		}
+
+		return s.notificationRepo.SendPasswordResetRequestedEvent(tx, requestID, e)
 	}); err != nil {
-		return nil, errors.InternalServerError(err.Error())
+		return errors.InternalServerError(err.Error())
 	}
 
-	return t, nil
+	return nil
 }
 
 func (s *userService) ResetUserPassword(resetToken string, req payloads.ResetPasswordRequest) *errors.ApiError {
 	t, err := s.passwordResetTokenRepo.GetToken(filters.PasswordResetTokenFilter{
 		Filter:     &filters.SingleFilter{},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: resetToken},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: s.authenticator.HashToken(resetToken)},
+		IsUsed:     &filters.Condition{Operator: filters.OpEqual, Value: false},
+		ExpiresAt:  &filters.Condition{Operator: filters.OpGreater, Value: time.Now().UTC()},
 	})
 	if err != nil {
 		return errors.InternalServerError(err.Error())
 	}
-	if t == nil {
+	if t == nil || !s.authenticator.DoTokensMatch(t.TokenValue, resetToken) {
 		return errors.UnauthorizedError("invalid or expired token")
 	}
 
@@ -396,7 +741,7 @@ func (s *userService) ResetUserPassword(resetToken string, req payloads.ResetPas
 			return err
 		}
 
-		tokens, err := s.getRemainingUserActivePasswordResetTokens(t.UserID, t.TokenValue)
+		tokens, err := s.getRemainingUserActivePasswordResetTokens(t.UserID, t.ID)
 		if err != nil {
 			return err
 		}
@@ -415,9 +760,245 @@ func (s *userService) ResetUserPassword(resetToken string, req payloads.ResetPas
 		return errors.InternalServerError(err.Error())
 	}
 
+	if err := s.loginThrottleService.UnlockAccount(u.Email); err != nil {
+		return errors.InternalServerError(err.Error())
+	}
+
 	return nil
 }
 
+func (s *userService) createLoginToken(userID uuid.UUID, email string) (*models.LoginToken, *errors.ApiError) {
+	token, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	tokenHash := s.authenticator.HashToken(token)
+
+	t, err := s.loginTokenRepo.GetLoginToken(filters.LoginTokenFilter{
+		Filter:     &filters.SingleFilter{Logic: filters.And},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: tokenHash},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if t != nil {
+		return nil, errors.InternalServerError("token value already exists")
+	}
+
+	now := time.Now().UTC()
+	validDuration := time.Duration(config.AppEnv.LoginTokenExpireTime) * time.Minute
+	t = &models.LoginToken{
+		ID:         uuid.New(),
+		UserID:     userID,
+		TokenValue: tokenHash,
+		Token:      token,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(validDuration),
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		return s.loginTokenRepo.CreateLoginToken(tx, t)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	if err := s.transactionManager.ExecuteInRedisTransaction(s.rdb, func(tx *redis.Tx) error {
+		return s.userSessionRepo.CreateUserSession(
+			s.userSessionRepo.GetUserSessionID(tokenHash),
+			validDuration,
+			&models.UserSession{UserID: userID, Email: email},
+		)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return t, nil
+}
+
+func (s *userService) createLoginChallenge(u *models.User) (*models.LoginChallenge, *errors.ApiError) {
+	token, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	validDuration := time.Duration(config.AppEnv.LoginChallengeExpireTime) * time.Minute
+	c := &models.LoginChallenge{
+		Token:     token,
+		ExpiresAt: time.Now().UTC().Add(validDuration),
+	}
+	if err := s.transactionManager.ExecuteInRedisTransaction(s.rdb, func(tx *redis.Tx) error {
+		return s.loginChallengeRepo.CreateLoginChallenge(s.authenticator.HashToken(token), validDuration, &models.UserSession{UserID: u.ID, Email: u.Email})
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	return c, nil
+}
+
+func (s *userService) getEnabledTotpSecret(userID uuid.UUID) (*models.UserTotpSecret, error) {
+	return s.userTotpSecretRepo.GetSecret(filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: userID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: true},
+	})
+}
+
+func (s *userService) verifySecondFactor(secret *models.UserTotpSecret, code string) (bool, error) {
+	if step, ok := s.authenticator.ValidateTotpCode(secret.Secret, code, time.Now().UTC()); ok {
+		if step <= secret.LastUsedStep {
+			return false, nil
+		}
+
+		var updated bool
+		if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+			var err error
+			updated, err = s.userTotpSecretRepo.UpdateLastUsedStep(tx, secret, step)
+			return err
+		}); err != nil {
+			return false, err
+		}
+
+		return updated, nil
+	}
+
+	recoveryCode, err := s.userRecoveryCodeRepo.GetCode(filters.UserRecoveryCodeFilter{
+		Filter:   &filters.SingleFilter{Logic: filters.And},
+		UserID:   &filters.Condition{Operator: filters.OpEqual, Value: secret.UserID},
+		CodeHash: &filters.Condition{Operator: filters.OpEqual, Value: s.authenticator.HashToken(strings.ToLower(strings.TrimSpace(code)))},
+		IsUsed:   &filters.Condition{Operator: filters.OpEqual, Value: false},
+	})
+	if err != nil {
+		return false, err
+	}
+	if recoveryCode == nil {
+		return false, nil
+	}
+
+	var used bool
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		var err error
+		used, err = s.userRecoveryCodeRepo.UseCode(tx, recoveryCode)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	return used, nil
+}
+
+func (s *userService) getOrCreateOidcUser(provider string, claims *models.OidcClaims) (*models.User, *errors.ApiError) {
+	identity, err := s.userIdentityRepo.GetIdentity(filters.UserIdentityFilter{
+		Filter:   &filters.SingleFilter{Logic: filters.And},
+		Provider: &filters.Condition{Operator: filters.OpEqual, Value: provider},
+		Subject:  &filters.Condition{Operator: filters.OpEqual, Value: claims.Subject},
+	})
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+	if identity != nil {
+		u, err := s.getUserById(identity.UserID, false)
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+		if u == nil {
+			return nil, errors.InternalServerError("user not found")
+		}
+
+		return u, nil
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, errors.UnauthorizedError("identity provider did not return a verified email")
+	}
+
+	u, err := s.getUserByEmail(claims.Email, false)
+	if err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	now := time.Now().UTC()
+	isNewUser := u == nil
+	// An unverified account may have been registered by someone else with the victim's email,
+	// so its credentials are discarded before the identity provider's owner takes it over.
+	takeOver := !isNewUser && !u.IsVerified
+	var hashedPassword string
+	if isNewUser || takeOver {
+		password, err := s.authenticator.GenerateRandomToken()
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+		hashedPassword, err = s.authenticator.GenerateHashedPassword(password)
+		if err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+	}
+	if isNewUser {
+		u = &models.User{
+			ID:           uuid.New(),
+			Email:        claims.Email,
+			PasswordHash: hashedPassword,
+			IsActive:     true,
+			IsVerified:   true,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+	}
+
+	identity = &models.UserIdentity{
+		ID:        uuid.New(),
+		UserID:    u.ID,
+		Provider:  provider,
+		Subject:   claims.Subject,
+		Email:     &claims.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.transactionManager.ExecuteInTransaction(s.db, func(tx *gorm.DB) error {
+		if isNewUser {
+			if err := s.userRepo.CreateUser(tx, u); err != nil {
+				return err
+			}
+
+			if err := s.userProfileRepo.CreateOrUpdateUserProfile(tx, &models.UserProfile{
+				ID:        uuid.New(),
+				UserID:    u.ID,
+				FirstName: claims.GivenName,
+				LastName:  claims.FamilyName,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}); err != nil {
+				return err
+			}
+		} else if takeOver {
+			if err := s.updateUserPassword(tx, u, hashedPassword); err != nil {
+				return err
+			}
+			if err := s.userTotpSecretRepo.DeleteUserSecret(tx, u.ID); err != nil {
+				return err
+			}
+			if err := s.userRecoveryCodeRepo.DeleteUserCodes(tx, u.ID); err != nil {
+				return err
+			}
+			if err := s.userRepo.VerifyUser(tx, u); err != nil {
+				return err
+			}
+		}
+
+		return s.userIdentityRepo.CreateIdentity(tx, identity)
+	}); err != nil {
+		return nil, errors.InternalServerError(err.Error())
+	}
+
+	if takeOver {
+		if err := s.transactionManager.ExecuteInRedisTransaction(s.rdb, func(tx *redis.Tx) error {
+			return s.userSessionRepo.DeleteUserSessions(u.ID)
+		}); err != nil {
+			return nil, errors.InternalServerError(err.Error())
+		}
+	}
+
+	return u, nil
+}
+
 func (s *userService) getUserById(id uuid.UUID, includeProfile bool) (*models.User, error) {
 	return s.userRepo.GetUser(filters.UserFilter{
 		Filter: &filters.SingleFilter{},
@@ -432,7 +1013,39 @@ func (s *userService) getUserByEmail(email string, includeProfile bool) (*models
 	}, includeProfile)
 }
 
-func (s *userService) getRemainingUserActivePasswordResetTokens(userID uuid.UUID, tokenValue string) ([]*models.PasswordResetToken, error) {
+func (s *userService) newUserRegistrationToken(currentTime time.Time) (*models.UserRegistrationToken, string, error) {
+	token, err := s.authenticator.GenerateRandomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &models.UserRegistrationToken{
+		ID:         uuid.New(),
+		TokenValue: s.authenticator.HashToken(token),
+		IsUsed:     false,
+		CreatedAt:  currentTime,
+		ExpiresAt:  currentTime.Add(time.Duration(config.AppEnv.UserRegistrationTokenExpireTime) * time.Minute),
+	}, token, nil
+}
+
+func (s *userService) revokeActiveUserRegistrationTokens(tx *gorm.DB, userID uuid.UUID) error {
+	tokens, err := s.userRegistrationTokenRepo.GetTokens(filters.UserRegistrationTokenFilter{
+		Filter:    &filters.MultiFilter{},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: userID},
+		IsUsed:    &filters.Condition{Operator: filters.OpEqual, Value: false},
+		ExpiresAt: &filters.Condition{Operator: filters.OpGreater, Value: time.Now().UTC()},
+	})
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	return s.userRegistrationTokenRepo.RevokeTokens(tx, tokens)
+}
+
+func (s *userService) getRemainingUserActivePasswordResetTokens(userID uuid.UUID, tokenID uuid.UUID) ([]*models.PasswordResetToken, error) {
 	allTokens, err := s.passwordResetTokenRepo.GetTokens(filters.PasswordResetTokenFilter{
 		Filter:    &filters.MultiFilter{},
 		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: userID},
@@ -444,7 +1057,7 @@ func (s *userService) getRemainingUserActivePasswordResetTokens(userID uuid.UUID
 
 	var tokens []*models.PasswordResetToken
 	for _, token := range allTokens {
-		if token.TokenValue != tokenValue {
+		if token.ID != tokenID {
 			tokens = append(tokens, token)
 		}
 	}
@@ -3,11 +3,15 @@ package services
 import (
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	appAuth "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/auth"
+	apiError "github.com/vantutran2k1-movie-reservation-system/reservation-service/app/errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/filters"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_auth"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_services"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/mocks/mock_transaction"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
@@ -15,7 +19,9 @@ import (
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestUserService_GetUser(t *testing.T) {
@@ -23,7 +29,7 @@ func TestUserService_GetUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockUserRepository(ctrl)
-	service := NewUserService(nil, nil, nil, nil, repo, nil, nil, nil, nil, nil, nil)
+	service := NewUserService(nil, nil, nil, nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	user := utils.GenerateUser()
 	filter := filters.UserFilter{
@@ -69,7 +75,7 @@ func TestUserService_UserExistsByEmail(t *testing.T) {
 	defer ctrl.Finish()
 
 	repo := mock_repositories.NewMockUserRepository(ctrl)
-	service := NewUserService(nil, nil, nil, nil, repo, nil, nil, nil, nil, nil, nil)
+	service := NewUserService(nil, nil, nil, nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	user := utils.GenerateUser()
 	filter := filters.UserFilter{
@@ -110,7 +116,8 @@ func TestUserService_CreateUser(t *testing.T) {
 	profileRepo := mock_repositories.NewMockUserProfileRepository(ctrl)
 	userRegisRepo := mock_repositories.NewMockUserRegistrationTokenRepository(ctrl)
 	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
-	service := NewUserService(nil, nil, auth, transaction, userRepo, profileRepo, nil, nil, nil, userRegisRepo, notificationRepo)
+	service := NewUserService(nil, nil, auth, transaction, userRepo, profileRepo, nil, nil, nil, userRegisRepo, notificationRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	requestID := uuid.New()
 
 	user := utils.GenerateUser()
 	req := payloads.CreateUserRequest{
@@ -132,7 +139,8 @@ func TestUserService_CreateUser(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
-		auth.EXPECT().GenerateRegistrationToken().Return("token value").Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -140,10 +148,11 @@ func TestUserService_CreateUser(t *testing.T) {
 		).Times(1)
 		userRepo.EXPECT().CreateOrUpdateUser(gomock.Any(), gomock.Any()).Return(&models.User{}, nil).Times(1)
 		profileRepo.EXPECT().CreateOrUpdateUserProfile(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userRegisRepo.EXPECT().GetTokens(gomock.Any()).Return(nil, nil).Times(1)
 		userRegisRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		notificationRepo.EXPECT().SendUserRegistrationEvent(gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendUserRegistrationEvent(gomock.Any(), requestID, gomock.Any()).Return(nil).Times(1)
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.NotNil(t, result)
 		assert.Nil(t, err)
@@ -151,10 +160,40 @@ func TestUserService_CreateUser(t *testing.T) {
 		assert.Equal(t, user.PasswordHash, result.PasswordHash)
 	})
 
+	t.Run("re-registration revokes previous tokens", func(t *testing.T) {
+		tokens := utils.GenerateUserRegistrationTokens(2)
+
+		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
+		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userRepo.EXPECT().CreateOrUpdateUser(gomock.Any(), gomock.Any()).Return(user, nil).Times(1)
+		profileRepo.EXPECT().CreateOrUpdateUserProfile(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userRegisRepo.EXPECT().GetTokens(gomock.Any()).Return(tokens, nil).Times(1)
+		userRegisRepo.EXPECT().RevokeTokens(gomock.Any(), tokens).Return(nil).Times(1)
+		userRegisRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, token *models.UserRegistrationToken) error {
+				assert.Equal(t, user.ID, token.UserID)
+				return nil
+			},
+		).Times(1)
+		notificationRepo.EXPECT().SendUserRegistrationEvent(gomock.Any(), requestID, gomock.Any()).Return(nil).Times(1)
+
+		result, err := service.CreateUser(req, requestID)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+	})
+
 	t.Run("duplicate email", func(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(true, nil).Times(1)
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -165,7 +204,7 @@ func TestUserService_CreateUser(t *testing.T) {
 	t.Run("error getting user", func(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(false, errors.New("error getting user")).Times(1)
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -177,7 +216,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return("", errors.New("error generating password hash"))
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -188,7 +227,8 @@ func TestUserService_CreateUser(t *testing.T) {
 	t.Run("error creating user", func(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil)
-		auth.EXPECT().GenerateRegistrationToken().Return("token value").Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -196,7 +236,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		).Times(1)
 		userRepo.EXPECT().CreateOrUpdateUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("error creating user")).Times(1)
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -207,7 +247,8 @@ func TestUserService_CreateUser(t *testing.T) {
 	t.Run("error creating profile", func(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
-		auth.EXPECT().GenerateRegistrationToken().Return("token value").Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -216,7 +257,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		userRepo.EXPECT().CreateOrUpdateUser(gomock.Any(), gomock.Any()).Return(&models.User{}, nil).Times(1)
 		profileRepo.EXPECT().CreateOrUpdateUserProfile(gomock.Any(), gomock.Any()).Return(errors.New("error creating profile")).Times(1)
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -224,10 +265,33 @@ func TestUserService_CreateUser(t *testing.T) {
 		assert.Equal(t, "error creating profile", err.Error())
 	})
 
+	t.Run("error revoking previous tokens", func(t *testing.T) {
+		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
+		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userRepo.EXPECT().CreateOrUpdateUser(gomock.Any(), gomock.Any()).Return(&models.User{}, nil).Times(1)
+		profileRepo.EXPECT().CreateOrUpdateUserProfile(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userRegisRepo.EXPECT().GetTokens(gomock.Any()).Return(nil, errors.New("error getting tokens")).Times(1)
+
+		result, err := service.CreateUser(req, requestID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting tokens", err.Error())
+	})
+
 	t.Run("error creating token", func(t *testing.T) {
 		userRepo.EXPECT().UserExists(filter).Return(false, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
-		auth.EXPECT().GenerateRegistrationToken().Return("token value").Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -235,9 +299,10 @@ func TestUserService_CreateUser(t *testing.T) {
 		).Times(1)
 		userRepo.EXPECT().CreateOrUpdateUser(gomock.Any(), gomock.Any()).Return(&models.User{}, nil).Times(1)
 		profileRepo.EXPECT().CreateOrUpdateUserProfile(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userRegisRepo.EXPECT().GetTokens(gomock.Any()).Return(nil, nil).Times(1)
 		userRegisRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(errors.New("error creating token")).Times(1)
 
-		result, err := service.CreateUser(req)
+		result, err := service.CreateUser(req, requestID)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -255,11 +320,15 @@ func TestUserService_LoginUser(t *testing.T) {
 	userRepo := mock_repositories.NewMockUserRepository(ctrl)
 	loginTokenRepo := mock_repositories.NewMockLoginTokenRepository(ctrl)
 	userSessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+	loginChallengeRepo := mock_repositories.NewMockLoginChallengeRepository(ctrl)
+	loginThrottleService := mock_services.NewMockLoginThrottleService(ctrl)
 
-	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, loginTokenRepo, userSessionRepo, nil, nil, nil)
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, loginTokenRepo, userSessionRepo, nil, nil, nil, userTotpSecretRepo, nil, loginChallengeRepo, nil, nil, loginThrottleService, nil, nil)
 
 	user := utils.GenerateUser()
 	token := utils.GenerateLoginToken()
+	clientIp := "127.0.0.1"
 	req := payloads.LoginUserRequest{
 		Email:    "example@example.com",
 		Password: "test password",
@@ -268,15 +337,24 @@ func TestUserService_LoginUser(t *testing.T) {
 		Filter: &filters.SingleFilter{},
 		Email:  &filters.Condition{Operator: filters.OpEqual, Value: req.Email},
 	}
+	secretFilter := filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: user.ID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: true},
+	}
 	tokenFilter := filters.LoginTokenFilter{
 		Filter:     &filters.SingleFilter{Logic: filters.And},
 		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token.TokenValue},
 	}
 
 	t.Run("success", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
-		auth.EXPECT().GenerateLoginToken().Return(token.TokenValue).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(req.Email, clientIp).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
 		loginTokenRepo.EXPECT().GetLoginToken(gomock.Eq(tokenFilter)).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -292,44 +370,105 @@ func TestUserService_LoginUser(t *testing.T) {
 		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
 		userSessionRepo.EXPECT().CreateUserSession(token.TokenValue, gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, challenge, err := service.LoginUser(req, clientIp)
 
 		assert.NotNil(t, result)
+		assert.Nil(t, challenge)
 		assert.Nil(t, err)
 		assert.Equal(t, user.ID, result.UserID)
 		assert.Equal(t, token.TokenValue, result.TokenValue)
 	})
 
+	t.Run("account locked", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(90*time.Second, nil).Times(1)
+
+		result, _, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+		assert.Equal(t, "too many failed login attempts, try again in 90 seconds", err.Error())
+	})
+
+	t.Run("error getting lockout", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), errors.New("error getting lockout")).Times(1)
+
+		result, _, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting lockout", err.Error())
+	})
+
 	t.Run("invalid email", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(nil, nil).Times(1)
+		auth.EXPECT().DoPasswordsMatch(appAuth.DummyPasswordHash, req.Password).Return(false).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(req.Email, clientIp).Return(nil).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, _, err := service.LoginUser(req, clientIp)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
-		assert.Equal(t, fmt.Sprintf("invalid email %s", req.Email), err.Error())
+		assert.Equal(t, "invalid email or password", err.Error())
 	})
 
 	t.Run("invalid password", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(false).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(req.Email, clientIp).Return(nil).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, _, err := service.LoginUser(req, clientIp)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
-		assert.Equal(t, "invalid password", err.Error())
+		assert.Equal(t, "invalid email or password", err.Error())
+	})
+
+	t.Run("error registering failed attempt", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(false).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(req.Email, clientIp).Return(errors.New("error registering attempt")).Times(1)
+
+		result, _, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error registering attempt", err.Error())
+	})
+
+	t.Run("error resetting failed attempts", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(req.Email, clientIp).Return(errors.New("error resetting attempts")).Times(1)
+
+		result, _, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error resetting attempts", err.Error())
 	})
 
 	t.Run("error getting token", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
-		auth.EXPECT().GenerateLoginToken().Return(token.TokenValue).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(req.Email, clientIp).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
 		loginTokenRepo.EXPECT().GetLoginToken(gomock.Eq(tokenFilter)).Return(nil, errors.New("error getting token")).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, _, err := service.LoginUser(req, clientIp)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -338,12 +477,16 @@ func TestUserService_LoginUser(t *testing.T) {
 	})
 
 	t.Run("token already exists", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
-		auth.EXPECT().GenerateLoginToken().Return(token.TokenValue).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(req.Email, clientIp).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
 		loginTokenRepo.EXPECT().GetLoginToken(gomock.Eq(tokenFilter)).Return(token, nil).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, _, err := service.LoginUser(req, clientIp)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -352,9 +495,13 @@ func TestUserService_LoginUser(t *testing.T) {
 	})
 
 	t.Run("error creating token", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
-		auth.EXPECT().GenerateLoginToken().Return(token.TokenValue).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(req.Email, clientIp).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
 		loginTokenRepo.EXPECT().GetLoginToken(gomock.Eq(tokenFilter)).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -363,7 +510,7 @@ func TestUserService_LoginUser(t *testing.T) {
 		).Times(1)
 		loginTokenRepo.EXPECT().CreateLoginToken(gomock.Any(), gomock.Any()).Return(errors.New("error creating token")).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, _, err := service.LoginUser(req, clientIp)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -372,9 +519,13 @@ func TestUserService_LoginUser(t *testing.T) {
 	})
 
 	t.Run("error creating user session", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
-		auth.EXPECT().GenerateLoginToken().Return(token.TokenValue).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(req.Email, clientIp).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
 		loginTokenRepo.EXPECT().GetLoginToken(gomock.Eq(tokenFilter)).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -390,29 +541,174 @@ func TestUserService_LoginUser(t *testing.T) {
 		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
 		userSessionRepo.EXPECT().CreateUserSession(token.TokenValue, gomock.Any(), gomock.Any()).Return(errors.New("error creating session")).Times(1)
 
-		result, err := service.LoginUser(req)
+		result, _, err := service.LoginUser(req, clientIp)
 
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error creating session", err.Error())
 	})
+
+	t.Run("two-factor challenge", func(t *testing.T) {
+		challengeToken := uuid.NewString()
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(utils.GenerateUserTotpSecret(), nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(challengeToken, nil).Times(1)
+		auth.EXPECT().HashToken(challengeToken).Return("hashed challenge").Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		loginChallengeRepo.EXPECT().CreateLoginChallenge("hashed challenge", gomock.Any(), &models.UserSession{UserID: user.ID, Email: user.Email}).Return(nil).Times(1)
+
+		result, challenge, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, challenge)
+		assert.Nil(t, err)
+		assert.Equal(t, challengeToken, challenge.Token)
+	})
+
+	t.Run("error getting totp secret", func(t *testing.T) {
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, errors.New("error getting secret")).Times(1)
+
+		result, challenge, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting secret", err.Error())
+	})
+
+	t.Run("error creating login challenge", func(t *testing.T) {
+		challengeToken := uuid.NewString()
+		loginThrottleService.EXPECT().GetLockout(req.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(utils.GenerateUserTotpSecret(), nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(challengeToken, nil).Times(1)
+		auth.EXPECT().HashToken(challengeToken).Return("hashed challenge").Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		loginChallengeRepo.EXPECT().CreateLoginChallenge("hashed challenge", gomock.Any(), gomock.Any()).Return(errors.New("error creating challenge")).Times(1)
+
+		result, challenge, err := service.LoginUser(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error creating challenge", err.Error())
+	})
+}
+
+func TestUserService_LoginUser_TwoFactorLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userRepo := mock_repositories.NewMockUserRepository(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+	userRecoveryCodeRepo := mock_repositories.NewMockUserRecoveryCodeRepository(ctrl)
+	loginChallengeRepo := mock_repositories.NewMockLoginChallengeRepository(ctrl)
+	loginThrottleService := mock_services.NewMockLoginThrottleService(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, nil, nil, nil, nil, nil, userTotpSecretRepo, userRecoveryCodeRepo, loginChallengeRepo, nil, nil, loginThrottleService, nil, nil)
+
+	user := utils.GenerateUser()
+	secret := utils.GenerateUserTotpSecret()
+	secret.UserID = user.ID
+	secret.IsEnabled = true
+	clientIp := "127.0.0.1"
+	maxAttempts := 5
+	req := payloads.LoginUserRequest{
+		Email:    user.Email,
+		Password: "test password",
+	}
+
+	failedAttempts := 0
+	loginThrottleService.EXPECT().GetLockout(user.Email, clientIp).DoAndReturn(func(string, string) (time.Duration, error) {
+		if failedAttempts >= maxAttempts {
+			return time.Minute, nil
+		}
+		return 0, nil
+	}).AnyTimes()
+	loginThrottleService.EXPECT().RegisterFailedAttempt(user.Email, clientIp).DoAndReturn(func(string, string) error {
+		failedAttempts++
+		return nil
+	}).AnyTimes()
+	loginThrottleService.EXPECT().ResetFailedAttempts(user.Email, clientIp).DoAndReturn(func(string, string) error {
+		failedAttempts = 0
+		return nil
+	}).AnyTimes()
+
+	session := &models.UserSession{UserID: user.ID, Email: user.Email}
+	userRepo.EXPECT().GetUser(gomock.Any(), false).Return(user, nil).AnyTimes()
+	auth.EXPECT().DoPasswordsMatch(user.PasswordHash, req.Password).Return(true).AnyTimes()
+	userTotpSecretRepo.EXPECT().GetSecret(gomock.Any()).Return(secret, nil).AnyTimes()
+	auth.EXPECT().GenerateRandomToken().Return("challenge", nil).AnyTimes()
+	auth.EXPECT().HashToken(gomock.Any()).Return("hashed").AnyTimes()
+	transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+			return fn(nil)
+		},
+	).AnyTimes()
+	loginChallengeRepo.EXPECT().CreateLoginChallenge("hashed", gomock.Any(), session).Return(nil).AnyTimes()
+	loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge("hashed").Return(session, nil).AnyTimes()
+	auth.EXPECT().ValidateTotpCode(secret.Secret, "000000", gomock.Any()).Return(int64(0), false).AnyTimes()
+	userRecoveryCodeRepo.EXPECT().GetCode(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	for i := 0; i < maxAttempts; i++ {
+		_, challenge, err := service.LoginUser(req, clientIp)
+		assert.Nil(t, err)
+		assert.NotNil(t, challenge)
+
+		result, err := service.VerifyTwoFactorLogin(payloads.VerifyTwoFactorLoginRequest{ChallengeToken: challenge.Token, Code: "000000"}, clientIp)
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+	}
+
+	result, challenge, err := service.LoginUser(req, clientIp)
+
+	assert.Nil(t, result)
+	assert.Nil(t, challenge)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
 }
 
 func TestUserService_LogoutUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	auth := mock_auth.NewMockAuthenticator(ctrl)
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	userSessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
 	loginTokenRepo := mock_repositories.NewMockLoginTokenRepository(ctrl)
 
-	service := NewUserService(nil, nil, nil, transaction, nil, nil, loginTokenRepo, userSessionRepo, nil, nil, nil)
+	service := NewUserService(nil, nil, auth, transaction, nil, nil, loginTokenRepo, userSessionRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	token := utils.GenerateLoginToken()
+	tokenFilter := filters.LoginTokenFilter{
+		Filter:     &filters.SingleFilter{Logic: filters.And},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token.TokenValue},
+	}
 
 	t.Run("success", func(t *testing.T) {
-		loginTokenRepo.EXPECT().GetLoginToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, token.Token).Return(true).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -427,15 +723,28 @@ func TestUserService_LogoutUser(t *testing.T) {
 		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
 		userSessionRepo.EXPECT().DeleteUserSession(token.TokenValue).Return(nil).Times(1)
 
-		err := service.LogoutUser(token.TokenValue)
+		err := service.LogoutUser(token.Token)
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("token not found", func(t *testing.T) {
-		loginTokenRepo.EXPECT().GetLoginToken(gomock.Any()).Return(nil, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(nil, nil).Times(1)
+
+		err := service.LogoutUser(token.Token)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "token not found", err.Error())
+	})
+
+	t.Run("token hash mismatch", func(t *testing.T) {
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, token.Token).Return(false).Times(1)
 
-		err := service.LogoutUser(token.TokenValue)
+		err := service.LogoutUser(token.Token)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
@@ -443,9 +752,10 @@ func TestUserService_LogoutUser(t *testing.T) {
 	})
 
 	t.Run("error getting token", func(t *testing.T) {
-		loginTokenRepo.EXPECT().GetLoginToken(gomock.Any()).Return(nil, errors.New("error getting token")).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(nil, errors.New("error getting token")).Times(1)
 
-		err := service.LogoutUser(token.TokenValue)
+		err := service.LogoutUser(token.Token)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -453,7 +763,9 @@ func TestUserService_LogoutUser(t *testing.T) {
 	})
 
 	t.Run("error revoking token", func(t *testing.T) {
-		loginTokenRepo.EXPECT().GetLoginToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, token.Token).Return(true).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -461,7 +773,7 @@ func TestUserService_LogoutUser(t *testing.T) {
 		).Times(1)
 		loginTokenRepo.EXPECT().RevokeLoginToken(gomock.Any(), token).Return(errors.New("error revoking token")).Times(1)
 
-		err := service.LogoutUser(token.TokenValue)
+		err := service.LogoutUser(token.Token)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -469,7 +781,9 @@ func TestUserService_LogoutUser(t *testing.T) {
 	})
 
 	t.Run("error deleting session", func(t *testing.T) {
-		loginTokenRepo.EXPECT().GetLoginToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, token.Token).Return(true).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
@@ -484,7 +798,7 @@ func TestUserService_LogoutUser(t *testing.T) {
 		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
 		userSessionRepo.EXPECT().DeleteUserSession(token.TokenValue).Return(errors.New("error deleting session")).Times(1)
 
-		err := service.LogoutUser(token.TokenValue)
+		err := service.LogoutUser(token.Token)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -496,14 +810,16 @@ func TestUserService_VerifyUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	auth := mock_auth.NewMockAuthenticator(ctrl)
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	userRepo := mock_repositories.NewMockUserRepository(ctrl)
 	tokenRepo := mock_repositories.NewMockUserRegistrationTokenRepository(ctrl)
 
-	service := NewUserService(nil, nil, nil, transaction, userRepo, nil, nil, nil, nil, tokenRepo, nil)
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, nil, nil, nil, tokenRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	user := utils.GenerateUser()
 	user.IsVerified = false
+	rawToken := "raw token"
 	token := utils.GenerateUserRegistrationToken()
 	token.UserID = user.ID
 	userFilter := filters.UserFilter{
@@ -512,7 +828,9 @@ func TestUserService_VerifyUser(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(gomock.Eq(userFilter), false).Return(user, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -522,15 +840,16 @@ func TestUserService_VerifyUser(t *testing.T) {
 		userRepo.EXPECT().VerifyUser(gomock.Any(), user).Return(nil).Times(1)
 		tokenRepo.EXPECT().UseToken(gomock.Any(), token).Return(nil).Times(1)
 
-		err := service.VerifyUser(token.TokenValue)
+		err := service.VerifyUser(rawToken)
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("error getting token", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(nil, errors.New("error getting token")).Times(1)
 
-		err := service.VerifyUser(token.TokenValue)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "error getting token")
@@ -538,9 +857,10 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 
 	t.Run("token not found", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(nil, nil).Times(1)
 
-		err := service.VerifyUser(token.TokenValue)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "invalid or expired token")
@@ -548,10 +868,12 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 
 	t.Run("error getting user", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(gomock.Eq(userFilter), false).Return(nil, errors.New("error getting user")).Times(1)
 
-		err := service.VerifyUser(user.Email)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "error getting user")
@@ -559,10 +881,12 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(gomock.Eq(userFilter), false).Return(nil, nil).Times(1)
 
-		err := service.VerifyUser(user.Email)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "user not found")
@@ -570,10 +894,12 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 
 	t.Run("user is verified", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(gomock.Eq(userFilter), false).Return(&models.User{IsVerified: true}, nil).Times(1)
 
-		err := service.VerifyUser(user.Email)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "user is already verified")
@@ -581,7 +907,9 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 
 	t.Run("error updating user", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(gomock.Eq(userFilter), false).Return(user, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -590,7 +918,7 @@ func TestUserService_VerifyUser(t *testing.T) {
 		).Times(1)
 		userRepo.EXPECT().VerifyUser(gomock.Any(), user).Return(errors.New("error updating user")).Times(1)
 
-		err := service.VerifyUser(user.Email)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "error updating user")
@@ -598,7 +926,9 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 
 	t.Run("error updating token", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(token.TokenValue).Times(1)
 		tokenRepo.EXPECT().GetToken(gomock.Any()).Return(token, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(token.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(gomock.Eq(userFilter), false).Return(user, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -608,7 +938,7 @@ func TestUserService_VerifyUser(t *testing.T) {
 		userRepo.EXPECT().VerifyUser(gomock.Any(), user).Return(nil).Times(1)
 		tokenRepo.EXPECT().UseToken(gomock.Any(), token).Return(errors.New("error updating token")).Times(1)
 
-		err := service.VerifyUser(user.Email)
+		err := service.VerifyUser(rawToken)
 
 		assert.NotNil(t, err)
 		assert.EqualError(t, err, "error updating token")
@@ -616,6 +946,154 @@ func TestUserService_VerifyUser(t *testing.T) {
 	})
 }
 
+func TestUserService_ResendVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userRepo := mock_repositories.NewMockUserRepository(ctrl)
+	tokenRepo := mock_repositories.NewMockUserRegistrationTokenRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
+	limiter := mock_services.NewMockRateLimiterService(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, nil, nil, nil, tokenRepo, notificationRepo, nil, nil, nil, nil, nil, nil, limiter, nil)
+	requestID := uuid.New()
+
+	user := utils.GenerateUser()
+	user.Email = "example@example.com"
+	user.IsVerified = false
+	user.Profile = utils.GenerateUserProfile()
+	req := payloads.ResendVerificationEmailRequest{Email: "Example@example.com"}
+	userFilter := filters.UserFilter{
+		Filter: &filters.SingleFilter{},
+		Email:  &filters.Condition{Operator: filters.OpEqual, Value: req.Email},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		tokens := utils.GenerateUserRegistrationTokens(2)
+
+		limiter.EXPECT().Allow("example@example.com").Return(true, time.Duration(0)).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		tokenRepo.EXPECT().GetTokens(gomock.Any()).Return(tokens, nil).Times(1)
+		tokenRepo.EXPECT().RevokeTokens(gomock.Any(), tokens).Return(nil).Times(1)
+		tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, token *models.UserRegistrationToken) error {
+				assert.Equal(t, user.ID, token.UserID)
+				assert.Equal(t, "hashed token value", token.TokenValue)
+				assert.False(t, token.IsUsed)
+				return nil
+			},
+		).Times(1)
+		notificationRepo.EXPECT().SendUserRegistrationEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.UserRegistrationEvent) error {
+				assert.Equal(t, user.ID, e.UserID)
+				assert.Equal(t, user.Email, e.Email)
+				assert.Equal(t, user.Profile.FirstName, e.FirstName)
+				assert.Equal(t, user.Profile.LastName, e.LastName)
+				assert.Equal(t, "token value", e.VerificationToken)
+				return nil
+			},
+		).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		limiter.EXPECT().Allow("example@example.com").Return(false, 90*time.Second).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+		assert.Equal(t, "too many verification emails requested, try again in 90 seconds", err.Error())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		limiter.EXPECT().Allow("example@example.com").Return(true, time.Duration(0)).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(nil, nil).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("user already verified", func(t *testing.T) {
+		verifiedUser := *user
+		verifiedUser.IsVerified = true
+
+		limiter.EXPECT().Allow("example@example.com").Return(true, time.Duration(0)).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(&verifiedUser, nil).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("error getting user", func(t *testing.T) {
+		limiter.EXPECT().Allow("example@example.com").Return(true, time.Duration(0)).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(nil, errors.New("error getting user")).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting user", err.Error())
+	})
+
+	t.Run("error revoking tokens", func(t *testing.T) {
+		tokens := utils.GenerateUserRegistrationTokens(1)
+
+		limiter.EXPECT().Allow("example@example.com").Return(true, time.Duration(0)).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		tokenRepo.EXPECT().GetTokens(gomock.Any()).Return(tokens, nil).Times(1)
+		tokenRepo.EXPECT().RevokeTokens(gomock.Any(), tokens).Return(errors.New("error revoking tokens")).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error revoking tokens", err.Error())
+	})
+
+	t.Run("error sending event", func(t *testing.T) {
+		limiter.EXPECT().Allow("example@example.com").Return(true, time.Duration(0)).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("token value", nil).Times(1)
+		auth.EXPECT().HashToken("token value").Return("hashed token value").Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		tokenRepo.EXPECT().GetTokens(gomock.Any()).Return(nil, nil).Times(1)
+		tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendUserRegistrationEvent(gomock.Any(), requestID, gomock.Any()).Return(errors.New("error sending event")).Times(1)
+
+		err := service.ResendVerificationEmail(req, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error sending event", err.Error())
+	})
+}
+
 func TestUserService_UpdateUserPassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -626,7 +1104,7 @@ func TestUserService_UpdateUserPassword(t *testing.T) {
 	loginTokenRepo := mock_repositories.NewMockLoginTokenRepository(ctrl)
 	userSessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
 
-	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, loginTokenRepo, userSessionRepo, nil, nil, nil)
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, loginTokenRepo, userSessionRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	user := utils.GenerateUser()
 	req := payloads.UpdatePasswordRequest{Password: "example password"}
@@ -772,11 +1250,15 @@ func TestUserService_CreatePasswordResetToken(t *testing.T) {
 	transaction := mock_transaction.NewMockTransactionManager(ctrl)
 	userRepo := mock_repositories.NewMockUserRepository(ctrl)
 	tokenRepo := mock_repositories.NewMockPasswordResetTokenRepository(ctrl)
+	notificationRepo := mock_repositories.NewMockNotificationRepository(ctrl)
 
-	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, nil, nil, tokenRepo, nil, nil)
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, nil, nil, tokenRepo, nil, notificationRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	requestID := uuid.New()
 
 	user := utils.GenerateUser()
+	user.Profile = utils.GenerateUserProfile()
 	token := utils.GeneratePasswordResetToken()
+	tokenHash := "hashed token"
 	req := payloads.CreatePasswordResetTokenRequest{
 		Email: "example@example.com",
 	}
@@ -786,80 +1268,91 @@ func TestUserService_CreatePasswordResetToken(t *testing.T) {
 	}
 	tokenFilter := filters.PasswordResetTokenFilter{
 		Filter:     &filters.SingleFilter{},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token.TokenValue},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: tokenHash},
 	}
 
 	t.Run("success", func(t *testing.T) {
-		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
-		auth.EXPECT().GeneratePasswordResetToken().Return(token.TokenValue).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.TokenValue, nil).Times(1)
+		auth.EXPECT().HashToken(token.TokenValue).Return(tokenHash).Times(1)
 		tokenRepo.EXPECT().GetToken(tokenFilter).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 				return fn(db)
 			},
 		).Times(1)
-		tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, created *models.PasswordResetToken) error {
+				assert.Equal(t, user.ID, created.UserID)
+				assert.Equal(t, tokenHash, created.TokenValue)
+				assert.False(t, created.IsUsed)
+				return nil
+			},
+		).Times(1)
+		notificationRepo.EXPECT().SendPasswordResetRequestedEvent(gomock.Any(), requestID, gomock.Any()).DoAndReturn(
+			func(tx *gorm.DB, _ uuid.UUID, e payloads.PasswordResetRequestedEvent) error {
+				assert.Equal(t, user.ID, e.UserID)
+				assert.Equal(t, user.Email, e.Email)
+				assert.Equal(t, user.Profile.FirstName, e.FirstName)
+				assert.Equal(t, user.Profile.LastName, e.LastName)
+				assert.Equal(t, token.TokenValue, e.ResetToken)
+				return nil
+			},
+		).Times(1)
 
-		result, err := service.CreatePasswordResetToken(req)
+		err := service.CreatePasswordResetToken(req, requestID)
 
-		assert.NotNil(t, result)
 		assert.Nil(t, err)
-		assert.Equal(t, user.ID, result.UserID)
-		assert.Equal(t, token.TokenValue, result.TokenValue)
-		assert.Equal(t, false, result.IsUsed)
 	})
 
 	t.Run("error getting user", func(t *testing.T) {
-		userRepo.EXPECT().GetUser(userFilter, false).Return(nil, errors.New("error getting user")).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(nil, errors.New("error getting user")).Times(1)
 
-		result, err := service.CreatePasswordResetToken(req)
+		err := service.CreatePasswordResetToken(req, requestID)
 
-		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting user", err.Error())
 	})
 
-	t.Run("error email not found", func(t *testing.T) {
-		userRepo.EXPECT().GetUser(userFilter, false).Return(nil, nil).Times(1)
+	t.Run("email not found", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, true).Return(nil, nil).Times(1)
 
-		result, err := service.CreatePasswordResetToken(req)
+		err := service.CreatePasswordResetToken(req, requestID)
 
-		assert.Nil(t, result)
-		assert.NotNil(t, err)
-		assert.Equal(t, http.StatusNotFound, err.StatusCode)
-		assert.Equal(t, fmt.Sprintf("email %s does not exist", req.Email), err.Error())
+		assert.Nil(t, err)
 	})
 
 	t.Run("error getting active tokens", func(t *testing.T) {
-		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
-		auth.EXPECT().GeneratePasswordResetToken().Return(token.TokenValue).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.TokenValue, nil).Times(1)
+		auth.EXPECT().HashToken(token.TokenValue).Return(tokenHash).Times(1)
 		tokenRepo.EXPECT().GetToken(tokenFilter).Return(nil, errors.New("error getting tokens")).Times(1)
 
-		result, err := service.CreatePasswordResetToken(req)
+		err := service.CreatePasswordResetToken(req, requestID)
 
-		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error getting tokens", err.Error())
 	})
 
 	t.Run("duplicate token", func(t *testing.T) {
-		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
-		auth.EXPECT().GeneratePasswordResetToken().Return(token.TokenValue).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.TokenValue, nil).Times(1)
+		auth.EXPECT().HashToken(token.TokenValue).Return(tokenHash).Times(1)
 		tokenRepo.EXPECT().GetToken(tokenFilter).Return(token, nil).Times(1)
 
-		result, err := service.CreatePasswordResetToken(req)
+		err := service.CreatePasswordResetToken(req, requestID)
 
-		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "token value already exists", err.Error())
 	})
 
 	t.Run("error creating token", func(t *testing.T) {
-		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
-		auth.EXPECT().GeneratePasswordResetToken().Return(token.TokenValue).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.TokenValue, nil).Times(1)
+		auth.EXPECT().HashToken(token.TokenValue).Return(tokenHash).Times(1)
 		tokenRepo.EXPECT().GetToken(tokenFilter).Return(nil, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
@@ -868,28 +1361,49 @@ func TestUserService_CreatePasswordResetToken(t *testing.T) {
 		).Times(1)
 		tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(errors.New("error creating token")).Times(1)
 
-		result, err := service.CreatePasswordResetToken(req)
+		err := service.CreatePasswordResetToken(req, requestID)
 
-		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error creating token", err.Error())
 	})
-}
-
-func TestUserService_ResetUserPassword(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
 
-	auth := mock_auth.NewMockAuthenticator(ctrl)
-	transaction := mock_transaction.NewMockTransactionManager(ctrl)
-	userRepo := mock_repositories.NewMockUserRepository(ctrl)
+	t.Run("error sending event", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, true).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.TokenValue, nil).Times(1)
+		auth.EXPECT().HashToken(token.TokenValue).Return(tokenHash).Times(1)
+		tokenRepo.EXPECT().GetToken(tokenFilter).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		tokenRepo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		notificationRepo.EXPECT().SendPasswordResetRequestedEvent(gomock.Any(), requestID, gomock.Any()).Return(errors.New("error sending event")).Times(1)
+
+		err := service.CreatePasswordResetToken(req, requestID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error sending event", err.Error())
+	})
+}
+
+func TestUserService_ResetUserPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userRepo := mock_repositories.NewMockUserRepository(ctrl)
 	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
 	loginTokenRepo := mock_repositories.NewMockLoginTokenRepository(ctrl)
 	resetTokenRepo := mock_repositories.NewMockPasswordResetTokenRepository(ctrl)
+	loginThrottleService := mock_services.NewMockLoginThrottleService(ctrl)
 
-	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, loginTokenRepo, sessionRepo, resetTokenRepo, nil, nil)
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, loginTokenRepo, sessionRepo, resetTokenRepo, nil, nil, nil, nil, nil, nil, nil, loginThrottleService, nil, nil)
 
+	rawToken := "raw token"
 	resetToken := utils.GeneratePasswordResetToken()
 	user := utils.GenerateUser()
 	allResetTokens := utils.GeneratePasswordResetTokens(3)
@@ -900,13 +1414,30 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		Filter: &filters.SingleFilter{},
 		ID:     &filters.Condition{Operator: filters.OpEqual, Value: resetToken.UserID},
 	}
-	tokenFilter := filters.PasswordResetTokenFilter{
-		Filter:     &filters.SingleFilter{},
-		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: resetToken.TokenValue},
+	tokenFilter := gomock.Cond(func(x any) bool {
+		f, ok := x.(filters.PasswordResetTokenFilter)
+		if !ok || f.ExpiresAt == nil || f.ExpiresAt.Operator != filters.OpGreater {
+			return false
+		}
+		expiresAt, ok := f.ExpiresAt.Value.(time.Time)
+		return ok && time.Since(expiresAt) < time.Minute &&
+			reflect.DeepEqual(f.TokenValue, &filters.Condition{Operator: filters.OpEqual, Value: resetToken.TokenValue}) &&
+			reflect.DeepEqual(f.IsUsed, &filters.Condition{Operator: filters.OpEqual, Value: false})
+	})
+	// getToken applies the used and expiry conditions of the filter the way the database would.
+	getToken := func(token *models.PasswordResetToken) func(filters.PasswordResetTokenFilter) (*models.PasswordResetToken, error) {
+		return func(f filters.PasswordResetTokenFilter) (*models.PasswordResetToken, error) {
+			if token.IsUsed != f.IsUsed.Value.(bool) || !token.ExpiresAt.After(f.ExpiresAt.Value.(time.Time)) {
+				return nil, nil
+			}
+			return token, nil
+		}
 	}
 
 	t.Run("success", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -925,16 +1456,47 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 			},
 		).Times(1)
 		sessionRepo.EXPECT().DeleteUserSessions(user.ID).Return(nil).Times(1)
+		loginThrottleService.EXPECT().UnlockAccount(user.Email).Return(nil).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("reset token not found", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(nil, nil).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid or expired token", err.Error())
+	})
+
+	t.Run("expired reset token", func(t *testing.T) {
+		expired := *resetToken
+		expired.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
+		resetTokenRepo.EXPECT().GetToken(tokenFilter).DoAndReturn(getToken(&expired)).Times(1)
+
+		err := service.ResetUserPassword(rawToken, req)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid or expired token", err.Error())
+	})
+
+	t.Run("used reset token", func(t *testing.T) {
+		used := *resetToken
+		used.IsUsed = true
+		used.ExpiresAt = time.Now().UTC().Add(time.Hour)
+
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
+		resetTokenRepo.EXPECT().GetToken(tokenFilter).DoAndReturn(getToken(&used)).Times(1)
+
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
@@ -942,9 +1504,10 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error getting active token", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(nil, errors.New("error getting token")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -952,10 +1515,12 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(nil, nil).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -963,10 +1528,12 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error getting user", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(nil, errors.New("error getting user")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -974,11 +1541,13 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error generating password", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return("", errors.New("error generating password")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -986,7 +1555,9 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error updating password", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -996,7 +1567,7 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		).Times(1)
 		userRepo.EXPECT().UpdatePassword(gomock.Any(), user, user.PasswordHash).Return(nil, errors.New("error updating password")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -1004,7 +1575,9 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error revoking user login tokens", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -1015,7 +1588,7 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		userRepo.EXPECT().UpdatePassword(gomock.Any(), user, user.PasswordHash).Return(user, nil).Times(1)
 		loginTokenRepo.EXPECT().RevokeUserLoginTokens(gomock.Any(), user.ID).Return(errors.New("error revoking tokens")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -1023,7 +1596,9 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error using reset token", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -1035,7 +1610,7 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		loginTokenRepo.EXPECT().RevokeUserLoginTokens(gomock.Any(), user.ID).Return(nil).Times(1)
 		resetTokenRepo.EXPECT().UseToken(gomock.Any(), resetToken).Return(errors.New("error using token")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -1043,7 +1618,9 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error getting password reset tokens", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -1056,7 +1633,7 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		resetTokenRepo.EXPECT().UseToken(gomock.Any(), resetToken).Return(nil).Times(1)
 		resetTokenRepo.EXPECT().GetTokens(gomock.Any()).Return(nil, errors.New("error getting tokens")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -1064,7 +1641,9 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error revoking reset tokens", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -1078,7 +1657,7 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		resetTokenRepo.EXPECT().GetTokens(gomock.Any()).Return(allResetTokens, nil).Times(1)
 		resetTokenRepo.EXPECT().RevokeTokens(gomock.Any(), gomock.Any()).Return(errors.New("error revoking tokens")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
@@ -1086,7 +1665,9 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 	})
 
 	t.Run("error deleting user sessions", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
 		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
 		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
 		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
 		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -1106,10 +1687,940 @@ func TestUserService_ResetUserPassword(t *testing.T) {
 		).Times(1)
 		sessionRepo.EXPECT().DeleteUserSessions(user.ID).Return(errors.New("error deleting tokens")).Times(1)
 
-		err := service.ResetUserPassword(resetToken.TokenValue, req)
+		err := service.ResetUserPassword(rawToken, req)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
 		assert.Equal(t, "error deleting tokens", err.Error())
 	})
+
+	t.Run("error unlocking account", func(t *testing.T) {
+		auth.EXPECT().HashToken(rawToken).Return(resetToken.TokenValue).Times(1)
+		resetTokenRepo.EXPECT().GetToken(tokenFilter).Return(resetToken, nil).Times(1)
+		auth.EXPECT().DoTokensMatch(resetToken.TokenValue, rawToken).Return(true).Times(1)
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		auth.EXPECT().GenerateHashedPassword(req.Password).Return(user.PasswordHash, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userRepo.EXPECT().UpdatePassword(gomock.Any(), user, user.PasswordHash).Return(user, nil).Times(1)
+		loginTokenRepo.EXPECT().RevokeUserLoginTokens(gomock.Any(), user.ID).Return(nil).Times(1)
+		resetTokenRepo.EXPECT().UseToken(gomock.Any(), resetToken).Return(nil).Times(1)
+		resetTokenRepo.EXPECT().GetTokens(gomock.Any()).Return(allResetTokens, nil).Times(1)
+		resetTokenRepo.EXPECT().RevokeTokens(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		sessionRepo.EXPECT().DeleteUserSessions(user.ID).Return(nil).Times(1)
+		loginThrottleService.EXPECT().UnlockAccount(user.Email).Return(errors.New("error unlocking account")).Times(1)
+
+		err := service.ResetUserPassword(rawToken, req)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error unlocking account", err.Error())
+	})
+}
+
+func TestUserService_VerifyTwoFactorLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	loginTokenRepo := mock_repositories.NewMockLoginTokenRepository(ctrl)
+	userSessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+	userRecoveryCodeRepo := mock_repositories.NewMockUserRecoveryCodeRepository(ctrl)
+	loginChallengeRepo := mock_repositories.NewMockLoginChallengeRepository(ctrl)
+	loginThrottleService := mock_services.NewMockLoginThrottleService(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, nil, nil, loginTokenRepo, userSessionRepo, nil, nil, nil, userTotpSecretRepo, userRecoveryCodeRepo, loginChallengeRepo, nil, nil, loginThrottleService, nil, nil)
+
+	session := utils.GenerateUserSession()
+	secret := utils.GenerateUserTotpSecret()
+	secret.UserID = session.UserID
+	secret.IsEnabled = true
+	token := utils.GenerateLoginToken()
+	clientIp := "127.0.0.1"
+	req := payloads.VerifyTwoFactorLoginRequest{
+		ChallengeToken: uuid.NewString(),
+		Code:           "123456",
+	}
+	challengeHash := "hashed challenge"
+	secretFilter := filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: session.UserID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: true},
+	}
+	codeFilter := filters.UserRecoveryCodeFilter{
+		Filter:   &filters.SingleFilter{Logic: filters.And},
+		UserID:   &filters.Condition{Operator: filters.OpEqual, Value: session.UserID},
+		CodeHash: &filters.Condition{Operator: filters.OpEqual, Value: "hashed code"},
+		IsUsed:   &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+	tokenFilter := filters.LoginTokenFilter{
+		Filter:     &filters.SingleFilter{Logic: filters.And},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token.TokenValue},
+	}
+
+	t.Run("success with totp code", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(secret.LastUsedStep+1, true).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(2)
+		userTotpSecretRepo.EXPECT().UpdateLastUsedStep(gomock.Any(), secret, secret.LastUsedStep+1).Return(true, nil).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(session.Email, clientIp).Return(nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(nil, nil).Times(1)
+		loginTokenRepo.EXPECT().CreateLoginToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
+		userSessionRepo.EXPECT().CreateUserSession(token.TokenValue, gomock.Any(), session).Return(nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, session.UserID, result.UserID)
+		assert.Equal(t, token.TokenValue, result.TokenValue)
+	})
+
+	t.Run("success with recovery code", func(t *testing.T) {
+		code := utils.GenerateUserRecoveryCode()
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(0), false).Times(1)
+		auth.EXPECT().HashToken(req.Code).Return("hashed code").Times(1)
+		userRecoveryCodeRepo.EXPECT().GetCode(codeFilter).Return(code, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(2)
+		userRecoveryCodeRepo.EXPECT().UseCode(gomock.Any(), code).Return(true, nil).Times(1)
+		loginThrottleService.EXPECT().ResetFailedAttempts(session.Email, clientIp).Return(nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(nil, nil).Times(1)
+		loginTokenRepo.EXPECT().CreateLoginToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
+		userSessionRepo.EXPECT().CreateUserSession(token.TokenValue, gomock.Any(), session).Return(nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, token.TokenValue, result.TokenValue)
+	})
+
+	t.Run("challenge not found", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(nil, nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid or expired challenge", err.Error())
+	})
+
+	t.Run("account locked", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(30*time.Second, nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, err.StatusCode)
+		assert.Equal(t, "too many failed login attempts, try again in 30 seconds", err.Error())
+	})
+
+	t.Run("two-factor disabled", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid or expired challenge", err.Error())
+	})
+
+	t.Run("replayed totp code", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(secret.LastUsedStep, true).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(session.Email, clientIp).Return(nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(0), false).Times(1)
+		auth.EXPECT().HashToken(req.Code).Return("hashed code").Times(1)
+		userRecoveryCodeRepo.EXPECT().GetCode(codeFilter).Return(nil, nil).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(session.Email, clientIp).Return(nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+	})
+
+	t.Run("error getting recovery code", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(0), false).Times(1)
+		auth.EXPECT().HashToken(req.Code).Return("hashed code").Times(1)
+		userRecoveryCodeRepo.EXPECT().GetCode(codeFilter).Return(nil, errors.New("error getting code")).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting code", err.Error())
+	})
+
+	t.Run("totp code used concurrently", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(secret.LastUsedStep+1, true).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userTotpSecretRepo.EXPECT().UpdateLastUsedStep(gomock.Any(), secret, secret.LastUsedStep+1).Return(false, nil).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(session.Email, clientIp).Return(nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+	})
+
+	t.Run("recovery code used concurrently", func(t *testing.T) {
+		code := utils.GenerateUserRecoveryCode()
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(session, nil).Times(1)
+		loginThrottleService.EXPECT().GetLockout(session.Email, clientIp).Return(time.Duration(0), nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(0), false).Times(1)
+		auth.EXPECT().HashToken(req.Code).Return("hashed code").Times(1)
+		userRecoveryCodeRepo.EXPECT().GetCode(codeFilter).Return(code, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userRecoveryCodeRepo.EXPECT().UseCode(gomock.Any(), code).Return(false, nil).Times(1)
+		loginThrottleService.EXPECT().RegisterFailedAttempt(session.Email, clientIp).Return(nil).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+	})
+
+	t.Run("error getting challenge", func(t *testing.T) {
+		auth.EXPECT().HashToken(req.ChallengeToken).Return(challengeHash).Times(1)
+		loginChallengeRepo.EXPECT().GetAndDeleteLoginChallenge(challengeHash).Return(nil, errors.New("error getting challenge")).Times(1)
+
+		result, err := service.VerifyTwoFactorLogin(req, clientIp)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error getting challenge", err.Error())
+	})
+}
+
+func TestUserService_EnrollTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userRepo := mock_repositories.NewMockUserRepository(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, userRepo, nil, nil, nil, nil, nil, nil, userTotpSecretRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	user := utils.GenerateUser()
+	secretValue := "JBSWY3DPEHPK3PXP"
+	userFilter := filters.UserFilter{
+		Filter: &filters.SingleFilter{},
+		ID:     &filters.Condition{Operator: filters.OpEqual, Value: user.ID},
+	}
+	secretFilter := filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: user.ID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: true},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateTotpSecret().Return(secretValue, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userTotpSecretRepo.EXPECT().DeleteUserSecret(gomock.Any(), user.ID).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().CreateSecret(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		auth.EXPECT().GetTotpProvisioningUri(secretValue, gomock.Any(), user.Email).Return("otpauth://totp/test").Times(1)
+
+		result, err := service.EnrollTwoFactor(user.ID)
+
+		assert.NotNil(t, result)
+		assert.Nil(t, err)
+		assert.Equal(t, secretValue, result.Secret)
+		assert.Equal(t, "otpauth://totp/test", result.ProvisioningUri)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, false).Return(nil, nil).Times(1)
+
+		result, err := service.EnrollTwoFactor(user.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "user does not exist", err.Error())
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(utils.GenerateUserTotpSecret(), nil).Times(1)
+
+		result, err := service.EnrollTwoFactor(user.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "two-factor authentication is already enabled", err.Error())
+	})
+
+	t.Run("error generating secret", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateTotpSecret().Return("", errors.New("error generating secret")).Times(1)
+
+		result, err := service.EnrollTwoFactor(user.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error generating secret", err.Error())
+	})
+
+	t.Run("error creating secret", func(t *testing.T) {
+		userRepo.EXPECT().GetUser(userFilter, false).Return(user, nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateTotpSecret().Return(secretValue, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userTotpSecretRepo.EXPECT().DeleteUserSecret(gomock.Any(), user.ID).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().CreateSecret(gomock.Any(), gomock.Any()).Return(errors.New("error creating secret")).Times(1)
+
+		result, err := service.EnrollTwoFactor(user.ID)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error creating secret", err.Error())
+	})
+}
+
+func TestUserService_ConfirmTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+	userRecoveryCodeRepo := mock_repositories.NewMockUserRecoveryCodeRepository(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, nil, nil, nil, nil, nil, nil, nil, userTotpSecretRepo, userRecoveryCodeRepo, nil, nil, nil, nil, nil, nil)
+
+	secret := utils.GenerateUserTotpSecret()
+	secret.IsEnabled = false
+	req := payloads.TwoFactorCodeRequest{Code: "123456"}
+	codes := []string{"aaaaa-bbbbb", "ccccc-ddddd"}
+	secretFilter := filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: secret.UserID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: false},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(100), true).Times(1)
+		auth.EXPECT().GenerateRecoveryCodes(gomock.Any()).Return(codes, nil).Times(1)
+		auth.EXPECT().HashToken(gomock.Any()).Return("hashed code").Times(len(codes))
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userTotpSecretRepo.EXPECT().EnableSecret(gomock.Any(), secret, int64(100)).Return(nil).Times(1)
+		userRecoveryCodeRepo.EXPECT().DeleteUserCodes(gomock.Any(), secret.UserID).Return(nil).Times(1)
+		userRecoveryCodeRepo.EXPECT().CreateCodes(gomock.Any(), gomock.Len(len(codes))).Return(nil).Times(1)
+
+		result, err := service.ConfirmTwoFactor(secret.UserID, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, codes, result)
+	})
+
+	t.Run("enrollment not found", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+
+		result, err := service.ConfirmTwoFactor(secret.UserID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "two-factor enrollment not found", err.Error())
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(0), false).Times(1)
+
+		result, err := service.ConfirmTwoFactor(secret.UserID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+	})
+
+	t.Run("error enabling secret", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(100), true).Times(1)
+		auth.EXPECT().GenerateRecoveryCodes(gomock.Any()).Return(codes, nil).Times(1)
+		auth.EXPECT().HashToken(gomock.Any()).Return("hashed code").Times(len(codes))
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userTotpSecretRepo.EXPECT().EnableSecret(gomock.Any(), secret, int64(100)).Return(errors.New("error enabling secret")).Times(1)
+
+		result, err := service.ConfirmTwoFactor(secret.UserID, req)
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error enabling secret", err.Error())
+	})
+}
+
+func TestUserService_DisableTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+	userRecoveryCodeRepo := mock_repositories.NewMockUserRecoveryCodeRepository(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, nil, nil, nil, nil, nil, nil, nil, userTotpSecretRepo, userRecoveryCodeRepo, nil, nil, nil, nil, nil, nil)
+
+	secret := utils.GenerateUserTotpSecret()
+	secret.IsEnabled = true
+	req := payloads.TwoFactorCodeRequest{Code: "123456"}
+	secretFilter := filters.UserTotpSecretFilter{
+		Filter:    &filters.SingleFilter{Logic: filters.And},
+		UserID:    &filters.Condition{Operator: filters.OpEqual, Value: secret.UserID},
+		IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: true},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(secret.LastUsedStep+1, true).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(2)
+		userTotpSecretRepo.EXPECT().UpdateLastUsedStep(gomock.Any(), secret, secret.LastUsedStep+1).Return(true, nil).Times(1)
+		userTotpSecretRepo.EXPECT().DeleteUserSecret(gomock.Any(), secret.UserID).Return(nil).Times(1)
+		userRecoveryCodeRepo.EXPECT().DeleteUserCodes(gomock.Any(), secret.UserID).Return(nil).Times(1)
+
+		err := service.DisableTwoFactor(secret.UserID, req)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("two-factor not enabled", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(nil, nil).Times(1)
+
+		err := service.DisableTwoFactor(secret.UserID, req)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "two-factor authentication is not enabled", err.Error())
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(int64(0), false).Times(1)
+		auth.EXPECT().HashToken(req.Code).Return("hashed code").Times(1)
+		userRecoveryCodeRepo.EXPECT().GetCode(gomock.Any()).Return(nil, nil).Times(1)
+
+		err := service.DisableTwoFactor(secret.UserID, req)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+		assert.Equal(t, "invalid two-factor code", err.Error())
+	})
+
+	t.Run("error deleting secret", func(t *testing.T) {
+		userTotpSecretRepo.EXPECT().GetSecret(secretFilter).Return(secret, nil).Times(1)
+		auth.EXPECT().ValidateTotpCode(secret.Secret, req.Code, gomock.Any()).Return(secret.LastUsedStep+1, true).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(2)
+		userTotpSecretRepo.EXPECT().UpdateLastUsedStep(gomock.Any(), secret, secret.LastUsedStep+1).Return(true, nil).Times(1)
+		userTotpSecretRepo.EXPECT().DeleteUserSecret(gomock.Any(), secret.UserID).Return(errors.New("error deleting secret")).Times(1)
+
+		err := service.DisableTwoFactor(secret.UserID, req)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error deleting secret", err.Error())
+	})
+}
+
+func TestUserService_StartOidcLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	oidcStateRepo := mock_repositories.NewMockOidcStateRepository(ctrl)
+	provider := mock_services.NewMockOidcProviderService(ctrl)
+
+	service := NewUserService(nil, nil, auth, transaction, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, oidcStateRepo, nil, nil, map[string]OidcProviderService{"google": provider})
+
+	t.Run("success", func(t *testing.T) {
+		auth.EXPECT().GenerateRandomToken().Return("state", nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("nonce", nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("verifier", nil).Times(1)
+		auth.EXPECT().GetPkceCodeChallenge("verifier").Return("challenge").Times(1)
+		provider.EXPECT().GetAuthorizationUrl("state", "nonce", "challenge").Return("https://idp.example.com/authorize?state=state", nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		oidcStateRepo.EXPECT().CreateState("state", gomock.Any(), &models.OidcState{Provider: "google", Nonce: "nonce", CodeVerifier: "verifier"}).Return(nil).Times(1)
+
+		result, err := service.StartOidcLogin("google")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "https://idp.example.com/authorize?state=state", result.AuthorizationUrl)
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		result, err := service.StartOidcLogin("unknown")
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "identity provider unknown is not supported", err.Error())
+	})
+
+	t.Run("error getting authorization url", func(t *testing.T) {
+		auth.EXPECT().GenerateRandomToken().Return("random", nil).Times(3)
+		auth.EXPECT().GetPkceCodeChallenge("random").Return("challenge").Times(1)
+		provider.EXPECT().GetAuthorizationUrl("random", "random", "challenge").Return("", apiError.InternalServerError("unexpected response from identity provider")).Times(1)
+
+		result, err := service.StartOidcLogin("google")
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "unexpected response from identity provider", err.Error())
+	})
+
+	t.Run("error storing state", func(t *testing.T) {
+		auth.EXPECT().GenerateRandomToken().Return("random", nil).Times(3)
+		auth.EXPECT().GetPkceCodeChallenge("random").Return("challenge").Times(1)
+		provider.EXPECT().GetAuthorizationUrl("random", "random", "challenge").Return("https://idp.example.com/authorize", nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		oidcStateRepo.EXPECT().CreateState("random", gomock.Any(), gomock.Any()).Return(errors.New("error storing state")).Times(1)
+
+		result, err := service.StartOidcLogin("google")
+
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error storing state", err.Error())
+	})
+}
+
+func TestUserService_CompleteOidcLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clientID := "client-id"
+	codeVerifier := "code-verifier"
+	idp := newStubIdentityProvider(t, clientID)
+	defer idp.server.Close()
+	idp.codeChallenge = pkceChallenge(codeVerifier)
+
+	auth := mock_auth.NewMockAuthenticator(ctrl)
+	transaction := mock_transaction.NewMockTransactionManager(ctrl)
+	userRepo := mock_repositories.NewMockUserRepository(ctrl)
+	userProfileRepo := mock_repositories.NewMockUserProfileRepository(ctrl)
+	loginTokenRepo := mock_repositories.NewMockLoginTokenRepository(ctrl)
+	userSessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	userTotpSecretRepo := mock_repositories.NewMockUserTotpSecretRepository(ctrl)
+	userRecoveryCodeRepo := mock_repositories.NewMockUserRecoveryCodeRepository(ctrl)
+	userIdentityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	oidcStateRepo := mock_repositories.NewMockOidcStateRepository(ctrl)
+	providers := map[string]OidcProviderService{
+		"google": NewOidcProviderService(idp.server.URL, clientID, "client-secret", "http://localhost/callback", 10),
+	}
+
+	service := NewUserService(nil, nil, auth, transaction, userRepo, userProfileRepo, loginTokenRepo, userSessionRepo, nil, nil, nil, userTotpSecretRepo, userRecoveryCodeRepo, nil, userIdentityRepo, oidcStateRepo, nil, nil, providers)
+
+	user := utils.GenerateUser()
+	user.Email = "example@example.com"
+	token := utils.GenerateLoginToken()
+	req := payloads.OidcCallbackRequest{Code: idp.code, State: "state"}
+	state := &models.OidcState{Provider: "google", Nonce: "nonce", CodeVerifier: codeVerifier}
+	identityFilter := filters.UserIdentityFilter{
+		Filter:   &filters.SingleFilter{Logic: filters.And},
+		Provider: &filters.Condition{Operator: filters.OpEqual, Value: "google"},
+		Subject:  &filters.Condition{Operator: filters.OpEqual, Value: "external-subject"},
+	}
+	emailFilter := filters.UserFilter{
+		Filter: &filters.SingleFilter{},
+		Email:  &filters.Condition{Operator: filters.OpEqual, Value: user.Email},
+	}
+	tokenFilter := filters.LoginTokenFilter{
+		Filter:     &filters.SingleFilter{Logic: filters.And},
+		TokenValue: &filters.Condition{Operator: filters.OpEqual, Value: token.TokenValue},
+	}
+
+	expectLoginToken := func(userID uuid.UUID, email string) {
+		userTotpSecretRepo.EXPECT().GetSecret(filters.UserTotpSecretFilter{
+			Filter:    &filters.SingleFilter{Logic: filters.And},
+			UserID:    &filters.Condition{Operator: filters.OpEqual, Value: userID},
+			IsEnabled: &filters.Condition{Operator: filters.OpEqual, Value: true},
+		}).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		loginTokenRepo.EXPECT().CreateLoginToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
+		userSessionRepo.EXPECT().CreateUserSession(token.TokenValue, gomock.Any(), &models.UserSession{UserID: userID, Email: email}).Return(nil).Times(1)
+	}
+
+	t.Run("new user is created and verified", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		var createdUser *models.User
+
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+		userIdentityRepo.EXPECT().GetIdentity(identityFilter).Return(nil, nil).Times(1)
+		userRepo.EXPECT().GetUser(emailFilter, false).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("random password", nil).Times(1)
+		auth.EXPECT().GenerateHashedPassword("random password").Return("hashed password", nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, u *models.User) error {
+			createdUser = u
+			return nil
+		}).Times(1)
+		userProfileRepo.EXPECT().CreateOrUpdateUserProfile(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, p *models.UserProfile) error {
+			assert.Equal(t, "John", p.FirstName)
+			assert.Equal(t, "Doe", p.LastName)
+			return nil
+		}).Times(1)
+		userIdentityRepo.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().GetSecret(gomock.Any()).Return(nil, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return(token.Token, nil).Times(1)
+		auth.EXPECT().HashToken(token.Token).Return(token.TokenValue).Times(1)
+		loginTokenRepo.EXPECT().GetLoginToken(tokenFilter).Return(nil, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		loginTokenRepo.EXPECT().CreateLoginToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		userSessionRepo.EXPECT().GetUserSessionID(token.TokenValue).Return(token.TokenValue).Times(1)
+		userSessionRepo.EXPECT().CreateUserSession(token.TokenValue, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, err)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, result)
+		assert.Equal(t, token.TokenValue, result.TokenValue)
+		assert.Equal(t, user.Email, createdUser.Email)
+		assert.True(t, createdUser.IsVerified)
+		assert.Equal(t, createdUser.ID, result.UserID)
+	})
+
+	t.Run("existing identity", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		identity := utils.GenerateUserIdentity()
+		identity.UserID = user.ID
+
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+		userIdentityRepo.EXPECT().GetIdentity(identityFilter).Return(identity, nil).Times(1)
+		userRepo.EXPECT().GetUser(filters.UserFilter{
+			Filter: &filters.SingleFilter{},
+			ID:     &filters.Condition{Operator: filters.OpEqual, Value: user.ID},
+		}, false).Return(user, nil).Times(1)
+		expectLoginToken(user.ID, user.Email)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, err)
+		assert.Nil(t, challenge)
+		assert.Equal(t, user.ID, result.UserID)
+	})
+
+	t.Run("identity is linked to existing verified user", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		existingUser := *user
+		existingUser.IsVerified = true
+
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+		userIdentityRepo.EXPECT().GetIdentity(identityFilter).Return(nil, nil).Times(1)
+		userRepo.EXPECT().GetUser(emailFilter, false).Return(&existingUser, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userIdentityRepo.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		expectLoginToken(user.ID, user.Email)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, err)
+		assert.Nil(t, challenge)
+		assert.Equal(t, user.ID, result.UserID)
+	})
+
+	t.Run("unverified existing user is taken over", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		existingUser := *user
+		existingUser.IsVerified = false
+
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+		userIdentityRepo.EXPECT().GetIdentity(identityFilter).Return(nil, nil).Times(1)
+		userRepo.EXPECT().GetUser(emailFilter, false).Return(&existingUser, nil).Times(1)
+		auth.EXPECT().GenerateRandomToken().Return("random password", nil).Times(1)
+		auth.EXPECT().GenerateHashedPassword("random password").Return("hashed password", nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userRepo.EXPECT().UpdatePassword(gomock.Any(), &existingUser, "hashed password").Return(&existingUser, nil).Times(1)
+		loginTokenRepo.EXPECT().RevokeUserLoginTokens(gomock.Any(), user.ID).Return(nil).Times(1)
+		userTotpSecretRepo.EXPECT().DeleteUserSecret(gomock.Any(), user.ID).Return(nil).Times(1)
+		userRecoveryCodeRepo.EXPECT().DeleteUserCodes(gomock.Any(), user.ID).Return(nil).Times(1)
+		userRepo.EXPECT().VerifyUser(gomock.Any(), &existingUser).Return(nil).Times(1)
+		userIdentityRepo.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, i *models.UserIdentity) error {
+			assert.Equal(t, user.ID, i.UserID)
+			assert.Equal(t, "google", i.Provider)
+			assert.Equal(t, "external-subject", i.Subject)
+			return nil
+		}).Times(1)
+		transaction.EXPECT().ExecuteInRedisTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(rdb *redis.Client, fn func(tx *redis.Tx) error) error {
+				return fn(nil)
+			},
+		).Times(1)
+		userSessionRepo.EXPECT().DeleteUserSessions(user.ID).Return(nil).Times(1)
+		expectLoginToken(user.ID, user.Email)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, err)
+		assert.Nil(t, challenge)
+		assert.Equal(t, user.ID, result.UserID)
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		result, challenge, err := service.CompleteOidcLogin("unknown", req)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(nil, nil).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid or expired state", err.Error())
+	})
+
+	t.Run("state issued for another provider", func(t *testing.T) {
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(&models.OidcState{Provider: "github", Nonce: "nonce", CodeVerifier: codeVerifier}, nil).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid or expired state", err.Error())
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "another nonce")
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "invalid nonce", err.Error())
+	})
+
+	t.Run("invalid authorization code", func(t *testing.T) {
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", payloads.OidcCallbackRequest{Code: "another code", State: req.State})
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "failed to exchange authorization code", err.Error())
+	})
+
+	t.Run("email not verified", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		idp.claims["email_verified"] = false
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+		userIdentityRepo.EXPECT().GetIdentity(identityFilter).Return(nil, nil).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+		assert.Equal(t, "identity provider did not return a verified email", err.Error())
+	})
+
+	t.Run("error creating identity", func(t *testing.T) {
+		idp.claims = idp.defaultClaims(clientID, "nonce")
+		oidcStateRepo.EXPECT().ConsumeState(req.State).Return(state, nil).Times(1)
+		userIdentityRepo.EXPECT().GetIdentity(identityFilter).Return(nil, nil).Times(1)
+		verifiedUser := *user
+		verifiedUser.IsVerified = true
+		userRepo.EXPECT().GetUser(emailFilter, false).Return(&verifiedUser, nil).Times(1)
+		transaction.EXPECT().ExecuteInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+				return fn(db)
+			},
+		).Times(1)
+		userIdentityRepo.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).Return(errors.New("error creating identity")).Times(1)
+
+		result, challenge, err := service.CompleteOidcLogin("google", req)
+
+		assert.Nil(t, result)
+		assert.Nil(t, challenge)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, "error creating identity", err.Error())
+	})
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/models"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -48,7 +49,8 @@ func GenerateLoginToken() *models.LoginToken {
 	return &models.LoginToken{
 		ID:         generateUUID(),
 		UserID:     generateUUID(),
-		TokenValue: uuid.NewString(),
+		TokenValue: generateString(numberChars+"abcdef", 64),
+		Token:      generateString(letterChars+numberChars, 43),
 		CreatedAt:  generateCurrentTime(),
 		ExpiresAt:  generateCurrentTime().Add(60 * time.Minute),
 	}
@@ -70,6 +72,7 @@ func GenerateMovie() *models.Movie {
 		DurationMinutes: generateInt(100, 200),
 		Language:        GetPointerOf(generateString(letterChars, 10)),
 		Rating:          GetPointerOf(generateFloat(0, 5)),
+		TrailerUrl:      GetPointerOf(generateURL()),
 		IsActive:        generateBool(),
 		CreatedAt:       generateCurrentTime(),
 		UpdatedAt:       generateCurrentTime(),
@@ -104,6 +107,60 @@ func GenerateGenres(count int) []*models.Genre {
 	return genres
 }
 
+func GeneratePerson() *models.Person {
+	return &models.Person{
+		ID:        generateUUID(),
+		Name:      generateName(),
+		Biography: GetPointerOf(generateString(letterChars, 100)),
+		CreatedAt: generateCurrentTime(),
+		UpdatedAt: generateCurrentTime(),
+	}
+}
+
+func GeneratePeople(count int) []*models.Person {
+	people := make([]*models.Person, count)
+	for i := 0; i < count; i++ {
+		people[i] = GeneratePerson()
+	}
+
+	return people
+}
+
+func GenerateMovieCredit() *models.MovieCredit {
+	return &models.MovieCredit{
+		MovieID:       generateUUID(),
+		PersonID:      generateUUID(),
+		Role:          constants.Actor,
+		CharacterName: GetPointerOf(generateName()),
+		Position:      generateInt(0, 10),
+	}
+}
+
+func GenerateMovieCertification() *models.MovieCertification {
+	return &models.MovieCertification{
+		MovieID:   generateUUID(),
+		CountryID: generateUUID(),
+		Rating:    generateString(uppercaseChars, 3),
+		MinAge:    generateInt(0, 21),
+	}
+}
+
+func GenerateMovieLanguage() *models.MovieLanguage {
+	return &models.MovieLanguage{
+		MovieID:  generateUUID(),
+		Language: "en-US",
+		Type:     constants.Subtitle,
+	}
+}
+
+func GenerateMovieExternalID() *models.MovieExternalID {
+	return &models.MovieExternalID{
+		MovieID:    generateUUID(),
+		Source:     constants.Imdb,
+		ExternalID: "tt" + generateString(numberChars, 7),
+	}
+}
+
 func GeneratePasswordResetToken() *models.PasswordResetToken {
 	return &models.PasswordResetToken{
 		ID:         generateUUID(),
@@ -126,6 +183,59 @@ func GenerateUserRegistrationToken() *models.UserRegistrationToken {
 	}
 }
 
+func GenerateUserRegistrationTokens(count int) []*models.UserRegistrationToken {
+	tokens := make([]*models.UserRegistrationToken, count)
+	for i := 0; i < count; i++ {
+		tokens[i] = GenerateUserRegistrationToken()
+	}
+
+	return tokens
+}
+
+func GenerateUserTotpSecret() *models.UserTotpSecret {
+	return &models.UserTotpSecret{
+		ID:           generateUUID(),
+		UserID:       generateUUID(),
+		Secret:       generateString("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567", 32),
+		IsEnabled:    generateBool(),
+		LastUsedStep: int64(generateInt(1, 1000000)),
+		CreatedAt:    generateCurrentTime(),
+		UpdatedAt:    generateCurrentTime(),
+	}
+}
+
+func GenerateUserRecoveryCode() *models.UserRecoveryCode {
+	return &models.UserRecoveryCode{
+		ID:        generateUUID(),
+		UserID:    generateUUID(),
+		CodeHash:  generateString(numberChars+"abcdef", 64),
+		IsUsed:    generateBool(),
+		CreatedAt: generateCurrentTime(),
+	}
+}
+
+func GenerateUserRecoveryCodes(count int) []*models.UserRecoveryCode {
+	codes := make([]*models.UserRecoveryCode, count)
+	for i := 0; i < count; i++ {
+		codes[i] = GenerateUserRecoveryCode()
+	}
+
+	return codes
+}
+
+func GenerateUserIdentity() *models.UserIdentity {
+	email := generateEmail()
+	return &models.UserIdentity{
+		ID:        generateUUID(),
+		UserID:    generateUUID(),
+		Provider:  "google",
+		Subject:   generateString(numberChars, 21),
+		Email:     &email,
+		CreatedAt: generateCurrentTime(),
+		UpdatedAt: generateCurrentTime(),
+	}
+}
+
 func GeneratePasswordResetTokens(count int) []*models.PasswordResetToken {
 	tokens := make([]*models.PasswordResetToken, count)
 	for i := 0; i < count; i++ {
@@ -172,9 +282,12 @@ func GenerateStates(count int) []*models.State {
 
 func GenerateCity() *models.City {
 	return &models.City{
-		ID:      generateUUID(),
-		Name:    generateString(lowercaseChars, 10),
-		StateID: generateUUID(),
+		ID:        generateUUID(),
+		Name:      generateString(lowercaseChars, 10),
+		StateID:   generateUUID(),
+		Latitude:  GetPointerOf(generateFloat(1, 90)),
+		Longitude: GetPointerOf(generateFloat(1, 180)),
+		TimeZone:  GetPointerOf("UTC"),
 	}
 }
 
@@ -189,8 +302,11 @@ func GenerateCities(count int) []*models.City {
 
 func GenerateTheater() *models.Theater {
 	return &models.Theater{
-		ID:   generateUUID(),
-		Name: generateString(letterChars, 10),
+		ID:       generateUUID(),
+		Name:     generateString(letterChars, 10),
+		Phone:    GetPointerOf(generateString(numberChars, 10)),
+		Website:  GetPointerOf(fmt.Sprintf("https://%s.com", generateString(lowercaseChars, 10))),
+		TimeZone: GetPointerOf("UTC"),
 	}
 }
 
@@ -224,6 +340,36 @@ func GenerateTheaterLocations(count int) []*models.TheaterLocation {
 	return locations
 }
 
+func GenerateTheaterOpeningHour() *models.TheaterOpeningHour {
+	return &models.TheaterOpeningHour{
+		ID:        generateUUID(),
+		TheaterID: generateUUID(),
+		Weekday:   generateInt(0, 6),
+		OpenTime:  "09:00:00",
+		CloseTime: "23:00:00",
+	}
+}
+
+func GenerateTheaterOpeningHours(count int) []*models.TheaterOpeningHour {
+	hours := make([]*models.TheaterOpeningHour, count)
+	for i := 0; i < count; i++ {
+		hours[i] = GenerateTheaterOpeningHour()
+	}
+
+	return hours
+}
+
+func GenerateTheaterPhoto() *models.TheaterPhoto {
+	return &models.TheaterPhoto{
+		ID:        generateUUID(),
+		TheaterID: generateUUID(),
+		Url:       fmt.Sprintf("https://%s.com/%s.jpg", generateString(lowercaseChars, 10), generateString(lowercaseChars, 10)),
+		Caption:   GetPointerOf(generateString(lowercaseChars, 20)),
+		Position:  generateInt(0, 10),
+		CreatedAt: generateCurrentTime(),
+	}
+}
+
 func GenerateSeat() *models.Seat {
 	return &models.Seat{
 		Id:        generateUUID(),
@@ -243,15 +389,18 @@ func GenerateShow() *models.Show {
 		constants.Scheduled,
 		constants.OnHold,
 	}
+	startTime := generateCurrentTime()
+	endTime := startTime.Add(60 * time.Minute)
 	return &models.Show{
 		Id:        generateUUID(),
 		MovieId:   GetPointerOf(generateUUID()),
 		TheaterId: GetPointerOf(generateUUID()),
-		StartTime: generateCurrentTime(),
-		EndTime:   generateCurrentTime().Add(60 * time.Minute),
+		StartTime: startTime,
+		EndTime:   endTime,
 		Status:    showStatuses[generateInt(0, len(showStatuses)-1)],
 		CreatedAt: generateCurrentTime(),
 		UpdatedAt: generateCurrentTime(),
+		TimeZone:  "UTC",
 	}
 }
 
@@ -264,6 +413,69 @@ func GenerateShows(count int) []*models.Show {
 	return shows
 }
 
+func GenerateOutboxMessage() *models.OutboxMessage {
+	return &models.OutboxMessage{
+		ID:            generateUUID(),
+		Topic:         generateName(),
+		Key:           generateUUID().String(),
+		Headers:       fmt.Sprintf(`{"%s": "%s", "%s": "%s"}`, constants.EventIDHeader, generateUUID(), constants.ContentType, constants.ApplicationJson),
+		Payload:       fmt.Sprintf(`{"email": "%s"}`, generateEmail()),
+		Status:        constants.OutboxPending,
+		Attempts:      0,
+		NextAttemptAt: generateCurrentTime(),
+		CreatedAt:     generateCurrentTime(),
+	}
+}
+
+func GenerateOutboxMessages(count int) []*models.OutboxMessage {
+	messages := make([]*models.OutboxMessage, count)
+	for i := 0; i < count; i++ {
+		messages[i] = GenerateOutboxMessage()
+	}
+
+	return messages
+}
+
+func GenerateProcessedEvent() *models.ProcessedEvent {
+	return &models.ProcessedEvent{
+		ConsumerGroup:  generateName(),
+		IdempotencyKey: generateUUID().String(),
+		Topic:          generateName(),
+		ProcessedAt:    generateCurrentTime(),
+	}
+}
+
+func GenerateEmailBounce() *models.EmailBounce {
+	return &models.EmailBounce{
+		ID:         generateUUID(),
+		Email:      generateEmail(),
+		BounceType: constants.HardBounce,
+		Reason:     GetPointerOf(generateName()),
+		BouncedAt:  generateCurrentTime(),
+		CreatedAt:  generateCurrentTime(),
+	}
+}
+
+func GenerateSeatBlock() *models.SeatBlock {
+	return &models.SeatBlock{
+		Id:        generateUUID(),
+		SeatId:    GetPointerOf(generateUUID()),
+		Reason:    generateName(),
+		BlockedBy: generateUUID(),
+		CreatedAt: generateCurrentTime(),
+	}
+}
+
+// Payloads
+func GenerateShowCertification() *payloads.ShowCertification {
+	return &payloads.ShowCertification{
+		CountryID:     GetPointerOf(generateUUID()),
+		Rating:        GetPointerOf(generateString(uppercaseChars, 3)),
+		MinAge:        generateInt(0, 21),
+		IdCheckMinAge: GetPointerOf(generateInt(0, 21)),
+	}
+}
+
 // Helpers
 const lowercaseChars = "abcdefghijklmnopqrstuvwxyz"
 const uppercaseChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -81,6 +81,10 @@ func getColumnName(field reflect.StructField) string {
 
 func shouldSkipField(field reflect.StructField) bool {
 	gormTag := field.Tag.Get("gorm")
+	if gormTag == "-" {
+		return true
+	}
+
 	for _, tag := range excludeTags {
 		if strings.Contains(gormTag, tag) {
 			return true
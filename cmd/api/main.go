@@ -1,19 +1,77 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/config"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // theater time zones must resolve even on hosts without zoneinfo
 )
 
 func main() {
 	a := app.InitApp()
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	config.CronJobManager.Start()
-	defer config.CronJobManager.Stop()
-	
-	if err := a.Router.Run(":" + config.AppEnv.AppPort); err != nil {
-		log.Fatal(err)
-		return
+
+	// A consumer that cannot join its group would silently stop processing events, so it shuts the service down instead.
+	consumerDone := make(chan struct{})
+	consumerErr := make(chan error, 1)
+	go func() {
+		defer close(consumerDone)
+		if err := a.EventConsumerService.Start(ctx); err != nil {
+			consumerErr <- err
+		}
+	}()
+
+	server := &http.Server{
+		Addr:    ":" + config.AppEnv.AppPort,
+		Handler: a.Router,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+	case err := <-consumerErr:
+		log.Printf("event consumer stopped: %s", err.Error())
+		exitCode = 1
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(config.AppEnv.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Println(err)
+	}
+
+	select {
+	case <-consumerDone:
+	case <-shutdownCtx.Done():
+		log.Println("timed out waiting for event consumers to finish")
 	}
+	<-config.CronJobManager.Stop().Done()
+
+	if err := a.EventConsumerService.Close(); err != nil {
+		log.Println(err)
+	}
+	if err := config.KafkaProducerClient.Close(); err != nil {
+		log.Println(err)
+	}
+
+	cancel()
+	os.Exit(exitCode)
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/payloads"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/repositories"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/services"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/transaction"
+	"github.com/vantutran2k1-movie-reservation-system/reservation-service/config"
+	"io"
+	"log"
+	"os"
+)
+
+func main() {
+	file := flag.String("file", "", "path to the CSV or GeoNames file to import")
+	format := flag.String("format", string(constants.CsvImportFormat), "format of the import file: csv or geonames")
+	countryInfo := flag.String("country-info", "", "optional GeoNames countryInfo.txt used for country names")
+	admin1Codes := flag.String("admin1-codes", "", "optional GeoNames admin1CodesASCII.txt used for state names")
+	batchSize := flag.Int("batch-size", 0, "number of rows per transaction, defaults to LOCATION_IMPORT_BATCH_SIZE")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	config.InitAppEnv()
+	config.InitDB()
+
+	if *batchSize <= 0 {
+		*batchSize = config.AppEnv.LocationImportBatchSize
+	}
+
+	source := payloads.LocationImportSource{Format: constants.LocationImportFormat(*format)}
+	source.Data = mustOpen(*file)
+	if *countryInfo != "" {
+		source.CountryInfo = mustOpen(*countryInfo)
+	}
+	if *admin1Codes != "" {
+		source.Admin1Codes = mustOpen(*admin1Codes)
+	}
+
+	service := services.NewLocationImportService(
+		config.DB,
+		transaction.NewTransactionManager(),
+		repositories.NewCountryRepository(config.DB),
+		repositories.NewStateRepository(config.DB),
+		repositories.NewCityRepository(config.DB),
+		*batchSize,
+	)
+
+	report, err := service.ImportLocations(source, func(report *payloads.LocationImportReport) {
+		log.Printf("processed %d rows: %d imported, %d failed", report.RowsProcessed, report.RowsImported, report.RowsFailed)
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	printReport(report)
+	if report.RowsFailed > 0 {
+		os.Exit(1)
+	}
+}
+
+func mustOpen(path string) io.Reader {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+
+	return f
+}
+
+func printReport(report *payloads.LocationImportReport) {
+	fmt.Printf("Rows processed:    %d\n", report.RowsProcessed)
+	fmt.Printf("Rows imported:     %d\n", report.RowsImported)
+	fmt.Printf("Rows failed:       %d\n", report.RowsFailed)
+	fmt.Printf("Countries created: %d, updated: %d\n", report.CountriesCreated, report.CountriesUpdated)
+	fmt.Printf("States created:    %d, updated: %d\n", report.StatesCreated, report.StatesUpdated)
+	fmt.Printf("Cities created:    %d, updated: %d\n", report.CitiesCreated, report.CitiesUpdated)
+
+	if len(report.Errors) == 0 {
+		return
+	}
+
+	fmt.Println("Errors:")
+	for _, e := range report.Errors {
+		fmt.Printf("  line %d: %s\n", e.Line, e.Message)
+	}
+	if report.ErrorsTruncated {
+		fmt.Printf("  ... only the first %d errors are shown\n", len(report.Errors))
+	}
+}
@@ -23,6 +23,7 @@ var RedisClient *redis.Client
 var MinioClient *minio.Client
 var ConfigcatClient *configcat.Client
 var KafkaProducerClient sarama.SyncProducer
+var KafkaConsumerGroupClient sarama.ConsumerGroup
 var CronJobManager *cron.Cron
 
 func InitDB() {
@@ -97,7 +98,21 @@ func InitKafkaProducer() {
 	KafkaProducerClient = producer
 }
 
+func InitKafkaConsumerGroup() {
+	config := sarama.NewConfig()
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	brokers := []string{AppEnv.KafkaBroker}
+	consumerGroup, err := sarama.NewConsumerGroup(brokers, AppEnv.KafkaConsumerGroup, config)
+	if err != nil {
+		log.Fatalf("Failed to create Kafka consumer group: %v", err)
+	}
+
+	KafkaConsumerGroupClient = consumerGroup
+}
+
 func InitCronjobManager() {
-	c := cron.New(cron.WithSeconds())
+	// Jobs run on UTC so that local DST transitions never skip or repeat a run.
+	c := cron.New(cron.WithSeconds(), cron.WithLocation(time.UTC))
 	CronJobManager = c
 }
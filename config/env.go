@@ -1,44 +1,93 @@
 package config
 
 import (
+	"fmt"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/constants"
 	"github.com/vantutran2k1-movie-reservation-system/reservation-service/app/utils"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Env struct {
-	AppPort                         string
-	GinMode                         string
-	DbHost                          string
-	DbPort                          string
-	DbUser                          string
-	DbPassword                      string
-	DbName                          string
-	RedisHost                       string
-	RedisPort                       string
-	RedisPassword                   string
-	RedisDatabase                   int
-	MinioHost                       string
-	MinioPort                       string
-	MinioConsolePort                int
-	MinioRootUser                   string
-	MinioRootPassword               string
-	MinioAccessKey                  string
-	MinioSecretKey                  string
-	MinioProfilePictureBucket       string
-	MaxProfilePictureFileSize       int
-	ConfigcatSdkKey                 string
-	LoginTokenExpireTime            int
-	PassResetTokenExpireTime        int
-	UserRegistrationTokenExpireTime int
-	MaxRequestsPerMinute            int
-	UserLocationApiTimeout          int
-	UserLocationApiUrl              string
-	KafkaBroker                     string
-	KafkaUserRegistrationTopic      string
-	PlatformUiEndpoint              string
+	AppPort                           string
+	GinMode                           string
+	TrustedProxies                    []string
+	RemoteIPHeaders                   []string
+	DbHost                            string
+	DbPort                            string
+	DbUser                            string
+	DbPassword                        string
+	DbName                            string
+	RedisHost                         string
+	RedisPort                         string
+	RedisPassword                     string
+	RedisDatabase                     int
+	MinioHost                         string
+	MinioPort                         string
+	MinioConsolePort                  int
+	MinioRootUser                     string
+	MinioRootPassword                 string
+	MinioAccessKey                    string
+	MinioSecretKey                    string
+	MinioProfilePictureBucket         string
+	MaxProfilePictureFileSize         int
+	MaxLocationImportFileSize         int
+	LocationImportBatchSize           int
+	ConfigcatSdkKey                   string
+	LoginTokenExpireTime              int
+	PassResetTokenExpireTime          int
+	UserRegistrationTokenExpireTime   int
+	LoginChallengeExpireTime          int
+	TotpIssuer                        string
+	MaxRequestsPerMinute              int
+	MaxVerificationEmailResends       int
+	VerificationEmailResendWindowTime int
+	MaxFailedLoginAttempts            int
+	MaxFailedLoginAttemptsPerIp       int
+	FailedLoginAttemptsWindowTime     int
+	LoginLockoutTime                  int
+	LoginBackoffTime                  int
+	UserLocationApiTimeout            int
+	UserLocationApiUrl                string
+	UserLocationProvider              string
+	UserLocationDatabasePaths         []string
+	UserLocationCacheTime             int
+	KafkaBroker                       string
+	EventProducer                     string
+	KafkaUserRegistrationTopic        string
+	KafkaPasswordResetTopic           string
+	KafkaMovieTopic                   string
+	KafkaGenreTopic                   string
+	KafkaTheaterTopic                 string
+	KafkaShowTopic                    string
+	KafkaConsumerGroup                string
+	KafkaPaymentResultTopic           string
+	KafkaEmailBounceTopic             string
+	KafkaDeadLetterTopicSuffix        string
+	ConsumerMaxAttempts               int
+	ConsumerRetryBackoffTime          int
+	ConsumerProcessedEventRetention   int
+	OutboxRelayInterval               int
+	OutboxRelayBatchSize              int
+	OutboxMaxAttempts                 int
+	OutboxRetryBackoffTime            int
+	OutboxMaxRetryBackoffTime         int
+	OutboxRetentionTime               int
+	PlatformUiEndpoint                string
+	OidcProviders                     []OidcProvider
+	OidcStateExpireTime               int
+	OidcApiTimeout                    int
+	ShutdownTimeout                   int
+}
+
+type OidcProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectUrl  string
 }
 
 var AppEnv Env
@@ -46,6 +95,8 @@ var AppEnv Env
 func InitAppEnv() {
 	AppEnv.AppPort = getOrDefault("APP_PORT", "8080")
 	AppEnv.GinMode = mustBeOneOf("GIN_MODE", utils.GetPointerOf(constants.GinDebugMode), constants.GinDebugMode, constants.GinReleaseMode)
+	AppEnv.TrustedProxies = getList("TRUSTED_PROXIES")
+	AppEnv.RemoteIPHeaders = getList("REMOTE_IP_HEADERS")
 
 	AppEnv.DbHost = getOrDefault("DB_HOST", "localhost")
 	AppEnv.DbPort = getOrDefault("DB_PORT", "5432")
@@ -68,21 +119,97 @@ func InitAppEnv() {
 	AppEnv.MinioProfilePictureBucket = getOrDefault("MINIO_PROFILE_PICTURE_BUCKET_NAME", "users.profile-pictures")
 	AppEnv.MaxProfilePictureFileSize = getOrDefaultInt("MAX_USER_PROFILE_PICTURE_FILE_SIZE_MB", 10)
 
+	AppEnv.MaxLocationImportFileSize = getOrDefaultInt("MAX_LOCATION_IMPORT_FILE_SIZE_MB", 50)
+	AppEnv.LocationImportBatchSize = getOrDefaultInt("LOCATION_IMPORT_BATCH_SIZE", 500)
+
 	AppEnv.ConfigcatSdkKey = mustGetEnv("CONFIGCAT_SDK_KEY")
 
 	AppEnv.LoginTokenExpireTime = getOrDefaultInt("LOGIN_TOKEN_EXPIRES_AFTER_MINUTES", 60)
 	AppEnv.PassResetTokenExpireTime = getOrDefaultInt("PASSWORD_RESET_TOKEN_EXPIRES_AFTER_MINUTES", 5)
 	AppEnv.UserRegistrationTokenExpireTime = getOrDefaultInt("USER_REGISTRATION_TOKEN_EXPIRES_AFTER_MINUTES", 5)
+	AppEnv.LoginChallengeExpireTime = getOrDefaultInt("LOGIN_CHALLENGE_EXPIRES_AFTER_MINUTES", 5)
+
+	AppEnv.TotpIssuer = getOrDefault("TOTP_ISSUER", "Movie Reservation System")
 
 	AppEnv.MaxRequestsPerMinute = getOrDefaultInt("MAX_REQUESTS_PER_MINUTE", 100)
+	AppEnv.MaxVerificationEmailResends = getOrDefaultInt("MAX_VERIFICATION_EMAIL_RESENDS", 3)
+	AppEnv.VerificationEmailResendWindowTime = getOrDefaultInt("VERIFICATION_EMAIL_RESEND_WINDOW_MINUTES", 15)
+
+	AppEnv.MaxFailedLoginAttempts = getOrDefaultInt("MAX_FAILED_LOGIN_ATTEMPTS", 10)
+	AppEnv.MaxFailedLoginAttemptsPerIp = getOrDefaultInt("MAX_FAILED_LOGIN_ATTEMPTS_PER_IP", 5)
+	AppEnv.FailedLoginAttemptsWindowTime = getOrDefaultInt("FAILED_LOGIN_ATTEMPTS_WINDOW_MINUTES", 15)
+	AppEnv.LoginLockoutTime = getOrDefaultInt("LOGIN_LOCKOUT_MINUTES", 15)
+	AppEnv.LoginBackoffTime = getOrDefaultInt("LOGIN_BACKOFF_SECONDS", 1)
 
 	AppEnv.UserLocationApiTimeout = getOrDefaultInt("USER_LOCATION_API_TIMEOUT_SECONDS", 10)
 	AppEnv.UserLocationApiUrl = getOrDefault("USER_LOCATION_API_URL", "http://ip-api.com/json/")
+	AppEnv.UserLocationProvider = mustBeOneOf("USER_LOCATION_PROVIDER", utils.GetPointerOf(constants.IpApiLocationProvider), constants.IpApiLocationProvider, constants.GeoIpDatabaseLocationProvider)
+	AppEnv.UserLocationDatabasePaths = getList("USER_LOCATION_DATABASE_PATHS")
+	AppEnv.UserLocationCacheTime = getOrDefaultInt("USER_LOCATION_CACHE_MINUTES", 1440)
 
 	AppEnv.KafkaBroker = getOrDefault("KAFKA_BROKER", "localhost:9092")
+	AppEnv.EventProducer = getOrDefault("EVENT_PRODUCER", "reservation-service")
 	AppEnv.KafkaUserRegistrationTopic = getOrDefault("KAFKA_USER_REGISTRATION_TOPIC", "users.user_registrations")
+	AppEnv.KafkaPasswordResetTopic = getOrDefault("KAFKA_PASSWORD_RESET_TOPIC", "users.password_resets")
+	AppEnv.KafkaMovieTopic = getOrDefault("KAFKA_MOVIE_TOPIC", "catalog.movies")
+	AppEnv.KafkaGenreTopic = getOrDefault("KAFKA_GENRE_TOPIC", "catalog.genres")
+	AppEnv.KafkaTheaterTopic = getOrDefault("KAFKA_THEATER_TOPIC", "catalog.theaters")
+	AppEnv.KafkaShowTopic = getOrDefault("KAFKA_SHOW_TOPIC", "catalog.shows")
+
+	AppEnv.KafkaConsumerGroup = getOrDefault("KAFKA_CONSUMER_GROUP", "reservation-service")
+	AppEnv.KafkaPaymentResultTopic = getOrDefault("KAFKA_PAYMENT_RESULT_TOPIC", "payments.payment_results")
+	AppEnv.KafkaEmailBounceTopic = getOrDefault("KAFKA_EMAIL_BOUNCE_TOPIC", "notifications.email_bounces")
+	AppEnv.KafkaDeadLetterTopicSuffix = getOrDefault("KAFKA_DEAD_LETTER_TOPIC_SUFFIX", ".dlt")
+	AppEnv.ConsumerMaxAttempts = getOrDefaultInt("CONSUMER_MAX_ATTEMPTS", 3)
+	AppEnv.ConsumerRetryBackoffTime = getOrDefaultInt("CONSUMER_RETRY_BACKOFF_SECONDS", 1)
+	AppEnv.ConsumerProcessedEventRetention = getOrDefaultInt("CONSUMER_PROCESSED_EVENT_RETENTION_HOURS", 336)
+
+	AppEnv.OutboxRelayInterval = getOrDefaultInt("OUTBOX_RELAY_INTERVAL_SECONDS", 5)
+	AppEnv.OutboxRelayBatchSize = getOrDefaultInt("OUTBOX_RELAY_BATCH_SIZE", 100)
+	AppEnv.OutboxMaxAttempts = getOrDefaultInt("OUTBOX_MAX_ATTEMPTS", 10)
+	AppEnv.OutboxRetryBackoffTime = getOrDefaultInt("OUTBOX_RETRY_BACKOFF_SECONDS", 5)
+	AppEnv.OutboxMaxRetryBackoffTime = getOrDefaultInt("OUTBOX_MAX_RETRY_BACKOFF_SECONDS", 3600)
+	AppEnv.OutboxRetentionTime = getOrDefaultInt("OUTBOX_RETENTION_HOURS", 168)
 
 	AppEnv.PlatformUiEndpoint = getOrDefault("PLATFORM_UI_ENDPOINT", "http://localhost:5173")
+
+	AppEnv.OidcProviders = getOidcProviders()
+	AppEnv.OidcStateExpireTime = getOrDefaultInt("OIDC_STATE_EXPIRES_AFTER_MINUTES", 10)
+	AppEnv.OidcApiTimeout = getOrDefaultInt("OIDC_API_TIMEOUT_SECONDS", 10)
+
+	AppEnv.ShutdownTimeout = getOrDefaultInt("SHUTDOWN_TIMEOUT_SECONDS", 30)
+}
+
+func getOidcProviders() []OidcProvider {
+	var providers []OidcProvider
+	for _, name := range strings.Split(getOrDefault("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := fmt.Sprintf("OIDC_%s_", strings.ToUpper(name))
+		providers = append(providers, OidcProvider{
+			Name:         name,
+			Issuer:       mustGetEnv(prefix + "ISSUER"),
+			ClientID:     mustGetEnv(prefix + "CLIENT_ID"),
+			ClientSecret: mustGetEnv(prefix + "CLIENT_SECRET"),
+			RedirectUrl:  getOrDefault(prefix+"REDIRECT_URL", fmt.Sprintf("%s/auth/%s/callback", AppEnv.PlatformUiEndpoint, name)),
+		})
+	}
+
+	return providers
+}
+
+func getList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
 }
 
 func getOrDefault(key string, defaultValue string) string {
@@ -4,6 +4,7 @@ go 1.21.5
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/IBM/sarama v1.44.0
 	github.com/configcat/go-sdk/v9 v9.0.7
 	github.com/gin-gonic/gin v1.10.0
 	github.com/go-playground/validator/v10 v10.23.0
@@ -20,7 +21,6 @@ require (
 )
 
 require (
-	github.com/IBM/sarama v1.44.0 // indirect
 	github.com/blang/semver/v4 v4.0.0 // indirect
 	github.com/bytedance/sonic v1.12.6 // indirect
 	github.com/bytedance/sonic/loader v0.2.1 // indirect
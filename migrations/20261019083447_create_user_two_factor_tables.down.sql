@@ -0,0 +1,3 @@
+DROP TABLE user_recovery_codes;
+
+DROP TABLE user_totp_secrets;
\ No newline at end of file
@@ -0,0 +1 @@
+-- Revoked plaintext tokens are not restored.
@@ -0,0 +1,3 @@
+UPDATE password_reset_tokens
+SET expires_at = CURRENT_TIMESTAMP AT TIME ZONE 'UTC'
+WHERE expires_at > CURRENT_TIMESTAMP AT TIME ZONE 'UTC';
@@ -0,0 +1,7 @@
+UPDATE login_tokens
+SET expires_at = CURRENT_TIMESTAMP AT TIME ZONE 'UTC'
+WHERE expires_at > CURRENT_TIMESTAMP AT TIME ZONE 'UTC';
+
+UPDATE user_registration_tokens
+SET expires_at = CURRENT_TIMESTAMP AT TIME ZONE 'UTC'
+WHERE expires_at > CURRENT_TIMESTAMP AT TIME ZONE 'UTC';
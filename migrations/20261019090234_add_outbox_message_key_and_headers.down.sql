@@ -0,0 +1,3 @@
+ALTER TABLE outbox
+    DROP COLUMN message_key,
+    DROP COLUMN headers;
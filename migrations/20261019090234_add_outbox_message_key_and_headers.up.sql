@@ -0,0 +1,3 @@
+ALTER TABLE outbox
+    ADD COLUMN message_key VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN headers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer_group VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    PRIMARY KEY (consumer_group, idempotency_key)
+);
@@ -0,0 +1 @@
+DROP TABLE email_bounces;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS email_bounces (
+    id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    bounce_type VARCHAR(20) NOT NULL,
+    reason TEXT,
+    bounced_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX idx_email_bounces_email ON email_bounces(email);
@@ -0,0 +1,10 @@
+ALTER TABLE seats DROP COLUMN IF EXISTS paired_seat_id;
+
+UPDATE seats SET type = 'REGULAR' WHERE type NOT IN ('REGULAR', 'VIP');
+
+ALTER TABLE seats ALTER COLUMN type DROP DEFAULT;
+ALTER TYPE seat_type RENAME TO seat_type_old;
+CREATE TYPE seat_type AS ENUM('REGULAR', 'VIP');
+ALTER TABLE seats ALTER COLUMN type TYPE seat_type USING type::text::seat_type;
+ALTER TABLE seats ALTER COLUMN type SET DEFAULT 'REGULAR';
+DROP TYPE seat_type_old;
\ No newline at end of file
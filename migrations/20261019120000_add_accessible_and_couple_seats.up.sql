@@ -0,0 +1,6 @@
+ALTER TYPE seat_type ADD VALUE IF NOT EXISTS 'WHEELCHAIR';
+ALTER TYPE seat_type ADD VALUE IF NOT EXISTS 'COMPANION';
+ALTER TYPE seat_type ADD VALUE IF NOT EXISTS 'COUPLE';
+
+ALTER TABLE seats
+ADD COLUMN paired_seat_id UUID REFERENCES seats(id) ON DELETE SET NULL;
\ No newline at end of file
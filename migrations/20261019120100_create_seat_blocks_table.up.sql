@@ -0,0 +1,11 @@
+CREATE TABLE seat_blocks (
+    id UUID PRIMARY KEY,
+    seat_id UUID NOT NULL REFERENCES seats(id) ON DELETE CASCADE,
+    show_id UUID REFERENCES shows(id) ON DELETE CASCADE,
+    reason VARCHAR(255) NOT NULL,
+    blocked_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+);
+
+CREATE UNIQUE INDEX idx_seat_blocks_permanent ON seat_blocks(seat_id) WHERE show_id IS NULL;
+CREATE UNIQUE INDEX idx_seat_blocks_show ON seat_blocks(seat_id, show_id) WHERE show_id IS NOT NULL;
\ No newline at end of file
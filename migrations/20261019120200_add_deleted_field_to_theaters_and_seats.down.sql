@@ -0,0 +1,11 @@
+DELETE FROM seats WHERE is_deleted;
+DROP INDEX IF EXISTS unique_seat_in_theater;
+ALTER TABLE seats ADD CONSTRAINT unique_seat_in_theater UNIQUE (theater_id, row, number);
+ALTER TABLE seats
+DROP COLUMN is_deleted;
+
+DELETE FROM theaters WHERE is_deleted;
+DROP INDEX IF EXISTS unique_active_theater_name;
+ALTER TABLE theaters ADD CONSTRAINT theaters_name_key UNIQUE (name);
+ALTER TABLE theaters
+DROP COLUMN is_deleted;
\ No newline at end of file
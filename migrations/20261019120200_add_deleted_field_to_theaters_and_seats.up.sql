@@ -0,0 +1,11 @@
+ALTER TABLE theaters
+ADD COLUMN is_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE theaters DROP CONSTRAINT IF EXISTS theaters_name_key;
+CREATE UNIQUE INDEX unique_active_theater_name ON theaters(name) WHERE NOT is_deleted;
+
+ALTER TABLE seats
+ADD COLUMN is_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE seats DROP CONSTRAINT IF EXISTS unique_seat_in_theater;
+CREATE UNIQUE INDEX unique_seat_in_theater ON seats(theater_id, row, number) WHERE NOT is_deleted;
\ No newline at end of file
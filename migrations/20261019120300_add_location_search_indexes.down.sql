@@ -0,0 +1,9 @@
+ALTER TABLE theater_locations
+    DROP CONSTRAINT theater_locations_city_id_fkey,
+    ADD CONSTRAINT theater_locations_city_id_fkey FOREIGN KEY (city_id) REFERENCES cities(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_city_name_trgm;
+DROP INDEX IF EXISTS idx_state_name_trgm;
+DROP INDEX IF EXISTS idx_country_name_trgm;
+
+DROP EXTENSION IF EXISTS pg_trgm;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_country_name_trgm ON countries USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_state_name_trgm ON states USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_city_name_trgm ON cities USING GIN (name gin_trgm_ops);
+
+ALTER TABLE theater_locations
+    DROP CONSTRAINT theater_locations_city_id_fkey,
+    ADD CONSTRAINT theater_locations_city_id_fkey FOREIGN KEY (city_id) REFERENCES cities(id) ON DELETE RESTRICT;
\ No newline at end of file
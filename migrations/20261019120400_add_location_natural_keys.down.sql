@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS unique_city_name_in_state;
+DROP INDEX IF EXISTS unique_state_code_in_country;
\ No newline at end of file
@@ -0,0 +1,36 @@
+-- States sharing a code within a country and cities sharing a name within a state are merged into the
+-- row with the lowest id before the unique indexes are created, references move to the kept row.
+WITH ranked AS (
+    SELECT id, first_value(id) OVER (PARTITION BY country_id, code ORDER BY id) AS keep_id
+    FROM states
+    WHERE code IS NOT NULL
+)
+UPDATE cities SET state_id = ranked.keep_id
+FROM ranked
+WHERE cities.state_id = ranked.id AND ranked.id <> ranked.keep_id;
+
+DELETE FROM states
+USING (
+    SELECT id, first_value(id) OVER (PARTITION BY country_id, code ORDER BY id) AS keep_id
+    FROM states
+    WHERE code IS NOT NULL
+) ranked
+WHERE states.id = ranked.id AND ranked.id <> ranked.keep_id;
+
+WITH ranked AS (
+    SELECT id, first_value(id) OVER (PARTITION BY state_id, name ORDER BY id) AS keep_id
+    FROM cities
+)
+UPDATE theater_locations SET city_id = ranked.keep_id
+FROM ranked
+WHERE theater_locations.city_id = ranked.id AND ranked.id <> ranked.keep_id;
+
+DELETE FROM cities
+USING (
+    SELECT id, first_value(id) OVER (PARTITION BY state_id, name ORDER BY id) AS keep_id
+    FROM cities
+) ranked
+WHERE cities.id = ranked.id AND ranked.id <> ranked.keep_id;
+
+CREATE UNIQUE INDEX unique_state_code_in_country ON states(country_id, code) WHERE code IS NOT NULL;
+CREATE UNIQUE INDEX unique_city_name_in_state ON cities(state_id, name);
\ No newline at end of file
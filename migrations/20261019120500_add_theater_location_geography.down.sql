@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_theater_location_geog;
+
+ALTER TABLE theater_locations DROP COLUMN IF EXISTS geog;
\ No newline at end of file
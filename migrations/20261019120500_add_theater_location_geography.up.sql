@@ -0,0 +1,14 @@
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS postgis;
+
+    ALTER TABLE theater_locations
+        ADD COLUMN geog geography(Point, 4326)
+            GENERATED ALWAYS AS (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography) STORED;
+
+    CREATE INDEX idx_theater_location_geog ON theater_locations USING GIST (geog);
+EXCEPTION
+    WHEN OTHERS THEN
+        RAISE NOTICE 'postgis is not available, nearby theaters will use the haversine fallback: %', SQLERRM;
+END
+$$;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE cities
+    DROP CONSTRAINT IF EXISTS check_city_coordinates,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS longitude;
\ No newline at end of file
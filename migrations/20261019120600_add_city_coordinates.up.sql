@@ -0,0 +1,4 @@
+ALTER TABLE cities
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION,
+    ADD CONSTRAINT check_city_coordinates CHECK ((latitude IS NULL) = (longitude IS NULL));
\ No newline at end of file
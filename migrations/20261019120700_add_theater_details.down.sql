@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS theater_photos;
+DROP TABLE IF EXISTS theater_opening_hours;
+DROP TABLE IF EXISTS theater_amenities;
+DROP TYPE IF EXISTS theater_amenity;
+
+ALTER TABLE theaters
+    DROP COLUMN time_zone,
+    DROP COLUMN website,
+    DROP COLUMN phone;
\ No newline at end of file
@@ -0,0 +1,34 @@
+ALTER TABLE theaters
+    ADD COLUMN phone VARCHAR(20),
+    ADD COLUMN website VARCHAR(255),
+    ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE TYPE theater_amenity AS ENUM('PARKING', 'FOOD', 'IMAX', 'DOLBY_ATMOS', 'WHEELCHAIR_ACCESS', 'HEARING_LOOP');
+
+CREATE TABLE theater_amenities (
+    theater_id UUID NOT NULL REFERENCES theaters(id) ON DELETE CASCADE,
+    amenity theater_amenity NOT NULL,
+    PRIMARY KEY (theater_id, amenity)
+);
+
+CREATE INDEX idx_theater_amenity ON theater_amenities(amenity);
+
+CREATE TABLE theater_opening_hours (
+    id UUID PRIMARY KEY,
+    theater_id UUID NOT NULL REFERENCES theaters(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL CHECK (close_time <> open_time),
+    CONSTRAINT unique_theater_opening_hour UNIQUE (theater_id, weekday)
+);
+
+CREATE TABLE theater_photos (
+    id UUID PRIMARY KEY,
+    theater_id UUID NOT NULL REFERENCES theaters(id) ON DELETE CASCADE,
+    url VARCHAR(255) NOT NULL,
+    caption VARCHAR(255),
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_theater_photo_theater ON theater_photos(theater_id, position);
\ No newline at end of file
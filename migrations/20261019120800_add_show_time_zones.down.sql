@@ -0,0 +1,7 @@
+UPDATE theaters SET time_zone = 'UTC' WHERE time_zone IS NULL;
+
+ALTER TABLE theaters
+    ALTER COLUMN time_zone SET NOT NULL,
+    ALTER COLUMN time_zone SET DEFAULT 'UTC';
+
+ALTER TABLE cities DROP COLUMN IF EXISTS time_zone;
\ No newline at end of file
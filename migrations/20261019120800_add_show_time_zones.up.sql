@@ -0,0 +1,7 @@
+ALTER TABLE cities ADD COLUMN time_zone VARCHAR(64);
+
+-- A theater without a time zone uses the zone of its city, then UTC. Existing theaters keep their
+-- zone, including UTC, because a chosen UTC can not be told apart from the old default.
+ALTER TABLE theaters
+    ALTER COLUMN time_zone DROP DEFAULT,
+    ALTER COLUMN time_zone DROP NOT NULL;
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS movie_external_ids;
+DROP TYPE IF EXISTS external_id_source;
+DROP TABLE IF EXISTS movie_languages;
+DROP TYPE IF EXISTS movie_language_type;
+DROP TABLE IF EXISTS movie_certifications;
+DROP TABLE IF EXISTS movie_credits;
+DROP TYPE IF EXISTS credit_role;
+DROP TABLE IF EXISTS people;
+
+ALTER TABLE movies DROP COLUMN trailer_url;
\ No newline at end of file
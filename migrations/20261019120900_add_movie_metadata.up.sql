@@ -0,0 +1,55 @@
+ALTER TABLE movies ADD COLUMN trailer_url VARCHAR(255);
+
+CREATE TABLE people (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    biography TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_people_name ON people(name);
+
+CREATE TYPE credit_role AS ENUM('ACTOR', 'DIRECTOR', 'WRITER', 'PRODUCER', 'COMPOSER');
+
+CREATE TABLE movie_credits (
+    movie_id UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    person_id UUID NOT NULL REFERENCES people(id),
+    role credit_role NOT NULL,
+    character_name VARCHAR(255),
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (movie_id, person_id, role)
+);
+
+CREATE INDEX idx_movie_credit_person ON movie_credits(person_id, role);
+
+CREATE TABLE movie_certifications (
+    movie_id UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    country_id UUID NOT NULL REFERENCES countries(id) ON DELETE CASCADE,
+    rating VARCHAR(10) NOT NULL,
+    min_age SMALLINT NOT NULL DEFAULT 0 CHECK (min_age BETWEEN 0 AND 21),
+    PRIMARY KEY (movie_id, country_id)
+);
+
+CREATE INDEX idx_movie_certification_country ON movie_certifications(country_id, rating);
+
+CREATE TYPE movie_language_type AS ENUM('SUBTITLE', 'DUB');
+
+CREATE TABLE movie_languages (
+    movie_id UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    type movie_language_type NOT NULL,
+    PRIMARY KEY (movie_id, language, type)
+);
+
+CREATE INDEX idx_movie_language ON movie_languages(language, type);
+
+CREATE TYPE external_id_source AS ENUM('IMDB', 'TMDB');
+
+CREATE TABLE movie_external_ids (
+    movie_id UUID NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    source external_id_source NOT NULL,
+    external_id VARCHAR(50) NOT NULL,
+    PRIMARY KEY (movie_id, source),
+    CONSTRAINT unique_movie_external_id UNIQUE (source, external_id)
+);
\ No newline at end of file
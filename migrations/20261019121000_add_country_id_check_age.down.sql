@@ -0,0 +1 @@
+ALTER TABLE countries DROP COLUMN id_check_min_age;
\ No newline at end of file
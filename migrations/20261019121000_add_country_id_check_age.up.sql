@@ -0,0 +1 @@
+ALTER TABLE countries ADD COLUMN id_check_min_age SMALLINT CHECK (id_check_min_age BETWEEN 0 AND 21);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_outbox_pending_key ON outbox(message_key, created_at) WHERE status = 'PENDING';
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events(processed_at);
\ No newline at end of file